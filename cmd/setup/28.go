@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 28.sql
+	executionOutbox string
+)
+
+type ExecutionOutbox struct {
+	dbClient *database.DB
+}
+
+func (mig *ExecutionOutbox) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, executionOutbox)
+	return err
+}
+
+func (mig *ExecutionOutbox) String() string {
+	return "28_execution_outbox"
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS system.execution_outbox (
+    instance_id TEXT NOT NULL,
+    id TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    attempts SMALLINT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT,
+    creation_date TIMESTAMPTZ NOT NULL,
+    dead_lettered_at TIMESTAMPTZ,
+
+    PRIMARY KEY (instance_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS execution_outbox_due_idx ON system.execution_outbox (next_attempt_at) WHERE dead_lettered_at IS NULL;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 29.sql
+	executionLogs string
+)
+
+type ExecutionLogs struct {
+	dbClient *database.DB
+}
+
+func (mig *ExecutionLogs) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, executionLogs)
+	return err
+}
+
+func (mig *ExecutionLogs) String() string {
+	return "29_execution_logs"
+}
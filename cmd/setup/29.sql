@@ -0,0 +1,15 @@
+ALTER TABLE system.execution_outbox ADD COLUMN IF NOT EXISTS execution_id TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS system.execution_logs (
+    instance_id TEXT NOT NULL,
+    log_date TIMESTAMPTZ NOT NULL,
+    execution_id TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    took BIGINT NOT NULL,
+    outcome SMALLINT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS execution_logs_instance_idx ON system.execution_logs (instance_id, log_date DESC);
+CREATE INDEX IF NOT EXISTS execution_logs_log_date_idx ON system.execution_logs (log_date);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 30.sql
+	executionLogChanges string
+)
+
+type ExecutionLogChanges struct {
+	dbClient *database.DB
+}
+
+func (mig *ExecutionLogChanges) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, executionLogChanges)
+	return err
+}
+
+func (mig *ExecutionLogChanges) String() string {
+	return "30_execution_log_changes"
+}
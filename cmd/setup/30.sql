@@ -0,0 +1 @@
+ALTER TABLE system.execution_logs ADD COLUMN IF NOT EXISTS changes JSONB;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 31.sql
+	createActionStorage string
+)
+
+type ActionStorage struct {
+	dbClient *database.DB
+}
+
+func (mig *ActionStorage) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createActionStorage)
+	return err
+}
+
+func (mig *ActionStorage) String() string {
+	return "31_action_storage"
+}
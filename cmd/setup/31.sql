@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS system.action_storage (
+    instance_id TEXT NOT NULL,
+    resource_owner TEXT NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    expires_at TIMESTAMPTZ,
+    change_date TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, resource_owner, key)
+);
+
+CREATE INDEX IF NOT EXISTS action_storage_expires_at_idx ON system.action_storage (instance_id, resource_owner, expires_at);
+
+-- the row of the organization is locked while an entry is set, so concurrent writes can't exceed the quota
+CREATE TABLE IF NOT EXISTS system.action_storage_locks (
+    instance_id TEXT NOT NULL,
+    resource_owner TEXT NOT NULL,
+
+    PRIMARY KEY (instance_id, resource_owner)
+);
@@ -110,6 +110,10 @@ type Steps struct {
 	s25User11AddLowerFieldsToVerifiedEmail *User11AddLowerFieldsToVerifiedEmail
 	s26AuthUsers3                          *AuthUsers3
 	s27IDPTemplate6SAMLNameIDFormat        *IDPTemplate6SAMLNameIDFormat
+	s28ExecutionOutbox                     *ExecutionOutbox
+	s29ExecutionLogs                       *ExecutionLogs
+	s30ExecutionLogChanges                 *ExecutionLogChanges
+	s31ActionStorage                       *ActionStorage
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
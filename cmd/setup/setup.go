@@ -140,6 +140,10 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s25User11AddLowerFieldsToVerifiedEmail = &User11AddLowerFieldsToVerifiedEmail{dbClient: esPusherDBClient}
 	steps.s26AuthUsers3 = &AuthUsers3{dbClient: esPusherDBClient}
 	steps.s27IDPTemplate6SAMLNameIDFormat = &IDPTemplate6SAMLNameIDFormat{dbClient: esPusherDBClient}
+	steps.s28ExecutionOutbox = &ExecutionOutbox{dbClient: queryDBClient}
+	steps.s29ExecutionLogs = &ExecutionLogs{dbClient: queryDBClient}
+	steps.s30ExecutionLogChanges = &ExecutionLogChanges{dbClient: queryDBClient}
+	steps.s31ActionStorage = &ActionStorage{dbClient: queryDBClient}
 
 	err = projection.Create(ctx, projectionDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -178,6 +182,10 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 		steps.s23CorrectGlobalUniqueConstraints,
 		steps.s24AddActorToAuthTokens,
 		steps.s26AuthUsers3,
+		steps.s28ExecutionOutbox,
+		steps.s29ExecutionLogs,
+		steps.s30ExecutionLogChanges,
+		steps.s31ActionStorage,
 	} {
 		mustExecuteMigration(ctx, eventstoreClient, step, "migration failed")
 	}
@@ -286,6 +294,7 @@ func initProjections(
 		keys.OTP,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -341,6 +350,7 @@ func initProjections(
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -25,7 +25,9 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -69,6 +71,10 @@ type Config struct {
 	LogStore          *logstore.Configs
 	Quotas            *QuotasConfig
 	Telemetry         *handlers.TelemetryPusherConfig
+	Executions        *ExecutionsConfig
+
+	PasswordExpiryNotifier *handlers.PasswordExpiryNotifierConfig
+	LDAPSyncer             *ldapsync.Config
 }
 
 type QuotasConfig struct {
@@ -79,6 +85,11 @@ type QuotasConfig struct {
 	Execution *logstore.EmitterConfig
 }
 
+type ExecutionsConfig struct {
+	execution.HandlerConfig `mapstructure:",squash"`
+	Outbox                  *execution.OutboxConfig
+}
+
 func MustNewConfig(v *viper.Viper) *Config {
 	config := new(Config)
 
@@ -67,11 +67,13 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
-	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+	logstore_execution "github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/stdout"
 	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/net"
@@ -167,6 +169,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.OTP,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -215,6 +218,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Target,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -233,7 +237,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	if err != nil {
 		return err
 	}
-	actionsExecutionDBEmitter, err := logstore.NewEmitter[*record.ExecutionLog](ctx, clock, config.Quotas.Execution, execution.NewDatabaseLogStorage(queryDBClient, commands, queries))
+	actionsExecutionDBEmitter, err := logstore.NewEmitter[*record.ExecutionLog](ctx, clock, config.Quotas.Execution, logstore_execution.NewDatabaseLogStorage(queryDBClient, commands, queries))
 	if err != nil {
 		return err
 	}
@@ -241,12 +245,28 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	actionsLogstoreSvc := logstore.New(queries, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter)
 	actions.SetLogstoreService(actionsLogstoreSvc)
 
+	targetStdoutEmitter, err := logstore.NewEmitter[*record.TargetLog](ctx, clock, &logstore.EmitterConfig{Enabled: config.LogStore.Target.Stdout.Enabled}, stdout.NewStdoutEmitter[*record.TargetLog]())
+	if err != nil {
+		return err
+	}
+	targetLogStorage := logstore_execution.NewDatabaseTargetLogStorage(queryDBClient, config.LogStore.Target.Keep)
+	targetDBEmitter, err := logstore.NewEmitter[*record.TargetLog](ctx, clock, config.LogStore.Target.Database, targetLogStorage)
+	if err != nil {
+		return err
+	}
+	if config.LogStore.Target.Database.Enabled {
+		targetLogStorage.StartCleanup(ctx, config.LogStore.Target.CleanupInterval)
+	}
+	execution.SetLogstoreService(logstore.New[*record.TargetLog](queries, nil, targetDBEmitter, targetStdoutEmitter))
+
 	notification.Register(
 		ctx,
 		config.Projections.Customizations["notifications"],
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["passwordexpirynotifier"],
 		*config.Telemetry,
+		*config.PasswordExpiryNotifier,
 		config.ExternalDomain,
 		config.ExternalPort,
 		config.ExternalSecure,
@@ -261,6 +281,30 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	)
 	notification.Start(ctx)
 
+	ldapsync.Register(
+		ctx,
+		config.Projections.Customizations["ldapsyncer"],
+		*config.LDAPSyncer,
+		commands,
+		queries,
+	)
+	ldapsync.Start(ctx)
+
+	executionOutbox := execution.NewOutbox(queryDBClient, queries, config.Executions.Outbox)
+	execution.SetOutbox(executionOutbox)
+	executionOutbox.Start(ctx)
+	execution.Register(
+		ctx,
+		config.Projections.Customizations["executions"],
+		&config.Executions.HandlerConfig,
+		eventstoreClient.EventTypes(),
+		queries,
+		executionOutbox,
+	)
+	execution.Start(ctx)
+	actionStorage := actions.NewStorage(queryDBClient, &config.Actions.Storage)
+	actions.SetStorage(actionStorage)
+
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
 	if err != nil {
@@ -279,6 +323,8 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		authZRepo,
 		keys,
 		permissionCheck,
+		executionOutbox,
+		actionStorage,
 	)
 	if err != nil {
 		return err
@@ -323,6 +369,8 @@ func startAPIs(
 	authZRepo authz_repo.Repository,
 	keys *encryption.EncryptionKeys,
 	permissionCheck domain.PermissionCheck,
+	executionOutbox *execution.Outbox,
+	actionStorage *actions.Storage,
 ) (*api.API, error) {
 	repo := struct {
 		authz_repo.Repository
@@ -386,7 +434,7 @@ func startAPIs(
 	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, config.ExternalSecure, keys.User, config.AuditLogRetention), tlsConfig); err != nil {
 		return nil, err
 	}
-	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure), tlsConfig); err != nil {
+	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, actionStorage, config.SystemDefaults, keys.User, config.ExternalSecure), tlsConfig); err != nil {
 		return nil, err
 	}
 	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure), tlsConfig); err != nil {
@@ -408,7 +456,7 @@ func startAPIs(
 	if err := apis.RegisterService(ctx, feature.CreateServer(commands, queries)); err != nil {
 		return nil, err
 	}
-	if err := apis.RegisterService(ctx, action_v3_alpha.CreateServer(commands, queries, domain.AllFunctions, apis.ListGrpcMethods, apis.ListGrpcServices)); err != nil {
+	if err := apis.RegisterService(ctx, action_v3_alpha.CreateServer(commands, queries, executionOutbox, domain.AllFunctions, apis.ListGrpcMethods, apis.ListGrpcServices)); err != nil {
 		return nil, err
 	}
 	if err := apis.RegisterService(ctx, user_schema_v3_alpha.CreateServer(commands, queries)); err != nil {
@@ -443,6 +491,7 @@ func startAPIs(
 	if err != nil {
 		return nil, fmt.Errorf("unable to start oidc provider: %w", err)
 	}
+	execution.SetJWTSigner(oidcServer.Signer)
 	apis.RegisterHandlerPrefixes(oidcServer, oidcPrefixes...)
 
 	samlProvider, err := saml.NewProvider(config.SAML, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.SAML, eventstore, dbClient, instanceInterceptor.Handler, userAgentInterceptor, limitingAccessInterceptor)
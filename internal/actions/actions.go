@@ -13,7 +13,8 @@ import (
 )
 
 type Config struct {
-	HTTP HTTPConfig
+	HTTP    HTTPConfig
+	Storage StorageConfig
 }
 
 var ErrHalt = errors.New("interrupt")
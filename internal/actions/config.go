@@ -31,6 +31,7 @@ type runConfig struct {
 	vm         *goja.Runtime
 	ctxParam   *ctxConfig
 	apiParam   *apiConfig
+	dryRun     *DryRun
 }
 
 func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
@@ -0,0 +1,311 @@
+package actions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// maxRandomBytes limits the length of randomBytes()
+	maxRandomBytes = 1024
+	// maxJWKSSize limits the size of the response of a JWKS endpoint
+	maxJWKSSize = 1 << 20
+	// maxJWTClockSkew is the maximum difference between the clocks of the issuer and ZITADEL
+	// tolerated when checking the exp, nbf and iat claims of a JWT
+	maxJWTClockSkew = time.Minute
+)
+
+// jwsAlgorithms are the asymmetric algorithms allowed to verify a JWS with the keys of a JWKS
+var jwsAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+func WithCrypto(ctx context.Context) Option {
+	return func(c *runConfig) {
+		c.modules["zitadel/crypto"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireCrypto(ctx, &http.Client{Transport: new(transport)}, runtime, module)
+		}
+	}
+}
+
+type Crypto struct {
+	runtime *goja.Runtime
+	client  *http.Client
+}
+
+func requireCrypto(ctx context.Context, client *http.Client, runtime *goja.Runtime, module *goja.Object) {
+	c := &Crypto{
+		client:  client,
+		runtime: runtime,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("hash", c.hash)).Warn("unable to set module")
+	logging.OnError(o.Set("hmac", c.hmac)).Warn("unable to set module")
+	logging.OnError(o.Set("timingSafeEqual", c.timingSafeEqual)).Warn("unable to set module")
+	logging.OnError(o.Set("randomBytes", c.randomBytes)).Warn("unable to set module")
+	logging.OnError(o.Set("verifyJWS", c.verifyJWS(ctx))).Warn("unable to set module")
+	logging.OnError(o.Set("verifyJWT", c.verifyJWT(ctx))).Warn("unable to set module")
+
+	base64url := runtime.NewObject()
+	logging.OnError(base64url.Set("encode", c.base64URLEncode)).Warn("unable to set module")
+	logging.OnError(base64url.Set("decode", c.base64URLDecode)).Warn("unable to set module")
+	logging.OnError(o.Set("base64url", base64url)).Warn("unable to set module")
+}
+
+// hash returns the hex encoded hash of the data
+// the first argument is the algorithm (`sha1`, `sha256`, `sha384` or `sha512`)
+// the second argument is the data as string or []byte
+func (c *Crypto) hash(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 2 {
+		logging.WithFields("count", len(call.Arguments)).Debug("other than 2 args provided")
+		panic(c.invalidArgCount())
+	}
+	h := c.hashFunc(call.Arguments[0].String())()
+	h.Write(c.dataFromArg(call.Arguments[1]))
+	return c.runtime.ToValue(hex.EncodeToString(h.Sum(nil)))
+}
+
+// hmac returns the hex encoded HMAC of the data
+// the first argument is the algorithm (`sha1`, `sha256`, `sha384` or `sha512`)
+// the second argument is the key and the third the data, both as string or []byte
+func (c *Crypto) hmac(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 3 {
+		logging.WithFields("count", len(call.Arguments)).Debug("other than 3 args provided")
+		panic(c.invalidArgCount())
+	}
+	mac := hmac.New(c.hashFunc(call.Arguments[0].String()), c.dataFromArg(call.Arguments[1]))
+	mac.Write(c.dataFromArg(call.Arguments[2]))
+	return c.runtime.ToValue(hex.EncodeToString(mac.Sum(nil)))
+}
+
+// timingSafeEqual compares two strings or []byte in constant time,
+// which must be used to compare signatures
+func (c *Crypto) timingSafeEqual(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 2 {
+		logging.WithFields("count", len(call.Arguments)).Debug("other than 2 args provided")
+		panic(c.invalidArgCount())
+	}
+	return c.runtime.ToValue(subtle.ConstantTimeCompare(c.dataFromArg(call.Arguments[0]), c.dataFromArg(call.Arguments[1])) == 1)
+}
+
+// randomBytes returns the base64url encoded cryptographically secure random bytes of the provided length
+func (c *Crypto) randomBytes(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		logging.WithFields("count", len(call.Arguments)).Debug("other than 1 arg provided")
+		panic(c.invalidArgCount())
+	}
+	length := call.Arguments[0].ToInteger()
+	if length <= 0 || length > maxRandomBytes {
+		panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(nil, "ACTIO-x8Rk2", "invalid length")))
+	}
+	data := make([]byte, length)
+	if _, err := rand.Read(data); err != nil {
+		panic(c.runtime.NewGoError(err))
+	}
+	return c.runtime.ToValue(base64.RawURLEncoding.EncodeToString(data))
+}
+
+func (c *Crypto) base64URLEncode(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		logging.WithFields("count", len(call.Arguments)).Debug("other than 1 arg provided")
+		panic(c.invalidArgCount())
+	}
+	return c.runtime.ToValue(base64.RawURLEncoding.EncodeToString(c.dataFromArg(call.Arguments[0])))
+}
+
+// base64URLDecode decodes base64url with or without padding
+func (c *Crypto) base64URLDecode(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		logging.WithFields("count", len(call.Arguments)).Debug("other than 1 arg provided")
+		panic(c.invalidArgCount())
+	}
+	encoded := call.Arguments[0].String()
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		if decoded, err = base64.URLEncoding.DecodeString(encoded); err != nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(err, "ACTIO-q2Vn7", "invalid base64url")))
+		}
+	}
+	return c.runtime.ToValue(string(decoded))
+}
+
+// verifyJWS verifies the signature of the compact serialized JWS with the keys of the JWKS
+// and returns the payload as string
+// the first argument is the JWS
+// the second argument is the JWKS as object or the URL to fetch it from
+func (c *Crypto) verifyJWS(ctx context.Context) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			logging.WithFields("count", len(call.Arguments)).Debug("other than 2 args provided")
+			panic(c.invalidArgCount())
+		}
+		jws, err := jose.ParseSigned(call.Arguments[0].String(), jwsAlgorithms)
+		if err != nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(err, "ACTIO-Vb3s1", "invalid jws")))
+		}
+		payload, err := jws.Verify(c.jwksFromArg(ctx, call.Arguments[1]))
+		if err != nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowPermissionDenied(err, "ACTIO-Uw5p0", "signature invalid")))
+		}
+		return c.runtime.ToValue(string(payload))
+	}
+}
+
+// verifyJWT verifies the signature of the JWT with the keys of the JWKS,
+// checks the required expiration, the not before and issued at claims with a maximum clock skew and returns the claims
+// the first argument is the JWT
+// the second argument is the JWKS as object or the URL to fetch it from
+// the third argument is optional and an object with the following fields possible:
+// - `issuer`: expected issuer of the JWT
+// - `audience`: expected audience of the JWT
+func (c *Crypto) verifyJWT(ctx context.Context) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 2 || len(call.Arguments) > 3 {
+			logging.WithFields("count", len(call.Arguments)).Debug("other than 2 or 3 args provided")
+			panic(c.invalidArgCount())
+		}
+		expected := jwt.Expected{Time: time.Now()}
+		if len(call.Arguments) == 3 {
+			if err := c.expectedFromArg(call.Arguments[2].ToObject(c.runtime), &expected); err != nil {
+				panic(c.runtime.NewGoError(err))
+			}
+		}
+		token, err := jwt.ParseSigned(call.Arguments[0].String(), jwsAlgorithms)
+		if err != nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(err, "ACTIO-Tz6e4", "invalid jwt")))
+		}
+		var (
+			registered jwt.Claims
+			claims     map[string]any
+		)
+		if err := token.Claims(c.jwksFromArg(ctx, call.Arguments[1]), &registered, &claims); err != nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowPermissionDenied(err, "ACTIO-Lk8c3", "signature invalid")))
+		}
+		if registered.Expiry == nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowPermissionDenied(nil, "ACTIO-Ve3x7", "exp missing")))
+		}
+		if err := registered.ValidateWithLeeway(expected, maxJWTClockSkew); err != nil {
+			panic(c.runtime.NewGoError(zerrors.ThrowPermissionDenied(err, "ACTIO-Hf1a9", "claims invalid")))
+		}
+		return c.runtime.ToValue(claims)
+	}
+}
+
+func (c *Crypto) expectedFromArg(arg *goja.Object, expected *jwt.Expected) error {
+	for _, key := range arg.Keys() {
+		switch key {
+		case "issuer":
+			expected.Issuer = arg.Get(key).String()
+		case "audience":
+			expected.AnyAudience = jwt.Audience{arg.Get(key).String()}
+		default:
+			return zerrors.ThrowInvalidArgument(nil, "ACTIO-Rq0d2", "key is invalid")
+		}
+	}
+	return nil
+}
+
+// jwksFromArg returns the JWKS of the argument,
+// which is either the JWKS as object or the URL to fetch it from
+func (c *Crypto) jwksFromArg(ctx context.Context, arg goja.Value) *jose.JSONWebKeySet {
+	var (
+		data []byte
+		err  error
+	)
+	switch a := arg.Export().(type) {
+	case string:
+		data = c.fetchJWKS(ctx, a)
+	default:
+		data, err = arg.ToObject(c.runtime).MarshalJSON()
+		if err != nil {
+			panic(c.runtime.NewGoError(err))
+		}
+	}
+	jwks := new(jose.JSONWebKeySet)
+	if err = json.Unmarshal(data, jwks); err != nil {
+		panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(err, "ACTIO-Ei9n6", "invalid jwks")))
+	}
+	return jwks
+}
+
+// fetchJWKS requests the JWKS with the same deny list as the http module
+func (c *Crypto) fetchJWKS(ctx context.Context, url string) []byte {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.client.Timeout = time.Until(deadline)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		panic(c.runtime.NewGoError(err))
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.client.Do(req)
+	if err != nil {
+		logging.WithError(err).Debug("call failed")
+		panic(c.runtime.NewGoError(err))
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		panic(c.runtime.NewGoError(zerrors.ThrowUnavailable(nil, "ACTIO-Pm4j7", "unable to fetch jwks")))
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxJWKSSize))
+	if err != nil {
+		logging.WithError(err).Warn("unable to parse body")
+		panic(c.runtime.NewGoError(err))
+	}
+	return data
+}
+
+// invalidArgCount returns an error, which can be caught in the script
+func (c *Crypto) invalidArgCount() *goja.Object {
+	return c.runtime.NewGoError(zerrors.ThrowInvalidArgument(nil, "ACTIO-Wc3m9", "invalid arg count"))
+}
+
+func (c *Crypto) hashFunc(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case "sha1":
+		return sha1.New
+	case "sha256":
+		return sha256.New
+	case "sha384":
+		return sha512.New384
+	case "sha512":
+		return sha512.New
+	default:
+		panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(nil, "ACTIO-Gz7w5", "algorithm not supported")))
+	}
+}
+
+func (c *Crypto) dataFromArg(arg goja.Value) []byte {
+	switch d := arg.Export().(type) {
+	case string:
+		return []byte(d)
+	case []byte:
+		return d
+	case goja.ArrayBuffer:
+		return d.Bytes()
+	default:
+		panic(c.runtime.NewGoError(zerrors.ThrowInvalidArgument(nil, "ACTIO-Sn2k8", "invalid type for data")))
+	}
+}
@@ -0,0 +1,232 @@
+package actions
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCryptoRuntime(t *testing.T) *goja.Runtime {
+	t.Helper()
+	runtime := goja.New()
+	module := runtime.NewObject()
+	require.NoError(t, module.Set("exports", runtime.NewObject()))
+	requireCrypto(context.Background(), &http.Client{Transport: new(transport)}, runtime, module)
+	require.NoError(t, runtime.Set("crypto", module.Get("exports")))
+	return runtime
+}
+
+func TestCrypto(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    any
+		wantErr bool
+	}{
+		{
+			name:   "sha256",
+			script: `crypto.hash("sha256", "zitadel")`,
+			want:   "713824a0429ee5517687e87f591c58253dc5ca78236aabe0f6c9e2da7c27786b",
+		},
+		{
+			name:   "sha1",
+			script: `crypto.hash("sha1", "zitadel")`,
+			want:   "a6b1e2a8e8cf627d55bb245f259e84fe44128770",
+		},
+		{
+			name:    "unsupported algorithm, error",
+			script:  `crypto.hash("md5", "zitadel")`,
+			wantErr: true,
+		},
+		{
+			name:    "missing data, error",
+			script:  `crypto.hash("sha256")`,
+			wantErr: true,
+		},
+		{
+			name:   "error can be caught",
+			script: `try { crypto.hash("md5", "zitadel") } catch (e) { "caught" }`,
+			want:   "caught",
+		},
+		{
+			name:   "hmac",
+			script: `crypto.hmac("sha256", "secret", "zitadel")`,
+			want:   "71e5a8fc6817eb43256ea7e19c8bf764421956651bd732d02a81ccdfe7dfe07b",
+		},
+		{
+			name:   "timing safe equal",
+			script: `crypto.timingSafeEqual("signature", "signature")`,
+			want:   true,
+		},
+		{
+			name:   "timing safe not equal",
+			script: `crypto.timingSafeEqual("signature", "other")`,
+			want:   false,
+		},
+		{
+			name:   "base64url encode",
+			script: `crypto.base64url.encode("zitadel?>")`,
+			want:   "eml0YWRlbD8-",
+		},
+		{
+			name:   "base64url decode",
+			script: `crypto.base64url.decode("eml0YWRlbD8-")`,
+			want:   "zitadel?>",
+		},
+		{
+			name:   "base64url decode with padding",
+			script: `crypto.base64url.decode("eg==")`,
+			want:   "z",
+		},
+		{
+			name:   "random bytes",
+			script: `crypto.randomBytes(32).length`,
+			want:   int64(43),
+		},
+		{
+			name:    "random bytes too long, error",
+			script:  `crypto.randomBytes(2048)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCryptoRuntime(t).RunString(tt.script)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Export())
+		})
+	}
+}
+
+func TestCrypto_verifyJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: "key"}}, nil)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "key", Algorithm: string(jose.RS256), Use: "sig"}}})
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherJWKS, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: otherKey.Public(), KeyID: "key", Algorithm: string(jose.RS256), Use: "sig"}}})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(jwks)
+	}))
+	defer server.Close()
+
+	signClaims := func(claims jwt.Claims) string {
+		t.Helper()
+		claims.Issuer = "https://issuer.example.com"
+		claims.Audience = jwt.Audience{"zitadel"}
+		claims.Subject = "user"
+		token, err := jwt.Signed(signer).Claims(claims).Claims(map[string]any{"email": "user@example.com"}).Serialize()
+		require.NoError(t, err)
+		return token
+	}
+	token := func(expiry time.Time) string {
+		t.Helper()
+		return signClaims(jwt.Claims{Expiry: jwt.NewNumericDate(expiry)})
+	}
+
+	tests := []struct {
+		name     string
+		script   string
+		denyList []AddressChecker
+		want     any
+		wantErr  bool
+	}{
+		{
+			name:   "inline jwks",
+			script: `crypto.verifyJWT("` + token(time.Now().Add(time.Hour)) + `", ` + string(jwks) + `).email`,
+			want:   "user@example.com",
+		},
+		{
+			name:   "jwks url with expected claims",
+			script: `crypto.verifyJWT("` + token(time.Now().Add(time.Hour)) + `", "` + server.URL + `", {issuer: "https://issuer.example.com", audience: "zitadel"}).sub`,
+			want:   "user",
+		},
+		{
+			name:   "jwks url denied, error",
+			script: `crypto.verifyJWT("` + token(time.Now().Add(time.Hour)) + `", "` + server.URL + `")`,
+			denyList: []AddressChecker{
+				mustNewIPChecker(t, "127.0.0.1"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "other key, error",
+			script:  `crypto.verifyJWT("` + token(time.Now().Add(time.Hour)) + `", ` + string(otherJWKS) + `)`,
+			wantErr: true,
+		},
+		{
+			name:    "expired, error",
+			script:  `crypto.verifyJWT("` + token(time.Now().Add(-time.Hour)) + `", ` + string(jwks) + `)`,
+			wantErr: true,
+		},
+		{
+			name:   "expired within clock skew",
+			script: `crypto.verifyJWT("` + token(time.Now().Add(-maxJWTClockSkew/2)) + `", ` + string(jwks) + `).sub`,
+			want:   "user",
+		},
+		{
+			name:    "exp missing, error",
+			script:  `crypto.verifyJWT("` + signClaims(jwt.Claims{}) + `", ` + string(jwks) + `)`,
+			wantErr: true,
+		},
+		{
+			name:    "issued in the future, error",
+			script:  `crypto.verifyJWT("` + signClaims(jwt.Claims{Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour)), IssuedAt: jwt.NewNumericDate(time.Now().Add(2 * maxJWTClockSkew))}) + `", ` + string(jwks) + `)`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer, error",
+			script:  `crypto.verifyJWT("` + token(time.Now().Add(time.Hour)) + `", ` + string(jwks) + `, {issuer: "https://other.example.com"})`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid option, error",
+			script:  `crypto.verifyJWT("` + token(time.Now().Add(time.Hour)) + `", ` + string(jwks) + `, {subject: "user"})`,
+			wantErr: true,
+		},
+		{
+			name:   "jws payload",
+			script: `JSON.parse(crypto.verifyJWS("` + token(time.Now().Add(time.Hour)) + `", ` + string(jwks) + `)).email`,
+			want:   "user@example.com",
+		},
+		{
+			name:    "invalid jws, error",
+			script:  `crypto.verifyJWS("invalid", ` + string(jwks) + `)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetHTTPConfig(&HTTPConfig{DenyList: tt.denyList})
+			t.Cleanup(func() { SetHTTPConfig(nil) })
+
+			got, err := newCryptoRuntime(t).RunString(tt.script)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Export())
+		})
+	}
+}
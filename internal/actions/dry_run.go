@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// DryRun collects the logs and the changes of the storage of an action run instead of persisting them.
+type DryRun struct {
+	Logs           []*record.ExecutionLog
+	StorageChanges []*StorageChange
+}
+
+type StorageChange struct {
+	Key string
+	// Value is the JSON encoded value, empty if the key was deleted
+	Value   []byte
+	TTL     time.Duration
+	Deleted bool
+}
+
+// WithDryRun runs the action without persisting its logs and storage changes,
+// they are collected in the dry run instead.
+func WithDryRun(dryRun *DryRun) Option {
+	return func(c *runConfig) {
+		c.dryRun = dryRun
+	}
+}
+
+// dryRunStorage reads the entries of the storage,
+// but collects the changes in the dry run.
+type dryRunStorage struct {
+	storage *Storage
+	dryRun  *DryRun
+	changes map[string]*StorageChange
+}
+
+func newDryRunStorage(storage *Storage, dryRun *DryRun) *dryRunStorage {
+	return &dryRunStorage{
+		storage: storage,
+		dryRun:  dryRun,
+		changes: make(map[string]*StorageChange),
+	}
+}
+
+func (s *dryRunStorage) Get(ctx context.Context, resourceOwner, key string) (*StorageEntry, error) {
+	change, ok := s.changes[key]
+	if !ok {
+		return s.storage.Get(ctx, resourceOwner, key)
+	}
+	if change.Deleted {
+		return nil, zerrors.ThrowNotFound(nil, "ACTIO-m3Dqs", "Errors.Action.Storage.NotFound")
+	}
+	return &StorageEntry{Key: key, Value: change.Value, Size: int64(len(key) + len(change.Value))}, nil
+}
+
+func (s *dryRunStorage) Set(_ context.Context, _, key string, value []byte, ttl time.Duration) error {
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+	if ttl < 0 {
+		return zerrors.ThrowInvalidArgument(nil, "ACTIO-Vb9ro", "Errors.Action.Storage.TTLInvalid")
+	}
+	if len(value) > s.storage.config.MaxValueSize {
+		return zerrors.ThrowInvalidArgument(nil, "ACTIO-e5Nhz", "Errors.Action.Storage.ValueTooLarge")
+	}
+	s.change(&StorageChange{Key: key, Value: value, TTL: ttl})
+	return nil
+}
+
+func (s *dryRunStorage) Delete(ctx context.Context, resourceOwner, key string) error {
+	if _, err := s.Get(ctx, resourceOwner, key); err != nil {
+		return err
+	}
+	s.change(&StorageChange{Key: key, Deleted: true})
+	return nil
+}
+
+func (s *dryRunStorage) change(change *StorageChange) {
+	s.changes[change.Key] = change
+	s.dryRun.StorageChanges = append(s.dryRun.StorageChanges, change)
+}
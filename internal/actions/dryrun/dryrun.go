@@ -0,0 +1,272 @@
+// Package dryrun runs actions (v1) against a provided context to test their scripts.
+// The mutations of the action are collected instead of being applied.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// reservedClaimPrefix is the prefix of the claims which can't be set by actions
+	reservedClaimPrefix = "urn:zitadel:iam"
+
+	maxTimeout = 20 * time.Second
+)
+
+type Action struct {
+	Name    string
+	Script  string
+	Timeout time.Duration
+}
+
+type Result struct {
+	// User contains the fields of the user changed by the set functions, e.g. `api.setFirstName()`
+	User       map[string]any
+	Metadata   []*domain.Metadata
+	Claims     map[string]any
+	ClaimLogs  []string
+	Attributes []*Attribute
+	UserGrants []*domain.UserGrant
+	// StorageChanges are the changes of the zitadel/storage module, which are not persisted
+	StorageChanges []*actions.StorageChange
+	Logs           []*record.ExecutionLog
+	// Err is the error returned by the action
+	Err error
+}
+
+type Attribute struct {
+	Name       string
+	NameFormat string
+	Values     []string
+}
+
+// Run runs the action of the flow and trigger type with the provided context fields.
+//
+// The fields of the context are provided as object,
+// functions of the context (e.g. `ctx.v1.getUser()`) are provided with their result under the name of the function.
+// If such a function is called with a key (e.g. `ctx.getClaim("email")`), the field of the key is returned.
+func Run(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, resourceOwner string, action *Action, ctxFields map[string]any) (*Result, error) {
+	if !flowType.HasTrigger(triggerType) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ACTIO-q4Xnb", "Errors.Flow.WrongTriggerType")
+	}
+	if action.Name == "" || action.Script == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ACTIO-w1Vkh", "Errors.Action.Invalid")
+	}
+	timeout := action.Timeout
+	if timeout <= 0 || timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+
+	result := &Result{
+		User:   make(map[string]any),
+		Claims: make(map[string]any),
+	}
+	apiFields, finish := apiFieldsOfTrigger(flowType, triggerType, result)
+
+	actionCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	dryRun := new(actions.DryRun)
+	result.Err = actions.Run(
+		actionCtx,
+		actions.SetContextFields(contextFieldOptions(ctxFields)...),
+		actions.WithAPIFields(apiFields...),
+		action.Script,
+		action.Name,
+		actions.WithHTTP(actionCtx),
+		actions.WithUUID(actionCtx),
+		actions.WithCrypto(actionCtx),
+		actions.WithStorage(actionCtx, resourceOwner),
+		actions.WithDryRun(dryRun),
+	)
+	finish()
+	result.StorageChanges = dryRun.StorageChanges
+	result.Logs = dryRun.Logs
+	return result, nil
+}
+
+// contextFieldOptions maps the provided fields to the context of the action
+func contextFieldOptions(fields map[string]any) []actions.FieldOption {
+	opts := make([]actions.FieldOption, 0, len(fields))
+	for key, value := range fields {
+		opts = append(opts, contextFieldOption(key, value))
+	}
+	return opts
+}
+
+func contextFieldOption(key string, value any) actions.FieldOption {
+	if strings.HasPrefix(key, "get") || key == "claimsJSON" {
+		return actions.SetFields(key, func(c *actions.FieldConfig) interface{} {
+			return getterFunc(c.Runtime, key, value)
+		})
+	}
+	if object, ok := value.(map[string]any); ok && len(object) > 0 {
+		opts := make([]interface{}, 0, len(object))
+		for _, opt := range contextFieldOptions(object) {
+			opts = append(opts, opt)
+		}
+		return actions.SetFields(key, opts...)
+	}
+	return actions.SetFields(key, value)
+}
+
+func getterFunc(runtime *goja.Runtime, key string, value any) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if key == "claimsJSON" {
+			if s, ok := value.(string); ok {
+				return runtime.ToValue(s)
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				panic(err)
+			}
+			return runtime.ToValue(string(data))
+		}
+		if object, ok := value.(map[string]any); ok && len(call.Arguments) == 1 {
+			return runtime.ToValue(object[call.Arguments[0].String()])
+		}
+		return runtime.ToValue(value)
+	}
+}
+
+// apiFieldsOfTrigger returns the api of the flow and trigger type, which collects the mutations in the result.
+// finish must be called after the run, to collect the remaining mutations.
+func apiFieldsOfTrigger(flowType domain.FlowType, triggerType domain.TriggerType, result *Result) (_ []actions.FieldOption, finish func()) {
+	switch triggerType {
+	case domain.TriggerTypePostAuthentication:
+		metadata, finish := metadataFields(result)
+		if flowType == domain.FlowTypeInternalAuthentication {
+			return metadata, finish
+		}
+		return append(userFields(result, "setPreferredUsername"), metadata...), finish
+	case domain.TriggerTypePreCreation:
+		metadata, finish := metadataFields(result)
+		return append(userFields(result, "setGender", "setUsername"), metadata...), finish
+	case domain.TriggerTypePostCreation:
+		grants := &object.UserGrants{UserGrants: make([]object.UserGrant, 0)}
+		return []actions.FieldOption{
+			actions.SetFields("userGrants", &grants.UserGrants),
+			actions.SetFields("v1",
+				actions.SetFields("appendUserGrant", func(c *actions.FieldConfig) interface{} {
+					return object.AppendGrantFunc(grants)(c)
+				}),
+			),
+		}, func() {
+			result.UserGrants = object.UserGrantsToDomain("", grants.UserGrants)
+		}
+	case domain.TriggerTypePreUserinfoCreation:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				claimFields("userinfo", result),
+				claimFields("claims", result),
+				setMetadataField(result),
+			),
+		}, func() {}
+	case domain.TriggerTypePreAccessTokenCreation:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				claimFields("claims", result),
+				setMetadataField(result),
+			),
+		}, func() {}
+	case domain.TriggerTypePreSAMLResponseCreation:
+		return []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("attributes",
+					actions.SetFields("setCustomAttribute", object.SetCustomAttributeFunc(result.hasAttribute, result.appendAttribute)),
+				),
+				setMetadataField(result),
+			),
+		}, func() {}
+	case domain.TriggerTypeUnspecified:
+	}
+	return nil, func() {}
+}
+
+func userFields(result *Result, additionalSetters ...string) []actions.FieldOption {
+	setters := append([]string{
+		"setFirstName",
+		"setLastName",
+		"setNickName",
+		"setDisplayName",
+		"setPreferredLanguage",
+		"setEmail",
+		"setEmailVerified",
+		"setPhone",
+		"setPhoneVerified",
+	}, additionalSetters...)
+	opts := make([]actions.FieldOption, len(setters))
+	for i, setter := range setters {
+		field := strings.ToLower(setter[3:4]) + setter[4:]
+		opts[i] = actions.SetFields(setter, func(value any) {
+			if field == "preferredLanguage" {
+				value = language.Make(fmt.Sprint(value)).String()
+			}
+			result.User[field] = value
+		})
+	}
+	return opts
+}
+
+func metadataFields(result *Result) (_ []actions.FieldOption, finish func()) {
+	metadataList := object.MetadataListFromDomain(nil)
+	return []actions.FieldOption{
+		actions.SetFields("metadata", func(c *actions.FieldConfig) interface{} {
+			return metadataList.MetadataListFromDomain(c.Runtime)
+		}),
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+			),
+		),
+	}, func() {
+		result.Metadata = append(result.Metadata, object.MetadataListToDomain(metadataList)...)
+	}
+}
+
+func claimFields(name string, result *Result) actions.FieldOption {
+	return object.ClaimFields(name, reservedClaimPrefix,
+		func(key string) bool {
+			_, ok := result.Claims[key]
+			return ok
+		},
+		func(key string, value interface{}) {
+			result.Claims[key] = value
+		},
+		&result.ClaimLogs,
+	)
+}
+
+func setMetadataField(result *Result) actions.FieldOption {
+	return actions.SetFields("user",
+		actions.SetFields("setMetadata", object.SetMetadataFunc(func(metadata *domain.Metadata) error {
+			result.Metadata = append(result.Metadata, metadata)
+			return nil
+		})),
+	)
+}
+
+func (r *Result) hasAttribute(name string) bool {
+	for _, attribute := range r.Attributes {
+		if attribute.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Result) appendAttribute(name, nameFormat string, values []string) {
+	r.Attributes = append(r.Attributes, &Attribute{Name: name, NameFormat: nameFormat, Values: values})
+}
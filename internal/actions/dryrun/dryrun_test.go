@@ -0,0 +1,184 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestRun(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	type args struct {
+		flowType    domain.FlowType
+		triggerType domain.TriggerType
+		script      string
+		ctxFields   map[string]any
+	}
+	type want struct {
+		user       map[string]any
+		metadata   []*domain.Metadata
+		claims     map[string]any
+		claimLogs  []string
+		attributes []*Attribute
+		userGrants []*domain.UserGrant
+		logs       []string
+		actionErr  bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr func(error) bool
+	}{
+		{
+			name: "wrong trigger type, error",
+			args: args{
+				flowType:    domain.FlowTypeCustomiseToken,
+				triggerType: domain.TriggerTypePostCreation,
+				script:      `function test(ctx, api) {}`,
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "external authentication, user and metadata",
+			args: args{
+				flowType:    domain.FlowTypeExternalAuthentication,
+				triggerType: domain.TriggerTypePostAuthentication,
+				script: `let logger = require("zitadel/log")
+function test(ctx, api) {
+	logger.log("tenant " + ctx.v1.providerInfo.tenant)
+	api.setFirstName(ctx.v1.externalUser.human.firstName.toUpperCase())
+	api.setPreferredLanguage("de-CH")
+	api.v1.user.appendMetadata("tenant", ctx.v1.providerInfo.tenant)
+}`,
+				ctxFields: map[string]any{
+					"v1": map[string]any{
+						"externalUser": map[string]any{
+							"human": map[string]any{"firstName": "given"},
+						},
+						"providerInfo": map[string]any{"tenant": "acme"},
+					},
+				},
+			},
+			want: want{
+				user:     map[string]any{"firstName": "GIVEN", "preferredLanguage": "de-CH"},
+				metadata: []*domain.Metadata{{Key: "tenant", Value: []byte(`"acme"`)}},
+				claims:   map[string]any{},
+				logs:     []string{"tenant acme"},
+			},
+		},
+		{
+			name: "post creation, user grants",
+			args: args{
+				flowType:    domain.FlowTypeInternalAuthentication,
+				triggerType: domain.TriggerTypePostCreation,
+				script: `function test(ctx, api) {
+	if (ctx.v1.getUser().human.email.endsWith("@example.com")) {
+		api.v1.appendUserGrant({projectId: "project", roles: ["admin"]})
+	}
+}`,
+				ctxFields: map[string]any{
+					"v1": map[string]any{
+						"getUser": map[string]any{
+							"human": map[string]any{"email": "user@example.com"},
+						},
+					},
+				},
+			},
+			want: want{
+				user:       map[string]any{},
+				claims:     map[string]any{},
+				userGrants: []*domain.UserGrant{{ProjectID: "project", RoleKeys: []string{"admin"}}},
+			},
+		},
+		{
+			name: "customise token, claims",
+			args: args{
+				flowType:    domain.FlowTypeCustomiseToken,
+				triggerType: domain.TriggerTypePreAccessTokenCreation,
+				script: `function test(ctx, api) {
+	api.v1.claims.setClaim("email", ctx.getClaim("email"))
+	api.v1.claims.setClaim("email", "other")
+	api.v1.claims.setClaim("urn:zitadel:iam:org:id", "org")
+	api.v1.user.setMetadata("claims", true)
+}`,
+				ctxFields: map[string]any{
+					"getClaim": map[string]any{"email": "user@example.com"},
+				},
+			},
+			want: want{
+				user:      map[string]any{},
+				metadata:  []*domain.Metadata{{Key: "claims", Value: []byte(`true`)}},
+				claims:    map[string]any{"email": "user@example.com"},
+				claimLogs: []string{`key "email" already exists`},
+			},
+		},
+		{
+			name: "saml response, attributes",
+			args: args{
+				flowType:    domain.FlowTypeCustomizeSAMLResponse,
+				triggerType: domain.TriggerTypePreSAMLResponseCreation,
+				script: `function test(ctx, api) {
+	api.v1.attributes.setCustomAttribute("roles", "", "admin", "user")
+}`,
+			},
+			want: want{
+				user:       map[string]any{},
+				claims:     map[string]any{},
+				attributes: []*Attribute{{Name: "roles", Values: []string{"admin", "user"}}},
+			},
+		},
+		{
+			name: "action fails, error in result",
+			args: args{
+				flowType:    domain.FlowTypeInternalAuthentication,
+				triggerType: domain.TriggerTypePostAuthentication,
+				script: `function test(ctx, api) {
+	api.v1.user.appendMetadata("key", "value")
+	throw "failed"
+}`,
+			},
+			want: want{
+				user:      map[string]any{},
+				metadata:  []*domain.Metadata{{Key: "key", Value: []byte(`"value"`)}},
+				claims:    map[string]any{},
+				actionErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(context.Background(), tt.args.flowType, tt.args.triggerType, "org", &Action{Name: "test", Script: tt.args.script}, tt.args.ctxFields)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			require.NoError(t, err)
+			if tt.want.actionErr {
+				assert.Error(t, got.Err)
+			} else {
+				assert.NoError(t, got.Err)
+			}
+			assert.Equal(t, tt.want.user, got.User)
+			assert.Equal(t, tt.want.metadata, got.Metadata)
+			assert.Equal(t, tt.want.claims, got.Claims)
+			assert.Equal(t, tt.want.claimLogs, got.ClaimLogs)
+			assert.Equal(t, tt.want.attributes, got.Attributes)
+			assert.Equal(t, tt.want.userGrants, got.UserGrants)
+
+			// the first and the last log are written by the run
+			require.Len(t, got.Logs, len(tt.want.logs)+2)
+			for i, log := range tt.want.logs {
+				assert.Equal(t, log, got.Logs[i+1].Message)
+			}
+		})
+	}
+}
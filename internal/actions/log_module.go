@@ -26,6 +26,7 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	dryRun     *DryRun
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
@@ -64,6 +65,10 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 	if last {
 		r.Took = ts.Sub(l.started)
 	}
+	if l.dryRun != nil {
+		l.dryRun.Logs = append(l.dryRun.Logs, r)
+		return
+	}
 	logstoreService.Handle(l.ctx, r)
 }
 
@@ -72,6 +77,7 @@ func withLogger(ctx context.Context) Option {
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
 		c.logger = newLogger(ctx, instanceID)
+		c.logger.dryRun = c.dryRun
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
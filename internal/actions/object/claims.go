@@ -0,0 +1,69 @@
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// ClaimFields provides `setClaim` and `appendLogIntoClaims` under name.
+// Claims prefixed with reservedPrefix are ignored,
+// setting an already existing claim is logged into claimLogs.
+func ClaimFields(name, reservedPrefix string, hasClaim func(key string) bool, appendClaim func(key string, value interface{}), claimLogs *[]string) actions.FieldOption {
+	return actions.SetFields(name,
+		actions.SetFields("setClaim", func(key string, value interface{}) {
+			if strings.HasPrefix(key, reservedPrefix) {
+				return
+			}
+			if !hasClaim(key) {
+				appendClaim(key, value)
+				return
+			}
+			*claimLogs = append(*claimLogs, fmt.Sprintf("key %q already exists", key))
+		}),
+		actions.SetFields("appendLogIntoClaims", func(entry string) {
+			*claimLogs = append(*claimLogs, entry)
+		}),
+	)
+}
+
+// SetMetadataFunc returns the `setMetadata(key, value)` function of the user,
+// which passes the metadata with the json encoded value to setMetadata.
+func SetMetadataFunc(setMetadata func(metadata *domain.Metadata) error) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			panic("exactly 2 (key, value) arguments expected")
+		}
+		value, err := json.Marshal(call.Arguments[1].Export())
+		if err != nil {
+			logging.WithError(err).Debug("unable to marshal")
+			panic(err)
+		}
+		metadata := &domain.Metadata{
+			Key:   call.Arguments[0].Export().(string),
+			Value: value,
+		}
+		if err = setMetadata(metadata); err != nil {
+			logging.WithError(err).Info("unable to set md in action")
+			panic(err)
+		}
+		return nil
+	}
+}
+
+// SetCustomAttributeFunc returns the `setCustomAttribute(name, nameFormat, ...values)` function of the SAML response,
+// already existing attributes are not overwritten.
+func SetCustomAttributeFunc(hasAttribute func(name string) bool, appendAttribute func(name, nameFormat string, values []string)) func(name string, nameFormat string, attributeValue ...string) {
+	return func(name string, nameFormat string, attributeValue ...string) {
+		if hasAttribute(name) {
+			return
+		}
+		appendAttribute(name, nameFormat, attributeValue)
+	}
+}
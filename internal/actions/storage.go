@@ -0,0 +1,290 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	storageGetStmt = `SELECT value, size, expires_at, change_date FROM system.action_storage` +
+		` WHERE instance_id = $1 AND resource_owner = $2 AND key = $3 AND (expires_at IS NULL OR expires_at > $4)`
+	storagePurgeStmt = `DELETE FROM system.action_storage WHERE instance_id = $1 AND resource_owner = $2 AND expires_at <= $3`
+	// storageLockStmt locks the row of the organization until the end of the transaction,
+	// so the quota is checked and the entry is written without concurrent writes of the organization.
+	storageLockStmt = `INSERT INTO system.action_storage_locks (instance_id, resource_owner) VALUES ($1, $2)` +
+		` ON CONFLICT (instance_id, resource_owner) DO UPDATE SET resource_owner = EXCLUDED.resource_owner`
+	// storageSetStmt only inserts or updates the entry if the size of all other entries of the organization
+	// and the size of the entry don't exceed the quota, it must be executed after storageLockStmt.
+	storageSetStmt = `INSERT INTO system.action_storage (instance_id, resource_owner, key, value, size, expires_at, change_date)` +
+		` SELECT $1::TEXT, $2::TEXT, $3::TEXT, $4::TEXT, $5::INTEGER, $6::TIMESTAMPTZ, $7::TIMESTAMPTZ` +
+		` WHERE $5::INTEGER + (SELECT COALESCE(SUM(size), 0) FROM system.action_storage WHERE instance_id = $1 AND resource_owner = $2 AND key <> $3) <= $8::BIGINT` +
+		` ON CONFLICT (instance_id, resource_owner, key) DO UPDATE SET value = EXCLUDED.value, size = EXCLUDED.size, expires_at = EXCLUDED.expires_at, change_date = EXCLUDED.change_date`
+	storageDeleteStmt  = `DELETE FROM system.action_storage WHERE instance_id = $1 AND resource_owner = $2 AND key = $3`
+	storageClearStmt   = `DELETE FROM system.action_storage WHERE instance_id = $1 AND resource_owner = $2`
+	storageEntriesStmt = `SELECT key, value, size, expires_at, change_date, COUNT(*) OVER (), SUM(size) OVER ()` +
+		` FROM system.action_storage WHERE instance_id = $1 AND resource_owner = $2 AND (expires_at IS NULL OR expires_at > $3) AND key LIKE $4` +
+		` ORDER BY key LIMIT $5 OFFSET $6`
+)
+
+var storageKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,200}$`)
+
+type StorageConfig struct {
+	// MaxValueSize is the maximum size of a single value in bytes
+	MaxValueSize int
+	// MaxOrgSize is the maximum size of all keys and values of an organization in bytes
+	MaxOrgSize int64
+	// DefaultTTL is used for entries stored without a ttl, 0 means the entries don't expire
+	DefaultTTL time.Duration
+	// MaxTTL is the maximum ttl of an entry, 0 means the ttl is not limited
+	MaxTTL time.Duration
+	// ListLimit is the maximum of entries returned by a search
+	ListLimit uint64
+}
+
+// Storage persists key value pairs of actions in a namespace per organization.
+// The size of all entries of an organization is limited by a quota.
+type Storage struct {
+	client *database.DB
+	config *StorageConfig
+	now    func() time.Time
+}
+
+func NewStorage(client *database.DB, config *StorageConfig) *Storage {
+	return &Storage{
+		client: client,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+var storage *Storage
+
+// SetStorage defines the storage used by the zitadel/storage module,
+// without a storage the module is not available in actions.
+func SetStorage(s *Storage) {
+	storage = s
+}
+
+type StorageEntry struct {
+	Key string
+	// Value is the JSON encoded value of the entry
+	Value      []byte
+	Size       int64
+	ExpiresAt  time.Time
+	ChangeDate time.Time
+}
+
+type StorageEntries struct {
+	Count uint64
+	// Size is the size of all found entries in bytes
+	Size    int64
+	Entries []*StorageEntry
+}
+
+// Get returns the entry of the organization, expired entries are not returned
+func (s *Storage) Get(ctx context.Context, resourceOwner, key string) (_ *StorageEntry, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := validateStorageKey(key); err != nil {
+		return nil, err
+	}
+	entry := &StorageEntry{Key: key}
+	err = s.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			var (
+				value     string
+				expiresAt sql.NullTime
+			)
+			if err := row.Scan(&value, &entry.Size, &expiresAt, &entry.ChangeDate); err != nil {
+				return err
+			}
+			entry.Value = []byte(value)
+			entry.ExpiresAt = expiresAt.Time
+			return nil
+		},
+		storageGetStmt,
+		authz.GetInstance(ctx).InstanceID(),
+		resourceOwner,
+		key,
+		s.now(),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, zerrors.ThrowNotFound(err, "ACTIO-Tq8vd", "Errors.Action.Storage.NotFound")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ACTIO-a9Fkq", "Errors.Internal")
+	}
+	return entry, nil
+}
+
+// Set inserts or replaces the JSON encoded value of the key.
+// If ttl is 0 the default ttl of the config is used.
+func (s *Storage) Set(ctx context.Context, resourceOwner, key string, value []byte, ttl time.Duration) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+	if ttl < 0 {
+		return zerrors.ThrowInvalidArgument(nil, "ACTIO-x7Rbe", "Errors.Action.Storage.TTLInvalid")
+	}
+	if len(value) > s.config.MaxValueSize {
+		return zerrors.ThrowInvalidArgument(nil, "ACTIO-Lw2pn", "Errors.Action.Storage.ValueTooLarge")
+	}
+	if ttl == 0 {
+		ttl = s.config.DefaultTTL
+	}
+	if s.config.MaxTTL > 0 && (ttl == 0 || ttl > s.config.MaxTTL) {
+		ttl = s.config.MaxTTL
+	}
+	now := s.now()
+	// expiresAt is NULL if the entry doesn't expire
+	var expiresAt any
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+
+	tx, err := s.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ACTIO-Wk3oe", "Errors.Internal")
+	}
+	defer func() {
+		if err != nil {
+			logging.OnError(tx.Rollback()).Debug("unable to rollback")
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			err = zerrors.ThrowInternal(err, "ACTIO-c2Vhm", "Errors.Internal")
+		}
+	}()
+	if _, err = tx.ExecContext(ctx, storageLockStmt, instanceID, resourceOwner); err != nil {
+		return zerrors.ThrowInternal(err, "ACTIO-Jp6sz", "Errors.Internal")
+	}
+	// expired entries must not count to the quota
+	if _, err = tx.ExecContext(ctx, storagePurgeStmt, instanceID, resourceOwner, now); err != nil {
+		return zerrors.ThrowInternal(err, "ACTIO-Ue4hs", "Errors.Internal")
+	}
+	result, err := tx.ExecContext(ctx, storageSetStmt,
+		instanceID,
+		resourceOwner,
+		key,
+		string(value),
+		len(key)+len(value),
+		expiresAt,
+		now,
+		s.config.MaxOrgSize,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ACTIO-3Gmzo", "Errors.Internal")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return zerrors.ThrowResourceExhausted(err, "ACTIO-Q9c1v", "Errors.Action.Storage.QuotaExceeded")
+	}
+	return nil
+}
+
+// Delete removes the entry of the organization
+func (s *Storage) Delete(ctx context.Context, resourceOwner, key string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+	result, err := s.client.ExecContext(ctx, storageDeleteStmt, authz.GetInstance(ctx).InstanceID(), resourceOwner, key)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ACTIO-nV5tw", "Errors.Internal")
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return zerrors.ThrowNotFound(err, "ACTIO-0sKcf", "Errors.Action.Storage.NotFound")
+	}
+	return nil
+}
+
+// Clear removes all entries of the organization
+func (s *Storage) Clear(ctx context.Context, resourceOwner string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if _, err = s.client.ExecContext(ctx, storageClearStmt, authz.GetInstance(ctx).InstanceID(), resourceOwner); err != nil {
+		return zerrors.ThrowInternal(err, "ACTIO-7eYpj", "Errors.Internal")
+	}
+	return nil
+}
+
+// Entries returns the entries of the organization ordered by key,
+// optionally filtered by the prefix of the key.
+func (s *Storage) Entries(ctx context.Context, resourceOwner, keyPrefix string, offset, limit uint64) (_ *StorageEntries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if limit == 0 || limit > s.config.ListLimit {
+		limit = s.config.ListLimit
+	}
+	entries := &StorageEntries{Entries: make([]*StorageEntry, 0)}
+	err = s.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				var (
+					entry     = new(StorageEntry)
+					value     string
+					expiresAt sql.NullTime
+				)
+				if err := rows.Scan(
+					&entry.Key,
+					&value,
+					&entry.Size,
+					&expiresAt,
+					&entry.ChangeDate,
+					&entries.Count,
+					&entries.Size,
+				); err != nil {
+					return err
+				}
+				entry.Value = []byte(value)
+				entry.ExpiresAt = expiresAt.Time
+				entries.Entries = append(entries.Entries, entry)
+			}
+			return rows.Err()
+		},
+		storageEntriesStmt,
+		authz.GetInstance(ctx).InstanceID(),
+		resourceOwner,
+		s.now(),
+		likePrefix(keyPrefix),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ACTIO-Hn3xa", "Errors.Internal")
+	}
+	return entries, nil
+}
+
+func validateStorageKey(key string) error {
+	if !storageKeyRegexp.MatchString(key) {
+		return zerrors.ThrowInvalidArgument(nil, "ACTIO-p4Wud", "Errors.Action.Storage.KeyInvalid")
+	}
+	return nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
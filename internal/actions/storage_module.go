@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WithStorage provides the key value storage of the organization to the action,
+// the module is only available if a storage is set.
+func WithStorage(ctx context.Context, resourceOwner string) Option {
+	return func(c *runConfig) {
+		if storage == nil {
+			return
+		}
+		c.modules["zitadel/storage"] = func(runtime *goja.Runtime, module *goja.Object) {
+			var s keyValueStorage = storage
+			if c.dryRun != nil {
+				s = newDryRunStorage(storage, c.dryRun)
+			}
+			requireStorage(ctx, s, resourceOwner, runtime, module)
+		}
+	}
+}
+
+type keyValueStorage interface {
+	Get(ctx context.Context, resourceOwner, key string) (*StorageEntry, error)
+	Set(ctx context.Context, resourceOwner, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, resourceOwner, key string) error
+}
+
+type StorageModule struct {
+	runtime       *goja.Runtime
+	storage       keyValueStorage
+	resourceOwner string
+}
+
+func requireStorage(ctx context.Context, storage keyValueStorage, resourceOwner string, runtime *goja.Runtime, module *goja.Object) {
+	s := &StorageModule{
+		runtime:       runtime,
+		storage:       storage,
+		resourceOwner: resourceOwner,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("get", s.get(ctx))).Warn("unable to set module")
+	logging.OnError(o.Set("set", s.set(ctx))).Warn("unable to set module")
+	logging.OnError(o.Set("delete", s.delete(ctx))).Warn("unable to set module")
+}
+
+// get returns the value of the key or null if the key doesn't exist or is expired
+func (s *StorageModule) get(ctx context.Context) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			logging.WithFields("count", len(call.Arguments)).Debug("other than 1 arg provided")
+			panic(s.invalidArgCount())
+		}
+		entry, err := s.storage.Get(ctx, s.resourceOwner, call.Arguments[0].String())
+		if zerrors.IsNotFound(err) {
+			return goja.Null()
+		}
+		if err != nil {
+			panic(s.runtime.NewGoError(err))
+		}
+		var value any
+		if err := json.Unmarshal(entry.Value, &value); err != nil {
+			panic(s.runtime.NewGoError(zerrors.ThrowInternal(err, "ACTIO-b6Xnq", "Errors.Internal")))
+		}
+		return s.runtime.ToValue(value)
+	}
+}
+
+// set stores the value of the key
+// the first argument is the key, the second the value which must be serializable to JSON
+// the optional third argument is the time to live in seconds
+func (s *StorageModule) set(ctx context.Context) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 && len(call.Arguments) != 3 {
+			logging.WithFields("count", len(call.Arguments)).Debug("other than 2 or 3 args provided")
+			panic(s.invalidArgCount())
+		}
+		value, err := json.Marshal(call.Arguments[1].Export())
+		if err != nil {
+			panic(s.runtime.NewGoError(zerrors.ThrowInvalidArgument(err, "ACTIO-Jd7ew", "value is not serializable")))
+		}
+		var ttl time.Duration
+		if len(call.Arguments) == 3 && !goja.IsUndefined(call.Arguments[2]) && !goja.IsNull(call.Arguments[2]) {
+			ttl = time.Duration(call.Arguments[2].ToInteger()) * time.Second
+		}
+		if err := s.storage.Set(ctx, s.resourceOwner, call.Arguments[0].String(), value, ttl); err != nil {
+			panic(s.runtime.NewGoError(err))
+		}
+		return goja.Undefined()
+	}
+}
+
+// delete removes the key and returns if the key existed
+func (s *StorageModule) delete(ctx context.Context) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			logging.WithFields("count", len(call.Arguments)).Debug("other than 1 arg provided")
+			panic(s.invalidArgCount())
+		}
+		err := s.storage.Delete(ctx, s.resourceOwner, call.Arguments[0].String())
+		if zerrors.IsNotFound(err) {
+			return s.runtime.ToValue(false)
+		}
+		if err != nil {
+			panic(s.runtime.NewGoError(err))
+		}
+		return s.runtime.ToValue(true)
+	}
+}
+
+func (s *StorageModule) invalidArgCount() *goja.Object {
+	return s.runtime.NewGoError(zerrors.ThrowInvalidArgument(nil, "ACTIO-Zk4sa", "invalid arg count"))
+}
@@ -0,0 +1,423 @@
+package actions
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestStorage_Set(t *testing.T) {
+	now := time.Now()
+	type args struct {
+		key   string
+		value string
+		ttl   time.Duration
+	}
+	tests := []struct {
+		name         string
+		config       *StorageConfig
+		args         args
+		expectations func() *mock.SQLMock
+		wantErr      func(error) bool
+	}{
+		{
+			name:   "invalid key, error",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100},
+			args:   args{key: "invalid key", value: `"value"`},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:   "negative ttl, error",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100},
+			args:   args{key: "key", value: `"value"`, ttl: -time.Second},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:   "value too large, error",
+			config: &StorageConfig{MaxValueSize: 4, MaxOrgSize: 100},
+			args:   args{key: "key", value: `"value"`},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:   "quota exceeded, error",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100},
+			args:   args{key: "key", value: `"value"`},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExcpectExec(storagePurgeStmt,
+						mock.WithExecArgs("instance", "org", now),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExcpectExec(storageSetStmt,
+						mock.WithExecArgs("instance", "org", "key", `"value"`, 10, nil, now, int64(100)),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExpectRollback(nil),
+				)
+			},
+			wantErr: zerrors.IsResourceExhausted,
+		},
+		{
+			name:   "lock failed, error",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100},
+			args:   args{key: "key", value: `"value"`},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecErr(errors.New("lock failed")),
+					),
+					mock.ExpectRollback(nil),
+				)
+			},
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name:   "without ttl, ok",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100},
+			args:   args{key: "key", value: `"value"`},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExcpectExec(storagePurgeStmt,
+						mock.WithExecArgs("instance", "org", now),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExcpectExec(storageSetStmt,
+						mock.WithExecArgs("instance", "org", "key", `"value"`, 10, nil, now, int64(100)),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+		},
+		{
+			name:   "default ttl, ok",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100, DefaultTTL: time.Hour},
+			args:   args{key: "key", value: `"value"`},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExcpectExec(storagePurgeStmt,
+						mock.WithExecArgs("instance", "org", now),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExcpectExec(storageSetStmt,
+						mock.WithExecArgs("instance", "org", "key", `"value"`, 10, now.Add(time.Hour), now, int64(100)),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+		},
+		{
+			name:   "ttl limited by max ttl, ok",
+			config: &StorageConfig{MaxValueSize: 10, MaxOrgSize: 100, MaxTTL: time.Minute},
+			args:   args{key: "key", value: `"value"`, ttl: time.Hour},
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExcpectExec(storagePurgeStmt,
+						mock.WithExecArgs("instance", "org", now),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExcpectExec(storageSetStmt,
+						mock.WithExecArgs("instance", "org", "key", `"value"`, 10, now.Add(time.Minute), now, int64(100)),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.expectations()
+			s := &Storage{
+				client: &database.DB{DB: dbMock.DB},
+				config: tt.config,
+				now: func() time.Time {
+					return now
+				},
+			}
+			err := s.Set(authz.WithInstanceID(context.Background(), "instance"), "org", tt.args.key, []byte(tt.args.value), tt.args.ttl)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			dbMock.Assert(t)
+		})
+	}
+}
+
+func TestStorage_Delete(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		expectations func() *mock.SQLMock
+		wantErr      func(error) bool
+	}{
+		{
+			name: "invalid key, error",
+			key:  strings.Repeat("k", 201),
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "not found, error",
+			key:  "key",
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(storageDeleteStmt,
+						mock.WithExecArgs("instance", "org", "key"),
+						mock.WithExecNoRowsAffected(),
+					),
+				)
+			},
+			wantErr: zerrors.IsNotFound,
+		},
+		{
+			name: "deleted",
+			key:  "key",
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(storageDeleteStmt,
+						mock.WithExecArgs("instance", "org", "key"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.expectations()
+			s := &Storage{
+				client: &database.DB{DB: dbMock.DB},
+				now:    time.Now,
+			}
+			err := s.Delete(authz.WithInstanceID(context.Background(), "instance"), "org", tt.key)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			dbMock.Assert(t)
+		})
+	}
+}
+
+func TestStorage_Entries(t *testing.T) {
+	now := time.Now()
+	dbMock := mock.NewSQLMock(t,
+		mock.ExpectBegin(nil),
+		mock.ExpectQuery(storageEntriesStmt,
+			mock.WithQueryArgs("instance", "org", now, `user\_%`, uint64(100), uint64(0)),
+			mock.WithQueryResult(
+				[]string{"key", "value", "size", "expires_at", "change_date", "count", "sum"},
+				[][]driver.Value{
+					{"user_1", `{"logins":1}`, 18, nil, now, 2, 36},
+					{"user_2", `{"logins":2}`, 18, now.Add(time.Hour), now, 2, 36},
+				},
+			),
+		),
+		mock.ExpectCommit(nil),
+	)
+	s := &Storage{
+		client: &database.DB{DB: dbMock.DB},
+		config: &StorageConfig{ListLimit: 100},
+		now: func() time.Time {
+			return now
+		},
+	}
+	entries, err := s.Entries(authz.WithInstanceID(context.Background(), "instance"), "org", "user_", 0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, &StorageEntries{
+		Count: 2,
+		Size:  36,
+		Entries: []*StorageEntry{
+			{Key: "user_1", Value: []byte(`{"logins":1}`), Size: 18, ChangeDate: now},
+			{Key: "user_2", Value: []byte(`{"logins":2}`), Size: 18, ExpiresAt: now.Add(time.Hour), ChangeDate: now},
+		},
+	}, entries)
+	dbMock.Assert(t)
+}
+
+func TestStorageModule(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		script       string
+		expectations func() *mock.SQLMock
+		want         any
+		wantErr      bool
+	}{
+		{
+			name:   "get",
+			script: `storage.get("key").logins`,
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(storageGetStmt,
+						mock.WithQueryArgs("instance", "org", "key", now),
+						mock.WithQueryResult(
+							[]string{"value", "size", "expires_at", "change_date"},
+							[][]driver.Value{{`{"logins":2}`, 15, nil, now}},
+						),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+			want: int64(2),
+		},
+		{
+			name:   "get not found",
+			script: `storage.get("key")`,
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(storageGetStmt,
+						mock.WithQueryArgs("instance", "org", "key", now),
+						mock.WithQueryResult([]string{"value", "size", "expires_at", "change_date"}, nil),
+					),
+				)
+			},
+			want: nil,
+		},
+		{
+			name:   "set with ttl",
+			script: `storage.set("key", {logins: 2}, 60)`,
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExcpectExec(storagePurgeStmt,
+						mock.WithExecArgs("instance", "org", now),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExcpectExec(storageSetStmt,
+						mock.WithExecArgs("instance", "org", "key", `{"logins":2}`, 15, now.Add(time.Minute), now, int64(100)),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExpectCommit(nil),
+				)
+			},
+			want: nil,
+		},
+		{
+			name:   "set quota exceeded can be caught",
+			script: `try { storage.set("key", {logins: 2}) } catch (e) { "caught" }`,
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec(storageLockStmt,
+						mock.WithExecArgs("instance", "org"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+					mock.ExcpectExec(storagePurgeStmt,
+						mock.WithExecArgs("instance", "org", now),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExcpectExec(storageSetStmt,
+						mock.WithExecArgs("instance", "org", "key", `{"logins":2}`, 15, nil, now, int64(100)),
+						mock.WithExecNoRowsAffected(),
+					),
+					mock.ExpectRollback(nil),
+				)
+			},
+			want: "caught",
+		},
+		{
+			name:   "set missing value, error",
+			script: `storage.set("key")`,
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "delete not found",
+			script: `storage.delete("key")`,
+			expectations: func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(storageDeleteStmt,
+						mock.WithExecArgs("instance", "org", "key"),
+						mock.WithExecNoRowsAffected(),
+					),
+				)
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.expectations()
+			s := &Storage{
+				client: &database.DB{DB: dbMock.DB},
+				config: &StorageConfig{MaxValueSize: 100, MaxOrgSize: 100},
+				now: func() time.Time {
+					return now
+				},
+			}
+			runtime := goja.New()
+			module := runtime.NewObject()
+			require.NoError(t, module.Set("exports", runtime.NewObject()))
+			requireStorage(authz.WithInstanceID(context.Background(), "instance"), s, "org", runtime, module)
+			require.NoError(t, runtime.Set("storage", module.Get("exports")))
+
+			got, err := runtime.RunString(tt.script)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Export())
+			dbMock.Assert(t)
+		})
+	}
+}
@@ -2,7 +2,10 @@ package action
 
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
@@ -117,6 +120,34 @@ func ActionToPb(action *query.Action) *action_pb.Action {
 	}
 }
 
+func StorageEntriesToPb(entries []*actions.StorageEntry) (_ []*action_pb.StorageEntry, err error) {
+	list := make([]*action_pb.StorageEntry, len(entries))
+	for i, entry := range entries {
+		list[i], err = StorageEntryToPb(entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func StorageEntryToPb(entry *actions.StorageEntry) (*action_pb.StorageEntry, error) {
+	value := new(structpb.Value)
+	if err := value.UnmarshalJSON(entry.Value); err != nil {
+		return nil, err
+	}
+	pb := &action_pb.StorageEntry{
+		Key:        entry.Key,
+		Value:      value,
+		Size:       uint64(entry.Size),
+		ChangeDate: timestamppb.New(entry.ChangeDate),
+	}
+	if !entry.ExpiresAt.IsZero() {
+		pb.ExpirationDate = timestamppb.New(entry.ExpiresAt)
+	}
+	return pb, nil
+}
+
 func ActionStateToPb(state domain.ActionState) action_pb.ActionState {
 	switch state {
 	case domain.ActionStateActive:
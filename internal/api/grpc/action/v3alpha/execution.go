@@ -48,7 +48,9 @@ func (s *Server) SetExecution(ctx context.Context, req *action.SetExecutionReque
 		}
 	}
 	set := &command.SetExecution{
-		Targets: targets,
+		Targets:       targets,
+		Condition:     req.GetExpression(),
+		MutableFields: req.GetMutableFields().GetPaths(),
 	}
 
 	var err error
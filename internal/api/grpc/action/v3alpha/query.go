@@ -2,13 +2,20 @@ package action
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	action "github.com/zitadel/zitadel/pkg/grpc/action/v3alpha"
@@ -134,6 +141,22 @@ func targetToPb(t *query.Target) *action.Target {
 		Name:     t.Name,
 		Timeout:  durationpb.New(t.Timeout),
 		Endpoint: t.Endpoint,
+
+		MaxConcurrency: uint32(t.MaxConcurrency),
+		State:          targetStateToPb(execution.GetTargetState(t.ResourceOwner, t.ID)),
+	}
+	if t.FailureThreshold > 0 {
+		target.CircuitBreaker = &action.CircuitBreaker{
+			FailureThreshold: uint32(t.FailureThreshold),
+			OpenDuration:     durationpb.New(t.OpenDuration),
+		}
+	}
+	if t.JWTAuthentication || len(t.ClientCertificate) > 0 || len(t.RootCAs) > 0 {
+		target.Authentication = &action.Authentication{
+			Jwt:               t.JWTAuthentication,
+			ClientCertificate: t.ClientCertificate,
+			RootCas:           t.RootCAs,
+		}
 	}
 
 	switch t.TargetType {
@@ -142,13 +165,31 @@ func targetToPb(t *query.Target) *action.Target {
 	case domain.TargetTypeCall:
 		target.TargetType = &action.Target_RestCall{RestCall: &action.SetRESTCall{InterruptOnError: t.InterruptOnError}}
 	case domain.TargetTypeAsync:
-		target.TargetType = &action.Target_RestAsync{RestAsync: &action.SetRESTAsync{}}
+		target.TargetType = &action.Target_RestAsync{RestAsync: &action.SetRESTAsync{MaxAttempts: uint32(t.MaxAttempts)}}
+	case domain.TargetTypeGRPC:
+		target.TargetType = &action.Target_Grpc{Grpc: &action.SetGRPC{InterruptOnError: t.InterruptOnError}}
 	default:
 		target.TargetType = nil
 	}
 	return target
 }
 
+func targetStateToPb(state execution.TargetState) *action.TargetState {
+	pb := &action.TargetState{
+		ConsecutiveFailures: uint32(state.ConsecutiveFailures),
+		RunningCalls:        uint32(state.RunningCalls),
+	}
+	switch state.Circuit {
+	case execution.CircuitStateOpen:
+		pb.CircuitState = action.CircuitState_CIRCUIT_STATE_OPEN
+	case execution.CircuitStateHalfOpen:
+		pb.CircuitState = action.CircuitState_CIRCUIT_STATE_HALF_OPEN
+	case execution.CircuitStateClosed:
+		pb.CircuitState = action.CircuitState_CIRCUIT_STATE_CLOSED
+	}
+	return pb
+}
+
 func (s *Server) ListExecutions(ctx context.Context, req *action.ListExecutionsRequest) (*action.ListExecutionsResponse, error) {
 	if err := checkExecutionEnabled(ctx); err != nil {
 		return nil, err
@@ -168,6 +209,181 @@ func (s *Server) ListExecutions(ctx context.Context, req *action.ListExecutionsR
 	}, nil
 }
 
+func (s *Server) ListExecutionDeadLetters(ctx context.Context, req *action.ListExecutionDeadLettersRequest) (*action.ListExecutionDeadLettersResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	offset, limit, _ := object.ListQueryToQuery(req.Query)
+	resp, err := s.outbox.DeadLetters(ctx, req.GetTargetId(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &action.ListExecutionDeadLettersResponse{
+		Result:  deadLettersToPb(resp.DeadLetters),
+		Details: object.ToListDetails(query.SearchResponse{Count: resp.Count}),
+	}, nil
+}
+
+func deadLettersToPb(deadLetters []*execution.DeadLetter) []*action.ExecutionDeadLetter {
+	d := make([]*action.ExecutionDeadLetter, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		d[i] = &action.ExecutionDeadLetter{
+			Id:             deadLetter.ID,
+			TargetId:       deadLetter.TargetID,
+			Payload:        deadLetter.Payload,
+			Attempts:       uint32(deadLetter.Attempts),
+			LastError:      deadLetter.LastError,
+			CreationDate:   timestamppb.New(deadLetter.CreationDate),
+			DeadLetteredAt: timestamppb.New(deadLetter.DeadLetteredAt),
+		}
+	}
+	return d
+}
+
+func (s *Server) ListExecutionLogs(ctx context.Context, req *action.ListExecutionLogsRequest) (*action.ListExecutionLogsResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	queries, err := listExecutionLogsRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.SearchExecutionLogs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &action.ListExecutionLogsResponse{
+		Result:  executionLogsToPb(resp.ExecutionLogs),
+		Details: object.ToListDetails(resp.SearchResponse),
+	}, nil
+}
+
+func listExecutionLogsRequestToModel(req *action.ListExecutionLogsRequest) (*query.ExecutionLogSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.Query)
+	queries := make([]query.SearchQuery, 0, 3)
+	if req.GetTargetId() != "" {
+		q, err := query.NewExecutionLogTargetIDSearchQuery(req.GetTargetId())
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	if req.GetExecutionId() != "" {
+		q, err := query.NewExecutionLogExecutionIDSearchQuery(req.GetExecutionId())
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	if req.GetOutcome() != action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_UNSPECIFIED {
+		q, err := query.NewExecutionLogOutcomeSearchQuery(executionLogOutcomeToRecord(req.GetOutcome()))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	return &query.ExecutionLogSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.ExecutionLogColumnLogDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func executionLogOutcomeToRecord(outcome action.ExecutionLogOutcome) record.TargetOutcome {
+	switch outcome {
+	case action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_SUCCESS:
+		return record.TargetOutcomeSuccess
+	case action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_FAILED:
+		return record.TargetOutcomeFailed
+	case action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_TIMEOUT:
+		return record.TargetOutcomeTimeout
+	case action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_MUTATED:
+		return record.TargetOutcomeMutated
+	case action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_REJECTED:
+		return record.TargetOutcomeRejected
+	case action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_UNSPECIFIED:
+		return record.TargetOutcomeUnspecified
+	default:
+		return record.TargetOutcomeUnspecified
+	}
+}
+
+func executionLogOutcomeToPb(outcome record.TargetOutcome) action.ExecutionLogOutcome {
+	switch outcome {
+	case record.TargetOutcomeSuccess:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_SUCCESS
+	case record.TargetOutcomeFailed:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_FAILED
+	case record.TargetOutcomeTimeout:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_TIMEOUT
+	case record.TargetOutcomeMutated:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_MUTATED
+	case record.TargetOutcomeRejected:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_REJECTED
+	case record.TargetOutcomeUnspecified:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_UNSPECIFIED
+	default:
+		return action.ExecutionLogOutcome_EXECUTION_LOG_OUTCOME_UNSPECIFIED
+	}
+}
+
+func executionLogsToPb(logs []*query.ExecutionLog) []*action.ExecutionLog {
+	l := make([]*action.ExecutionLog, len(logs))
+	for i, log := range logs {
+		l[i] = &action.ExecutionLog{
+			LogDate:     timestamppb.New(log.LogDate),
+			ExecutionId: log.ExecutionID,
+			TargetId:    log.TargetID,
+			Took:        durationpb.New(log.Took),
+			Outcome:     executionLogOutcomeToPb(log.Outcome),
+			StatusCode:  uint32(log.StatusCode),
+			Error:       log.Error,
+			Changes:     fieldChangesToPb(log.Changes),
+		}
+	}
+	return l
+}
+
+func fieldChangesToPb(changes []*record.FieldChange) []*action.FieldChange {
+	c := make([]*action.FieldChange, len(changes))
+	for i, change := range changes {
+		c[i] = &action.FieldChange{
+			Path:     change.Path,
+			OldValue: fieldValueToPb(change.OldValue),
+			NewValue: fieldValueToPb(change.NewValue),
+		}
+	}
+	return c
+}
+
+func fieldValueToPb(value json.RawMessage) *structpb.Value {
+	if len(value) == 0 {
+		return nil
+	}
+	v := new(structpb.Value)
+	if err := protojson.Unmarshal(value, v); err != nil {
+		return nil
+	}
+	return v
+}
+
+func (s *Server) ReplayExecutionDeadLetter(ctx context.Context, req *action.ReplayExecutionDeadLetterRequest) (*action.ReplayExecutionDeadLetterResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.outbox.ReplayDeadLetter(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &action.ReplayExecutionDeadLetterResponse{}, nil
+}
+
 func listExecutionsRequestToModel(req *action.ListExecutionsRequest) (*query.ExecutionSearchQueries, error) {
 	offset, limit, asc := object.ListQueryToQuery(req.Query)
 	queries, err := executionQueriesToQuery(req.Queries)
@@ -297,10 +513,19 @@ func executionToPb(e *query.Execution) *action.Execution {
 	}
 
 	return &action.Execution{
-		Details:   object.DomainToDetailsPb(&e.ObjectDetails),
-		Condition: executionIDToCondition(e.ID),
-		Targets:   targets,
+		Details:       object.DomainToDetailsPb(&e.ObjectDetails),
+		Condition:     executionIDToCondition(e.ID),
+		Targets:       targets,
+		Expression:    e.Condition,
+		MutableFields: mutableFieldsToPb(e.MutableFields),
+	}
+}
+
+func mutableFieldsToPb(paths []string) *fieldmaskpb.FieldMask {
+	if len(paths) == 0 {
+		return nil
 	}
+	return &fieldmaskpb.FieldMask{Paths: paths}
 }
 
 func executionIDToCondition(include string) *action.Condition {
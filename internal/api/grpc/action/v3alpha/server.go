@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	action "github.com/zitadel/zitadel/pkg/grpc/action/v3alpha"
@@ -19,6 +20,7 @@ type Server struct {
 	action.UnimplementedActionServiceServer
 	command             *command.Commands
 	query               *query.Queries
+	outbox              *execution.Outbox
 	ListActionFunctions func() []string
 	ListGRPCMethods     func() []string
 	ListGRPCServices    func() []string
@@ -29,6 +31,7 @@ type Config struct{}
 func CreateServer(
 	command *command.Commands,
 	query *query.Queries,
+	outbox *execution.Outbox,
 	listActionFunctions func() []string,
 	listGRPCMethods func() []string,
 	listGRPCServices func() []string,
@@ -36,6 +39,7 @@ func CreateServer(
 	return &Server{
 		command:             command,
 		query:               query,
+		outbox:              outbox,
 		ListActionFunctions: listActionFunctions,
 		ListGRPCMethods:     listGRPCMethods,
 		ListGRPCServices:    listGRPCServices,
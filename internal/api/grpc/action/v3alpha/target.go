@@ -4,12 +4,15 @@ import (
 	"context"
 
 	"github.com/muhlemmer/gu"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	action "github.com/zitadel/zitadel/pkg/grpc/action/v3alpha"
 )
 
@@ -24,8 +27,9 @@ func (s *Server) CreateTarget(ctx context.Context, req *action.CreateTargetReque
 		return nil, err
 	}
 	return &action.CreateTargetResponse{
-		Id:      add.AggregateID,
-		Details: object.DomainToDetailsPb(details),
+		Id:         add.AggregateID,
+		Details:    object.DomainToDetailsPb(details),
+		SigningKey: add.SigningKey,
 	}, nil
 }
 
@@ -43,6 +47,46 @@ func (s *Server) UpdateTarget(ctx context.Context, req *action.UpdateTargetReque
 	}, nil
 }
 
+func (s *Server) RotateTargetSigningKey(ctx context.Context, req *action.RotateTargetSigningKeyRequest) (*action.RotateTargetSigningKeyResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	rotate := rotateTargetSigningKeyToCommand(req)
+	details, err := s.command.RotateTargetSigningKey(ctx, rotate, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &action.RotateTargetSigningKeyResponse{
+		Details:    object.DomainToDetailsPb(details),
+		SigningKey: rotate.SigningKey,
+	}, nil
+}
+
+func (s *Server) TestTarget(ctx context.Context, req *action.TestTargetRequest) (*action.TestTargetResponse, error) {
+	if err := checkExecutionEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	target, err := s.query.ExecutionTargetByID(ctx, req.GetTargetId())
+	if err != nil {
+		return nil, err
+	}
+	payload, err := req.GetPayload().MarshalJSON()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "ACTION-q1z9wbfm6e", "Errors.Target.InvalidPayload")
+	}
+	resp, err := execution.TestTarget(ctx, target, payload)
+	if err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "ACTION-7rj4xn2kla", "Errors.Target.TestFailed")
+	}
+	return &action.TestTargetResponse{
+		StatusCode: uint32(resp.StatusCode),
+		Took:       durationpb.New(resp.Took),
+		Body:       resp.Body,
+	}, nil
+}
+
 func (s *Server) DeleteTarget(ctx context.Context, req *action.DeleteTargetRequest) (*action.DeleteTargetResponse, error) {
 	if err := checkExecutionEnabled(ctx); err != nil {
 		return nil, err
@@ -61,6 +105,7 @@ func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 	var (
 		targetType       domain.TargetType
 		interruptOnError bool
+		maxAttempts      uint8
 	)
 	switch t := req.GetTargetType().(type) {
 	case *action.CreateTargetRequest_RestWebhook:
@@ -71,6 +116,10 @@ func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 		interruptOnError = t.RestCall.InterruptOnError
 	case *action.CreateTargetRequest_RestAsync:
 		targetType = domain.TargetTypeAsync
+		maxAttempts = uint8(t.RestAsync.GetMaxAttempts())
+	case *action.CreateTargetRequest_Grpc:
+		targetType = domain.TargetTypeGRPC
+		interruptOnError = t.Grpc.InterruptOnError
 	}
 	return &command.AddTarget{
 		Name:             req.GetName(),
@@ -78,6 +127,10 @@ func createTargetToCommand(req *action.CreateTargetRequest) *command.AddTarget {
 		Endpoint:         req.GetEndpoint(),
 		Timeout:          req.GetTimeout().AsDuration(),
 		InterruptOnError: interruptOnError,
+		MaxAttempts:      maxAttempts,
+		Authentication:   authenticationToCommand(req.GetAuthentication()),
+		MaxConcurrency:   uint16(req.GetMaxConcurrency()),
+		CircuitBreaker:   circuitBreakerToCommand(req.GetCircuitBreaker()),
 	}
 }
 
@@ -97,16 +150,60 @@ func updateTargetToCommand(req *action.UpdateTargetRequest) *command.ChangeTarge
 		case *action.UpdateTargetRequest_RestWebhook:
 			target.TargetType = gu.Ptr(domain.TargetTypeWebhook)
 			target.InterruptOnError = gu.Ptr(t.RestWebhook.InterruptOnError)
+			target.MaxAttempts = gu.Ptr(uint8(0))
 		case *action.UpdateTargetRequest_RestCall:
 			target.TargetType = gu.Ptr(domain.TargetTypeCall)
 			target.InterruptOnError = gu.Ptr(t.RestCall.InterruptOnError)
+			target.MaxAttempts = gu.Ptr(uint8(0))
 		case *action.UpdateTargetRequest_RestAsync:
 			target.TargetType = gu.Ptr(domain.TargetTypeAsync)
 			target.InterruptOnError = gu.Ptr(false)
+			target.MaxAttempts = gu.Ptr(uint8(t.RestAsync.GetMaxAttempts()))
+		case *action.UpdateTargetRequest_Grpc:
+			target.TargetType = gu.Ptr(domain.TargetTypeGRPC)
+			target.InterruptOnError = gu.Ptr(t.Grpc.InterruptOnError)
+			target.MaxAttempts = gu.Ptr(uint8(0))
 		}
 	}
 	if req.Timeout != nil {
 		target.Timeout = gu.Ptr(req.GetTimeout().AsDuration())
 	}
+	target.Authentication = authenticationToCommand(req.GetAuthentication())
+	if req.MaxConcurrency != nil {
+		target.MaxConcurrency = gu.Ptr(uint16(req.GetMaxConcurrency()))
+	}
+	target.CircuitBreaker = circuitBreakerToCommand(req.GetCircuitBreaker())
 	return target
 }
+
+func circuitBreakerToCommand(req *action.CircuitBreaker) *command.TargetCircuitBreaker {
+	if req == nil {
+		return nil
+	}
+	return &command.TargetCircuitBreaker{
+		FailureThreshold: uint16(req.GetFailureThreshold()),
+		OpenDuration:     req.GetOpenDuration().AsDuration(),
+	}
+}
+
+func authenticationToCommand(req *action.SetAuthentication) *command.TargetAuthentication {
+	if req == nil {
+		return nil
+	}
+	return &command.TargetAuthentication{
+		Headers:           req.GetHeaders(),
+		JWT:               req.GetJwt(),
+		ClientCertificate: req.GetClientCertificate(),
+		ClientKey:         req.GetClientKey(),
+		RootCAs:           req.GetRootCas(),
+	}
+}
+
+func rotateTargetSigningKeyToCommand(req *action.RotateTargetSigningKeyRequest) *command.RotateTargetSigningKey {
+	return &command.RotateTargetSigningKey{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GetTargetId(),
+		},
+		GracePeriod: req.GetGracePeriod().AsDuration(),
+	}
+}
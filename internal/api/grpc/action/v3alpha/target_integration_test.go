@@ -369,6 +369,75 @@ func TestServer_UpdateTarget(t *testing.T) {
 	}
 }
 
+func TestServer_RotateTargetSigningKey(t *testing.T) {
+	ensureFeatureEnabled(t)
+	target := Tester.CreateTarget(CTX, t, "", "https://example.com", domain.TargetTypeWebhook, false)
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *action.RotateTargetSigningKeyRequest
+		want    *action.RotateTargetSigningKeyResponse
+		wantErr bool
+	}{
+		{
+			name: "missing permission",
+			ctx:  Tester.WithAuthorization(context.Background(), integration.OrgOwner),
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId: target.GetId(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "not existing",
+			ctx:  CTX,
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId: "notexisting",
+			},
+			wantErr: true,
+		},
+		{
+			name: "rotate signing key",
+			ctx:  CTX,
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId: target.GetId(),
+			},
+			want: &action.RotateTargetSigningKeyResponse{
+				Details: &object.Details{
+					ChangeDate:    timestamppb.Now(),
+					ResourceOwner: Tester.Instance.InstanceID(),
+				},
+			},
+		},
+		{
+			name: "rotate signing key with grace period",
+			ctx:  CTX,
+			req: &action.RotateTargetSigningKeyRequest{
+				TargetId:    target.GetId(),
+				GracePeriod: durationpb.New(time.Hour),
+			},
+			want: &action.RotateTargetSigningKeyResponse{
+				Details: &object.Details{
+					ChangeDate:    timestamppb.Now(),
+					ResourceOwner: Tester.Instance.InstanceID(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Client.RotateTargetSigningKey(tt.ctx, tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			integration.AssertDetails(t, tt.want, got)
+			assert.NotEmpty(t, got.GetSigningKey())
+			assert.NotEqual(t, target.GetSigningKey(), got.GetSigningKey())
+		})
+	}
+}
+
 func TestServer_DeleteTarget(t *testing.T) {
 	ensureFeatureEnabled(t)
 	target := Tester.CreateTarget(CTX, t, "", "https://example.com", domain.TargetTypeWebhook, false)
@@ -56,7 +56,9 @@ func Test_createTargetToCommand(t *testing.T) {
 				Name:     "target 1",
 				Endpoint: "https://example.com/hooks/1",
 				TargetType: &action.CreateTargetRequest_RestAsync{
-					RestAsync: &action.SetRESTAsync{},
+					RestAsync: &action.SetRESTAsync{
+						MaxAttempts: 3,
+					},
 				},
 				Timeout: durationpb.New(10 * time.Second),
 			}},
@@ -66,6 +68,87 @@ func Test_createTargetToCommand(t *testing.T) {
 				Endpoint:         "https://example.com/hooks/1",
 				Timeout:          10 * time.Second,
 				InterruptOnError: false,
+				MaxAttempts:      3,
+			},
+		},
+		{
+			name: "all fields (authentication)",
+			args: args{&action.CreateTargetRequest{
+				Name:     "target 1",
+				Endpoint: "https://example.com/hooks/1",
+				TargetType: &action.CreateTargetRequest_RestWebhook{
+					RestWebhook: &action.SetRESTWebhook{},
+				},
+				Timeout: durationpb.New(10 * time.Second),
+				Authentication: &action.SetAuthentication{
+					Headers:           map[string]string{"X-Api-Key": "secret"},
+					Jwt:               true,
+					ClientCertificate: []byte("certificate"),
+					ClientKey:         []byte("key"),
+					RootCas:           []byte("ca"),
+				},
+			}},
+			want: &command.AddTarget{
+				Name:             "target 1",
+				TargetType:       domain.TargetTypeWebhook,
+				Endpoint:         "https://example.com/hooks/1",
+				Timeout:          10 * time.Second,
+				InterruptOnError: false,
+				Authentication: &command.TargetAuthentication{
+					Headers:           map[string]string{"X-Api-Key": "secret"},
+					JWT:               true,
+					ClientCertificate: []byte("certificate"),
+					ClientKey:         []byte("key"),
+					RootCAs:           []byte("ca"),
+				},
+			},
+		},
+		{
+			name: "all fields (grpc)",
+			args: args{&action.CreateTargetRequest{
+				Name:     "target 1",
+				Endpoint: "https://example.com:443",
+				TargetType: &action.CreateTargetRequest_Grpc{
+					Grpc: &action.SetGRPC{
+						InterruptOnError: true,
+					},
+				},
+				Timeout: durationpb.New(10 * time.Second),
+			}},
+			want: &command.AddTarget{
+				Name:             "target 1",
+				TargetType:       domain.TargetTypeGRPC,
+				Endpoint:         "https://example.com:443",
+				Timeout:          10 * time.Second,
+				InterruptOnError: true,
+			},
+		},
+		{
+			name: "all fields (circuit breaker)",
+			args: args{&action.CreateTargetRequest{
+				Name:     "target 1",
+				Endpoint: "https://example.com/hooks/1",
+				TargetType: &action.CreateTargetRequest_RestCall{
+					RestCall: &action.SetRESTCall{},
+				},
+				Timeout:        durationpb.New(10 * time.Second),
+				MaxConcurrency: 10,
+				CircuitBreaker: &action.CircuitBreaker{
+					FailureThreshold: 5,
+					OpenDuration:     durationpb.New(30 * time.Second),
+				},
+			}},
+			want: &command.AddTarget{
+				Name:             "target 1",
+				TargetType:       domain.TargetTypeCall,
+				Endpoint:         "https://example.com/hooks/1",
+				Timeout:          10 * time.Second,
+				InterruptOnError: false,
+				MaxConcurrency:   10,
+				CircuitBreaker: &command.TargetCircuitBreaker{
+					FailureThreshold: 5,
+					OpenDuration:     30 * time.Second,
+				},
 			},
 		},
 		{
@@ -159,6 +242,7 @@ func Test_updateTargetToCommand(t *testing.T) {
 				Endpoint:         gu.Ptr("https://example.com/hooks/1"),
 				Timeout:          gu.Ptr(10 * time.Second),
 				InterruptOnError: gu.Ptr(false),
+				MaxAttempts:      gu.Ptr(uint8(0)),
 			},
 		},
 		{
@@ -179,6 +263,7 @@ func Test_updateTargetToCommand(t *testing.T) {
 				Endpoint:         gu.Ptr("https://example.com/hooks/1"),
 				Timeout:          gu.Ptr(10 * time.Second),
 				InterruptOnError: gu.Ptr(true),
+				MaxAttempts:      gu.Ptr(uint8(0)),
 			},
 		},
 		{
@@ -187,7 +272,9 @@ func Test_updateTargetToCommand(t *testing.T) {
 				Name:     gu.Ptr("target 1"),
 				Endpoint: gu.Ptr("https://example.com/hooks/1"),
 				TargetType: &action.UpdateTargetRequest_RestAsync{
-					RestAsync: &action.SetRESTAsync{},
+					RestAsync: &action.SetRESTAsync{
+						MaxAttempts: 3,
+					},
 				},
 				Timeout: durationpb.New(10 * time.Second),
 			}},
@@ -197,6 +284,39 @@ func Test_updateTargetToCommand(t *testing.T) {
 				Endpoint:         gu.Ptr("https://example.com/hooks/1"),
 				Timeout:          gu.Ptr(10 * time.Second),
 				InterruptOnError: gu.Ptr(false),
+				MaxAttempts:      gu.Ptr(uint8(3)),
+			},
+		},
+		{
+			name: "all fields (grpc)",
+			args: args{&action.UpdateTargetRequest{
+				TargetType: &action.UpdateTargetRequest_Grpc{
+					Grpc: &action.SetGRPC{
+						InterruptOnError: true,
+					},
+				},
+			}},
+			want: &command.ChangeTarget{
+				TargetType:       gu.Ptr(domain.TargetTypeGRPC),
+				InterruptOnError: gu.Ptr(true),
+				MaxAttempts:      gu.Ptr(uint8(0)),
+			},
+		},
+		{
+			name: "all fields (circuit breaker)",
+			args: args{&action.UpdateTargetRequest{
+				MaxConcurrency: gu.Ptr(uint32(10)),
+				CircuitBreaker: &action.CircuitBreaker{
+					FailureThreshold: 5,
+					OpenDuration:     durationpb.New(30 * time.Second),
+				},
+			}},
+			want: &command.ChangeTarget{
+				MaxConcurrency: gu.Ptr(uint16(10)),
+				CircuitBreaker: &command.TargetCircuitBreaker{
+					FailureThreshold: 5,
+					OpenDuration:     30 * time.Second,
+				},
 			},
 		},
 		{
@@ -217,6 +337,7 @@ func Test_updateTargetToCommand(t *testing.T) {
 				Endpoint:         gu.Ptr("https://example.com/hooks/1"),
 				Timeout:          gu.Ptr(10 * time.Second),
 				InterruptOnError: gu.Ptr(true),
+				MaxAttempts:      gu.Ptr(uint8(0)),
 			},
 		},
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/handlers"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -396,6 +397,294 @@ func (s *Server) ResetCustomPasswordChangeMessageTextToDefault(ctx context.Conte
 	}, nil
 }
 
+func (s *Server) GetDefaultMFAAddedMessageText(ctx context.Context, req *admin_pb.GetDefaultMFAAddedMessageTextRequest) (*admin_pb.GetDefaultMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MFAAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMFAAddedMessageText(ctx context.Context, req *admin_pb.GetCustomMFAAddedMessageTextRequest) (*admin_pb.GetCustomMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MFAAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMFAAddedMessageText(ctx context.Context, req *admin_pb.SetDefaultMFAAddedMessageTextRequest) (*admin_pb.SetDefaultMFAAddedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMFAAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMFAAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFAAddedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMFAAddedMessageTextToDefaultRequest) (*admin_pb.ResetCustomMFAAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MFAAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMFAAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFARemovedMessageText(ctx context.Context, req *admin_pb.GetDefaultMFARemovedMessageTextRequest) (*admin_pb.GetDefaultMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MFARemovedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMFARemovedMessageText(ctx context.Context, req *admin_pb.GetCustomMFARemovedMessageTextRequest) (*admin_pb.GetCustomMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MFARemovedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMFARemovedMessageText(ctx context.Context, req *admin_pb.SetDefaultMFARemovedMessageTextRequest) (*admin_pb.SetDefaultMFARemovedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMFARemovedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMFARemovedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFARemovedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMFARemovedMessageTextToDefaultRequest) (*admin_pb.ResetCustomMFARemovedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MFARemovedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMFARemovedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultEmailChangedMessageText(ctx context.Context, req *admin_pb.GetDefaultEmailChangedMessageTextRequest) (*admin_pb.GetDefaultEmailChangedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.EmailChangedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultEmailChangedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomEmailChangedMessageText(ctx context.Context, req *admin_pb.GetCustomEmailChangedMessageTextRequest) (*admin_pb.GetCustomEmailChangedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.EmailChangedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomEmailChangedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultEmailChangedMessageText(ctx context.Context, req *admin_pb.SetDefaultEmailChangedMessageTextRequest) (*admin_pb.SetDefaultEmailChangedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetEmailChangedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultEmailChangedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomEmailChangedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomEmailChangedMessageTextToDefaultRequest) (*admin_pb.ResetCustomEmailChangedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.EmailChangedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomEmailChangedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultNewUserAgentLoginMessageText(ctx context.Context, req *admin_pb.GetDefaultNewUserAgentLoginMessageTextRequest) (*admin_pb.GetDefaultNewUserAgentLoginMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.NewUserAgentLoginMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultNewUserAgentLoginMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomNewUserAgentLoginMessageText(ctx context.Context, req *admin_pb.GetCustomNewUserAgentLoginMessageTextRequest) (*admin_pb.GetCustomNewUserAgentLoginMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.NewUserAgentLoginMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomNewUserAgentLoginMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultNewUserAgentLoginMessageText(ctx context.Context, req *admin_pb.SetDefaultNewUserAgentLoginMessageTextRequest) (*admin_pb.SetDefaultNewUserAgentLoginMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetNewUserAgentLoginCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultNewUserAgentLoginMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomNewUserAgentLoginMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomNewUserAgentLoginMessageTextToDefaultRequest) (*admin_pb.ResetCustomNewUserAgentLoginMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.NewUserAgentLoginMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomNewUserAgentLoginMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultUserLockedMessageText(ctx context.Context, req *admin_pb.GetDefaultUserLockedMessageTextRequest) (*admin_pb.GetDefaultUserLockedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.UserLockedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultUserLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomUserLockedMessageText(ctx context.Context, req *admin_pb.GetCustomUserLockedMessageTextRequest) (*admin_pb.GetCustomUserLockedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.UserLockedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomUserLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultUserLockedMessageText(ctx context.Context, req *admin_pb.SetDefaultUserLockedMessageTextRequest) (*admin_pb.SetDefaultUserLockedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetUserLockedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultUserLockedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomUserLockedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomUserLockedMessageTextToDefaultRequest) (*admin_pb.ResetCustomUserLockedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.UserLockedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomUserLockedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultPasswordExpiryWarningMessageText(ctx context.Context, req *admin_pb.GetDefaultPasswordExpiryWarningMessageTextRequest) (*admin_pb.GetDefaultPasswordExpiryWarningMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.PasswordExpiryWarningMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultPasswordExpiryWarningMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomPasswordExpiryWarningMessageText(ctx context.Context, req *admin_pb.GetCustomPasswordExpiryWarningMessageTextRequest) (*admin_pb.GetCustomPasswordExpiryWarningMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.PasswordExpiryWarningMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomPasswordExpiryWarningMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultPasswordExpiryWarningMessageText(ctx context.Context, req *admin_pb.SetDefaultPasswordExpiryWarningMessageTextRequest) (*admin_pb.SetDefaultPasswordExpiryWarningMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetPasswordExpiryWarningCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultPasswordExpiryWarningMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomPasswordExpiryWarningMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomPasswordExpiryWarningMessageTextToDefaultRequest) (*admin_pb.ResetCustomPasswordExpiryWarningMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.PasswordExpiryWarningMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomPasswordExpiryWarningMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetDefaultPasswordlessRegistrationMessageText(ctx context.Context, req *admin_pb.GetDefaultPasswordlessRegistrationMessageTextRequest) (*admin_pb.GetDefaultPasswordlessRegistrationMessageTextResponse, error) {
 	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.PasswordlessRegistrationMessageType, req.Language)
 	if err != nil {
@@ -490,3 +779,15 @@ func (s *Server) ResetCustomLoginTextToDefault(ctx context.Context, req *admin_p
 		),
 	}, nil
 }
+
+func (s *Server) PreviewMessageText(ctx context.Context, req *admin_pb.PreviewMessageTextRequest) (*admin_pb.PreviewMessageTextResponse, error) {
+	preview, err := handlers.PreviewMessage(ctx, s.query, req.OrgId, req.MessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.PreviewMessageTextResponse{
+		Subject: preview.Subject,
+		Html:    preview.HTML,
+		Text:    preview.Text,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -161,6 +162,32 @@ func UpdateSMTPToConfig(req *admin_pb.UpdateSMTPConfigRequest) *smtp.Config {
 	}
 }
 
+func AddSMTPConfigHTTPToConfig(req *admin_pb.AddSMTPConfigHTTPRequest) *httpemail.Config {
+	return &httpemail.Config{
+		SenderAddress:      req.SenderAddress,
+		SenderName:         req.SenderName,
+		ReplyToAddress:     req.ReplyToAddress,
+		Endpoint:           req.Endpoint,
+		Headers:            headersToConfig(req.Headers),
+		BodyTemplate:       req.BodyTemplate,
+		ContentType:        req.ContentType,
+		SuccessStatusCodes: statusCodesToConfig(req.SuccessStatusCodes),
+	}
+}
+
+func UpdateSMTPConfigHTTPToConfig(req *admin_pb.UpdateSMTPConfigHTTPRequest) *httpemail.Config {
+	return &httpemail.Config{
+		SenderAddress:      req.SenderAddress,
+		SenderName:         req.SenderName,
+		ReplyToAddress:     req.ReplyToAddress,
+		Endpoint:           req.Endpoint,
+		Headers:            updateHeadersToConfig(req.Headers, req.ClearHeaders),
+		BodyTemplate:       req.BodyTemplate,
+		ContentType:        req.ContentType,
+		SuccessStatusCodes: statusCodesToConfig(req.SuccessStatusCodes),
+	}
+}
+
 func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 	mapped := &settings_pb.SMTPConfig{
 		Description:    smtp.Description,
@@ -173,10 +200,45 @@ func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 		Details:        obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.ResourceOwner),
 		Id:             smtp.ID,
 		State:          settings_pb.SMTPConfigState(smtp.State),
+		Priority:       smtp.Priority,
+	}
+	if smtp.HTTPConfig != nil {
+		mapped.Http = &settings_pb.SMTPHTTPConfig{
+			Endpoint:           smtp.HTTPConfig.Endpoint,
+			BodyTemplate:       smtp.HTTPConfig.BodyTemplate,
+			ContentType:        smtp.HTTPConfig.ContentType,
+			SuccessStatusCodes: statusCodesToPb(smtp.HTTPConfig.SuccessStatusCodes),
+		}
 	}
+	mapped.Dkim = smtpDKIMConfigToPb(smtp.DKIM)
 	return mapped
 }
 
+func smtpDKIMConfigToPb(dkim *query.SMTPDKIM) *settings_pb.SMTPDKIMConfig {
+	if dkim == nil {
+		return nil
+	}
+	return &settings_pb.SMTPDKIMConfig{
+		Selector: dkim.Selector,
+		Domain:   dkim.Domain,
+	}
+}
+
+func setSMTPConfigDKIMToConfig(req *admin_pb.SetSMTPConfigDKIMRequest) *smtp.DKIM {
+	return &smtp.DKIM{
+		Selector:   req.Selector,
+		Domain:     req.Domain,
+		PrivateKey: req.PrivateKey,
+	}
+}
+
+func SMTPDKIMRecordToPb(record *smtp.DKIMRecord) *settings_pb.SMTPDKIMRecord {
+	return &settings_pb.SMTPDKIMRecord{
+		Name:  record.Name,
+		Value: record.Value,
+	}
+}
+
 func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolicy {
 	return &settings_pb.SecurityPolicy{
 		Details:               obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
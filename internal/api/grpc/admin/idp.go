@@ -6,6 +6,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
 	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
@@ -405,6 +406,40 @@ func (s *Server) UpdateLDAPProvider(ctx context.Context, req *admin_pb.UpdateLDA
 	}, nil
 }
 
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *admin_pb.GetLDAPProviderSyncRequest) (*admin_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPProviderSyncByIDAndResourceOwner(ctx, true, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetLDAPProviderSyncResponse{Sync: idp_grpc.LDAPSyncToPb(sync)}, nil
+}
+
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *admin_pb.SetLDAPProviderSyncRequest) (*admin_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetInstanceLDAPProviderSync(ctx, req.Id, setLDAPProviderSyncToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SyncLDAPProvider(ctx context.Context, req *admin_pb.SyncLDAPProviderRequest) (*admin_pb.SyncLDAPProviderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, command.LDAPSyncRequestTimeout)
+	defer cancel()
+	linkedUsers, err := s.query.LDAPProviderLinkedUsers(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.command.SyncInstanceLDAPProvider(ctx, req.Id, req.DryRun, linkedUsers)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SyncLDAPProviderResponse{
+		Report: idp_grpc.LDAPSyncReportToPb(report),
+	}, nil
+}
+
 func (s *Server) AddAppleProvider(ctx context.Context, req *admin_pb.AddAppleProviderRequest) (*admin_pb.AddAppleProviderResponse, error) {
 	id, details, err := s.command.AddInstanceAppleProvider(ctx, addAppleProviderToCommand(req))
 	if err != nil {
@@ -445,6 +445,15 @@ func updateLDAPProviderToCommand(req *admin_pb.UpdateLDAPProviderRequest) comman
 	}
 }
 
+func setLDAPProviderSyncToCommand(req *admin_pb.SetLDAPProviderSyncRequest) *command.LDAPSyncSettings {
+	return &command.LDAPSyncSettings{
+		Enabled:                req.Enabled,
+		DryRun:                 req.DryRun,
+		Interval:               req.Interval.AsDuration(),
+		MaxDeactivationPercent: req.MaxDeactivationPercent,
+	}
+}
+
 func addAppleProviderToCommand(req *admin_pb.AddAppleProviderRequest) command.AppleProvider {
 	return command.AppleProvider{
 		Name:       req.Name,
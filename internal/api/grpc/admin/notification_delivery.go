@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/notification"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotificationDeliveries(ctx context.Context, req *admin_pb.ListNotificationDeliveriesRequest) (*admin_pb.ListNotificationDeliveriesResponse, error) {
+	queries, err := notification.ListQueryToDeliverySearchQueries(req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchNotificationDeliveries(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationDeliveriesResponse{
+		Details: object.ToListDetails(res.Count, res.Sequence, res.LastRun),
+		Result:  notification.DeliveriesToPb(res.Deliveries),
+	}, nil
+}
+
+func (s *Server) ResendNotification(ctx context.Context, req *admin_pb.ResendNotificationRequest) (*admin_pb.ResendNotificationResponse, error) {
+	details, err := s.command.ResendNotification(ctx, req.Id, "")
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResendNotificationResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -1,22 +1,30 @@
 package admin
 
 import (
+	"net/http"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
 )
 
-func listSMSConfigsToModel(req *admin_pb.ListSMSProvidersRequest) (*query.SMSConfigsSearchQueries, error) {
+func listSMSConfigsToModel(req *admin_pb.ListSMSProvidersRequest, instanceID string) (*query.SMSConfigsSearchQueries, error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
+	resourceOwnerQuery, err := query.NewSMSProviderResourceOwnerQuery(instanceID)
+	if err != nil {
+		return nil, err
+	}
 	return &query.SMSConfigsSearchQueries{
 		SearchRequest: query.SearchRequest{
 			Offset: offset,
 			Limit:  limit,
 			Asc:    asc,
 		},
+		Queries: []query.SearchQuery{resourceOwnerQuery},
 	}, nil
 }
 
@@ -41,6 +49,9 @@ func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
 	if config.TwilioConfig != nil {
 		return TwilioConfigToPb(config.TwilioConfig)
 	}
+	if config.HTTPConfig != nil {
+		return HTTPConfigToPb(config.HTTPConfig)
+	}
 	return nil
 }
 
@@ -53,6 +64,32 @@ func TwilioConfigToPb(twilio *query.Twilio) *settings_pb.SMSProvider_Twilio {
 	}
 }
 
+func HTTPConfigToPb(config *query.HTTP) *settings_pb.SMSProvider_Http {
+	return &settings_pb.SMSProvider_Http{
+		Http: &settings_pb.HTTPConfig{
+			Endpoint:           config.Endpoint,
+			Method:             config.Method,
+			BodyTemplate:       config.BodyTemplate,
+			ContentType:        config.ContentType,
+			SenderId:           config.SenderID,
+			SuccessStatusCodes: statusCodesToPb(config.SuccessStatusCodes),
+			SuccessJsonPath:    config.SuccessJSONPath,
+			SuccessJsonValue:   config.SuccessJSONValue,
+		},
+	}
+}
+
+func statusCodesToPb(codes []int) []int32 {
+	if len(codes) == 0 {
+		return nil
+	}
+	c := make([]int32, len(codes))
+	for i, code := range codes {
+		c[i] = int32(code)
+	}
+	return c
+}
+
 func smsStateToPb(state domain.SMSConfigState) settings_pb.SMSProviderConfigState {
 	switch state {
 	case domain.SMSConfigStateInactive:
@@ -78,3 +115,63 @@ func UpdateSMSConfigTwilioToConfig(req *admin_pb.UpdateSMSProviderTwilioRequest)
 		SenderNumber: req.SenderNumber,
 	}
 }
+
+func AddSMSConfigHTTPToConfig(req *admin_pb.AddSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		Endpoint:           req.Endpoint,
+		Method:             req.Method,
+		Headers:            headersToConfig(req.Headers),
+		BodyTemplate:       req.BodyTemplate,
+		ContentType:        req.ContentType,
+		SenderID:           req.SenderId,
+		SuccessStatusCodes: statusCodesToConfig(req.SuccessStatusCodes),
+		SuccessJSONPath:    req.SuccessJsonPath,
+		SuccessJSONValue:   req.SuccessJsonValue,
+	}
+}
+
+func UpdateSMSConfigHTTPToConfig(req *admin_pb.UpdateSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		Endpoint:           req.Endpoint,
+		Method:             req.Method,
+		Headers:            updateHeadersToConfig(req.Headers, req.ClearHeaders),
+		BodyTemplate:       req.BodyTemplate,
+		ContentType:        req.ContentType,
+		SenderID:           req.SenderId,
+		SuccessStatusCodes: statusCodesToConfig(req.SuccessStatusCodes),
+		SuccessJSONPath:    req.SuccessJsonPath,
+		SuccessJSONValue:   req.SuccessJsonValue,
+	}
+}
+
+// headersToConfig returns nil if no headers are set, so they are not changed on update
+func headersToConfig(headers map[string]string) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	h := make(http.Header, len(headers))
+	for key, value := range headers {
+		h.Set(key, value)
+	}
+	return h
+}
+
+// updateHeadersToConfig returns the headers of the request if they are set or cleared, otherwise nil, so they are not changed
+func updateHeadersToConfig(headers map[string]string, clearHeaders bool) http.Header {
+	h := headersToConfig(headers)
+	if h == nil && clearHeaders {
+		return http.Header{}
+	}
+	return h
+}
+
+func statusCodesToConfig(codes []int32) []int {
+	if len(codes) == 0 {
+		return nil
+	}
+	c := make([]int, len(codes))
+	for i, code := range codes {
+		c[i] = int(code)
+	}
+	return c
+}
@@ -20,9 +20,7 @@ func (s *Server) GetSMTPConfig(ctx context.Context, req *admin_pb.GetSMTPConfigR
 
 func (s *Server) GetSMTPConfigById(ctx context.Context, req *admin_pb.GetSMTPConfigByIdRequest) (*admin_pb.GetSMTPConfigByIdResponse, error) {
 	instanceID := authz.GetInstance(ctx).InstanceID()
-	resourceOwner := instanceID // Will be replaced when orgs have smtp configs
-
-	smtp, err := s.query.SMTPConfigByID(ctx, instanceID, resourceOwner, req.Id)
+	smtp, err := s.query.SMTPConfigByID(ctx, instanceID, instanceID, req.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +56,27 @@ func (s *Server) UpdateSMTPConfig(ctx context.Context, req *admin_pb.UpdateSMTPC
 	}, nil
 }
 
+func (s *Server) AddSMTPConfigHTTP(ctx context.Context, req *admin_pb.AddSMTPConfigHTTPRequest) (*admin_pb.AddSMTPConfigHTTPResponse, error) {
+	id, details, err := s.command.AddSMTPConfigHTTP(ctx, authz.GetInstance(ctx).InstanceID(), req.Description, AddSMTPConfigHTTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSMTPConfigHTTPResponse{
+		Details: object.DomainToAddDetailsPb(details),
+		Id:      id,
+	}, nil
+}
+
+func (s *Server) UpdateSMTPConfigHTTP(ctx context.Context, req *admin_pb.UpdateSMTPConfigHTTPRequest) (*admin_pb.UpdateSMTPConfigHTTPResponse, error) {
+	details, err := s.command.ChangeSMTPConfigHTTP(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.Description, UpdateSMTPConfigHTTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMTPConfigHTTPResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) RemoveSMTPConfig(ctx context.Context, req *admin_pb.RemoveSMTPConfigRequest) (*admin_pb.RemoveSMTPConfigResponse, error) {
 	details, err := s.command.RemoveSMTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
 	if err != nil {
@@ -84,8 +103,45 @@ func (s *Server) UpdateSMTPConfigPassword(ctx context.Context, req *admin_pb.Upd
 	}, nil
 }
 
+func (s *Server) SetSMTPConfigDKIM(ctx context.Context, req *admin_pb.SetSMTPConfigDKIMRequest) (*admin_pb.SetSMTPConfigDKIMResponse, error) {
+	record, details, err := s.command.SetSMTPConfigDKIM(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, setSMTPConfigDKIMToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetSMTPConfigDKIMResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner),
+		Record: SMTPDKIMRecordToPb(record),
+	}, nil
+}
+
+func (s *Server) RemoveSMTPConfigDKIM(ctx context.Context, req *admin_pb.RemoveSMTPConfigDKIMRequest) (*admin_pb.RemoveSMTPConfigDKIMResponse, error) {
+	details, err := s.command.RemoveSMTPConfigDKIM(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveSMTPConfigDKIMResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner),
+	}, nil
+}
+
+func (s *Server) VerifySMTPConfigDKIM(ctx context.Context, req *admin_pb.VerifySMTPConfigDKIMRequest) (*admin_pb.VerifySMTPConfigDKIMResponse, error) {
+	record, err := s.command.VerifySMTPConfigDKIM(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.VerifySMTPConfigDKIMResponse{
+		Record: SMTPDKIMRecordToPb(record),
+	}, nil
+}
+
 func (s *Server) ListSMTPConfigs(ctx context.Context, req *admin_pb.ListSMTPConfigsRequest) (*admin_pb.ListSMTPConfigsResponse, error) {
-	queries, err := listSMTPConfigsToModel(req)
+	queries, err := listSMTPConfigsToModel(req, authz.GetInstance(ctx).InstanceID())
 	if err != nil {
 		return nil, err
 	}
@@ -100,14 +156,19 @@ func (s *Server) ListSMTPConfigs(ctx context.Context, req *admin_pb.ListSMTPConf
 }
 
 func (s *Server) ActivateSMTPConfig(ctx context.Context, req *admin_pb.ActivateSMTPConfigRequest) (*admin_pb.ActivateSMTPConfigResponse, error) {
-	// Get the ID of current SMTP active provider if any
-	currentActiveProviderID := ""
-	smtp, err := s.query.SMTPConfigActive(ctx, authz.GetInstance(ctx).InstanceID())
-	if err == nil {
-		currentActiveProviderID = smtp.ID
+	// Get the IDs of the current SMTP active providers if any, they are deactivated unless they should be kept as failover
+	var currentActiveProviderIDs []string
+	if !req.KeepActiveProviders {
+		smtps, err := s.query.SMTPConfigsActive(ctx, authz.GetInstance(ctx).InstanceID())
+		if err == nil {
+			currentActiveProviderIDs = make([]string, len(smtps))
+			for i, smtp := range smtps {
+				currentActiveProviderIDs[i] = smtp.ID
+			}
+		}
 	}
 
-	result, err := s.command.ActivateSMTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, currentActiveProviderID)
+	result, err := s.command.ActivateSMTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, currentActiveProviderIDs...)
 	if err != nil {
 		return nil, err
 
@@ -118,6 +179,16 @@ func (s *Server) ActivateSMTPConfig(ctx context.Context, req *admin_pb.ActivateS
 	}, nil
 }
 
+func (s *Server) SetSMTPConfigPriority(ctx context.Context, req *admin_pb.SetSMTPConfigPriorityRequest) (*admin_pb.SetSMTPConfigPriorityResponse, error) {
+	result, err := s.command.SetSMTPConfigPriority(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.Priority)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetSMTPConfigPriorityResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
 func (s *Server) DeactivateSMTPConfig(ctx context.Context, req *admin_pb.DeactivateSMTPConfigRequest) (*admin_pb.DeactivateSMTPConfigResponse, error) {
 	result, err := s.command.DeactivateSMTPConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
 	if err != nil {
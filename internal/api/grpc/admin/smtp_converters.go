@@ -7,14 +7,19 @@ import (
 	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
 )
 
-func listSMTPConfigsToModel(req *admin_pb.ListSMTPConfigsRequest) (*query.SMTPConfigsSearchQueries, error) {
+func listSMTPConfigsToModel(req *admin_pb.ListSMTPConfigsRequest, instanceID string) (*query.SMTPConfigsSearchQueries, error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
+	resourceOwnerQuery, err := query.NewSMTPConfigResourceOwnerSearchQuery(instanceID)
+	if err != nil {
+		return nil, err
+	}
 	return &query.SMTPConfigsSearchQueries{
 		SearchRequest: query.SearchRequest{
 			Offset: offset,
 			Limit:  limit,
 			Asc:    asc,
 		},
+		Queries: []query.SearchQuery{resourceOwnerQuery},
 	}, nil
 }
 
@@ -29,6 +34,8 @@ func SMTPConfigToProviderPb(config *query.SMTPConfig) *settings_pb.SMTPConfig {
 		State:         settings_pb.SMTPConfigState(config.State),
 		SenderAddress: config.SenderAddress,
 		SenderName:    config.SenderName,
+		Priority:      config.Priority,
+		Dkim:          smtpDKIMConfigToPb(config.DKIM),
 	}
 }
 
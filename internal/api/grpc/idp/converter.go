@@ -4,6 +4,7 @@ import (
 	"github.com/crewjam/saml"
 	"github.com/muhlemmer/gu"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -643,6 +644,69 @@ func ldapAttributesToPb(attributes idp.LDAPAttributes) *idp_pb.LDAPAttributes {
 	}
 }
 
+func LDAPSyncToPb(sync *query.LDAPProviderSync) *idp_pb.LDAPSync {
+	pb := &idp_pb.LDAPSync{
+		Details:                obj_grpc.ToViewDetailsPb(sync.Sequence, sync.ChangeDate, sync.ChangeDate, sync.ResourceOwner),
+		Enabled:                sync.Enabled,
+		DryRun:                 sync.DryRun,
+		LastReport:             LDAPSyncReportToPb(sync.LastSyncReport),
+		MaxDeactivationPercent: sync.MaxDeactivationPercent,
+	}
+	if sync.Interval != 0 {
+		pb.Interval = durationpb.New(sync.Interval)
+	}
+	if !sync.LastSyncDate.IsZero() {
+		pb.LastSyncDate = timestamppb.New(sync.LastSyncDate)
+	}
+	return pb
+}
+
+func LDAPSyncReportToPb(report *domain.LDAPSyncReport) *idp_pb.LDAPSyncReport {
+	if report == nil {
+		return nil
+	}
+	entries := make([]*idp_pb.LDAPSyncReportEntry, len(report.Entries))
+	for i, entry := range report.Entries {
+		entries[i] = &idp_pb.LDAPSyncReportEntry{
+			Action:         ldapSyncActionToPb(entry.Action),
+			UserId:         entry.UserID,
+			ExternalUserId: entry.ExternalUserID,
+			Username:       entry.Username,
+			Error:          entry.Error,
+		}
+	}
+	return &idp_pb.LDAPSyncReport{
+		DryRun:                    report.DryRun,
+		StartedAt:                 timestamppb.New(report.StartedAt),
+		FinishedAt:                timestamppb.New(report.FinishedAt),
+		Found:                     report.Found,
+		Created:                   report.Created,
+		Updated:                   report.Updated,
+		Deactivated:               report.Deactivated,
+		Failed:                    report.Failed,
+		Entries:                   entries,
+		Truncated:                 report.Truncated,
+		DeactivationLimitExceeded: report.DeactivationLimitExceeded,
+	}
+}
+
+func ldapSyncActionToPb(action domain.LDAPSyncAction) idp_pb.LDAPSyncAction {
+	switch action {
+	case domain.LDAPSyncActionCreated:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_CREATED
+	case domain.LDAPSyncActionUpdated:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_UPDATED
+	case domain.LDAPSyncActionDeactivated:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_DEACTIVATED
+	case domain.LDAPSyncActionFailed:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_FAILED
+	case domain.LDAPSyncActionUnspecified:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_UNSPECIFIED
+	default:
+		return idp_pb.LDAPSyncAction_LDAP_SYNC_ACTION_UNSPECIFIED
+	}
+}
+
 func appleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.AppleIDPTemplate) {
 	providerConfig.Config = &idp_pb.ProviderConfig_Apple{
 		Apple: &idp_pb.AppleConfig{
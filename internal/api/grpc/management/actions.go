@@ -2,7 +2,9 @@ package management
 
 import (
 	"context"
+	"time"
 
+	"github.com/zitadel/zitadel/internal/actions/dryrun"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -96,3 +98,76 @@ func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequ
 	_, err = s.command.DeleteAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID, flowTypes...)
 	return &mgmt_pb.DeleteActionResponse{}, err
 }
+
+func (s *Server) ListActionStorageEntries(ctx context.Context, req *mgmt_pb.ListActionStorageEntriesRequest) (*mgmt_pb.ListActionStorageEntriesResponse, error) {
+	offset, limit, _ := obj_grpc.ListQueryToModel(req.GetQuery())
+	entries, err := s.actionStorage.Entries(ctx, authz.GetCtxData(ctx).OrgID, req.GetKeyPrefix(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	result, err := action_grpc.StorageEntriesToPb(entries.Entries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionStorageEntriesResponse{
+		Details: obj_grpc.ToListDetails(entries.Count, 0, time.Time{}),
+		Result:  result,
+		Size:    uint64(entries.Size),
+	}, nil
+}
+
+func (s *Server) GetActionStorageEntry(ctx context.Context, req *mgmt_pb.GetActionStorageEntryRequest) (*mgmt_pb.GetActionStorageEntryResponse, error) {
+	entry, err := s.actionStorage.Get(ctx, authz.GetCtxData(ctx).OrgID, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	pb, err := action_grpc.StorageEntryToPb(entry)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetActionStorageEntryResponse{
+		Entry: pb,
+	}, nil
+}
+
+func (s *Server) RemoveActionStorageEntry(ctx context.Context, req *mgmt_pb.RemoveActionStorageEntryRequest) (*mgmt_pb.RemoveActionStorageEntryResponse, error) {
+	if err := s.actionStorage.Delete(ctx, authz.GetCtxData(ctx).OrgID, req.GetKey()); err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionStorageEntryResponse{}, nil
+}
+
+func (s *Server) ClearActionStorage(ctx context.Context, _ *mgmt_pb.ClearActionStorageRequest) (*mgmt_pb.ClearActionStorageResponse, error) {
+	if err := s.actionStorage.Clear(ctx, authz.GetCtxData(ctx).OrgID); err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ClearActionStorageResponse{}, nil
+}
+
+func (s *Server) DryRunAction(ctx context.Context, req *mgmt_pb.DryRunActionRequest) (*mgmt_pb.DryRunActionResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	action := dryRunScriptToAction(req.GetScript())
+	if req.GetActionId() != "" {
+		queriedAction, err := s.query.GetActionByID(ctx, req.GetActionId(), orgID, false)
+		if err != nil {
+			return nil, err
+		}
+		action = &dryrun.Action{
+			Name:    queriedAction.Name,
+			Script:  queriedAction.Script,
+			Timeout: queriedAction.Timeout(),
+		}
+	}
+	result, err := dryrun.Run(
+		ctx,
+		action_grpc.FlowTypeToDomain(req.GetFlowType()),
+		action_grpc.TriggerTypeToDomain(req.GetTriggerType()),
+		orgID,
+		action,
+		req.GetContext().AsMap(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return dryRunResultToPb(result)
+}
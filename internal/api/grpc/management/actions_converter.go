@@ -1,12 +1,20 @@
 package management
 
 import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/actions/dryrun"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
+	action_pb "github.com/zitadel/zitadel/pkg/grpc/action"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
@@ -65,3 +73,87 @@ func ActionQueryToQuery(query interface{}) (query.SearchQuery, error) {
 	}
 	return nil, zerrors.ThrowInvalidArgument(nil, "MGMT-dsg3z", "Errors.Query.InvalidRequest")
 }
+
+func dryRunScriptToAction(script *mgmt_pb.DryRunScript) *dryrun.Action {
+	return &dryrun.Action{
+		Name:    script.GetName(),
+		Script:  script.GetScript(),
+		Timeout: script.GetTimeout().AsDuration(),
+	}
+}
+
+func dryRunResultToPb(result *dryrun.Result) (_ *mgmt_pb.DryRunActionResponse, err error) {
+	resp := &mgmt_pb.DryRunActionResponse{
+		Metadata:       make([]*action_pb.DryRunMetadata, len(result.Metadata)),
+		ClaimLogs:      result.ClaimLogs,
+		Attributes:     make([]*action_pb.DryRunAttribute, len(result.Attributes)),
+		UserGrants:     make([]*action_pb.DryRunUserGrant, len(result.UserGrants)),
+		StorageChanges: make([]*action_pb.DryRunStorageChange, len(result.StorageChanges)),
+		Logs:           make([]*action_pb.DryRunLog, len(result.Logs)),
+	}
+	if resp.User, err = mapToStructPb(result.User); err != nil {
+		return nil, err
+	}
+	if resp.Claims, err = mapToStructPb(result.Claims); err != nil {
+		return nil, err
+	}
+	for i, metadata := range result.Metadata {
+		resp.Metadata[i] = &action_pb.DryRunMetadata{
+			Key:   metadata.Key,
+			Value: metadata.Value,
+		}
+	}
+	for i, attribute := range result.Attributes {
+		resp.Attributes[i] = &action_pb.DryRunAttribute{
+			Name:       attribute.Name,
+			NameFormat: attribute.NameFormat,
+			Values:     attribute.Values,
+		}
+	}
+	for i, grant := range result.UserGrants {
+		resp.UserGrants[i] = &action_pb.DryRunUserGrant{
+			ProjectId:      grant.ProjectID,
+			ProjectGrantId: grant.ProjectGrantID,
+			Roles:          grant.RoleKeys,
+		}
+	}
+	for i, change := range result.StorageChanges {
+		resp.StorageChanges[i] = &action_pb.DryRunStorageChange{
+			Key:     change.Key,
+			Ttl:     durationpb.New(change.TTL),
+			Deleted: change.Deleted,
+		}
+		if change.Deleted {
+			continue
+		}
+		resp.StorageChanges[i].Value = new(structpb.Value)
+		if err = resp.StorageChanges[i].Value.UnmarshalJSON(change.Value); err != nil {
+			return nil, err
+		}
+	}
+	for i, log := range result.Logs {
+		resp.Logs[i] = &action_pb.DryRunLog{
+			LogDate: timestamppb.New(log.LogDate),
+			Level:   log.LogLevel.String(),
+			Message: log.Message,
+		}
+	}
+	if result.Err != nil {
+		resp.Error = result.Err.Error()
+	}
+	return resp, nil
+}
+
+// mapToStructPb converts the values set by the action through JSON,
+// as they are not limited to the types supported by [structpb.NewStruct]
+func mapToStructPb(m map[string]any) (*structpb.Struct, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "MANAG-Xk2qp", "Errors.Internal")
+	}
+	s := new(structpb.Struct)
+	if err = s.UnmarshalJSON(data); err != nil {
+		return nil, zerrors.ThrowInternal(err, "MANAG-n7Dw4", "Errors.Internal")
+	}
+	return s, nil
+}
@@ -396,6 +396,294 @@ func (s *Server) ResetCustomPasswordChangeMessageTextToDefault(ctx context.Conte
 	}, nil
 }
 
+func (s *Server) GetCustomMFAAddedMessageText(ctx context.Context, req *mgmt_pb.GetCustomMFAAddedMessageTextRequest) (*mgmt_pb.GetCustomMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.MFAAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFAAddedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultMFAAddedMessageTextRequest) (*mgmt_pb.GetDefaultMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.MFAAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomMFAAddedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomMFAAddedMessageTextRequest) (*mgmt_pb.SetCustomMFAAddedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetMFAAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMFAAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFAAddedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMFAAddedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomMFAAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.MFAAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMFAAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomMFARemovedMessageText(ctx context.Context, req *mgmt_pb.GetCustomMFARemovedMessageTextRequest) (*mgmt_pb.GetCustomMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.MFARemovedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFARemovedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultMFARemovedMessageTextRequest) (*mgmt_pb.GetDefaultMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.MFARemovedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomMFARemovedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomMFARemovedMessageTextRequest) (*mgmt_pb.SetCustomMFARemovedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetMFARemovedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMFARemovedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFARemovedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMFARemovedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomMFARemovedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.MFARemovedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMFARemovedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomEmailChangedMessageText(ctx context.Context, req *mgmt_pb.GetCustomEmailChangedMessageTextRequest) (*mgmt_pb.GetCustomEmailChangedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.EmailChangedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomEmailChangedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultEmailChangedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultEmailChangedMessageTextRequest) (*mgmt_pb.GetDefaultEmailChangedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.EmailChangedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultEmailChangedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomEmailChangedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomEmailChangedMessageTextRequest) (*mgmt_pb.SetCustomEmailChangedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetEmailChangedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomEmailChangedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomEmailChangedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomEmailChangedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomEmailChangedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.EmailChangedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomEmailChangedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomNewUserAgentLoginMessageText(ctx context.Context, req *mgmt_pb.GetCustomNewUserAgentLoginMessageTextRequest) (*mgmt_pb.GetCustomNewUserAgentLoginMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.NewUserAgentLoginMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomNewUserAgentLoginMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultNewUserAgentLoginMessageText(ctx context.Context, req *mgmt_pb.GetDefaultNewUserAgentLoginMessageTextRequest) (*mgmt_pb.GetDefaultNewUserAgentLoginMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.NewUserAgentLoginMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultNewUserAgentLoginMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomNewUserAgentLoginMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomNewUserAgentLoginMessageTextRequest) (*mgmt_pb.SetCustomNewUserAgentLoginMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetNewUserAgentLoginCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomNewUserAgentLoginMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomNewUserAgentLoginMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomNewUserAgentLoginMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomNewUserAgentLoginMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.NewUserAgentLoginMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomNewUserAgentLoginMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomUserLockedMessageText(ctx context.Context, req *mgmt_pb.GetCustomUserLockedMessageTextRequest) (*mgmt_pb.GetCustomUserLockedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.UserLockedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomUserLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultUserLockedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultUserLockedMessageTextRequest) (*mgmt_pb.GetDefaultUserLockedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.UserLockedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultUserLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomUserLockedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomUserLockedMessageTextRequest) (*mgmt_pb.SetCustomUserLockedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetUserLockedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomUserLockedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomUserLockedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomUserLockedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomUserLockedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.UserLockedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomUserLockedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomPasswordExpiryWarningMessageText(ctx context.Context, req *mgmt_pb.GetCustomPasswordExpiryWarningMessageTextRequest) (*mgmt_pb.GetCustomPasswordExpiryWarningMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.PasswordExpiryWarningMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomPasswordExpiryWarningMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultPasswordExpiryWarningMessageText(ctx context.Context, req *mgmt_pb.GetDefaultPasswordExpiryWarningMessageTextRequest) (*mgmt_pb.GetDefaultPasswordExpiryWarningMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.PasswordExpiryWarningMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultPasswordExpiryWarningMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomPasswordExpiryWarningMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomPasswordExpiryWarningMessageTextRequest) (*mgmt_pb.SetCustomPasswordExpiryWarningMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetPasswordExpiryWarningCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomPasswordExpiryWarningMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomPasswordExpiryWarningMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomPasswordExpiryWarningMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomPasswordExpiryWarningMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.PasswordExpiryWarningMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomPasswordExpiryWarningMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetCustomPasswordlessRegistrationMessageText(ctx context.Context, req *mgmt_pb.GetCustomPasswordlessRegistrationMessageTextRequest) (*mgmt_pb.GetCustomPasswordlessRegistrationMessageTextResponse, error) {
 	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.PasswordlessRegistrationMessageType, req.Language, false)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	idp_grpc "github.com/zitadel/zitadel/internal/api/grpc/idp"
 	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
@@ -397,6 +398,40 @@ func (s *Server) UpdateLDAPProvider(ctx context.Context, req *mgmt_pb.UpdateLDAP
 	}, nil
 }
 
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *mgmt_pb.GetLDAPProviderSyncRequest) (*mgmt_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPProviderSyncByIDAndResourceOwner(ctx, true, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetLDAPProviderSyncResponse{Sync: idp_grpc.LDAPSyncToPb(sync)}, nil
+}
+
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *mgmt_pb.SetLDAPProviderSyncRequest) (*mgmt_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetOrgLDAPProviderSync(ctx, authz.GetCtxData(ctx).OrgID, req.Id, setLDAPProviderSyncToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SyncLDAPProvider(ctx context.Context, req *mgmt_pb.SyncLDAPProviderRequest) (*mgmt_pb.SyncLDAPProviderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, command.LDAPSyncRequestTimeout)
+	defer cancel()
+	linkedUsers, err := s.query.LDAPProviderLinkedUsers(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.command.SyncOrgLDAPProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.DryRun, linkedUsers)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SyncLDAPProviderResponse{
+		Report: idp_grpc.LDAPSyncReportToPb(report),
+	}, nil
+}
+
 func (s *Server) AddAppleProvider(ctx context.Context, req *mgmt_pb.AddAppleProviderRequest) (*mgmt_pb.AddAppleProviderResponse, error) {
 	id, details, err := s.command.AddOrgAppleProvider(ctx, authz.GetCtxData(ctx).OrgID, addAppleProviderToCommand(req))
 	if err != nil {
@@ -438,6 +438,15 @@ func updateLDAPProviderToCommand(req *mgmt_pb.UpdateLDAPProviderRequest) command
 	}
 }
 
+func setLDAPProviderSyncToCommand(req *mgmt_pb.SetLDAPProviderSyncRequest) *command.LDAPSyncSettings {
+	return &command.LDAPSyncSettings{
+		Enabled:                req.Enabled,
+		DryRun:                 req.DryRun,
+		Interval:               req.Interval.AsDuration(),
+		MaxDeactivationPercent: req.MaxDeactivationPercent,
+	}
+}
+
 func addAppleProviderToCommand(req *mgmt_pb.AddAppleProviderRequest) command.AppleProvider {
 	return command.AppleProvider{
 		Name:       req.Name,
@@ -0,0 +1,41 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/notification"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListOrgNotificationDeliveries(ctx context.Context, req *mgmt_pb.ListOrgNotificationDeliveriesRequest) (*mgmt_pb.ListOrgNotificationDeliveriesResponse, error) {
+	queries, err := notification.ListQueryToDeliverySearchQueries(req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	orgQuery, err := query.NewNotificationDeliveryResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	queries.Queries = append(queries.Queries, orgQuery)
+	res, err := s.query.SearchNotificationDeliveries(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgNotificationDeliveriesResponse{
+		Details: object.ToListDetails(res.Count, res.Sequence, res.LastRun),
+		Result:  notification.DeliveriesToPb(res.Deliveries),
+	}, nil
+}
+
+func (s *Server) ResendOrgNotification(ctx context.Context, req *mgmt_pb.ResendOrgNotificationRequest) (*mgmt_pb.ResendOrgNotificationResponse, error) {
+	details, err := s.command.ResendNotification(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResendOrgNotificationResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
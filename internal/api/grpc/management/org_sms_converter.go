@@ -0,0 +1,161 @@
+package management
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func listOrgSMSConfigsToModel(req *mgmt_pb.ListOrgSMSProvidersRequest, orgID string) (*query.SMSConfigsSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	resourceOwnerQuery, err := query.NewSMSProviderResourceOwnerQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.SMSConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{resourceOwnerQuery},
+	}, nil
+}
+
+func orgSMSConfigsToPb(configs []*query.SMSConfig) []*settings_pb.SMSProvider {
+	c := make([]*settings_pb.SMSProvider, len(configs))
+	for i, config := range configs {
+		c[i] = orgSMSConfigToPb(config)
+	}
+	return c
+}
+
+func orgSMSConfigToPb(config *query.SMSConfig) *settings_pb.SMSProvider {
+	provider := &settings_pb.SMSProvider{
+		Details: object.ToViewDetailsPb(config.Sequence, config.CreationDate, config.ChangeDate, config.ResourceOwner),
+		Id:      config.ID,
+		State:   orgSMSStateToPb(config.State),
+	}
+	if config.TwilioConfig != nil {
+		provider.Config = &settings_pb.SMSProvider_Twilio{
+			Twilio: &settings_pb.TwilioConfig{
+				Sid:          config.TwilioConfig.SID,
+				SenderNumber: config.TwilioConfig.SenderNumber,
+			},
+		}
+	}
+	if config.HTTPConfig != nil {
+		provider.Config = &settings_pb.SMSProvider_Http{
+			Http: &settings_pb.HTTPConfig{
+				Endpoint:           config.HTTPConfig.Endpoint,
+				Method:             config.HTTPConfig.Method,
+				BodyTemplate:       config.HTTPConfig.BodyTemplate,
+				ContentType:        config.HTTPConfig.ContentType,
+				SenderId:           config.HTTPConfig.SenderID,
+				SuccessStatusCodes: orgSMSStatusCodesToPb(config.HTTPConfig.SuccessStatusCodes),
+				SuccessJsonPath:    config.HTTPConfig.SuccessJSONPath,
+				SuccessJsonValue:   config.HTTPConfig.SuccessJSONValue,
+			},
+		}
+	}
+	return provider
+}
+
+func orgSMSStateToPb(state domain.SMSConfigState) settings_pb.SMSProviderConfigState {
+	if state == domain.SMSConfigStateActive {
+		return settings_pb.SMSProviderConfigState_SMS_PROVIDER_CONFIG_ACTIVE
+	}
+	return settings_pb.SMSProviderConfigState_SMS_PROVIDER_CONFIG_INACTIVE
+}
+
+func orgSMSStatusCodesToPb(codes []int) []int32 {
+	if len(codes) == 0 {
+		return nil
+	}
+	c := make([]int32, len(codes))
+	for i, code := range codes {
+		c[i] = int32(code)
+	}
+	return c
+}
+
+func addOrgSMSConfigTwilioToConfig(req *mgmt_pb.AddOrgSMSProviderTwilioRequest) *twilio.Config {
+	return &twilio.Config{
+		SID:          req.Sid,
+		SenderNumber: req.SenderNumber,
+		Token:        req.Token,
+	}
+}
+
+func updateOrgSMSConfigTwilioToConfig(req *mgmt_pb.UpdateOrgSMSProviderTwilioRequest) *twilio.Config {
+	return &twilio.Config{
+		SID:          req.Sid,
+		SenderNumber: req.SenderNumber,
+	}
+}
+
+func addOrgSMSConfigHTTPToConfig(req *mgmt_pb.AddOrgSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		Endpoint:           req.Endpoint,
+		Method:             req.Method,
+		Headers:            orgSMSHeadersToConfig(req.Headers),
+		BodyTemplate:       req.BodyTemplate,
+		ContentType:        req.ContentType,
+		SenderID:           req.SenderId,
+		SuccessStatusCodes: orgSMSStatusCodesToConfig(req.SuccessStatusCodes),
+		SuccessJSONPath:    req.SuccessJsonPath,
+		SuccessJSONValue:   req.SuccessJsonValue,
+	}
+}
+
+func updateOrgSMSConfigHTTPToConfig(req *mgmt_pb.UpdateOrgSMSProviderHTTPRequest) *httpsms.Config {
+	return &httpsms.Config{
+		Endpoint:           req.Endpoint,
+		Method:             req.Method,
+		Headers:            updateOrgSMSHeadersToConfig(req.Headers, req.ClearHeaders),
+		BodyTemplate:       req.BodyTemplate,
+		ContentType:        req.ContentType,
+		SenderID:           req.SenderId,
+		SuccessStatusCodes: orgSMSStatusCodesToConfig(req.SuccessStatusCodes),
+		SuccessJSONPath:    req.SuccessJsonPath,
+		SuccessJSONValue:   req.SuccessJsonValue,
+	}
+}
+
+// orgSMSHeadersToConfig returns nil if no headers are set, so they are not changed on update
+func orgSMSHeadersToConfig(headers map[string]string) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	h := make(http.Header, len(headers))
+	for key, value := range headers {
+		h.Set(key, value)
+	}
+	return h
+}
+
+// updateOrgSMSHeadersToConfig returns the headers of the request if they are set or cleared, otherwise nil, so they are not changed
+func updateOrgSMSHeadersToConfig(headers map[string]string, clearHeaders bool) http.Header {
+	h := orgSMSHeadersToConfig(headers)
+	if h == nil && clearHeaders {
+		return http.Header{}
+	}
+	return h
+}
+
+func orgSMSStatusCodesToConfig(codes []int32) []int {
+	if len(codes) == 0 {
+		return nil
+	}
+	c := make([]int, len(codes))
+	for i, code := range codes {
+		c[i] = int(code)
+	}
+	return c
+}
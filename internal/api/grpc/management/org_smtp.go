@@ -0,0 +1,126 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListOrgSMTPConfigs(ctx context.Context, req *mgmt_pb.ListOrgSMTPConfigsRequest) (*mgmt_pb.ListOrgSMTPConfigsResponse, error) {
+	queries, err := listOrgSMTPConfigsToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchSMTPConfigs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgSMTPConfigsResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.LastRun),
+		Result:  orgSMTPConfigsToPb(result.Configs),
+	}, nil
+}
+
+func (s *Server) GetOrgSMTPConfig(ctx context.Context, req *mgmt_pb.GetOrgSMTPConfigRequest) (*mgmt_pb.GetOrgSMTPConfigResponse, error) {
+	smtp, err := s.query.SMTPConfigByID(ctx, authz.GetInstance(ctx).InstanceID(), authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetOrgSMTPConfigResponse{
+		SmtpConfig: orgSMTPConfigToPb(smtp),
+	}, nil
+}
+
+func (s *Server) AddOrgSMTPConfig(ctx context.Context, req *mgmt_pb.AddOrgSMTPConfigRequest) (*mgmt_pb.AddOrgSMTPConfigResponse, error) {
+	id, details, err := s.command.AddOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, addOrgSMTPConfigToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOrgSMTPConfigResponse{
+		Details: object.DomainToAddDetailsPb(details),
+		Id:      id,
+	}, nil
+}
+
+func (s *Server) UpdateOrgSMTPConfig(ctx context.Context, req *mgmt_pb.UpdateOrgSMTPConfigRequest) (*mgmt_pb.UpdateOrgSMTPConfigResponse, error) {
+	details, err := s.command.ChangeOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateOrgSMTPConfigToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateOrgSMTPConfigResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateOrgSMTPConfigPassword(ctx context.Context, req *mgmt_pb.UpdateOrgSMTPConfigPasswordRequest) (*mgmt_pb.UpdateOrgSMTPConfigPasswordResponse, error) {
+	details, err := s.command.ChangeOrgSMTPConfigPassword(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateOrgSMTPConfigPasswordResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ActivateOrgSMTPConfig(ctx context.Context, req *mgmt_pb.ActivateOrgSMTPConfigRequest) (*mgmt_pb.ActivateOrgSMTPConfigResponse, error) {
+	details, err := s.command.ActivateOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ActivateOrgSMTPConfigResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DeactivateOrgSMTPConfig(ctx context.Context, req *mgmt_pb.DeactivateOrgSMTPConfigRequest) (*mgmt_pb.DeactivateOrgSMTPConfigResponse, error) {
+	details, err := s.command.DeactivateOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.DeactivateOrgSMTPConfigResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgSMTPConfig(ctx context.Context, req *mgmt_pb.RemoveOrgSMTPConfigRequest) (*mgmt_pb.RemoveOrgSMTPConfigResponse, error) {
+	details, err := s.command.RemoveOrgSMTPConfig(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgSMTPConfigResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetOrgSMTPConfigDKIM(ctx context.Context, req *mgmt_pb.SetOrgSMTPConfigDKIMRequest) (*mgmt_pb.SetOrgSMTPConfigDKIMResponse, error) {
+	record, details, err := s.command.SetOrgSMTPConfigDKIM(ctx, authz.GetCtxData(ctx).OrgID, req.Id, setOrgSMTPConfigDKIMToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetOrgSMTPConfigDKIMResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+		Record:  smtpDKIMRecordToPb(record),
+	}, nil
+}
+
+func (s *Server) RemoveOrgSMTPConfigDKIM(ctx context.Context, req *mgmt_pb.RemoveOrgSMTPConfigDKIMRequest) (*mgmt_pb.RemoveOrgSMTPConfigDKIMResponse, error) {
+	details, err := s.command.RemoveOrgSMTPConfigDKIM(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgSMTPConfigDKIMResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) VerifyOrgSMTPConfigDKIM(ctx context.Context, req *mgmt_pb.VerifyOrgSMTPConfigDKIMRequest) (*mgmt_pb.VerifyOrgSMTPConfigDKIMResponse, error) {
+	record, err := s.command.VerifyOrgSMTPConfigDKIM(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.VerifyOrgSMTPConfigDKIMResponse{
+		Record: smtpDKIMRecordToPb(record),
+	}, nil
+}
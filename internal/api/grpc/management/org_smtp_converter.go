@@ -0,0 +1,104 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
+)
+
+func listOrgSMTPConfigsToModel(req *mgmt_pb.ListOrgSMTPConfigsRequest, orgID string) (*query.SMTPConfigsSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	resourceOwnerQuery, err := query.NewSMTPConfigResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.SMTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{resourceOwnerQuery},
+	}, nil
+}
+
+func addOrgSMTPConfigToConfig(req *mgmt_pb.AddOrgSMTPConfigRequest) *smtp.Config {
+	return &smtp.Config{
+		Description:    req.Description,
+		Tls:            req.Tls,
+		From:           req.SenderAddress,
+		FromName:       req.SenderName,
+		ReplyToAddress: req.ReplyToAddress,
+		SMTP: smtp.SMTP{
+			Host:     req.Host,
+			User:     req.User,
+			Password: req.Password,
+		},
+	}
+}
+
+func updateOrgSMTPConfigToConfig(req *mgmt_pb.UpdateOrgSMTPConfigRequest) *smtp.Config {
+	return &smtp.Config{
+		Description:    req.Description,
+		Tls:            req.Tls,
+		From:           req.SenderAddress,
+		FromName:       req.SenderName,
+		ReplyToAddress: req.ReplyToAddress,
+		SMTP: smtp.SMTP{
+			Host:     req.Host,
+			User:     req.User,
+			Password: req.Password,
+		},
+	}
+}
+
+func orgSMTPConfigsToPb(configs []*query.SMTPConfig) []*settings_pb.SMTPConfig {
+	c := make([]*settings_pb.SMTPConfig, len(configs))
+	for i, config := range configs {
+		c[i] = orgSMTPConfigToPb(config)
+	}
+	return c
+}
+
+func orgSMTPConfigToPb(config *query.SMTPConfig) *settings_pb.SMTPConfig {
+	return &settings_pb.SMTPConfig{
+		Details:        object.ToViewDetailsPb(config.Sequence, config.CreationDate, config.ChangeDate, config.ResourceOwner),
+		Id:             config.ID,
+		Description:    config.Description,
+		Tls:            config.TLS,
+		Host:           config.Host,
+		User:           config.User,
+		State:          settings_pb.SMTPConfigState(config.State),
+		SenderAddress:  config.SenderAddress,
+		SenderName:     config.SenderName,
+		ReplyToAddress: config.ReplyToAddress,
+		Dkim:           orgSMTPDKIMConfigToPb(config.DKIM),
+	}
+}
+
+func orgSMTPDKIMConfigToPb(dkim *query.SMTPDKIM) *settings_pb.SMTPDKIMConfig {
+	if dkim == nil {
+		return nil
+	}
+	return &settings_pb.SMTPDKIMConfig{
+		Selector: dkim.Selector,
+		Domain:   dkim.Domain,
+	}
+}
+
+func setOrgSMTPConfigDKIMToConfig(req *mgmt_pb.SetOrgSMTPConfigDKIMRequest) *smtp.DKIM {
+	return &smtp.DKIM{
+		Selector:   req.Selector,
+		Domain:     req.Domain,
+		PrivateKey: req.PrivateKey,
+	}
+}
+
+func smtpDKIMRecordToPb(record *smtp.DKIMRecord) *settings_pb.SMTPDKIMRecord {
+	return &settings_pb.SMTPDKIMRecord{
+		Name:  record.Name,
+		Value: record.Value,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
@@ -25,6 +26,7 @@ type Server struct {
 	management.UnimplementedManagementServiceServer
 	command        *command.Commands
 	query          *query.Queries
+	actionStorage  *actions.Storage
 	systemDefaults systemdefaults.SystemDefaults
 	assetAPIPrefix func(context.Context) string
 	userCodeAlg    crypto.EncryptionAlgorithm
@@ -34,6 +36,7 @@ type Server struct {
 func CreateServer(
 	command *command.Commands,
 	query *query.Queries,
+	actionStorage *actions.Storage,
 	sd systemdefaults.SystemDefaults,
 	userCodeAlg crypto.EncryptionAlgorithm,
 	externalSecure bool,
@@ -41,6 +44,7 @@ func CreateServer(
 	return &Server{
 		command:        command,
 		query:          query,
+		actionStorage:  actionStorage,
 		systemDefaults: sd,
 		assetAPIPrefix: assets.AssetAPI(externalSecure),
 		userCodeAlg:    userCodeAlg,
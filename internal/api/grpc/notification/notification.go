@@ -0,0 +1,139 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	notification_pb "github.com/zitadel/zitadel/pkg/grpc/notification"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
+)
+
+// ListQueryToDeliverySearchQueries converts the request to search queries,
+// the deliveries are sorted by their creation date
+func ListQueryToDeliverySearchQueries(listQuery *object_pb.ListQuery, deliveryQueries []*notification_pb.NotificationDeliveryQuery) (*query.NotificationDeliverySearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(listQuery)
+	queries, err := DeliveryQueriesToQuery(deliveryQueries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.NotificationDeliveryColumnCreationDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func DeliveryQueriesToQuery(queries []*notification_pb.NotificationDeliveryQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = DeliveryQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func DeliveryQueryToQuery(deliveryQuery *notification_pb.NotificationDeliveryQuery) (query.SearchQuery, error) {
+	switch q := deliveryQuery.Query.(type) {
+	case *notification_pb.NotificationDeliveryQuery_UserIdQuery:
+		return query.NewNotificationDeliveryUserIDSearchQuery(q.UserIdQuery.UserId)
+	case *notification_pb.NotificationDeliveryQuery_StateQuery:
+		return query.NewNotificationDeliveryStateSearchQuery(DeliveryStateToDomain(q.StateQuery.State))
+	case *notification_pb.NotificationDeliveryQuery_ChannelQuery:
+		channel, err := ChannelToDomain(q.ChannelQuery.Channel)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewNotificationDeliveryChannelSearchQuery(channel)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "NOTIF-Jr6qk", "List.Query.Invalid")
+	}
+}
+
+func DeliveriesToPb(deliveries []*query.NotificationDelivery) []*notification_pb.NotificationDelivery {
+	d := make([]*notification_pb.NotificationDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		d[i] = DeliveryToPb(delivery)
+	}
+	return d
+}
+
+func DeliveryToPb(delivery *query.NotificationDelivery) *notification_pb.NotificationDelivery {
+	return &notification_pb.NotificationDelivery{
+		Id: delivery.ID,
+		Details: object.ToViewDetailsPb(
+			delivery.Sequence,
+			delivery.CreationDate,
+			delivery.ChangeDate,
+			delivery.ResourceOwner,
+		),
+		State:               DeliveryStateToPb(delivery.State),
+		UserId:              delivery.UserID,
+		Channel:             ChannelToPb(delivery.Channel),
+		MessageType:         delivery.MessageType,
+		Recipient:           delivery.Recipient,
+		ProviderId:          delivery.Provider,
+		ProviderResponse:    delivery.Response,
+		TriggeringEventType: delivery.TriggeringEventType,
+	}
+}
+
+func DeliveryStateToPb(state domain.NotificationDeliveryState) notification_pb.NotificationDeliveryState {
+	switch state {
+	case domain.NotificationDeliveryStateDelivered:
+		return notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_DELIVERED
+	case domain.NotificationDeliveryStateFailed:
+		return notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_FAILED
+	case domain.NotificationDeliveryStateResendRequested:
+		return notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_RESEND_REQUESTED
+	case domain.NotificationDeliveryStateUnspecified:
+		return notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED
+	default:
+		return notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func DeliveryStateToDomain(state notification_pb.NotificationDeliveryState) domain.NotificationDeliveryState {
+	switch state {
+	case notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_DELIVERED:
+		return domain.NotificationDeliveryStateDelivered
+	case notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_FAILED:
+		return domain.NotificationDeliveryStateFailed
+	case notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_RESEND_REQUESTED:
+		return domain.NotificationDeliveryStateResendRequested
+	case notification_pb.NotificationDeliveryState_NOTIFICATION_DELIVERY_STATE_UNSPECIFIED:
+		return domain.NotificationDeliveryStateUnspecified
+	default:
+		return domain.NotificationDeliveryStateUnspecified
+	}
+}
+
+func ChannelToPb(channel domain.NotificationType) notification_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeEmail:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	case domain.NotificationTypeSms:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED
+	}
+}
+
+func ChannelToDomain(channel notification_pb.NotificationChannel) (domain.NotificationType, error) {
+	switch channel {
+	case notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL:
+		return domain.NotificationTypeEmail, nil
+	case notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS:
+		return domain.NotificationTypeSms, nil
+	case notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED:
+		return 0, zerrors.ThrowInvalidArgument(nil, "NOTIF-Wd3mz", "List.Query.Invalid")
+	default:
+		return 0, zerrors.ThrowInvalidArgument(nil, "NOTIF-Wd3mz", "List.Query.Invalid")
+	}
+}
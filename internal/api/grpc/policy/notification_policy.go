@@ -10,6 +10,7 @@ func ModelNotificationPolicyToPb(policy *query.NotificationPolicy) *policy_pb.No
 	return &policy_pb.NotificationPolicy{
 		IsDefault:      policy.IsDefault,
 		PasswordChange: policy.PasswordChange,
+		SecurityAlerts: policy.SecurityAlerts,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -26,6 +26,8 @@ type mockExecutionTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	Conditions       []string
+	MutableFields    []string
 }
 
 func (e *mockExecutionTarget) SetEndpoint(endpoint string) {
@@ -49,6 +51,34 @@ func (e *mockExecutionTarget) GetTargetID() string {
 func (e *mockExecutionTarget) GetExecutionID() string {
 	return e.ExecutionID
 }
+func (e *mockExecutionTarget) GetSigningKeys() []string {
+	return nil
+}
+func (e *mockExecutionTarget) GetHeaders() map[string]string {
+	return nil
+}
+func (e *mockExecutionTarget) IsJWTAuthentication() bool {
+	return false
+}
+func (e *mockExecutionTarget) GetClientCertificate() ([]byte, []byte) {
+	return nil, nil
+}
+func (e *mockExecutionTarget) GetRootCAs() []byte {
+	return nil
+}
+func (e *mockExecutionTarget) GetMaxConcurrency() uint16 {
+	return 0
+}
+func (e *mockExecutionTarget) GetCircuitBreaker() (uint16, time.Duration) {
+	return 0, 0
+}
+func (e *mockExecutionTarget) GetConditions() []string {
+	return e.Conditions
+}
+
+func (e *mockExecutionTarget) GetMutableFields() []string {
+	return e.MutableFields
+}
 
 type mockContentRequest struct {
 	Content string
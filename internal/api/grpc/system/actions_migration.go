@@ -0,0 +1,20 @@
+package system
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) MigrateActionsToExecutions(ctx context.Context, req *system.MigrateActionsToExecutionsRequest) (*system.MigrateActionsToExecutionsResponse, error) {
+	instanceID := req.GetInstanceId()
+	if authz.GetInstance(ctx).InstanceID() != instanceID {
+		ctx = authz.WithInstanceID(ctx, instanceID)
+	}
+	report, err := s.command.MigrateActionsV1(ctx, migrateActionsToCommand(req), instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return actionsMigrationReportToPb(report), nil
+}
@@ -0,0 +1,60 @@
+package system
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/action"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func migrateActionsToCommand(req *system.MigrateActionsToExecutionsRequest) *command.MigrateActionsV1 {
+	return &command.MigrateActionsV1{
+		Endpoint: req.GetEndpoint(),
+		DryRun:   req.GetDryRun(),
+	}
+}
+
+func actionsMigrationReportToPb(report *command.ActionsV1MigrationReport) *system.MigrateActionsToExecutionsResponse {
+	triggers := make([]*system.MigrateActionsTrigger, len(report.Triggers))
+	for i, trigger := range report.Triggers {
+		triggers[i] = &system.MigrateActionsTrigger{
+			OrgId:       trigger.OrgID,
+			FlowType:    action.FlowTypeToPb(trigger.FlowType),
+			TriggerType: action.TriggerTypeToPb(trigger.TriggerType),
+			Function:    trigger.Function,
+			Actions:     actionsMigrationActionsToPb(trigger.Actions),
+		}
+	}
+	executions := make([]*system.MigrateActionsExecution, len(report.Executions))
+	for i, execution := range report.Executions {
+		executions[i] = &system.MigrateActionsExecution{
+			Function:  execution.Function,
+			Condition: execution.Condition,
+			TargetIds: execution.TargetIDs,
+			Exists:    execution.Exists,
+
+			MultipleOrganizations: execution.MultipleOrganizations,
+		}
+	}
+	return &system.MigrateActionsToExecutionsResponse{
+		Triggers:   triggers,
+		Executions: executions,
+	}
+}
+
+func actionsMigrationActionsToPb(actions []*command.ActionsV1Action) []*system.MigrateActionsAction {
+	pb := make([]*system.MigrateActionsAction, len(actions))
+	for i, a := range actions {
+		pb[i] = &system.MigrateActionsAction{
+			ActionId:      a.ID,
+			Name:          a.Name,
+			Script:        a.Script,
+			Timeout:       durationpb.New(a.Timeout),
+			AllowedToFail: a.AllowedToFail,
+			TargetId:      a.TargetID,
+			Endpoint:      a.Endpoint,
+		}
+	}
+	return pb
+}
@@ -0,0 +1,77 @@
+//go:build integration
+
+package system_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/management"
+	"github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func TestServer_MigrateActionsToExecutions(t *testing.T) {
+	_, instanceID, iamOwnerCtx := Tester.UseIsolatedInstance(t, CTX, SystemCTX)
+
+	createdAction, err := Tester.Client.Mgmt.CreateAction(iamOwnerCtx, &management.CreateActionRequest{
+		Name:    "claims",
+		Script:  "function claims(ctx, api) {}",
+		Timeout: durationpb.New(0),
+	})
+	require.NoError(t, err)
+	_, err = Tester.Client.Mgmt.SetTriggerActions(iamOwnerCtx, &management.SetTriggerActionsRequest{
+		FlowType:    "2",
+		TriggerType: "5",
+		ActionIds:   []string{createdAction.GetId()},
+	})
+	require.NoError(t, err)
+	function := domain.FunctionName(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation)
+
+	t.Run("instance not found, error", func(t *testing.T) {
+		_, err := Tester.Client.System.MigrateActionsToExecutions(SystemCTX, &system.MigrateActionsToExecutionsRequest{
+			InstanceId: "notexisting",
+			DryRun:     true,
+		})
+		require.Error(t, err)
+	})
+	t.Run("dry run, report", func(t *testing.T) {
+		resp, err := Tester.Client.System.MigrateActionsToExecutions(SystemCTX, &system.MigrateActionsToExecutionsRequest{
+			InstanceId: instanceID,
+			Endpoint:   "https://example.com",
+			DryRun:     true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetTriggers(), 1)
+		assert.Equal(t, domain.FlowTypeCustomiseToken.ID(), resp.GetTriggers()[0].GetFlowType().GetId())
+		require.Len(t, resp.GetTriggers()[0].GetActions(), 1)
+		assert.Equal(t, createdAction.GetId(), resp.GetTriggers()[0].GetActions()[0].GetActionId())
+		assert.Empty(t, resp.GetTriggers()[0].GetActions()[0].GetTargetId())
+		require.Len(t, resp.GetExecutions(), 1)
+		assert.Equal(t, function, resp.GetExecutions()[0].GetFunction())
+		assert.Empty(t, resp.GetExecutions()[0].GetTargetIds())
+	})
+	t.Run("migrate, targets and execution created", func(t *testing.T) {
+		resp, err := Tester.Client.System.MigrateActionsToExecutions(SystemCTX, &system.MigrateActionsToExecutionsRequest{
+			InstanceId: instanceID,
+			Endpoint:   "https://example.com",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetExecutions(), 1)
+		assert.False(t, resp.GetExecutions()[0].GetExists())
+		assert.Len(t, resp.GetExecutions()[0].GetTargetIds(), 1)
+		assert.NotEmpty(t, resp.GetExecutions()[0].GetCondition())
+	})
+	t.Run("migrate again, execution exists", func(t *testing.T) {
+		resp, err := Tester.Client.System.MigrateActionsToExecutions(SystemCTX, &system.MigrateActionsToExecutionsRequest{
+			InstanceId: instanceID,
+			Endpoint:   "https://example.com",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetExecutions(), 1)
+		assert.True(t, resp.GetExecutions()[0].GetExists())
+	})
+}
@@ -513,60 +513,13 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, user *query.User, userGra
 
 		apiFields := actions.WithAPIFields(
 			actions.SetFields("v1",
-				actions.SetFields("userinfo",
-					actions.SetFields("setClaim", func(key string, value interface{}) {
-						if strings.HasPrefix(key, ClaimPrefix) {
-							return
-						}
-						if userInfo.Claims[key] == nil {
-							userInfo.AppendClaims(key, value)
-							return
-						}
-						claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", key))
-					}),
-					actions.SetFields("appendLogIntoClaims", func(entry string) {
-						claimLogs = append(claimLogs, entry)
-					}),
-				),
-				actions.SetFields("claims",
-					actions.SetFields("setClaim", func(key string, value interface{}) {
-						if strings.HasPrefix(key, ClaimPrefix) {
-							return
-						}
-						if userInfo.Claims[key] == nil {
-							userInfo.AppendClaims(key, value)
-							return
-						}
-						claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", key))
-					}),
-					actions.SetFields("appendLogIntoClaims", func(entry string) {
-						claimLogs = append(claimLogs, entry)
-					}),
-				),
+				object.ClaimFields("userinfo", ClaimPrefix, hasUserinfoClaim(userInfo), userInfo.AppendClaims, &claimLogs),
+				object.ClaimFields("claims", ClaimPrefix, hasUserinfoClaim(userInfo), userInfo.AppendClaims, &claimLogs),
 				actions.SetFields("user",
-					actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
-						if len(call.Arguments) != 2 {
-							panic("exactly 2 (key, value) arguments expected")
-						}
-						key := call.Arguments[0].Export().(string)
-						val := call.Arguments[1].Export()
-
-						value, err := json.Marshal(val)
-						if err != nil {
-							logging.WithError(err).Debug("unable to marshal")
-							panic(err)
-						}
-
-						metadata := &domain.Metadata{
-							Key:   key,
-							Value: value,
-						}
-						if _, err = o.command.SetUserMetadata(ctx, metadata, userInfo.Subject, user.ResourceOwner); err != nil {
-							logging.WithError(err).Info("unable to set md in action")
-							panic(err)
-						}
-						return nil
-					}),
+					actions.SetFields("setMetadata", object.SetMetadataFunc(func(metadata *domain.Metadata) error {
+						_, err := o.command.SetUserMetadata(ctx, metadata, userInfo.Subject, user.ResourceOwner)
+						return err
+					})),
 				),
 			),
 		)
@@ -577,7 +530,7 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, user *query.User, userGra
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, action.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
@@ -588,7 +541,20 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, user *query.User, userGra
 		}
 	}
 
-	return nil
+	function := domain.FunctionName(domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation)
+	response, err := functionExecution(ctx, o.query, &ContextInfo{
+		Function:   function,
+		UserInfo:   userInfo,
+		User:       user,
+		UserGrants: userGrants.Values(),
+	})
+	if err != nil {
+		return err
+	}
+	return applyFunctionResponse(ctx, o.command, function, userInfo.Subject, user.ResourceOwner, response,
+		func(key string) bool { return userInfo.Claims[key] != nil },
+		userInfo.AppendClaims,
+	)
 }
 
 func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clientID string, scopes []string) (claims map[string]interface{}, err error) {
@@ -725,45 +691,21 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 
 		apiFields := actions.WithAPIFields(
 			actions.SetFields("v1",
-				actions.SetFields("claims",
-					actions.SetFields("setClaim", func(key string, value interface{}) {
-						if strings.HasPrefix(key, ClaimPrefix) {
-							return
-						}
-						if _, ok := claims[key]; !ok {
-							claims = appendClaim(claims, key, value)
-							return
-						}
-						claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", key))
-					}),
-					actions.SetFields("appendLogIntoClaims", func(entry string) {
-						claimLogs = append(claimLogs, entry)
-					}),
+				object.ClaimFields("claims", ClaimPrefix,
+					func(key string) bool {
+						_, ok := claims[key]
+						return ok
+					},
+					func(key string, value interface{}) {
+						claims = appendClaim(claims, key, value)
+					},
+					&claimLogs,
 				),
 				actions.SetFields("user",
-					actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
-						if len(call.Arguments) != 2 {
-							panic("exactly 2 (key, value) arguments expected")
-						}
-						key := call.Arguments[0].Export().(string)
-						val := call.Arguments[1].Export()
-
-						value, err := json.Marshal(val)
-						if err != nil {
-							logging.WithError(err).Debug("unable to marshal")
-							panic(err)
-						}
-
-						metadata := &domain.Metadata{
-							Key:   key,
-							Value: value,
-						}
-						if _, err = o.command.SetUserMetadata(ctx, metadata, userID, user.ResourceOwner); err != nil {
-							logging.WithError(err).Info("unable to set md in action")
-							panic(err)
-						}
-						return nil
-					}),
+					actions.SetFields("setMetadata", object.SetMetadataFunc(func(metadata *domain.Metadata) error {
+						_, err := o.command.SetUserMetadata(ctx, metadata, userID, user.ResourceOwner)
+						return err
+					})),
 				),
 			),
 		)
@@ -774,7 +716,7 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, action.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
@@ -786,6 +728,28 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 		}
 	}
 
+	function := domain.FunctionName(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation)
+	response, err := functionExecution(ctx, o.query, &ContextInfo{
+		Function:   function,
+		Claims:     claims,
+		User:       user,
+		UserGrants: userGrants.Values(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = applyFunctionResponse(ctx, o.command, function, userID, user.ResourceOwner, response,
+		func(key string) bool {
+			_, ok := claims[key]
+			return ok
+		},
+		func(key string, value any) {
+			claims = appendClaim(claims, key, value)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
 
@@ -949,6 +913,12 @@ func appendClaim(claims map[string]interface{}, claim string, value interface{})
 	return claims
 }
 
+func hasUserinfoClaim(userInfo *oidc.UserInfo) func(key string) bool {
+	return func(key string) bool {
+		return userInfo.Claims[key] != nil
+	}
+}
+
 func userinfoClaims(userInfo *oidc.UserInfo) func(c *actions.FieldConfig) interface{} {
 	return func(c *actions.FieldConfig) interface{} {
 		marshalled, err := json.Marshal(userInfo)
@@ -0,0 +1,122 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var _ execution.ContextInfo = &ContextInfo{}
+
+// ContextInfo is sent to the targets of the function executions during the creation of tokens and userinfo
+type ContextInfo struct {
+	Function     string               `json:"function,omitempty"`
+	UserInfo     *oidc.UserInfo       `json:"userinfo,omitempty"`
+	Claims       map[string]any       `json:"claims,omitempty"`
+	User         *query.User          `json:"user,omitempty"`
+	UserMetadata []query.UserMetadata `json:"user_metadata,omitempty"`
+	Org          *query.UserInfoOrg   `json:"org,omitempty"`
+	UserGrants   []query.UserGrant    `json:"user_grants,omitempty"`
+	Response     *ContextInfoResponse `json:"response,omitempty"`
+}
+
+// ContextInfoResponse is the expected response of the targets,
+// the responses of multiple targets are combined.
+type ContextInfoResponse struct {
+	SetUserMetadata []*domain.Metadata `json:"set_user_metadata,omitempty"`
+	AppendClaims    []*AppendClaim     `json:"append_claims,omitempty"`
+	AppendLogClaims []string           `json:"append_log_claims,omitempty"`
+}
+
+type AppendClaim struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+func (c *ContextInfo) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *ContextInfo) SetHTTPResponseBody(resp []byte) error {
+	response := new(ContextInfoResponse)
+	if err := json.Unmarshal(resp, response); err != nil {
+		return err
+	}
+	if c.Response == nil {
+		c.Response = response
+		return nil
+	}
+	c.Response.SetUserMetadata = append(c.Response.SetUserMetadata, response.SetUserMetadata...)
+	c.Response.AppendClaims = append(c.Response.AppendClaims, response.AppendClaims...)
+	c.Response.AppendLogClaims = append(c.Response.AppendLogClaims, response.AppendLogClaims...)
+	return nil
+}
+
+func (c *ContextInfo) GetContent() interface{} {
+	return c.Response
+}
+
+// functionExecution calls the targets of the execution set for the function,
+// the combined response of the targets is returned, which is nil if no target responded.
+func functionExecution(ctx context.Context, queries execution.Queries, info *ContextInfo) (_ *ContextInfoResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	targets, err := execution.QueryExecutionTargetsForFunction(ctx, queries, info.Function)
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+	if _, err = execution.CallTargets(ctx, targets, info); err != nil {
+		return nil, err
+	}
+	return info.Response, nil
+}
+
+// applyFunctionResponse sets the user metadata and appends the claims of the response of a function execution.
+// Reserved and already existing claims are not overwritten, which is noted in the log claim of the function.
+func applyFunctionResponse(
+	ctx context.Context,
+	commands *command.Commands,
+	function, userID, resourceOwner string,
+	response *ContextInfoResponse,
+	claimExists func(key string) bool,
+	appendClaim func(key string, value any),
+) error {
+	if response == nil {
+		return nil
+	}
+	for _, metadata := range response.SetUserMetadata {
+		if _, err := commands.SetUserMetadata(ctx, metadata, userID, resourceOwner); err != nil {
+			return err
+		}
+	}
+	claimLogs := make([]string, 0, len(response.AppendLogClaims))
+	for _, claim := range response.AppendClaims {
+		if strings.HasPrefix(claim.Key, ClaimPrefix) {
+			continue
+		}
+		if !claimExists(claim.Key) {
+			appendClaim(claim.Key, claim.Value)
+			continue
+		}
+		claimLogs = append(claimLogs, fmt.Sprintf("key %q already exists", claim.Key))
+	}
+	claimLogs = append(claimLogs, response.AppendLogClaims...)
+	if len(claimLogs) > 0 {
+		appendClaim(fmt.Sprintf(ClaimActionLogFormat, function), claimLogs)
+	}
+	return nil
+}
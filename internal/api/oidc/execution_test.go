@@ -0,0 +1,76 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+)
+
+func TestContextInfo_SetHTTPResponseBody(t *testing.T) {
+	info := &ContextInfo{}
+	require.NoError(t, info.SetHTTPResponseBody([]byte(`{"append_claims":[{"key":"first","value":"value"}],"append_log_claims":["log1"]}`)))
+	require.NoError(t, info.SetHTTPResponseBody([]byte(`{"append_claims":[{"key":"second","value":1}],"append_log_claims":["log2"]}`)))
+	assert.Error(t, info.SetHTTPResponseBody([]byte(`invalid`)))
+
+	assert.Equal(t, &ContextInfoResponse{
+		AppendClaims: []*AppendClaim{
+			{Key: "first", Value: "value"},
+			{Key: "second", Value: float64(1)},
+		},
+		AppendLogClaims: []string{"log1", "log2"},
+	}, info.GetContent())
+}
+
+func Test_applyFunctionResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *ContextInfoResponse
+		want     map[string]any
+	}{
+		{
+			name:     "no response",
+			response: nil,
+			want:     map[string]any{"existing": "value"},
+		},
+		{
+			name: "claims appended",
+			response: &ContextInfoResponse{
+				AppendClaims: []*AppendClaim{
+					{Key: "claim", Value: "value"},
+				},
+			},
+			want: map[string]any{
+				"existing": "value",
+				"claim":    "value",
+			},
+		},
+		{
+			name: "reserved and existing claims ignored",
+			response: &ContextInfoResponse{
+				AppendClaims: []*AppendClaim{
+					{Key: ClaimPrefix + ":reserved", Value: "value"},
+					{Key: "existing", Value: "changed"},
+				},
+				AppendLogClaims: []string{"log"},
+			},
+			want: map[string]any{
+				"existing":                            "value",
+				"urn:zitadel:iam:action:function:log": []string{`key "existing" already exists`, "log"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userInfo := &oidc.UserInfo{Claims: map[string]any{"existing": "value"}}
+			err := applyFunctionResponse(context.Background(), nil, "function", "userID", "orgID", tt.response,
+				func(key string) bool { return userInfo.Claims[key] != nil },
+				userInfo.AppendClaims,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, userInfo.Claims)
+		})
+	}
+}
@@ -91,6 +91,13 @@ func (s *Server) getSignerOnce() signerFunc {
 	}
 }
 
+// Signer returns the signer of the instance's current signing key.
+// It is used to sign the JWT sent to execution targets.
+func (s *Server) Signer(ctx context.Context) (jose.Signer, error) {
+	signer, _, err := s.getSignerOnce()(ctx)
+	return signer, err
+}
+
 // userInfoFunc is a getter function that allows add-hoc retrieval of a user.
 type userInfoFunc func(ctx context.Context) (*oidc.UserInfo, error)
 
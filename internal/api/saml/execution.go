@@ -0,0 +1,76 @@
+package saml
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var _ execution.ContextInfo = &ContextInfo{}
+
+// ContextInfo is sent to the targets of the function executions during the creation of the SAML response
+type ContextInfo struct {
+	Function   string               `json:"function,omitempty"`
+	User       *query.User          `json:"user,omitempty"`
+	UserGrants []query.UserGrant    `json:"user_grants,omitempty"`
+	Response   *ContextInfoResponse `json:"response,omitempty"`
+}
+
+// ContextInfoResponse is the expected response of the targets,
+// the responses of multiple targets are combined.
+type ContextInfoResponse struct {
+	SetUserMetadata []*domain.Metadata `json:"set_user_metadata,omitempty"`
+	AppendAttribute []*AppendAttribute `json:"append_attribute,omitempty"`
+}
+
+type AppendAttribute struct {
+	Name       string   `json:"name"`
+	NameFormat string   `json:"name_format"`
+	Value      []string `json:"value"`
+}
+
+func (c *ContextInfo) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (c *ContextInfo) SetHTTPResponseBody(resp []byte) error {
+	response := new(ContextInfoResponse)
+	if err := json.Unmarshal(resp, response); err != nil {
+		return err
+	}
+	if c.Response == nil {
+		c.Response = response
+		return nil
+	}
+	c.Response.SetUserMetadata = append(c.Response.SetUserMetadata, response.SetUserMetadata...)
+	c.Response.AppendAttribute = append(c.Response.AppendAttribute, response.AppendAttribute...)
+	return nil
+}
+
+func (c *ContextInfo) GetContent() interface{} {
+	return c.Response
+}
+
+// functionExecution calls the targets of the execution set for the function,
+// the combined response of the targets is returned, which is nil if no target responded.
+func functionExecution(ctx context.Context, queries execution.Queries, info *ContextInfo) (_ *ContextInfoResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	targets, err := execution.QueryExecutionTargetsForFunction(ctx, queries, info.Function)
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+	if _, err = execution.CallTargets(ctx, targets, info); err != nil {
+		return nil, err
+	}
+	return info.Response, nil
+}
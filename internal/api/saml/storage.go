@@ -2,7 +2,6 @@ package saml
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/dop251/goja"
@@ -265,37 +264,21 @@ func (p *Storage) getCustomAttributes(ctx context.Context, user *query.User, use
 		apiFields := actions.WithAPIFields(
 			actions.SetFields("v1",
 				actions.SetFields("attributes",
-					actions.SetFields("setCustomAttribute", func(name string, nameFormat string, attributeValue ...string) {
-						if _, ok := customAttributes[name]; !ok {
-							customAttributes = appendCustomAttribute(customAttributes, name, nameFormat, attributeValue)
-							return
-						}
-					}),
+					actions.SetFields("setCustomAttribute", object.SetCustomAttributeFunc(
+						func(name string) bool {
+							_, ok := customAttributes[name]
+							return ok
+						},
+						func(name, nameFormat string, values []string) {
+							customAttributes = appendCustomAttribute(customAttributes, name, nameFormat, values)
+						},
+					)),
 				),
 				actions.SetFields("user",
-					actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
-						if len(call.Arguments) != 2 {
-							panic("exactly 2 (key, value) arguments expected")
-						}
-						key := call.Arguments[0].Export().(string)
-						val := call.Arguments[1].Export()
-
-						value, err := json.Marshal(val)
-						if err != nil {
-							logging.WithError(err).Debug("unable to marshal")
-							panic(err)
-						}
-
-						metadata := &domain.Metadata{
-							Key:   key,
-							Value: value,
-						}
-						if _, err = p.command.SetUserMetadata(ctx, metadata, user.ID, user.ResourceOwner); err != nil {
-							logging.WithError(err).Info("unable to set md in action")
-							panic(err)
-						}
-						return nil
-					}),
+					actions.SetFields("setMetadata", object.SetMetadataFunc(func(metadata *domain.Metadata) error {
+						_, err := p.command.SetUserMetadata(ctx, metadata, user.ID, user.ResourceOwner)
+						return err
+					})),
 				),
 			),
 		)
@@ -306,13 +289,33 @@ func (p *Storage) getCustomAttributes(ctx context.Context, user *query.User, use
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, action.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
 			return nil, err
 		}
 	}
+
+	contextInfo := &ContextInfo{
+		Function:   domain.FunctionName(domain.FlowTypeCustomizeSAMLResponse, domain.TriggerTypePreSAMLResponseCreation),
+		User:       user,
+		UserGrants: userGrants.Values(),
+	}
+	response, err := functionExecution(ctx, p.query, contextInfo)
+	if err != nil || response == nil {
+		return customAttributes, err
+	}
+	for _, metadata := range response.SetUserMetadata {
+		if _, err = p.command.SetUserMetadata(ctx, metadata, user.ID, user.ResourceOwner); err != nil {
+			return nil, err
+		}
+	}
+	for _, attribute := range response.AppendAttribute {
+		if _, ok := customAttributes[attribute.Name]; !ok {
+			customAttributes = appendCustomAttribute(customAttributes, attribute.Name, attribute.NameFormat, attribute.Value)
+		}
+	}
 	return customAttributes, nil
 }
 
@@ -151,7 +151,7 @@ func (l *Login) runPostExternalAuthenticationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, a.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
@@ -224,7 +224,7 @@ func (l *Login) runPostInternalAuthenticationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, a.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
@@ -343,7 +343,7 @@ func (l *Login) runPreCreationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, a.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
@@ -419,7 +419,7 @@ func (l *Login) runPostCreationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx), actions.WithCrypto(actionCtx), actions.WithStorage(actionCtx, a.ResourceOwner))...,
 		)
 		cancel()
 		if err != nil {
@@ -0,0 +1,294 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// actionV1MaxTimeout is the timeout used by actions (v1) if none or a greater one is set
+const actionV1MaxTimeout = 20 * time.Second
+
+// actionsV1Functions are the triggers of actions (v1) which call a function execution at the same point
+var actionsV1Functions = map[domain.FlowType][]domain.TriggerType{
+	domain.FlowTypeCustomiseToken: {
+		domain.TriggerTypePreUserinfoCreation,
+		domain.TriggerTypePreAccessTokenCreation,
+	},
+	domain.FlowTypeCustomizeSAMLResponse: {
+		domain.TriggerTypePreSAMLResponseCreation,
+	},
+}
+
+// ActionsV1FunctionName returns the function execution equivalent to the trigger of actions (v1),
+// it returns an empty string if the trigger has no equivalent.
+func ActionsV1FunctionName(flowType domain.FlowType, triggerType domain.TriggerType) string {
+	if !slices.Contains(actionsV1Functions[flowType], triggerType) {
+		return ""
+	}
+	return domain.FunctionName(flowType, triggerType)
+}
+
+type MigrateActionsV1 struct {
+	// Endpoint is the base URL of the services replacing the scripts,
+	// the endpoint of the target of an action is Endpoint/<org id>/<action id>
+	Endpoint string
+	// DryRun only reports the flows without creating targets and executions
+	DryRun bool
+}
+
+func (m *MigrateActionsV1) IsValid() error {
+	if m.DryRun {
+		return nil
+	}
+	endpoint, err := url.Parse(m.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-q8v2mzr4xk", "Errors.Target.InvalidURL")
+	}
+	return nil
+}
+
+func (m *MigrateActionsV1) targetEndpoint(orgID, actionID string) string {
+	return strings.TrimSuffix(m.Endpoint, "/") + "/" + orgID + "/" + actionID
+}
+
+// ActionsV1MigrationReport contains all triggers of actions (v1) with at least one active action
+// and the function executions created for them.
+type ActionsV1MigrationReport struct {
+	Triggers   []*ActionsV1Trigger
+	Executions []*ActionsV1Execution
+}
+
+type ActionsV1Trigger struct {
+	OrgID       string
+	FlowType    domain.FlowType
+	TriggerType domain.TriggerType
+	// Function is the name of the equivalent function execution, empty if the trigger has no equivalent
+	Function string
+	// Actions are the scripts which must be rewritten as external services
+	Actions []*ActionsV1Action
+}
+
+type ActionsV1Action struct {
+	ID            string
+	Name          string
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+	// TargetID is the id of the created target, empty if none was created
+	TargetID string
+	// Endpoint is the endpoint of the target, where the service replacing the script is expected
+	Endpoint string
+}
+
+type ActionsV1Execution struct {
+	Function string
+	// Condition restricts the execution to the users of the organization of the actions
+	Condition string
+	TargetIDs []string
+	// Exists is set if an execution for the function already existed, in which case it is not changed
+	Exists bool
+	// MultipleOrganizations is set if actions of multiple organizations use the trigger.
+	// As the execution can't be restricted to the users of each organization, it must be created manually.
+	MultipleOrganizations bool
+}
+
+// MigrateActionsV1 inventories the flows of actions (v1) of all organizations of the instance
+// and creates a target of type call for every action of a trigger with an equivalent function execution,
+// as well as the function executions calling these targets, restricted to the users of the organization.
+// An action used by multiple triggers is migrated to a single target and all targets and executions are pushed at once.
+// Existing executions are not changed and the flows of actions (v1) are kept, so they must be cleared after the services are available.
+// If actions of multiple organizations use the same trigger, nothing is migrated, as the calls would leak the users to the services of the other organizations.
+func (c *Commands) MigrateActionsV1(ctx context.Context, migrate *MigrateActionsV1, resourceOwner string) (_ *ActionsV1MigrationReport, err error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-x3c7kd9wfm", "Errors.IDMissing")
+	}
+	if err := migrate.IsValid(); err != nil {
+		return nil, err
+	}
+	rm := newActionsV1ReadModel(resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, rm); err != nil {
+		return nil, err
+	}
+	report := &ActionsV1MigrationReport{
+		Triggers: rm.report(),
+	}
+
+	migrations := make([]*actionsV1FunctionMigration, 0)
+	for _, flowType := range domain.AllFlowTypes() {
+		for _, triggerType := range flowType.TriggerTypes() {
+			function := ActionsV1FunctionName(flowType, triggerType)
+			if function == "" {
+				continue
+			}
+			triggers := report.triggersOfFunction(function)
+			if len(triggers) == 0 {
+				continue
+			}
+			migration, err := c.prepareActionsV1Function(ctx, migrate, resourceOwner, function, triggers)
+			if err != nil {
+				return nil, err
+			}
+			report.Executions = append(report.Executions, migration.execution)
+			if !migration.execution.Exists {
+				migrations = append(migrations, migration)
+			}
+		}
+	}
+	if migrate.DryRun || len(migrations) == 0 {
+		return report, nil
+	}
+
+	cmds := make([]eventstore.Command, 0, len(migrations))
+	// an action used by multiple triggers is called by the same target
+	targetIDs := make(map[string]string)
+	for _, migration := range migrations {
+		migrationCmds, err := c.migrateActionsV1Function(ctx, resourceOwner, migration, targetIDs)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, migrationCmds...)
+	}
+	if _, err := c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+type actionsV1FunctionMigration struct {
+	execution *ActionsV1Execution
+	triggers  []*ActionsV1Trigger
+	existing  *ExecutionWriteModel
+}
+
+// prepareActionsV1Function checks if the execution of the function can be created,
+// it fails if actions of multiple organizations use the trigger and it's not a dry run.
+func (c *Commands) prepareActionsV1Function(ctx context.Context, migrate *MigrateActionsV1, resourceOwner, function string, triggers []*ActionsV1Trigger) (*actionsV1FunctionMigration, error) {
+	migration := &actionsV1FunctionMigration{
+		execution: &ActionsV1Execution{
+			Function: function,
+		},
+		triggers: triggers,
+	}
+	var err error
+	migration.existing, err = c.getExecutionWriteModelByID(ctx, ExecutionFunctionCondition(function).ID(), resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if migration.existing.Exists() {
+		migration.execution.Exists = true
+		return migration, nil
+	}
+	if len(triggers) > 1 {
+		if !migrate.DryRun {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-f4wz8n2qcl", "Errors.Execution.MigrationMultipleOrgs")
+		}
+		migration.execution.MultipleOrganizations = true
+		return migration, nil
+	}
+	migration.execution.Condition = fmt.Sprintf("payload.user.resource_owner == %q", triggers[0].OrgID)
+	if migrate.Endpoint == "" {
+		return migration, nil
+	}
+	for _, a := range triggers[0].Actions {
+		a.Endpoint = migrate.targetEndpoint(triggers[0].OrgID, a.ID)
+	}
+	return migration, nil
+}
+
+// migrateActionsV1Function returns the commands adding a target for every action of the trigger without one in targetIDs
+// and setting the function execution calling them.
+func (c *Commands) migrateActionsV1Function(ctx context.Context, resourceOwner string, migration *actionsV1FunctionMigration, targetIDs map[string]string) ([]eventstore.Command, error) {
+	trigger := migration.triggers[0]
+	cmds := make([]eventstore.Command, 0, len(trigger.Actions)+1)
+	targets := make([]*execution.Target, 0, len(trigger.Actions))
+	for _, a := range trigger.Actions {
+		if _, ok := targetIDs[a.ID]; !ok {
+			add := &AddTarget{
+				Name:             fmt.Sprintf("actions-v1-%s-%s", trigger.OrgID, a.Name),
+				TargetType:       domain.TargetTypeCall,
+				Endpoint:         a.Endpoint,
+				Timeout:          a.Timeout,
+				InterruptOnError: !a.AllowedToFail,
+			}
+			_, cmd, _, err := c.addTargetCommand(ctx, add, resourceOwner)
+			if err != nil {
+				return nil, err
+			}
+			cmds = append(cmds, cmd)
+			targetIDs[a.ID] = add.AggregateID
+		}
+		a.TargetID = targetIDs[a.ID]
+		targets = append(targets, &execution.Target{Type: domain.ExecutionTargetTypeTarget, Target: a.TargetID})
+		migration.execution.TargetIDs = append(migration.execution.TargetIDs, a.TargetID)
+	}
+	return append(cmds, execution.NewSetEventV2(
+		ctx,
+		ExecutionAggregateFromWriteModel(&migration.existing.WriteModel),
+		targets,
+		migration.execution.Condition,
+		nil,
+	)), nil
+}
+
+// report returns the triggers with active actions ordered by organization, flow and trigger type
+func (rm *actionsV1ReadModel) report() []*ActionsV1Trigger {
+	orgIDs := make([]string, 0, len(rm.flows))
+	for orgID := range rm.flows {
+		orgIDs = append(orgIDs, orgID)
+	}
+	slices.Sort(orgIDs)
+
+	triggers := make([]*ActionsV1Trigger, 0)
+	for _, orgID := range orgIDs {
+		for _, flowType := range domain.AllFlowTypes() {
+			for _, triggerType := range flowType.TriggerTypes() {
+				trigger := &ActionsV1Trigger{
+					OrgID:       orgID,
+					FlowType:    flowType,
+					TriggerType: triggerType,
+					Function:    ActionsV1FunctionName(flowType, triggerType),
+				}
+				for _, id := range rm.flows[orgID][flowType][triggerType] {
+					a, ok := rm.actions[id]
+					if !ok || a.state != domain.ActionStateActive {
+						continue
+					}
+					timeout := a.timeout
+					if timeout <= 0 || timeout > actionV1MaxTimeout {
+						timeout = actionV1MaxTimeout
+					}
+					trigger.Actions = append(trigger.Actions, &ActionsV1Action{
+						ID:            id,
+						Name:          a.name,
+						Script:        a.script,
+						Timeout:       timeout,
+						AllowedToFail: a.allowedToFail,
+					})
+				}
+				if len(trigger.Actions) > 0 {
+					triggers = append(triggers, trigger)
+				}
+			}
+		}
+	}
+	return triggers
+}
+
+func (r *ActionsV1MigrationReport) triggersOfFunction(function string) []*ActionsV1Trigger {
+	triggers := make([]*ActionsV1Trigger, 0)
+	for _, trigger := range r.Triggers {
+		if trigger.Function == function {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
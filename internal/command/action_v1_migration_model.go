@@ -0,0 +1,133 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// actionsV1ReadModel collects the flows and actions (v1) of all organizations of the instance
+type actionsV1ReadModel struct {
+	eventstore.WriteModel
+
+	// flows contains the action ids of the triggers by organization and flow type
+	flows   map[string]map[domain.FlowType]map[domain.TriggerType][]string
+	actions map[string]*actionV1
+}
+
+type actionV1 struct {
+	orgID         string
+	name          string
+	script        string
+	timeout       time.Duration
+	allowedToFail bool
+	state         domain.ActionState
+}
+
+func newActionsV1ReadModel(instanceID string) *actionsV1ReadModel {
+	return &actionsV1ReadModel{
+		WriteModel: eventstore.WriteModel{
+			InstanceID: instanceID,
+		},
+		flows:   make(map[string]map[domain.FlowType]map[domain.TriggerType][]string),
+		actions: make(map[string]*actionV1),
+	}
+}
+
+func (rm *actionsV1ReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.TriggerActionsSetEvent:
+			rm.triggers(e.Aggregate().ID, e.FlowType)[e.TriggerType] = e.ActionIDs
+		case *org.TriggerActionsCascadeRemovedEvent:
+			triggers := rm.triggers(e.Aggregate().ID, e.FlowType)
+			for triggerType, actionIDs := range triggers {
+				triggers[triggerType] = slices.DeleteFunc(actionIDs, func(id string) bool {
+					return id == e.ActionID
+				})
+			}
+		case *org.FlowClearedEvent:
+			delete(rm.flows[e.Aggregate().ID], e.FlowType)
+		case *org.OrgRemovedEvent:
+			delete(rm.flows, e.Aggregate().ID)
+		case *action.AddedEvent:
+			rm.actions[e.Aggregate().ID] = &actionV1{
+				orgID:         e.Aggregate().ResourceOwner,
+				name:          e.Name,
+				script:        e.Script,
+				timeout:       e.Timeout,
+				allowedToFail: e.AllowedToFail,
+				state:         domain.ActionStateActive,
+			}
+		case *action.ChangedEvent:
+			a, ok := rm.actions[e.Aggregate().ID]
+			if !ok {
+				continue
+			}
+			if e.Name != nil {
+				a.name = *e.Name
+			}
+			if e.Script != nil {
+				a.script = *e.Script
+			}
+			if e.Timeout != nil {
+				a.timeout = *e.Timeout
+			}
+			if e.AllowedToFail != nil {
+				a.allowedToFail = *e.AllowedToFail
+			}
+		case *action.DeactivatedEvent:
+			rm.setActionState(e.Aggregate().ID, domain.ActionStateInactive)
+		case *action.ReactivatedEvent:
+			rm.setActionState(e.Aggregate().ID, domain.ActionStateActive)
+		case *action.RemovedEvent:
+			delete(rm.actions, e.Aggregate().ID)
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *actionsV1ReadModel) triggers(orgID string, flowType domain.FlowType) map[domain.TriggerType][]string {
+	flows, ok := rm.flows[orgID]
+	if !ok {
+		flows = make(map[domain.FlowType]map[domain.TriggerType][]string)
+		rm.flows[orgID] = flows
+	}
+	triggers, ok := flows[flowType]
+	if !ok {
+		triggers = make(map[domain.TriggerType][]string)
+		flows[flowType] = triggers
+	}
+	return triggers
+}
+
+func (rm *actionsV1ReadModel) setActionState(id string, state domain.ActionState) {
+	if a, ok := rm.actions[id]; ok {
+		a.state = state
+	}
+}
+
+func (rm *actionsV1ReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(rm.InstanceID).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.TriggerActionsSetEventType,
+			org.TriggerActionsCascadeRemovedEventType,
+			org.FlowClearedEventType,
+			org.OrgRemovedEventType).
+		Or().
+		AggregateTypes(action.AggregateType).
+		EventTypes(
+			action.AddedEventType,
+			action.ChangedEventType,
+			action.DeactivatedEventType,
+			action.ReactivatedEventType,
+			action.RemovedEventType).
+		Builder()
+}
@@ -0,0 +1,450 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/target"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_MigrateActionsV1(t *testing.T) {
+	accessTokenFunction := domain.FunctionName(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation)
+	userinfoFunction := domain.FunctionName(domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation)
+	actionsV1Events := func() []expect {
+		return []expect{
+			expectFilter(
+				eventFromEventPusher(
+					action.NewAddedEvent(context.Background(), &action.NewAggregate("action1", "org1").Aggregate, "claims", "script1", 0, false),
+				),
+				eventFromEventPusher(
+					action.NewAddedEvent(context.Background(), &action.NewAggregate("action2", "org1").Aggregate, "inactive", "script2", time.Second, false),
+				),
+				eventFromEventPusher(
+					action.NewDeactivatedEvent(context.Background(), &action.NewAggregate("action2", "org1").Aggregate),
+				),
+				eventFromEventPusher(
+					action.NewAddedEvent(context.Background(), &action.NewAggregate("action3", "org1").Aggregate, "login", "script3", time.Second, true),
+				),
+				eventFromEventPusher(
+					org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+						domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action1", "action2"}),
+				),
+				eventFromEventPusher(
+					org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+						domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication, []string{"action3"}),
+				),
+			),
+		}
+	}
+	wantTriggers := func(endpoint, targetID string) []*ActionsV1Trigger {
+		return []*ActionsV1Trigger{
+			{
+				OrgID:       "org1",
+				FlowType:    domain.FlowTypeCustomiseToken,
+				TriggerType: domain.TriggerTypePreAccessTokenCreation,
+				Function:    accessTokenFunction,
+				Actions: []*ActionsV1Action{
+					{ID: "action1", Name: "claims", Script: "script1", Timeout: actionV1MaxTimeout, TargetID: targetID, Endpoint: endpoint},
+				},
+			},
+			{
+				OrgID:       "org1",
+				FlowType:    domain.FlowTypeInternalAuthentication,
+				TriggerType: domain.TriggerTypePostAuthentication,
+				Actions: []*ActionsV1Action{
+					{ID: "action3", Name: "login", Script: "script3", Timeout: time.Second, AllowedToFail: true},
+				},
+			},
+		}
+	}
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		migrate       *MigrateActionsV1
+		resourceOwner string
+	}
+	type res struct {
+		report *ActionsV1MigrationReport
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"resourceowner missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				migrate: &MigrateActionsV1{DryRun: true},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"endpoint invalid, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "invalid"},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no flows, empty report",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				report: &ActionsV1MigrationReport{
+					Triggers: []*ActionsV1Trigger{},
+				},
+			},
+		},
+		{
+			"dry run, report",
+			fields{
+				eventstore: expectEventstore(
+					append(actionsV1Events(),
+						expectFilter(),
+					)...,
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com/", DryRun: true},
+				resourceOwner: "instance",
+			},
+			res{
+				report: &ActionsV1MigrationReport{
+					Triggers: wantTriggers("https://example.com/org1/action1", ""),
+					Executions: []*ActionsV1Execution{
+						{Function: accessTokenFunction, Condition: `payload.user.resource_owner == "org1"`},
+					},
+				},
+			},
+		},
+		{
+			"execution already exists, unchanged",
+			fields{
+				eventstore: expectEventstore(
+					append(actionsV1Events(),
+						expectFilter(
+							eventFromEventPusher(
+								execution.NewSetEventV2(context.Background(),
+									execution.NewAggregate(execution.ID(domain.ExecutionTypeFunction, accessTokenFunction), "instance"),
+									[]*execution.Target{{Type: domain.ExecutionTargetTypeTarget, Target: "target"}},
+									"",
+									nil,
+								),
+							),
+						),
+					)...,
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				report: &ActionsV1MigrationReport{
+					Triggers: wantTriggers("", ""),
+					Executions: []*ActionsV1Execution{
+						{Function: accessTokenFunction, Exists: true},
+					},
+				},
+			},
+		},
+		{
+			"migrate, ok",
+			fields{
+				eventstore: expectEventstore(
+					append(actionsV1Events(),
+						expectFilter(),
+						expectFilter(),
+						expectPush(
+							target.NewAddedEvent(context.Background(),
+								target.NewAggregate("target1", "instance"),
+								"actions-v1-org1-claims",
+								domain.TargetTypeCall,
+								"https://example.com/org1/action1",
+								actionV1MaxTimeout,
+								true,
+								0,
+								nil,
+								0,
+								nil,
+								targetSigningKey("12345678"),
+							),
+							execution.NewSetEventV2(context.Background(),
+								execution.NewAggregate(execution.ID(domain.ExecutionTypeFunction, accessTokenFunction), "instance"),
+								[]*execution.Target{{Type: domain.ExecutionTargetTypeTarget, Target: "target1"}},
+								`payload.user.resource_owner == "org1"`,
+								nil,
+							),
+						),
+					)...,
+				),
+				idGenerator: mock.ExpectID(t, "target1"),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				report: &ActionsV1MigrationReport{
+					Triggers: wantTriggers("https://example.com/org1/action1", "target1"),
+					Executions: []*ActionsV1Execution{
+						{
+							Function:  accessTokenFunction,
+							Condition: `payload.user.resource_owner == "org1"`,
+							TargetIDs: []string{"target1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"action of multiple triggers, one target",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(), &action.NewAggregate("action1", "org1").Aggregate, "claims", "script1", time.Second, true),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, []string{"action1"}),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action1"}),
+						),
+					),
+					expectFilter(),
+					expectFilter(),
+					expectFilter(),
+					expectPush(
+						target.NewAddedEvent(context.Background(),
+							target.NewAggregate("target1", "instance"),
+							"actions-v1-org1-claims",
+							domain.TargetTypeCall,
+							"https://example.com/org1/action1",
+							time.Second,
+							false,
+							0,
+							nil,
+							0,
+							nil,
+							targetSigningKey("12345678"),
+						),
+						execution.NewSetEventV2(context.Background(),
+							execution.NewAggregate(execution.ID(domain.ExecutionTypeFunction, userinfoFunction), "instance"),
+							[]*execution.Target{{Type: domain.ExecutionTargetTypeTarget, Target: "target1"}},
+							`payload.user.resource_owner == "org1"`,
+							nil,
+						),
+						execution.NewSetEventV2(context.Background(),
+							execution.NewAggregate(execution.ID(domain.ExecutionTypeFunction, accessTokenFunction), "instance"),
+							[]*execution.Target{{Type: domain.ExecutionTargetTypeTarget, Target: "target1"}},
+							`payload.user.resource_owner == "org1"`,
+							nil,
+						),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "target1"),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				report: &ActionsV1MigrationReport{
+					Triggers: []*ActionsV1Trigger{
+						{
+							OrgID:       "org1",
+							FlowType:    domain.FlowTypeCustomiseToken,
+							TriggerType: domain.TriggerTypePreUserinfoCreation,
+							Function:    userinfoFunction,
+							Actions: []*ActionsV1Action{
+								{ID: "action1", Name: "claims", Script: "script1", Timeout: time.Second, AllowedToFail: true, TargetID: "target1", Endpoint: "https://example.com/org1/action1"},
+							},
+						},
+						{
+							OrgID:       "org1",
+							FlowType:    domain.FlowTypeCustomiseToken,
+							TriggerType: domain.TriggerTypePreAccessTokenCreation,
+							Function:    accessTokenFunction,
+							Actions: []*ActionsV1Action{
+								{ID: "action1", Name: "claims", Script: "script1", Timeout: time.Second, AllowedToFail: true, TargetID: "target1", Endpoint: "https://example.com/org1/action1"},
+							},
+						},
+					},
+					Executions: []*ActionsV1Execution{
+						{
+							Function:  userinfoFunction,
+							Condition: `payload.user.resource_owner == "org1"`,
+							TargetIDs: []string{"target1"},
+						},
+						{
+							Function:  accessTokenFunction,
+							Condition: `payload.user.resource_owner == "org1"`,
+							TargetIDs: []string{"target1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"multiple organizations, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(), &action.NewAggregate("action1", "org1").Aggregate, "claims", "script1", time.Second, true),
+						),
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(), &action.NewAggregate("action2", "org2").Aggregate, "claims", "script2", time.Second, true),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action1"}),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org2").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action2"}),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org3").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action3"}),
+						),
+						eventFromEventPusher(
+							org.NewOrgRemovedEvent(context.Background(), &org.NewAggregate("org3").Aggregate, "org3", nil, false, nil, nil, nil),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com"},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"multiple organizations, dry run, reported",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(), &action.NewAggregate("action1", "org1").Aggregate, "claims", "script1", time.Second, true),
+						),
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(), &action.NewAggregate("action2", "org2").Aggregate, "claims", "script2", time.Second, true),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action1"}),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org2").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action2"}),
+						),
+						eventFromEventPusher(
+							org.NewTriggerActionsSetEvent(context.Background(), &org.NewAggregate("org3").Aggregate,
+								domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, []string{"action3"}),
+						),
+						eventFromEventPusher(
+							org.NewOrgRemovedEvent(context.Background(), &org.NewAggregate("org3").Aggregate, "org3", nil, false, nil, nil, nil),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args{
+				migrate:       &MigrateActionsV1{Endpoint: "https://example.com", DryRun: true},
+				resourceOwner: "instance",
+			},
+			res{
+				report: &ActionsV1MigrationReport{
+					Triggers: []*ActionsV1Trigger{
+						{
+							OrgID:       "org1",
+							FlowType:    domain.FlowTypeCustomiseToken,
+							TriggerType: domain.TriggerTypePreAccessTokenCreation,
+							Function:    accessTokenFunction,
+							Actions: []*ActionsV1Action{
+								{ID: "action1", Name: "claims", Script: "script1", Timeout: time.Second, AllowedToFail: true},
+							},
+						},
+						{
+							OrgID:       "org2",
+							FlowType:    domain.FlowTypeCustomiseToken,
+							TriggerType: domain.TriggerTypePreAccessTokenCreation,
+							Function:    accessTokenFunction,
+							Actions: []*ActionsV1Action{
+								{ID: "action2", Name: "claims", Script: "script2", Timeout: time.Second, AllowedToFail: true},
+							},
+						},
+					},
+					Executions: []*ActionsV1Execution{
+						{
+							Function:              accessTokenFunction,
+							MultipleOrganizations: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                  tt.fields.eventstore(t),
+				idGenerator:                 tt.fields.idGenerator,
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("12345678", 0),
+				targetEncryption:            crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			report, err := c.MigrateActionsV1(context.Background(), tt.args.migrate, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.report, report)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	exec "github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/repository/execution"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -178,6 +179,10 @@ type SetExecution struct {
 	models.ObjectRoot
 
 	Targets []*execution.Target
+	// Condition is an optional CEL expression, see [exec.CompileCondition]
+	Condition string
+	// MutableFields restrict the fields the targets can change, see [exec.ValidateMutableFields]
+	MutableFields []string
 }
 
 func (t SetExecution) GetIncludes() []string {
@@ -204,6 +209,14 @@ func (e *SetExecution) IsValid() error {
 	if len(e.Targets) == 0 {
 		return zerrors.ThrowInvalidArgument(nil, "COMMAND-56bteot2uj", "Errors.Execution.NoTargets")
 	}
+	if e.Condition != "" {
+		if err := exec.CompileCondition(e.Condition); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "COMMAND-k7w2c9rd4m", "Errors.Execution.ConditionInvalid")
+		}
+	}
+	if err := exec.ValidateMutableFields(e.MutableFields); err != nil {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-p1v6xq3zte", "Errors.Execution.InvalidMutableField")
+	}
 	return nil
 }
 
@@ -239,6 +252,8 @@ func (c *Commands) setExecution(ctx context.Context, set *SetExecution, resource
 		ctx,
 		ExecutionAggregateFromWriteModel(&wm.WriteModel),
 		set.Targets,
+		set.Condition,
+		set.MutableFields,
 	)); err != nil {
 		return nil, err
 	}
@@ -14,6 +14,8 @@ type ExecutionWriteModel struct {
 	Targets          []string
 	Includes         []string
 	ExecutionTargets []*execution.Target
+	Condition        string
+	MutableFields    []string
 }
 
 func (e *ExecutionWriteModel) IncludeList() []string {
@@ -48,10 +50,14 @@ func (wm *ExecutionWriteModel) Reduce() error {
 			wm.Includes = e.Includes
 		case *execution.SetEventV2:
 			wm.ExecutionTargets = e.Targets
+			wm.Condition = e.Condition
+			wm.MutableFields = e.MutableFields
 		case *execution.RemovedEvent:
 			wm.Targets = nil
 			wm.Includes = nil
 			wm.ExecutionTargets = nil
+			wm.Condition = ""
+			wm.MutableFields = nil
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -39,6 +39,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -62,6 +64,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -76,6 +80,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -104,6 +110,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -127,6 +135,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -155,6 +165,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -164,6 +176,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -173,6 +187,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -197,6 +213,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -206,6 +224,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -220,6 +240,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -243,6 +265,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -252,6 +276,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -266,6 +292,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -289,6 +317,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -298,6 +328,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -307,6 +339,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -340,6 +374,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -349,6 +385,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -358,6 +396,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -381,6 +421,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -395,6 +437,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -404,6 +448,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -438,6 +484,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -452,6 +500,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -461,6 +511,8 @@ func TestCommandSide_executionsExistsWriteModel(t *testing.T) {
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -191,6 +191,11 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								0,
+								nil,
+								0,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -200,6 +205,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -225,6 +232,166 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid condition, error",
+			fields{
+				eventstore:       expectEventstore(),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"method",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					},
+					Condition: "org_id ==",
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push ok, method target with condition",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							target.NewAddedEvent(context.Background(),
+								target.NewAggregate("target", "instance"),
+								"name",
+								domain.TargetTypeWebhook,
+								"https://example.com",
+								time.Second,
+								true,
+								0,
+								nil,
+								0,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						execution.NewSetEventV2(context.Background(),
+							execution.NewAggregate("request/method", "instance"),
+							[]*execution.Target{
+								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+							},
+							`org_id == "org"`,
+							nil,
+						),
+					),
+				),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"method",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					},
+					Condition: `org_id == "org"`,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+		{
+			"invalid mutable field, error",
+			fields{
+				eventstore:       expectEventstore(),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"method",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					},
+					MutableFields: []string{"user..email"},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push ok, method target with mutable fields",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							target.NewAddedEvent(context.Background(),
+								target.NewAggregate("target", "instance"),
+								"name",
+								domain.TargetTypeWebhook,
+								"https://example.com",
+								time.Second,
+								true,
+								0,
+								nil,
+								0,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						execution.NewSetEventV2(context.Background(),
+							execution.NewAggregate("request/method", "instance"),
+							[]*execution.Target{
+								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+							},
+							"",
+							[]string{"user.email"},
+						),
+					),
+				),
+				grpcMethodExists: existsMock(true),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					"method",
+					"",
+					false,
+				},
+				set: &SetExecution{
+					Targets: []*execution.Target{
+						{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
+					},
+					MutableFields: []string{"user.email"},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
 		{
 			"push ok, service target",
 			fields{
@@ -238,6 +405,11 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								0,
+								nil,
+								0,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -247,6 +419,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -285,6 +459,11 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								0,
+								nil,
+								0,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -294,6 +473,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -355,6 +536,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -365,6 +548,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -374,6 +559,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeInclude, Target: "request/include"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -436,6 +623,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -446,6 +635,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -455,6 +646,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeInclude, Target: "request/include"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -516,6 +709,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -526,6 +721,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -535,6 +732,8 @@ func TestCommands_SetExecutionRequest(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeInclude, Target: "request/include"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -704,6 +903,11 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							"https://example.com",
 							time.Second,
 							true,
+							0,
+							nil,
+							0,
+							nil,
+							nil,
 						),
 					),
 					expectPushFailed(
@@ -713,6 +917,8 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -797,6 +1003,11 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 								"https://example.com",
 								time.Second,
 								true,
+								0,
+								nil,
+								0,
+								nil,
+								nil,
 							),
 						),
 					),
@@ -806,6 +1017,8 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -844,6 +1057,8 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -882,6 +1097,8 @@ func TestCommands_SetExecutionResponse(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1053,6 +1270,8 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1137,6 +1356,8 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1175,6 +1396,8 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1213,6 +1436,8 @@ func TestCommands_SetExecutionEvent(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1357,6 +1582,8 @@ func TestCommands_SetExecutionFunction(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1430,6 +1657,8 @@ func TestCommands_SetExecutionFunction(t *testing.T) {
 							[]*execution.Target{
 								{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							},
+							"",
+							nil,
 						),
 					),
 				),
@@ -1548,6 +1777,8 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1603,6 +1834,8 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1639,6 +1872,8 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1675,6 +1910,8 @@ func TestCommands_DeleteExecutionRequest(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1796,6 +2033,8 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1851,6 +2090,8 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1887,6 +2128,8 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -1923,6 +2166,8 @@ func TestCommands_DeleteExecutionResponse(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2026,6 +2271,8 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2101,6 +2348,8 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2157,6 +2406,8 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2213,6 +2464,8 @@ func TestCommands_DeleteExecutionEvent(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2316,6 +2569,8 @@ func TestCommands_DeleteExecutionFunction(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2363,6 +2618,8 @@ func TestCommands_DeleteExecutionFunction(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2679,6 +2936,8 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2706,6 +2965,8 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2789,6 +3050,8 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2825,6 +3088,8 @@ func TestCommands_getExecutionIncludes(t *testing.T) {
 								[]*execution.Target{
 									{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 								},
+								"",
+								nil,
 							),
 						),
 					),
@@ -2,15 +2,31 @@ package command
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	exec "github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/repository/target"
 	"github.com/zitadel/zitadel/internal/zerrors"
+	"github.com/zitadel/zitadel/pkg/actions"
 )
 
+var defaultTargetSigningKeyConfig = &crypto.GeneratorConfig{
+	Length:              32,
+	IncludeLowerLetters: true,
+	IncludeUpperLetters: true,
+	IncludeDigits:       true,
+}
+
 type AddTarget struct {
 	models.ObjectRoot
 
@@ -19,6 +35,18 @@ type AddTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	// MaxAttempts defines how often an async call is tried before it is moved to the dead letters,
+	// the default of the runtime configuration is used if not set.
+	MaxAttempts uint8
+	// Authentication is optional and defines how ZITADEL authenticates itself to the endpoint
+	Authentication *TargetAuthentication
+	// MaxConcurrency limits the calls executed at the same time, 0 means unlimited
+	MaxConcurrency uint16
+	// CircuitBreaker is optional and stops the calls to the target after consecutive failures
+	CircuitBreaker *TargetCircuitBreaker
+
+	// SigningKey is only set after the creation of the target
+	SigningKey string
 }
 
 func (a *AddTarget) IsValid() error {
@@ -32,34 +60,156 @@ func (a *AddTarget) IsValid() error {
 	if err != nil || a.Endpoint == "" {
 		return zerrors.ThrowInvalidArgument(err, "COMMAND-1r2k6qo6wg", "Errors.Target.InvalidURL")
 	}
+	if a.TargetType == domain.TargetTypeGRPC && !validGRPCEndpoint(a.Endpoint) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-h0q6ve3mbx", "Errors.Target.InvalidURL")
+	}
+	if err := a.CircuitBreaker.IsValid(); err != nil {
+		return err
+	}
+	return a.Authentication.IsValid()
+}
+
+// TargetCircuitBreaker opens the circuit after FailureThreshold consecutive failed calls,
+// so no calls are sent to the target until a single probing call succeeds after the OpenDuration.
+type TargetCircuitBreaker struct {
+	FailureThreshold uint16
+	OpenDuration     time.Duration
+}
+
+// IsValid checks the circuit breaker, a nil circuit breaker or a FailureThreshold of 0 disables it
+func (c *TargetCircuitBreaker) IsValid() error {
+	if c == nil || c.FailureThreshold == 0 {
+		return nil
+	}
+	if c.OpenDuration <= 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-u3f8kq0xbn", "Errors.Target.InvalidCircuitBreaker")
+	}
+	return nil
+}
+
+func (c *TargetCircuitBreaker) toEvent() *target.CircuitBreaker {
+	if c == nil {
+		return nil
+	}
+	return &target.CircuitBreaker{
+		FailureThreshold: c.FailureThreshold,
+		OpenDuration:     c.OpenDuration,
+	}
+}
+
+// TargetAuthentication defines how ZITADEL authenticates itself to the endpoint of a target.
+type TargetAuthentication struct {
+	// Headers are sent with every call to the target and stored encrypted
+	Headers map[string]string
+	// JWT defines if a short-lived JWT signed by the key of the instance is sent as bearer token
+	JWT bool
+	// ClientCertificate and ClientKey are the PEM encoded certificate and private key presented to the endpoint for mTLS
+	ClientCertificate []byte
+	ClientKey         []byte
+	// RootCAs is the PEM encoded CA bundle to verify the certificate of the endpoint,
+	// the CAs of the system are used if not set
+	RootCAs []byte
+}
+
+// reservedTargetHeaders can't be overwritten by the custom headers of a target
+var reservedTargetHeaders = []string{
+	"Content-Type",
+	http.CanonicalHeaderKey(actions.SigningHeader),
+}
 
+func (a *TargetAuthentication) IsValid() error {
+	if a == nil {
+		return nil
+	}
+	for key := range a.Headers {
+		canonicalKey := http.CanonicalHeaderKey(key)
+		if key == "" || slices.Contains(reservedTargetHeaders, canonicalKey) || (a.JWT && canonicalKey == "Authorization") {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-m1c6x4e8ry", "Errors.Target.InvalidHeader")
+		}
+	}
+	if len(a.ClientCertificate) > 0 || len(a.ClientKey) > 0 {
+		if _, err := tls.X509KeyPair(a.ClientCertificate, a.ClientKey); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "COMMAND-5kdn1r0kyw", "Errors.Target.InvalidClientCertificate")
+		}
+	}
+	if len(a.RootCAs) > 0 && !x509.NewCertPool().AppendCertsFromPEM(a.RootCAs) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-g9x2vmu1ha", "Errors.Target.InvalidRootCAs")
+	}
 	return nil
 }
 
+// encryptTargetAuthentication encrypts the custom headers and the client key of the authentication
+func (c *Commands) encryptTargetAuthentication(a *TargetAuthentication) (_ *target.Authentication, err error) {
+	if a == nil {
+		return nil, nil
+	}
+	authentication := &target.Authentication{
+		JWT:               a.JWT,
+		ClientCertificate: a.ClientCertificate,
+		RootCAs:           a.RootCAs,
+	}
+	if len(a.Headers) > 0 {
+		headers, err := json.Marshal(a.Headers)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "COMMAND-2m0cbxkfyp", "Errors.Internal")
+		}
+		if authentication.Headers, err = crypto.Encrypt(headers, c.targetEncryption); err != nil {
+			return nil, err
+		}
+	}
+	if len(a.ClientKey) > 0 {
+		if authentication.ClientKey, err = crypto.Encrypt(a.ClientKey, c.targetEncryption); err != nil {
+			return nil, err
+		}
+	}
+	return authentication, nil
+}
+
 func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	wm, cmd, signingKey, err := c.addTargetCommand(ctx, add, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, cmd); err != nil {
+		return nil, err
+	}
+	add.SigningKey = signingKey
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// addTargetCommand checks the target and returns the command adding it together with the plain signing key,
+// so it can be pushed with other commands.
+func (c *Commands) addTargetCommand(ctx context.Context, add *AddTarget, resourceOwner string) (_ *TargetWriteModel, _ eventstore.Command, signingKey string, err error) {
 	if resourceOwner == "" {
-		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-brml926e2d", "Errors.IDMissing")
+		return nil, nil, "", zerrors.ThrowInvalidArgument(nil, "COMMAND-brml926e2d", "Errors.IDMissing")
 	}
 
 	if err := add.IsValid(); err != nil {
-		return nil, err
+		return nil, nil, "", err
 	}
 
 	if add.AggregateID == "" {
 		add.AggregateID, err = c.idGenerator.Next()
 		if err != nil {
-			return nil, err
+			return nil, nil, "", err
 		}
 	}
 	wm, err := c.getTargetWriteModelByID(ctx, add.AggregateID, resourceOwner)
 	if err != nil {
-		return nil, err
+		return nil, nil, "", err
 	}
 	if wm.State.Exists() {
-		return nil, zerrors.ThrowAlreadyExists(nil, "INSTANCE-9axkz0jvzm", "Errors.Target.AlreadyExists")
+		return nil, nil, "", zerrors.ThrowAlreadyExists(nil, "INSTANCE-9axkz0jvzm", "Errors.Target.AlreadyExists")
 	}
-
-	pushedEvents, err := c.eventstore.Push(ctx, target.NewAddedEvent(
+	authentication, err := c.encryptTargetAuthentication(add.Authentication)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	key, err := c.newTargetSigningKey(ctx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return wm, target.NewAddedEvent(
 		ctx,
 		TargetAggregateFromWriteModel(&wm.WriteModel),
 		add.Name,
@@ -67,14 +217,12 @@ func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner
 		add.Endpoint,
 		add.Timeout,
 		add.InterruptOnError,
-	))
-	if err != nil {
-		return nil, err
-	}
-	if err := AppendAndReduce(wm, pushedEvents...); err != nil {
-		return nil, err
-	}
-	return writeModelToObjectDetails(&wm.WriteModel), nil
+		add.MaxAttempts,
+		authentication,
+		add.MaxConcurrency,
+		add.CircuitBreaker.toEvent(),
+		key.Crypted,
+	), key.Plain, nil
 }
 
 type ChangeTarget struct {
@@ -85,6 +233,12 @@ type ChangeTarget struct {
 	Endpoint         *string
 	Timeout          *time.Duration
 	InterruptOnError *bool
+	MaxAttempts      *uint8
+	// Authentication replaces the whole authentication of the target if set
+	Authentication *TargetAuthentication
+	MaxConcurrency *uint16
+	// CircuitBreaker replaces the circuit breaker of the target if set
+	CircuitBreaker *TargetCircuitBreaker
 }
 
 func (a *ChangeTarget) IsValid() error {
@@ -103,9 +257,35 @@ func (a *ChangeTarget) IsValid() error {
 			return zerrors.ThrowInvalidArgument(err, "COMMAND-jsbaera7b6", "Errors.Target.InvalidURL")
 		}
 	}
+	if err := a.CircuitBreaker.IsValid(); err != nil {
+		return err
+	}
+	return a.Authentication.IsValid()
+}
+
+// validGRPCEndpoint checks the endpoint if the target is or becomes a gRPC target,
+// as the type and the endpoint can be changed independently.
+func (a *ChangeTarget) validGRPCEndpoint(existing *TargetWriteModel) error {
+	targetType, endpoint := existing.TargetType, existing.Endpoint
+	if a.TargetType != nil {
+		targetType = *a.TargetType
+	}
+	if a.Endpoint != nil {
+		endpoint = *a.Endpoint
+	}
+	if targetType == domain.TargetTypeGRPC && !validGRPCEndpoint(endpoint) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-s9c1rk4ayw", "Errors.Target.InvalidURL")
+	}
 	return nil
 }
 
+// validGRPCEndpoint checks that the endpoint of a gRPC target has a host and the scheme https,
+// or http for unencrypted connections.
+func validGRPCEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && u.Host != "" && (u.Scheme == "https" || u.Scheme == "http")
+}
+
 func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resourceOwner string) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-zqibgg0wwh", "Errors.IDMissing")
@@ -121,6 +301,13 @@ func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resou
 	if !existing.State.Exists() {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-xj14f2cccn", "Errors.Target.NotFound")
 	}
+	if err := change.validGRPCEndpoint(existing); err != nil {
+		return nil, err
+	}
+	authentication, err := c.encryptTargetAuthentication(change.Authentication)
+	if err != nil {
+		return nil, err
+	}
 
 	changedEvent := existing.NewChangedEvent(
 		ctx,
@@ -129,7 +316,12 @@ func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resou
 		change.TargetType,
 		change.Endpoint,
 		change.Timeout,
-		change.InterruptOnError)
+		change.InterruptOnError,
+		change.MaxAttempts,
+		authentication,
+		change.MaxConcurrency,
+		change.CircuitBreaker.toEvent(),
+	)
 	if changedEvent == nil {
 		return writeModelToObjectDetails(&existing.WriteModel), nil
 	}
@@ -141,9 +333,75 @@ func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resou
 	if err != nil {
 		return nil, err
 	}
+	exec.RemoveTarget(resourceOwner, change.AggregateID)
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+type RotateTargetSigningKey struct {
+	models.ObjectRoot
+
+	// GracePeriod defines how long the previous signing key is still used to sign the calls.
+	GracePeriod time.Duration
+
+	// SigningKey is only set after the rotation of the signing key
+	SigningKey string
+}
+
+func (r *RotateTargetSigningKey) IsValid() error {
+	if r.AggregateID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-5u1ybg6h6u", "Errors.IDMissing")
+	}
+	if r.GracePeriod < 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ww2i5sa8pb", "Errors.Target.InvalidGracePeriod")
+	}
+	return nil
+}
+
+// RotateTargetSigningKey generates a new signing key for the target,
+// during the grace period the calls are signed with the new and the previous signing key.
+func (c *Commands) RotateTargetSigningKey(ctx context.Context, rotate *RotateTargetSigningKey, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-kcr3v0xg0b", "Errors.IDMissing")
+	}
+	if err := rotate.IsValid(); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.getTargetWriteModelByID(ctx, rotate.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-3k2s9x7bgm", "Errors.Target.NotFound")
+	}
+	signingKey, err := c.newTargetSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousSigningKey *crypto.CryptoValue
+	if rotate.GracePeriod > 0 {
+		previousSigningKey = existing.SigningKey
+	}
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		target.NewSigningKeyRotatedEvent(ctx,
+			TargetAggregateFromWriteModel(&existing.WriteModel),
+			signingKey.Crypted,
+			previousSigningKey,
+			rotate.GracePeriod,
+		),
+	); err != nil {
+		return nil, err
+	}
+	rotate.SigningKey = signingKey.Plain
 	return writeModelToObjectDetails(&existing.WriteModel), nil
 }
 
+func (c *Commands) newTargetSigningKey(ctx context.Context) (*EncryptedCode, error) {
+	return c.newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeSigningKey, c.targetEncryption, defaultTargetSigningKeyConfig) //nolint:staticcheck
+}
+
 func (c *Commands) DeleteTarget(ctx context.Context, id, resourceOwner string) (*domain.ObjectDetails, error) {
 	if id == "" || resourceOwner == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-obqos2l3no", "Errors.IDMissing")
@@ -166,6 +424,7 @@ func (c *Commands) DeleteTarget(ctx context.Context, id, resourceOwner string) (
 	); err != nil {
 		return nil, err
 	}
+	exec.RemoveTarget(resourceOwner, id)
 	return writeModelToObjectDetails(&existing.WriteModel), nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/target"
@@ -18,6 +19,11 @@ type TargetWriteModel struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	MaxAttempts      uint8
+	Authentication   *target.Authentication
+	MaxConcurrency   uint16
+	CircuitBreaker   *target.CircuitBreaker
+	SigningKey       *crypto.CryptoValue
 
 	State domain.TargetState
 }
@@ -40,6 +46,12 @@ func (wm *TargetWriteModel) Reduce() error {
 			wm.TargetType = e.TargetType
 			wm.Endpoint = e.Endpoint
 			wm.Timeout = e.Timeout
+			wm.InterruptOnError = e.InterruptOnError
+			wm.MaxAttempts = e.MaxAttempts
+			wm.Authentication = e.Authentication
+			wm.MaxConcurrency = e.MaxConcurrency
+			wm.CircuitBreaker = e.CircuitBreaker
+			wm.SigningKey = e.SigningKey
 			wm.State = domain.TargetActive
 		case *target.ChangedEvent:
 			if e.Name != nil {
@@ -57,6 +69,20 @@ func (wm *TargetWriteModel) Reduce() error {
 			if e.InterruptOnError != nil {
 				wm.InterruptOnError = *e.InterruptOnError
 			}
+			if e.MaxAttempts != nil {
+				wm.MaxAttempts = *e.MaxAttempts
+			}
+			if e.Authentication != nil {
+				wm.Authentication = e.Authentication
+			}
+			if e.MaxConcurrency != nil {
+				wm.MaxConcurrency = *e.MaxConcurrency
+			}
+			if e.CircuitBreaker != nil {
+				wm.CircuitBreaker = e.CircuitBreaker
+			}
+		case *target.SigningKeyRotatedEvent:
+			wm.SigningKey = e.SigningKey
 		case *target.RemovedEvent:
 			wm.State = domain.TargetRemoved
 		}
@@ -72,6 +98,7 @@ func (wm *TargetWriteModel) Query() *eventstore.SearchQueryBuilder {
 		AggregateIDs(wm.AggregateID).
 		EventTypes(target.AddedEventType,
 			target.ChangedEventType,
+			target.SigningKeyRotatedEventType,
 			target.RemovedEventType).
 		Builder()
 }
@@ -84,6 +111,10 @@ func (wm *TargetWriteModel) NewChangedEvent(
 	endpoint *string,
 	timeout *time.Duration,
 	interruptOnError *bool,
+	maxAttempts *uint8,
+	authentication *target.Authentication,
+	maxConcurrency *uint16,
+	circuitBreaker *target.CircuitBreaker,
 ) *target.ChangedEvent {
 	changes := make([]target.Changes, 0)
 	if name != nil && wm.Name != *name {
@@ -101,6 +132,19 @@ func (wm *TargetWriteModel) NewChangedEvent(
 	if interruptOnError != nil && wm.InterruptOnError != *interruptOnError {
 		changes = append(changes, target.ChangeInterruptOnError(*interruptOnError))
 	}
+	if maxAttempts != nil && wm.MaxAttempts != *maxAttempts {
+		changes = append(changes, target.ChangeMaxAttempts(*maxAttempts))
+	}
+	// the encrypted values can't be compared, so the authentication is always replaced if set
+	if authentication != nil {
+		changes = append(changes, target.ChangeAuthentication(authentication))
+	}
+	if maxConcurrency != nil && wm.MaxConcurrency != *maxConcurrency {
+		changes = append(changes, target.ChangeMaxConcurrency(*maxConcurrency))
+	}
+	if circuitBreaker != nil && (wm.CircuitBreaker == nil || *wm.CircuitBreaker != *circuitBreaker) {
+		changes = append(changes, target.ChangeCircuitBreaker(circuitBreaker))
+	}
 	if len(changes) == 0 {
 		return nil
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/target"
@@ -20,9 +21,23 @@ func targetAddEvent(aggID, resourceOwner string) *target.AddedEvent {
 		"https://example.com",
 		time.Second,
 		false,
+		0,
+		nil,
+		0,
+		nil,
+		targetSigningKey("12345678"),
 	)
 }
 
+func targetSigningKey(key string) *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(key),
+	}
+}
+
 func targetRemoveEvent(aggID, resourceOwner string) *target.RemovedEvent {
 	return target.NewRemovedEvent(context.Background(),
 		target.NewAggregate(aggID, resourceOwner),
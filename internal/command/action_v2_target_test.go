@@ -2,12 +2,22 @@ package command
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -17,7 +27,28 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
+// testTargetCertificate returns a PEM encoded self-signed certificate and its private key
+func testTargetCertificate(t *testing.T) (certificate, key []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "zitadel"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
 func TestCommands_AddTarget(t *testing.T) {
+	certificate, key := testTargetCertificate(t)
 	type fields struct {
 		eventstore  func(t *testing.T) *eventstore.Eventstore
 		idGenerator id.Generator
@@ -28,9 +59,10 @@ func TestCommands_AddTarget(t *testing.T) {
 		resourceOwner string
 	}
 	type res struct {
-		id      string
-		details *domain.ObjectDetails
-		err     func(error) bool
+		id         string
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
 	}
 	tests := []struct {
 		name   string
@@ -118,6 +150,131 @@ func TestCommands_AddTarget(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			"grpc without host, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:       "name",
+					TargetType: domain.TargetTypeGRPC,
+					Timeout:    time.Second,
+					Endpoint:   "dns:///example.com:443",
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"reserved header, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:     "name",
+					Timeout:  time.Second,
+					Endpoint: "https://example.com",
+					Authentication: &TargetAuthentication{
+						Headers: map[string]string{"zitadel-signature": "signature"},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"authorization header with jwt, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:     "name",
+					Timeout:  time.Second,
+					Endpoint: "https://example.com",
+					Authentication: &TargetAuthentication{
+						Headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+						JWT:     true,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"client certificate without key, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:     "name",
+					Timeout:  time.Second,
+					Endpoint: "https://example.com",
+					Authentication: &TargetAuthentication{
+						ClientCertificate: certificate,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid root CAs, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:     "name",
+					Timeout:  time.Second,
+					Endpoint: "https://example.com",
+					Authentication: &TargetAuthentication{
+						RootCAs: []byte("no certificate"),
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"circuit breaker without open duration, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:     "name",
+					Timeout:  time.Second,
+					Endpoint: "https://example.com",
+					CircuitBreaker: &TargetCircuitBreaker{
+						FailureThreshold: 5,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"unique constraint failed, error",
 			fields{
@@ -132,6 +289,11 @@ func TestCommands_AddTarget(t *testing.T) {
 							"https://example.com",
 							time.Second,
 							false,
+							0,
+							nil,
+							0,
+							nil,
+							targetSigningKey("12345678"),
 						),
 					),
 				),
@@ -199,7 +361,8 @@ func TestCommands_AddTarget(t *testing.T) {
 				resourceOwner: "instance",
 			},
 			res{
-				id: "id1",
+				id:         "id1",
+				signingKey: "12345678",
 				details: &domain.ObjectDetails{
 					ResourceOwner: "instance",
 				},
@@ -214,6 +377,12 @@ func TestCommands_AddTarget(t *testing.T) {
 						func() eventstore.Command {
 							event := targetAddEvent("id1", "instance")
 							event.InterruptOnError = true
+							event.MaxAttempts = 3
+							event.MaxConcurrency = 10
+							event.CircuitBreaker = &target.CircuitBreaker{
+								FailureThreshold: 5,
+								OpenDuration:     time.Minute,
+							}
 							return event
 						}(),
 					),
@@ -228,11 +397,64 @@ func TestCommands_AddTarget(t *testing.T) {
 					Endpoint:         "https://example.com",
 					Timeout:          time.Second,
 					InterruptOnError: true,
+					MaxAttempts:      3,
+					MaxConcurrency:   10,
+					CircuitBreaker: &TargetCircuitBreaker{
+						FailureThreshold: 5,
+						OpenDuration:     time.Minute,
+					},
 				},
 				resourceOwner: "instance",
 			},
 			res{
-				id: "id1",
+				id:         "id1",
+				signingKey: "12345678",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+		{
+			"push authentication ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event := targetAddEvent("id1", "instance")
+							event.Authentication = &target.Authentication{
+								Headers:           targetSigningKey(`{"X-Api-Key":"key"}`),
+								JWT:               true,
+								ClientCertificate: certificate,
+								ClientKey:         targetSigningKey(string(key)),
+								RootCAs:           certificate,
+							}
+							return event
+						}(),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddTarget{
+					Name:       "name",
+					TargetType: domain.TargetTypeWebhook,
+					Endpoint:   "https://example.com",
+					Timeout:    time.Second,
+					Authentication: &TargetAuthentication{
+						Headers:           map[string]string{"X-Api-Key": "key"},
+						JWT:               true,
+						ClientCertificate: certificate,
+						ClientKey:         key,
+						RootCAs:           certificate,
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				id:         "id1",
+				signingKey: "12345678",
 				details: &domain.ObjectDetails{
 					ResourceOwner: "instance",
 				},
@@ -242,8 +464,10 @@ func TestCommands_AddTarget(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore:  tt.fields.eventstore(t),
-				idGenerator: tt.fields.idGenerator,
+				eventstore:                  tt.fields.eventstore(t),
+				idGenerator:                 tt.fields.idGenerator,
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("12345678", 0),
+				targetEncryption:            crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			}
 			details, err := c.AddTarget(tt.args.ctx, tt.args.add, tt.args.resourceOwner)
 			if tt.res.err == nil {
@@ -254,6 +478,7 @@ func TestCommands_AddTarget(t *testing.T) {
 			}
 			if tt.res.err == nil {
 				assert.Equal(t, tt.res.id, tt.args.add.AggregateID)
+				assert.Equal(t, tt.res.signingKey, tt.args.add.SigningKey)
 				assert.Equal(t, tt.res.details, details)
 			}
 		})
@@ -392,6 +617,32 @@ func TestCommands_ChangeTarget(t *testing.T) {
 				err: zerrors.IsNotFound,
 			},
 		},
+		{
+			"change to grpc with invalid endpoint, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeTarget{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					TargetType: gu.Ptr(domain.TargetTypeGRPC),
+					Endpoint:   gu.Ptr("urn:zitadel:target"),
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"no changes",
 			fields{
@@ -506,6 +757,15 @@ func TestCommands_ChangeTarget(t *testing.T) {
 								target.ChangeTargetType(domain.TargetTypeCall),
 								target.ChangeTimeout(10 * time.Second),
 								target.ChangeInterruptOnError(true),
+								target.ChangeMaxAttempts(3),
+								target.ChangeAuthentication(&target.Authentication{
+									Headers: targetSigningKey(`{"X-Api-Key":"key"}`),
+								}),
+								target.ChangeMaxConcurrency(10),
+								target.ChangeCircuitBreaker(&target.CircuitBreaker{
+									FailureThreshold: 5,
+									OpenDuration:     time.Minute,
+								}),
 							},
 						),
 					),
@@ -522,6 +782,15 @@ func TestCommands_ChangeTarget(t *testing.T) {
 					TargetType:       gu.Ptr(domain.TargetTypeCall),
 					Timeout:          gu.Ptr(10 * time.Second),
 					InterruptOnError: gu.Ptr(true),
+					MaxAttempts:      gu.Ptr(uint8(3)),
+					Authentication: &TargetAuthentication{
+						Headers: map[string]string{"X-Api-Key": "key"},
+					},
+					MaxConcurrency: gu.Ptr(uint16(10)),
+					CircuitBreaker: &TargetCircuitBreaker{
+						FailureThreshold: 5,
+						OpenDuration:     time.Minute,
+					},
 				},
 				resourceOwner: "instance",
 			},
@@ -535,7 +804,8 @@ func TestCommands_ChangeTarget(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore: tt.fields.eventstore(t),
+				eventstore:       tt.fields.eventstore(t),
+				targetEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			}
 			details, err := c.ChangeTarget(tt.args.ctx, tt.args.change, tt.args.resourceOwner)
 			if tt.res.err == nil {
@@ -551,6 +821,190 @@ func TestCommands_ChangeTarget(t *testing.T) {
 	}
 }
 
+func TestCommands_RotateTargetSigningKey(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		rotate        *RotateTargetSigningKey
+		resourceOwner string
+	}
+	type res struct {
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"resourceowner missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				rotate: &RotateTargetSigningKey{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+				},
+				resourceOwner: "",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"id missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				rotate:        &RotateTargetSigningKey{},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"negative grace period, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				rotate: &RotateTargetSigningKey{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					GracePeriod: -time.Hour,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				rotate: &RotateTargetSigningKey{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"rotate without grace period, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						target.NewSigningKeyRotatedEvent(context.Background(),
+							target.NewAggregate("id1", "instance"),
+							targetSigningKey("87654321"),
+							nil,
+							0,
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				rotate: &RotateTargetSigningKey{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				signingKey: "87654321",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+		{
+			"rotate with grace period, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						target.NewSigningKeyRotatedEvent(context.Background(),
+							target.NewAggregate("id1", "instance"),
+							targetSigningKey("87654321"),
+							targetSigningKey("12345678"),
+							time.Hour,
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				rotate: &RotateTargetSigningKey{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					GracePeriod: time.Hour,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				signingKey: "87654321",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                  tt.fields.eventstore(t),
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("87654321", 0),
+			}
+			details, err := c.RotateTargetSigningKey(tt.args.ctx, tt.args.rotate, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.signingKey, tt.args.rotate.SigningKey)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
 func TestCommands_DeleteTarget(t *testing.T) {
 	type fields struct {
 		eventstore func(t *testing.T) *eventstore.Eventstore
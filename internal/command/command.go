@@ -8,6 +8,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
 	"strconv"
 	"sync"
@@ -51,6 +52,7 @@ type Commands struct {
 	smtpEncryption                  crypto.EncryptionAlgorithm
 	smsEncryption                   crypto.EncryptionAlgorithm
 	userEncryption                  crypto.EncryptionAlgorithm
+	targetEncryption                crypto.EncryptionAlgorithm
 	userPasswordHasher              *crypto.Hasher
 	secretHasher                    *crypto.Hasher
 	machineKeySize                  int
@@ -58,6 +60,7 @@ type Commands struct {
 	domainVerificationAlg           crypto.EncryptionAlgorithm
 	domainVerificationGenerator     crypto.Generator
 	domainVerificationValidator     func(domain, token, verifier string, checkType api_http.CheckType) error
+	dkimRecordLookup                func(ctx context.Context, name string) ([]string, error)
 	sessionTokenCreator             func(sessionID string) (id string, token string, err error)
 	sessionTokenVerifier            func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
 	defaultAccessTokenLifetime      time.Duration
@@ -74,6 +77,7 @@ type Commands struct {
 	publicKeyLifetime       time.Duration
 	certificateLifetime     time.Duration
 	defaultSecretGenerators *SecretGenerators
+	otpSendLimits           sd.OTPSendLimits
 
 	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
 
@@ -95,7 +99,7 @@ func StartCommands(
 	externalDomain string,
 	externalSecure bool,
 	externalPort uint16,
-	idpConfigEncryption, otpEncryption, smtpEncryption, smsEncryption, userEncryption, domainVerificationEncryption, oidcEncryption, samlEncryption crypto.EncryptionAlgorithm,
+	idpConfigEncryption, otpEncryption, smtpEncryption, smsEncryption, userEncryption, domainVerificationEncryption, oidcEncryption, samlEncryption, targetEncryption crypto.EncryptionAlgorithm,
 	httpClient *http.Client,
 	permissionCheck domain.PermissionCheck,
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error),
@@ -136,6 +140,7 @@ func StartCommands(
 		smtpEncryption:                  smtpEncryption,
 		smsEncryption:                   smsEncryption,
 		userEncryption:                  userEncryption,
+		targetEncryption:                targetEncryption,
 		userPasswordHasher:              userPasswordHasher,
 		secretHasher:                    secretHasher,
 		machineKeySize:                  int(defaults.SecretGenerators.MachineKeySize),
@@ -143,6 +148,7 @@ func StartCommands(
 		domainVerificationAlg:           domainVerificationEncryption,
 		domainVerificationGenerator:     crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, domainVerificationEncryption),
 		domainVerificationValidator:     api_http.ValidateDomain,
+		dkimRecordLookup:                net.DefaultResolver.LookupTXT,
 		keyAlgorithm:                    oidcEncryption,
 		certificateAlgorithm:            samlEncryption,
 		webauthnConfig:                  webAuthN,
@@ -156,6 +162,7 @@ func StartCommands(
 		defaultRefreshTokenLifetime:     defaultRefreshTokenLifetime,
 		defaultRefreshTokenIdleLifetime: defaultRefreshTokenIdleLifetime,
 		defaultSecretGenerators:         defaultSecretGenerators,
+		otpSendLimits:                   defaults.OTPSendLimits,
 		samlCertificateAndKeyGenerator:  samlCertificateAndKeyGenerator(defaults.KeyConfig.Size),
 		// always true for now until we can check with an eventlist
 		EventExisting: func(event string) bool { return true },
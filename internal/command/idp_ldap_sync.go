@@ -0,0 +1,471 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// LDAPSyncMinInterval is the minimal interval between two scheduled synchronizations of an LDAP provider
+	LDAPSyncMinInterval = 15 * time.Minute
+	// LDAPSyncRequestTimeout bounds a synchronization requested through the API,
+	// if the request has no earlier deadline
+	LDAPSyncRequestTimeout = 5 * time.Minute
+)
+
+type LDAPSyncSettings struct {
+	Enabled  bool
+	DryRun   bool
+	Interval time.Duration
+	// MaxDeactivationPercent is the maximum share of the linked users deactivated in a single synchronization,
+	// if more users would be deactivated, the synchronization is run as dry run.
+	// If not set, [domain.LDAPSyncDefaultMaxDeactivationPercent] is used.
+	MaxDeactivationPercent uint32
+}
+
+func (s *LDAPSyncSettings) Validate() error {
+	if s.Enabled && s.Interval < LDAPSyncMinInterval {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Hk2qz", "Errors.Invalid.Argument")
+	}
+	if s.MaxDeactivationPercent > 100 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xe6kr", "Errors.Invalid.Argument")
+	}
+	return nil
+}
+
+// SetInstanceLDAPProviderSync sets the scheduled synchronization of an LDAP provider of the instance
+func (c *Commands) SetInstanceLDAPProviderSync(ctx context.Context, id string, settings *LDAPSyncSettings) (*domain.ObjectDetails, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	writeModel := NewLDAPInstanceIDPSyncWriteModel(instanceID, id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ms8ve", "Errors.IDPConfig.NotExisting")
+	}
+	if !writeModel.hasSyncChanged(settings) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err := c.pushAppendAndReduce(ctx, writeModel, instance.NewLDAPIDPSyncSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		id,
+		settings.Enabled,
+		settings.DryRun,
+		settings.Interval,
+		settings.MaxDeactivationPercent,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// SetOrgLDAPProviderSync sets the scheduled synchronization of an LDAP provider of the organization
+func (c *Commands) SetOrgLDAPProviderSync(ctx context.Context, resourceOwner, id string, settings *LDAPSyncSettings) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Zt3nc", "Errors.ResourceOwnerMissing")
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	writeModel := NewLDAPOrgIDPSyncWriteModel(resourceOwner, id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Pq4wd", "Errors.Org.IDPConfig.NotExisting")
+	}
+	if !writeModel.hasSyncChanged(settings) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err := c.pushAppendAndReduce(ctx, writeModel, org.NewLDAPIDPSyncSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		id,
+		settings.Enabled,
+		settings.DryRun,
+		settings.Interval,
+		settings.MaxDeactivationPercent,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// SyncInstanceLDAPProvider synchronizes the users linked to an LDAP provider of the instance with the directory.
+// Users created by the synchronization are added to the default organization.
+// The linked users are compared with the users of the directory.
+// On a dry run the report is computed without changing any user.
+// The report is only stored if the synchronization has changes.
+func (c *Commands) SyncInstanceLDAPProvider(ctx context.Context, id string, dryRun bool, linkedUsers []*domain.LDAPSyncLinkedUser) (*domain.LDAPSyncReport, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	writeModel := NewLDAPInstanceIDPSyncWriteModel(instanceID, id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rk7xj", "Errors.IDPConfig.NotExisting")
+	}
+	report, err := c.syncLDAPProvider(ctx, &writeModel.LDAPIDPSyncWriteModel, authz.GetInstance(ctx).DefaultOrganisationID(), dryRun, linkedUsers)
+	if err != nil {
+		return nil, err
+	}
+	if !report.HasChanges() {
+		return report, nil
+	}
+	if _, err = c.eventstore.Push(ctx, instance.NewLDAPIDPSyncedEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, report)); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// SyncOrgLDAPProvider synchronizes the users linked to an LDAP provider of the organization with the directory.
+// Users created by the synchronization are added to the organization.
+// The linked users are compared with the users of the directory.
+// On a dry run the report is computed without changing any user.
+// The report is only stored if the synchronization has changes.
+func (c *Commands) SyncOrgLDAPProvider(ctx context.Context, resourceOwner, id string, dryRun bool, linkedUsers []*domain.LDAPSyncLinkedUser) (*domain.LDAPSyncReport, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Lb9sf", "Errors.ResourceOwnerMissing")
+	}
+	writeModel := NewLDAPOrgIDPSyncWriteModel(resourceOwner, id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Vn5tg", "Errors.Org.IDPConfig.NotExisting")
+	}
+	report, err := c.syncLDAPProvider(ctx, &writeModel.LDAPIDPSyncWriteModel, resourceOwner, dryRun, linkedUsers)
+	if err != nil {
+		return nil, err
+	}
+	if !report.HasChanges() {
+		return report, nil
+	}
+	if _, err = c.eventstore.Push(ctx, org.NewLDAPIDPSyncedEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, report)); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (c *Commands) syncLDAPProvider(ctx context.Context, writeModel *LDAPIDPSyncWriteModel, orgID string, dryRun bool, linkedUsers []*domain.LDAPSyncLinkedUser) (_ *domain.LDAPSyncReport, err error) {
+	report := &domain.LDAPSyncReport{
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+	provider, err := writeModel.ToProvider("", c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	ldapProvider, ok := provider.(*ldap.Provider)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "COMMAND-Gw3pd", "Errors.Internal")
+	}
+	directoryUsers, err := ldapProvider.SearchUsers(ctx, ldap.DefaultPageSize)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Jd8ws", "Errors.Internal")
+	}
+	report.Found = uint32(len(directoryUsers))
+
+	actions := ldapSyncPlan(ctx, directoryUsers, linkedUsers, writeModel.IsAutoCreation, writeModel.IsAutoUpdate)
+	if !ldapSyncDeactivationsAllowed(actions, linkedUsers, writeModel.SyncMaxDeactivationPercent) {
+		logging.WithFields("idp", writeModel.ID, "found", report.Found, "linked", len(linkedUsers)).Warn("ldap sync would deactivate too many users, run as dry run")
+		report.DeactivationLimitExceeded = true
+		report.DryRun = true
+		dryRun = true
+	}
+	mustBeDomain := false
+	if !dryRun && writeModel.IsAutoCreation {
+		domainPolicy, err := c.domainPolicyWriteModel(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		mustBeDomain = domainPolicy.UserLoginMustBeDomain
+	}
+	for _, action := range actions {
+		// the remaining actions are executed by the next synchronization
+		if err = ctx.Err(); err != nil {
+			return nil, zerrors.ThrowDeadlineExceeded(err, "COMMAND-Tc6fw", "Errors.Internal")
+		}
+		if !dryRun {
+			c.executeLDAPSyncAction(ctx, writeModel.ID, orgID, mustBeDomain, action)
+		}
+		report.Add(action.entry)
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// ldapSyncDeactivationsAllowed checks that the planned deactivations don't exceed the maximum share of the active linked users,
+// which prevents the deactivation of all users if the directory returns too few users, e.g. because of a changed filter or missing permissions.
+func ldapSyncDeactivationsAllowed(actions []*ldapSyncAction, linkedUsers []*domain.LDAPSyncLinkedUser, maxPercent uint32) bool {
+	if maxPercent == 0 {
+		maxPercent = domain.LDAPSyncDefaultMaxDeactivationPercent
+	}
+	var deactivations, active int
+	for _, action := range actions {
+		if action.entry.Action == domain.LDAPSyncActionDeactivated {
+			deactivations++
+		}
+	}
+	for _, linkedUser := range linkedUsers {
+		if isUserStateExists(linkedUser.State) && !isUserStateInactive(linkedUser.State) {
+			active++
+		}
+	}
+	return deactivations*100 <= active*int(maxPercent)
+}
+
+func (c *Commands) executeLDAPSyncAction(ctx context.Context, idpID, orgID string, mustBeDomain bool, action *ldapSyncAction) {
+	var err error
+	switch action.entry.Action {
+	case domain.LDAPSyncActionCreated:
+		human := ldapUserToAddHuman(idpID, action.user, mustBeDomain)
+		if err = c.AddHuman(ctx, orgID, human, false); err == nil {
+			action.entry.UserID = human.ID
+		}
+	case domain.LDAPSyncActionUpdated:
+		err = c.updateLDAPSyncUser(ctx, action.linkedUser, action.user)
+	case domain.LDAPSyncActionDeactivated:
+		_, err = c.DeactivateUser(ctx, action.linkedUser.UserID, action.linkedUser.ResourceOwner)
+	case domain.LDAPSyncActionUnspecified, domain.LDAPSyncActionFailed:
+		return
+	}
+	if err != nil {
+		logging.WithFields("idp", idpID, "user", action.entry.UserID, "externalUser", action.entry.ExternalUserID).WithError(err).Warn("ldap sync action failed")
+		action.entry.Action = domain.LDAPSyncActionFailed
+		action.entry.Error = err.Error()
+	}
+}
+
+// updateLDAPSyncUser updates the linked user with the attributes of the directory,
+// the changes are computed again on the current state of the user, as the linked users are read from the projection
+func (c *Commands) updateLDAPSyncUser(ctx context.Context, linkedUser *domain.LDAPSyncLinkedUser, directoryUser *ldap.User) error {
+	human, err := c.getHumanWriteModelByID(ctx, linkedUser.UserID, linkedUser.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(human.UserState) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Wm4kt", "Errors.User.NotFound")
+	}
+	cmds, err := ldapUserChanges(ctx, UserAggregateFromWriteModel(&human.WriteModel), humanToLDAPSyncLinkedUser(human), directoryUser)
+	if err != nil || len(cmds) == 0 {
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
+func humanToLDAPSyncLinkedUser(human *HumanWriteModel) *domain.LDAPSyncLinkedUser {
+	return &domain.LDAPSyncLinkedUser{
+		UserID:            human.AggregateID,
+		ResourceOwner:     human.ResourceOwner,
+		Username:          human.UserName,
+		State:             human.UserState,
+		FirstName:         human.FirstName,
+		LastName:          human.LastName,
+		NickName:          human.NickName,
+		DisplayName:       human.DisplayName,
+		PreferredLanguage: human.PreferredLanguage,
+		Email:             human.Email,
+		IsEmailVerified:   human.IsEmailVerified,
+		Phone:             human.Phone,
+		IsPhoneVerified:   human.IsPhoneVerified,
+	}
+}
+
+type ldapSyncAction struct {
+	entry *domain.LDAPSyncReportEntry
+	// user is the directory user to be created or whose attributes update the linked user
+	user *ldap.User
+	// linkedUser is the user to be updated or deactivated
+	linkedUser *domain.LDAPSyncLinkedUser
+}
+
+// ldapSyncPlan compares the users of the directory with the linked users by their external id:
+// unlinked directory users are created if auto creation is allowed,
+// linked users are updated with the mapped attributes if auto update is allowed
+// and linked users no longer present in the directory are deactivated.
+// If the directory returned no users at all, no user is deactivated.
+func ldapSyncPlan(ctx context.Context, directoryUsers []*ldap.User, linkedUsers []*domain.LDAPSyncLinkedUser, autoCreation, autoUpdate bool) []*ldapSyncAction {
+	linked := make(map[string]*domain.LDAPSyncLinkedUser, len(linkedUsers))
+	for _, linkedUser := range linkedUsers {
+		linked[linkedUser.ExternalUserID] = linkedUser
+	}
+	actions := make([]*ldapSyncAction, 0)
+	present := make(map[string]struct{}, len(directoryUsers))
+	for _, directoryUser := range directoryUsers {
+		entry := &domain.LDAPSyncReportEntry{
+			ExternalUserID: directoryUser.ID,
+			Username:       directoryUser.PreferredUsername,
+		}
+		if directoryUser.ID == "" {
+			entry.Action = domain.LDAPSyncActionFailed
+			entry.Error = "id attribute missing"
+			actions = append(actions, &ldapSyncAction{entry: entry})
+			continue
+		}
+		present[directoryUser.ID] = struct{}{}
+		linkedUser, ok := linked[directoryUser.ID]
+		if !ok {
+			if autoCreation {
+				entry.Action = domain.LDAPSyncActionCreated
+				actions = append(actions, &ldapSyncAction{entry: entry, user: directoryUser})
+			}
+			continue
+		}
+		if !autoUpdate || !isUserStateExists(linkedUser.State) {
+			continue
+		}
+		entry.UserID = linkedUser.UserID
+		cmds, err := ldapUserChanges(ctx, &user.NewAggregate(linkedUser.UserID, linkedUser.ResourceOwner).Aggregate, linkedUser, directoryUser)
+		if err != nil {
+			entry.Action = domain.LDAPSyncActionFailed
+			entry.Error = err.Error()
+			actions = append(actions, &ldapSyncAction{entry: entry})
+			continue
+		}
+		if len(cmds) > 0 {
+			entry.Action = domain.LDAPSyncActionUpdated
+			actions = append(actions, &ldapSyncAction{entry: entry, user: directoryUser, linkedUser: linkedUser})
+		}
+	}
+	if len(directoryUsers) == 0 {
+		return actions
+	}
+	missing := make([]*domain.LDAPSyncLinkedUser, 0)
+	for _, linkedUser := range linkedUsers {
+		if _, ok := present[linkedUser.ExternalUserID]; !ok {
+			missing = append(missing, linkedUser)
+		}
+	}
+	slices.SortFunc(missing, func(a, b *domain.LDAPSyncLinkedUser) int {
+		return strings.Compare(a.ExternalUserID, b.ExternalUserID)
+	})
+	for _, linkedUser := range missing {
+		if !isUserStateExists(linkedUser.State) || isUserStateInactive(linkedUser.State) {
+			continue
+		}
+		entry := &domain.LDAPSyncReportEntry{
+			UserID:         linkedUser.UserID,
+			ExternalUserID: linkedUser.ExternalUserID,
+			Username:       linkedUser.Username,
+		}
+		if isUserStateInitial(linkedUser.State) {
+			entry.Action = domain.LDAPSyncActionFailed
+			entry.Error = "Errors.User.CantDeactivateInitial"
+			actions = append(actions, &ldapSyncAction{entry: entry})
+			continue
+		}
+		entry.Action = domain.LDAPSyncActionDeactivated
+		actions = append(actions, &ldapSyncAction{entry: entry, linkedUser: linkedUser})
+	}
+	return actions
+}
+
+// ldapUserChanges returns the events to update the profile, email and phone of the user with the attributes of the directory,
+// attributes without a value in the directory are not changed
+func ldapUserChanges(ctx context.Context, agg *eventstore.Aggregate, human *domain.LDAPSyncLinkedUser, directoryUser *ldap.User) ([]eventstore.Command, error) {
+	cmds := make([]eventstore.Command, 0)
+
+	profileChanges := make([]user.ProfileChanges, 0)
+	if directoryUser.FirstName != "" && directoryUser.FirstName != human.FirstName {
+		profileChanges = append(profileChanges, user.ChangeFirstName(directoryUser.FirstName))
+	}
+	if directoryUser.LastName != "" && directoryUser.LastName != human.LastName {
+		profileChanges = append(profileChanges, user.ChangeLastName(directoryUser.LastName))
+	}
+	if directoryUser.NickName != "" && directoryUser.NickName != human.NickName {
+		profileChanges = append(profileChanges, user.ChangeNickName(directoryUser.NickName))
+	}
+	if directoryUser.DisplayName != "" && directoryUser.DisplayName != human.DisplayName {
+		profileChanges = append(profileChanges, user.ChangeDisplayName(directoryUser.DisplayName))
+	}
+	if !directoryUser.PreferredLanguage.IsRoot() && directoryUser.PreferredLanguage != human.PreferredLanguage {
+		profileChanges = append(profileChanges, user.ChangePreferredLanguage(directoryUser.PreferredLanguage))
+	}
+	if len(profileChanges) > 0 {
+		profileEvent, err := user.NewHumanProfileChangedEvent(ctx, agg, profileChanges)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, profileEvent)
+	}
+
+	if email := directoryUser.Email.Normalize(); email != "" {
+		if err := email.Validate(); err != nil {
+			return nil, err
+		}
+		if email != human.Email {
+			cmds = append(cmds, user.NewHumanEmailChangedEvent(ctx, agg, email))
+		}
+		if directoryUser.EmailVerified && (email != human.Email || !human.IsEmailVerified) {
+			cmds = append(cmds, user.NewHumanEmailVerifiedEvent(ctx, agg))
+		}
+	}
+
+	if directoryUser.Phone != "" {
+		phone, err := directoryUser.Phone.Normalize()
+		if err != nil {
+			return nil, err
+		}
+		if phone != human.Phone {
+			cmds = append(cmds, user.NewHumanPhoneChangedEvent(ctx, agg, phone))
+		}
+		if directoryUser.PhoneVerified && (phone != human.Phone || !human.IsPhoneVerified) {
+			cmds = append(cmds, user.NewHumanPhoneVerifiedEvent(ctx, agg))
+		}
+	}
+	return cmds, nil
+}
+
+func ldapUserToAddHuman(idpID string, directoryUser *ldap.User, mustBeDomain bool) *AddHuman {
+	username := directoryUser.PreferredUsername
+	if mustBeDomain {
+		if index := strings.LastIndex(username, "@"); index > 1 {
+			username = username[:index]
+		}
+	}
+	return &AddHuman{
+		Username:          username,
+		FirstName:         directoryUser.FirstName,
+		LastName:          directoryUser.LastName,
+		NickName:          directoryUser.NickName,
+		DisplayName:       directoryUser.DisplayName,
+		PreferredLanguage: directoryUser.PreferredLanguage,
+		Email: Email{
+			Address:  directoryUser.Email,
+			Verified: directoryUser.EmailVerified,
+		},
+		Phone: Phone{
+			Number:   directoryUser.Phone,
+			Verified: directoryUser.PhoneVerified,
+		},
+		ExternalIDP: true,
+		Links: []*AddLink{
+			{
+				IDPID:         idpID,
+				DisplayName:   directoryUser.PreferredUsername,
+				IDPExternalID: directoryUser.ID,
+			},
+		},
+	}
+}
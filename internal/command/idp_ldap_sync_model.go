@@ -0,0 +1,142 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type LDAPIDPSyncWriteModel struct {
+	LDAPIDPWriteModel
+
+	SyncEnabled                bool
+	SyncDryRun                 bool
+	SyncInterval               time.Duration
+	SyncMaxDeactivationPercent uint32
+}
+
+func (wm *LDAPIDPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		e, ok := event.(*idp.LDAPIDPSyncSetEvent)
+		if !ok || wm.ID != e.ID {
+			continue
+		}
+		wm.SyncEnabled = e.Enabled
+		wm.SyncDryRun = e.DryRun
+		wm.SyncInterval = e.Interval
+		wm.SyncMaxDeactivationPercent = e.MaxDeactivationPercent
+	}
+	return wm.LDAPIDPWriteModel.Reduce()
+}
+
+func (wm *LDAPIDPSyncWriteModel) hasSyncChanged(settings *LDAPSyncSettings) bool {
+	return wm.SyncEnabled != settings.Enabled ||
+		wm.SyncDryRun != settings.DryRun ||
+		wm.SyncInterval != settings.Interval ||
+		wm.SyncMaxDeactivationPercent != settings.MaxDeactivationPercent
+}
+
+type InstanceLDAPIDPSyncWriteModel struct {
+	LDAPIDPSyncWriteModel
+}
+
+func NewLDAPInstanceIDPSyncWriteModel(instanceID, id string) *InstanceLDAPIDPSyncWriteModel {
+	return &InstanceLDAPIDPSyncWriteModel{
+		LDAPIDPSyncWriteModel{
+			LDAPIDPWriteModel: LDAPIDPWriteModel{
+				WriteModel: eventstore.WriteModel{
+					AggregateID:   instanceID,
+					ResourceOwner: instanceID,
+				},
+				ID: id,
+			},
+		},
+	}
+}
+
+func (wm *InstanceLDAPIDPSyncWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.LDAPIDPAddedEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
+		case *instance.LDAPIDPChangedEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.LDAPIDPChangedEvent)
+		case *instance.LDAPIDPSyncSetEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.LDAPIDPSyncSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceLDAPIDPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.LDAPIDPAddedEventType,
+			instance.LDAPIDPChangedEventType,
+			instance.LDAPIDPSyncSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+type OrgLDAPIDPSyncWriteModel struct {
+	LDAPIDPSyncWriteModel
+}
+
+func NewLDAPOrgIDPSyncWriteModel(orgID, id string) *OrgLDAPIDPSyncWriteModel {
+	return &OrgLDAPIDPSyncWriteModel{
+		LDAPIDPSyncWriteModel{
+			LDAPIDPWriteModel: LDAPIDPWriteModel{
+				WriteModel: eventstore.WriteModel{
+					AggregateID:   orgID,
+					ResourceOwner: orgID,
+				},
+				ID: id,
+			},
+		},
+	}
+}
+
+func (wm *OrgLDAPIDPSyncWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.LDAPIDPAddedEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
+		case *org.LDAPIDPChangedEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.LDAPIDPChangedEvent)
+		case *org.LDAPIDPSyncSetEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.LDAPIDPSyncSetEvent)
+		case *org.IDPRemovedEvent:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.LDAPIDPSyncWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgLDAPIDPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.LDAPIDPAddedEventType,
+			org.LDAPIDPChangedEventType,
+			org.LDAPIDPSyncSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
@@ -0,0 +1,535 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetInstanceLDAPProviderSync(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		settings *LDAPSyncSettings
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid interval",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				settings: &LDAPSyncSettings{
+					Enabled:  true,
+					Interval: time.Minute,
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Hk2qz", ""))
+				},
+			},
+		},
+		{
+			"not found",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				settings: &LDAPSyncSettings{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Ms8ve", ""))
+				},
+			},
+		},
+		{
+			"no changes",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instanceLDAPIDPAddedEvent(),
+						),
+						eventFromEventPusher(
+							instance.NewLDAPIDPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								true,
+								false,
+								time.Hour,
+								0,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				settings: &LDAPSyncSettings{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			"set ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instanceLDAPIDPAddedEvent(),
+						),
+					),
+					expectPush(
+						instance.NewLDAPIDPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							true,
+							true,
+							time.Hour,
+							0,
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				settings: &LDAPSyncSettings{
+					Enabled:  true,
+					DryRun:   true,
+					Interval: time.Hour,
+				},
+			},
+			res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			"disable ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instanceLDAPIDPAddedEvent(),
+						),
+						eventFromEventPusher(
+							instance.NewLDAPIDPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								true,
+								false,
+								time.Hour,
+								0,
+							),
+						),
+					),
+					expectPush(
+						instance.NewLDAPIDPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							false,
+							false,
+							0,
+							0,
+						),
+					),
+				),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				id:       "id1",
+				settings: &LDAPSyncSettings{},
+			},
+			res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceLDAPProviderSync(tt.args.ctx, tt.args.id, tt.args.settings)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func instanceLDAPIDPAddedEvent() *instance.LDAPIDPAddedEvent {
+	return instance.NewLDAPIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"id1",
+		"name",
+		[]string{"server"},
+		false,
+		"basedn",
+		"binddn",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("password"),
+		},
+		"user",
+		[]string{"object"},
+		[]string{"filter"},
+		time.Second*30,
+		idp.LDAPAttributes{IDAttribute: "uid"},
+		idp.Options{},
+	)
+}
+
+func Test_ldapSyncPlan(t *testing.T) {
+	linkedUser := func(userID, externalUserID string, state domain.UserState) *domain.LDAPSyncLinkedUser {
+		return &domain.LDAPSyncLinkedUser{
+			UserID:            userID,
+			ResourceOwner:     "org1",
+			ExternalUserID:    externalUserID,
+			Username:          "username",
+			State:             state,
+			FirstName:         "firstname",
+			LastName:          "lastname",
+			DisplayName:       "firstname lastname",
+			PreferredLanguage: language.English,
+			Email:             "email@test.com",
+			IsEmailVerified:   true,
+		}
+	}
+	type args struct {
+		directoryUsers []*ldap.User
+		linkedUsers    []*domain.LDAPSyncLinkedUser
+		autoCreation   bool
+		autoUpdate     bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*ldapSyncAction
+	}{
+		{
+			name: "empty",
+			args: args{},
+			want: []*ldapSyncAction{},
+		},
+		{
+			name: "missing id, failed",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{PreferredUsername: "username"},
+				},
+			},
+			want: []*ldapSyncAction{
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:   domain.LDAPSyncActionFailed,
+						Username: "username",
+						Error:    "id attribute missing",
+					},
+				},
+			},
+		},
+		{
+			name: "unlinked without auto creation, ignored",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{ID: "external1"},
+				},
+			},
+			want: []*ldapSyncAction{},
+		},
+		{
+			name: "unlinked with auto creation, created",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{ID: "external1", PreferredUsername: "username"},
+				},
+				autoCreation: true,
+			},
+			want: []*ldapSyncAction{
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:         domain.LDAPSyncActionCreated,
+						ExternalUserID: "external1",
+						Username:       "username",
+					},
+					user: &ldap.User{ID: "external1", PreferredUsername: "username"},
+				},
+			},
+		},
+		{
+			name: "linked without changes, ignored",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{
+						ID:                "external1",
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						DisplayName:       "firstname lastname",
+						PreferredLanguage: language.English,
+						Email:             "email@test.com",
+						EmailVerified:     true,
+					},
+				},
+				linkedUsers: []*domain.LDAPSyncLinkedUser{
+					linkedUser("user1", "external1", domain.UserStateActive),
+				},
+				autoUpdate: true,
+			},
+			want: []*ldapSyncAction{},
+		},
+		{
+			name: "linked with changes, updated",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{
+						ID:            "external1",
+						FirstName:     "new firstname",
+						Email:         "new@test.com",
+						EmailVerified: true,
+						Phone:         "+41791234567",
+					},
+				},
+				linkedUsers: []*domain.LDAPSyncLinkedUser{
+					linkedUser("user1", "external1", domain.UserStateActive),
+				},
+				autoUpdate: true,
+			},
+			want: []*ldapSyncAction{
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:         domain.LDAPSyncActionUpdated,
+						UserID:         "user1",
+						ExternalUserID: "external1",
+					},
+					user: &ldap.User{
+						ID:            "external1",
+						FirstName:     "new firstname",
+						Email:         "new@test.com",
+						EmailVerified: true,
+						Phone:         "+41791234567",
+					},
+					linkedUser: linkedUser("user1", "external1", domain.UserStateActive),
+				},
+			},
+		},
+		{
+			name: "linked with changes without auto update, ignored",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{ID: "external1", FirstName: "new firstname"},
+				},
+				linkedUsers: []*domain.LDAPSyncLinkedUser{
+					linkedUser("user1", "external1", domain.UserStateActive),
+				},
+			},
+			want: []*ldapSyncAction{},
+		},
+		{
+			name: "linked with invalid email, failed",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{ID: "external1", Email: "invalid"},
+				},
+				linkedUsers: []*domain.LDAPSyncLinkedUser{
+					linkedUser("user1", "external1", domain.UserStateActive),
+				},
+				autoUpdate: true,
+			},
+			want: []*ldapSyncAction{
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:         domain.LDAPSyncActionFailed,
+						UserID:         "user1",
+						ExternalUserID: "external1",
+						Error:          zerrors.ThrowInvalidArgument(nil, "EMAIL-599BI", "Errors.User.Email.Invalid").Error(),
+					},
+				},
+			},
+		},
+		{
+			name: "linked not in empty directory, ignored",
+			args: args{
+				linkedUsers: []*domain.LDAPSyncLinkedUser{
+					linkedUser("user1", "external1", domain.UserStateActive),
+				},
+			},
+			want: []*ldapSyncAction{},
+		},
+		{
+			name: "linked not in directory, deactivated",
+			args: args{
+				directoryUsers: []*ldap.User{
+					{ID: "external6"},
+				},
+				linkedUsers: []*domain.LDAPSyncLinkedUser{
+					linkedUser("user5", "external5", domain.UserStateLocked),
+					linkedUser("user1", "external1", domain.UserStateActive),
+					linkedUser("user2", "external2", domain.UserStateInactive),
+					linkedUser("user3", "external3", domain.UserStateDeleted),
+					linkedUser("user4", "external4", domain.UserStateInitial),
+				},
+			},
+			want: []*ldapSyncAction{
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:         domain.LDAPSyncActionDeactivated,
+						UserID:         "user1",
+						ExternalUserID: "external1",
+						Username:       "username",
+					},
+					linkedUser: linkedUser("user1", "external1", domain.UserStateActive),
+				},
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:         domain.LDAPSyncActionFailed,
+						UserID:         "user4",
+						ExternalUserID: "external4",
+						Username:       "username",
+						Error:          "Errors.User.CantDeactivateInitial",
+					},
+				},
+				{
+					entry: &domain.LDAPSyncReportEntry{
+						Action:         domain.LDAPSyncActionDeactivated,
+						UserID:         "user5",
+						ExternalUserID: "external5",
+						Username:       "username",
+					},
+					linkedUser: linkedUser("user5", "external5", domain.UserStateLocked),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ldapSyncPlan(context.Background(), tt.args.directoryUsers, tt.args.linkedUsers, tt.args.autoCreation, tt.args.autoUpdate)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ldapSyncDeactivationsAllowed(t *testing.T) {
+	deactivated := &ldapSyncAction{entry: &domain.LDAPSyncReportEntry{Action: domain.LDAPSyncActionDeactivated}}
+	updated := &ldapSyncAction{entry: &domain.LDAPSyncReportEntry{Action: domain.LDAPSyncActionUpdated}}
+	linkedUsers := func(active, inactive int) []*domain.LDAPSyncLinkedUser {
+		users := make([]*domain.LDAPSyncLinkedUser, 0, active+inactive)
+		for i := 0; i < active; i++ {
+			users = append(users, &domain.LDAPSyncLinkedUser{State: domain.UserStateActive})
+		}
+		for i := 0; i < inactive; i++ {
+			users = append(users, &domain.LDAPSyncLinkedUser{State: domain.UserStateInactive})
+		}
+		return users
+	}
+	type args struct {
+		actions     []*ldapSyncAction
+		linkedUsers []*domain.LDAPSyncLinkedUser
+		maxPercent  uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "no deactivations, allowed",
+			args: args{
+				actions:     []*ldapSyncAction{updated},
+				linkedUsers: linkedUsers(10, 0),
+			},
+			want: true,
+		},
+		{
+			name: "default limit, allowed",
+			args: args{
+				actions:     []*ldapSyncAction{deactivated, updated},
+				linkedUsers: linkedUsers(10, 0),
+			},
+			want: true,
+		},
+		{
+			name: "default limit, exceeded",
+			args: args{
+				actions:     []*ldapSyncAction{deactivated, deactivated},
+				linkedUsers: linkedUsers(10, 0),
+			},
+			want: false,
+		},
+		{
+			name: "inactive users not counted, exceeded",
+			args: args{
+				actions:     []*ldapSyncAction{deactivated},
+				linkedUsers: linkedUsers(5, 10),
+			},
+			want: false,
+		},
+		{
+			name: "custom limit, allowed",
+			args: args{
+				actions:     []*ldapSyncAction{deactivated, deactivated, deactivated},
+				linkedUsers: linkedUsers(10, 0),
+				maxPercent:  30,
+			},
+			want: true,
+		},
+		{
+			name: "all allowed",
+			args: args{
+				actions:     []*ldapSyncAction{deactivated, deactivated},
+				linkedUsers: linkedUsers(2, 0),
+				maxPercent:  100,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ldapSyncDeactivationsAllowed(tt.args.actions, tt.args.linkedUsers, tt.args.maxPercent))
+		})
+	}
+}
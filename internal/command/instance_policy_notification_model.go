@@ -54,13 +54,16 @@ func (wm *InstanceNotificationPolicyWriteModel) Query() *eventstore.SearchQueryB
 func (wm *InstanceNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange, securityAlerts bool,
 ) (*instance.NotificationPolicyChangedEvent, bool) {
 
 	changes := make([]policy.NotificationPolicyChanges, 0)
 	if wm.PasswordChange != passwordChange {
 		changes = append(changes, policy.ChangePasswordChange(passwordChange))
 	}
+	if wm.SecurityAlerts != securityAlerts {
+		changes = append(changes, policy.ChangeSecurityAlerts(securityAlerts))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
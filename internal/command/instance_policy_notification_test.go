@@ -21,6 +21,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 		ctx            context.Context
 		resourceOwner  string
 		passwordChange bool
+		securityAlerts bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -42,6 +43,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -66,6 +68,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 						instance.NewNotificationPolicyAddedEvent(context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
 							true,
+							false,
 						),
 					),
 				),
@@ -91,6 +94,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 						instance.NewNotificationPolicyAddedEvent(context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
 							true,
+							false,
 						),
 					),
 				),
@@ -112,7 +116,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange, tt.args.securityAlerts)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -134,6 +138,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 		ctx            context.Context
 		resourceOwner  string
 		passwordChange bool
+		securityAlerts bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -172,6 +177,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -196,6 +202,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								false,
+								false,
 							),
 						),
 					),
@@ -217,13 +224,52 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change security alerts, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewNotificationPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true,
+								false,
+							),
+						),
+					),
+					expectPush(
+						func() *instance.NotificationPolicyChangedEvent {
+							event, _ := instance.NewNotificationPolicyChangedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								[]policy.NotificationPolicyChanges{
+									policy.ChangeSecurityAlerts(true),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx:            context.Background(),
+				resourceOwner:  "INSTANCE",
+				passwordChange: true,
+				securityAlerts: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange, tt.args.securityAlerts)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
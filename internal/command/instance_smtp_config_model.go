@@ -2,10 +2,12 @@ package command
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
@@ -21,13 +23,32 @@ type IAMSMTPConfigWriteModel struct {
 	SenderAddress  string
 	SenderName     string
 	ReplyToAddress string
+	Priority       uint32
 	State          domain.SMTPConfigState
+	// HTTP is set if the emails are sent through an HTTP API instead of SMTP
+	HTTP *SMTPHTTPConfig
+	// DKIM is set if the emails are signed
+	DKIM *SMTPDKIMConfig
 
 	domain                                 string
 	domainState                            domain.InstanceDomainState
 	smtpSenderAddressMatchesInstanceDomain bool
 }
 
+type SMTPHTTPConfig struct {
+	Endpoint           string
+	Headers            *crypto.CryptoValue
+	BodyTemplate       string
+	ContentType        string
+	SuccessStatusCodes []int
+}
+
+type SMTPDKIMConfig struct {
+	Selector   string
+	Domain     string
+	PrivateKey *crypto.CryptoValue
+}
+
 func NewIAMSMTPConfigWriteModel(instanceID, id, domain string) *IAMSMTPConfigWriteModel {
 	return &IAMSMTPConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
@@ -73,11 +94,40 @@ func (wm *IAMSMTPConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.reduceSMTPConfigChangedEvent(e)
+		case *instance.SMTPConfigHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceSMTPConfigHTTPAddedEvent(e)
+		case *instance.SMTPConfigHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceSMTPConfigHTTPChangedEvent(e)
+		case *instance.SMTPConfigDKIMSetEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.DKIM = &SMTPDKIMConfig{
+				Selector:   e.Selector,
+				Domain:     e.Domain,
+				PrivateKey: e.PrivateKey,
+			}
+		case *instance.SMTPConfigDKIMRemovedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.DKIM = nil
 		case *instance.SMTPConfigRemovedEvent:
 			if wm.ID != e.ID {
 				continue
 			}
 			wm.reduceSMTPConfigRemovedEvent(e)
+		case *instance.SMTPConfigPriorityChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Priority = e.Priority
 		case *instance.SMTPConfigActivatedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -123,6 +173,11 @@ func (wm *IAMSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMTPConfigActivatedEventType,
 			instance.SMTPConfigDeactivatedEventType,
 			instance.SMTPConfigRemovedEventType,
+			instance.SMTPConfigPriorityChangedEventType,
+			instance.SMTPConfigHTTPAddedEventType,
+			instance.SMTPConfigHTTPChangedEventType,
+			instance.SMTPConfigDKIMSetEventType,
+			instance.SMTPConfigDKIMRemovedEventType,
 			instance.InstanceDomainAddedEventType,
 			instance.InstanceDomainRemovedEventType,
 			instance.DomainPolicyAddedEventType,
@@ -171,6 +226,47 @@ func (wm *IAMSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregat
 	return changeEvent, true, nil
 }
 
+// NewHTTPChangedEvent returns the changes of the http config,
+// the headers are changed if they are set, as they can't be compared encrypted.
+func (wm *IAMSMTPConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id, description string, config *httpemail.Config, headers *crypto.CryptoValue) (*instance.SMTPConfigHTTPChangedEvent, bool, error) {
+	changes := make([]instance.SMTPConfigHTTPChanges, 0)
+	if wm.Description != description {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPDescription(description))
+	}
+	if wm.SenderAddress != config.SenderAddress {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPSenderAddress(config.SenderAddress))
+	}
+	if wm.SenderName != config.SenderName {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPSenderName(config.SenderName))
+	}
+	if wm.ReplyToAddress != config.ReplyToAddress {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPReplyToAddress(config.ReplyToAddress))
+	}
+	if wm.HTTP.Endpoint != config.Endpoint {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPEndpoint(config.Endpoint))
+	}
+	if headers != nil {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPHeaders(headers))
+	}
+	if wm.HTTP.BodyTemplate != config.BodyTemplate {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPBodyTemplate(config.BodyTemplate))
+	}
+	if wm.HTTP.ContentType != config.ContentType {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPContentType(config.ContentType))
+	}
+	if !slices.Equal(wm.HTTP.SuccessStatusCodes, config.SuccessStatusCodes) {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPSuccessStatusCodes(config.SuccessStatusCodes))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMTPConfigHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
 func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigAddedEvent(e *instance.SMTPConfigAddedEvent) {
 	wm.Description = e.Description
 	wm.TLS = e.TLS
@@ -225,6 +321,54 @@ func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigChangedEvent(e *instance.SMTP
 	}
 }
 
+func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigHTTPAddedEvent(e *instance.SMTPConfigHTTPAddedEvent) {
+	wm.Description = e.Description
+	wm.SenderAddress = e.SenderAddress
+	wm.SenderName = e.SenderName
+	wm.ReplyToAddress = e.ReplyToAddress
+	wm.HTTP = &SMTPHTTPConfig{
+		Endpoint:           e.Endpoint,
+		Headers:            e.Headers,
+		BodyTemplate:       e.BodyTemplate,
+		ContentType:        e.ContentType,
+		SuccessStatusCodes: e.SuccessStatusCodes,
+	}
+	wm.State = domain.SMTPConfigStateInactive
+}
+
+func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigHTTPChangedEvent(e *instance.SMTPConfigHTTPChangedEvent) {
+	if e.Description != nil {
+		wm.Description = *e.Description
+	}
+	if e.SenderAddress != nil {
+		wm.SenderAddress = *e.SenderAddress
+	}
+	if e.SenderName != nil {
+		wm.SenderName = *e.SenderName
+	}
+	if e.ReplyToAddress != nil {
+		wm.ReplyToAddress = *e.ReplyToAddress
+	}
+	if wm.HTTP == nil {
+		return
+	}
+	if e.Endpoint != nil {
+		wm.HTTP.Endpoint = *e.Endpoint
+	}
+	if e.Headers != nil {
+		wm.HTTP.Headers = e.Headers
+	}
+	if e.BodyTemplate != nil {
+		wm.HTTP.BodyTemplate = *e.BodyTemplate
+	}
+	if e.ContentType != nil {
+		wm.HTTP.ContentType = *e.ContentType
+	}
+	if e.SuccessStatusCodes != nil {
+		wm.HTTP.SuccessStatusCodes = *e.SuccessStatusCodes
+	}
+}
+
 func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigRemovedEvent(e *instance.SMTPConfigRemovedEvent) {
 	wm.Description = ""
 	wm.TLS = false
@@ -234,6 +378,9 @@ func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigRemovedEvent(e *instance.SMTP
 	wm.Host = ""
 	wm.User = ""
 	wm.Password = nil
+	wm.HTTP = nil
+	wm.DKIM = nil
+	wm.Priority = 0
 	wm.State = domain.SMTPConfigStateRemoved
 
 	// If ID has empty value we're dealing with the old and unique smtp settings
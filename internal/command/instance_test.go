@@ -209,7 +209,7 @@ func instancePoliciesEvents(ctx context.Context, instanceID string) []eventstore
 		instance.NewLoginPolicySecondFactorAddedEvent(ctx, &instanceAgg.Aggregate, domain.SecondFactorTypeU2F),
 		instance.NewLoginPolicyMultiFactorAddedEvent(ctx, &instanceAgg.Aggregate, domain.MultiFactorTypeU2FWithPIN),
 		instance.NewPrivacyPolicyAddedEvent(ctx, &instanceAgg.Aggregate, "", "", "", "", "", "", ""),
-		instance.NewNotificationPolicyAddedEvent(ctx, &instanceAgg.Aggregate, true),
+		instance.NewNotificationPolicyAddedEvent(ctx, &instanceAgg.Aggregate, true, false),
 		instance.NewLockoutPolicyAddedEvent(ctx, &instanceAgg.Aggregate, 0, 0, true),
 		instance.NewLabelPolicyAddedEvent(ctx, &instanceAgg.Aggregate, "#5469d4", "#fafafa", "#cd3d56", "#000000", "#2073c4", "#111827", "#ff3b5b", "#ffffff", false, false, false, domain.LabelPolicyThemeAuto),
 		instance.NewLabelPolicyActivatedEvent(ctx, &instanceAgg.Aggregate),
@@ -255,7 +255,8 @@ func instanceSetupPoliciesConfig() *InstanceSetup {
 		}{true, true, true, false, false, false, false, true, false, false, domain.PasswordlessTypeAllowed, "", 240 * time.Hour, 240 * time.Hour, 720 * time.Hour, 18 * time.Hour, 12 * time.Hour},
 		NotificationPolicy: struct {
 			PasswordChange bool
-		}{true},
+			SecurityAlerts bool
+		}{true, false},
 		PrivacyPolicy: struct {
 			TOSLink        string
 			PrivacyLink    string
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// NotificationDelivery is an attempt of a provider to deliver a message to a user
+type NotificationDelivery struct {
+	UserID        string
+	ResourceOwner string
+	Channel       domain.NotificationType
+	MessageType   string
+	// Recipient is the plain email address or phone number, which is masked before it's stored
+	Recipient       string
+	Provider        string
+	TriggeringEvent eventstore.Event
+	// Err is the error returned by the provider, nil if the message was delivered
+	Err error
+}
+
+func (d *NotificationDelivery) maskedRecipient() string {
+	if d.Channel == domain.NotificationTypeSms {
+		return domain.MaskPhone(d.Recipient)
+	}
+	return domain.MaskEmail(d.Recipient)
+}
+
+// deliveryErrorCode classifies the error of a provider, e.g. `Unavailable:EMAIL-Hd7wq`.
+// The error text isn't stored, as it might contain the response of the provider or its credentials.
+func deliveryErrorCode(err error) string {
+	var kind string
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), zerrors.IsDeadlineExceeded(err):
+		kind = "DeadlineExceeded"
+	case zerrors.IsUnavailable(err):
+		kind = "Unavailable"
+	case zerrors.IsErrorInvalidArgument(err):
+		kind = "InvalidArgument"
+	case zerrors.IsInternal(err):
+		kind = "Internal"
+	default:
+		kind = "Unknown"
+	}
+	zErr := new(zerrors.ZitadelError)
+	if !errors.As(err, &zErr) {
+		return kind
+	}
+	return kind + ":" + zErr.GetID()
+}
+
+// AddNotificationDelivery records the attempt to deliver a message, so it can be listed and resent if it failed.
+func (c *Commands) AddNotificationDelivery(ctx context.Context, delivery *NotificationDelivery) (_ *domain.ObjectDetails, err error) {
+	if delivery.TriggeringEvent == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-p7fw2kx0qd", "Errors.Notification.Delivery.Invalid")
+	}
+	resourceOwner := delivery.ResourceOwner
+	if resourceOwner == "" {
+		resourceOwner = delivery.TriggeringEvent.Aggregate().ResourceOwner
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	wm := NewNotificationDeliveryWriteModel(id, resourceOwner)
+	agg := notification.NewAggregate(id, resourceOwner, authz.GetInstance(ctx).InstanceID())
+	payload := notification.Delivery{
+		UserID:          delivery.UserID,
+		Channel:         delivery.Channel,
+		MessageType:     delivery.MessageType,
+		Recipient:       delivery.maskedRecipient(),
+		Provider:        delivery.Provider,
+		TriggeringEvent: notification.NewTriggeringEvent(delivery.TriggeringEvent),
+	}
+	var event eventstore.Command = notification.NewDeliveredEvent(ctx, agg, payload)
+	if delivery.Err != nil {
+		payload.Response = deliveryErrorCode(delivery.Err)
+		event = notification.NewDeliveryFailedEvent(ctx, agg, payload)
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, event); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// ResendNotification requests the message of a failed delivery to be sent again.
+// The resourceOwner restricts the delivery to an organization, if it's empty the delivery can be of any organization of the instance.
+func (c *Commands) ResendNotification(ctx context.Context, id, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-w3nq8vb5ka", "Errors.IDMissing")
+	}
+	wm := NewNotificationDeliveryWriteModel(id, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-c6hz4rtm1e", "Errors.Notification.Delivery.NotFound")
+	}
+	if wm.State != domain.NotificationDeliveryStateFailed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-j2yd9sxo5u", "Errors.Notification.Delivery.NotFailed")
+	}
+	if err := c.pushAppendAndReduce(ctx, wm,
+		notification.NewResendRequestedEvent(ctx,
+			notification.NewAggregate(wm.AggregateID, wm.ResourceOwner, wm.InstanceID),
+			wm.TriggeringEvent,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
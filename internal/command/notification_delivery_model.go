@@ -0,0 +1,55 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type NotificationDeliveryWriteModel struct {
+	eventstore.WriteModel
+
+	TriggeringEvent *notification.TriggeringEvent
+	State           domain.NotificationDeliveryState
+}
+
+// NewNotificationDeliveryWriteModel creates the write model of a delivery,
+// the resourceOwner can be empty to search the delivery in the whole instance
+func NewNotificationDeliveryWriteModel(id, resourceOwner string) *NotificationDeliveryWriteModel {
+	return &NotificationDeliveryWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationDeliveryWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.DeliveredEvent:
+			wm.TriggeringEvent = e.TriggeringEvent
+			wm.State = domain.NotificationDeliveryStateDelivered
+		case *notification.DeliveryFailedEvent:
+			wm.TriggeringEvent = e.TriggeringEvent
+			wm.State = domain.NotificationDeliveryStateFailed
+		case *notification.ResendRequestedEvent:
+			wm.State = domain.NotificationDeliveryStateResendRequested
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationDeliveryWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.DeliveredEventType,
+			notification.DeliveryFailedEventType,
+			notification.ResendRequestedEventType,
+		).
+		Builder()
+}
@@ -0,0 +1,326 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddNotificationDelivery(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	triggeringEvent := user.NewHumanEmailCodeAddedEventV2(ctx, &user.NewAggregate("user1", "org1").Aggregate, nil, 0, "", false, "")
+	triggeringEvent.Seq = 3
+	wantTriggeringEvent := &notification.TriggeringEvent{
+		AggregateType: user.AggregateType,
+		AggregateID:   "user1",
+		ResourceOwner: "org1",
+		EventType:     user.HumanEmailCodeAddedType,
+		Sequence:      3,
+	}
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		delivery *NotificationDelivery
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"triggering event missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				delivery: &NotificationDelivery{},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"email delivered, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectPush(
+						notification.NewDeliveredEvent(ctx,
+							notification.NewAggregate("delivery1", "org1", "instance"),
+							notification.Delivery{
+								UserID:          "user1",
+								Channel:         domain.NotificationTypeEmail,
+								MessageType:     domain.VerifyEmailMessageType,
+								Recipient:       "j***@example.com",
+								Provider:        "smtp1",
+								TriggeringEvent: wantTriggeringEvent,
+							},
+						),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "delivery1"),
+			},
+			args{
+				delivery: &NotificationDelivery{
+					UserID:          "user1",
+					Channel:         domain.NotificationTypeEmail,
+					MessageType:     domain.VerifyEmailMessageType,
+					Recipient:       "john@example.com",
+					Provider:        "smtp1",
+					TriggeringEvent: triggeringEvent,
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"sms failed, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectPush(
+						notification.NewDeliveryFailedEvent(ctx,
+							notification.NewAggregate("delivery1", "org2", "instance"),
+							notification.Delivery{
+								UserID:          "user1",
+								Channel:         domain.NotificationTypeSms,
+								MessageType:     domain.VerifyPhoneMessageType,
+								Recipient:       "+41*******67",
+								Provider:        "sms1",
+								Response:        "Unavailable:HTTPSMS-Ho2ks",
+								TriggeringEvent: wantTriggeringEvent,
+							},
+						),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "delivery1"),
+			},
+			args{
+				delivery: &NotificationDelivery{
+					UserID:          "user1",
+					ResourceOwner:   "org2",
+					Channel:         domain.NotificationTypeSms,
+					MessageType:     domain.VerifyPhoneMessageType,
+					Recipient:       "+41791234567",
+					Provider:        "sms1",
+					TriggeringEvent: triggeringEvent,
+					Err:             zerrors.ThrowUnavailable(errors.New("gateway unavailable: secret response"), "HTTPSMS-Ho2ks", "could not send message"),
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			details, err := c.AddNotificationDelivery(ctx, tt.args.delivery)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ResendNotification(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	triggeringEvent := &notification.TriggeringEvent{
+		AggregateType: user.AggregateType,
+		AggregateID:   "user1",
+		ResourceOwner: "org1",
+		EventType:     user.HumanEmailCodeAddedType,
+		Sequence:      3,
+	}
+	delivery := notification.Delivery{
+		UserID:          "user1",
+		Channel:         domain.NotificationTypeEmail,
+		MessageType:     domain.VerifyEmailMessageType,
+		Recipient:       "j***@example.com",
+		Provider:        "smtp1",
+		TriggeringEvent: triggeringEvent,
+	}
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				id:            "delivery1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"delivered, precondition error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewDeliveredEvent(ctx, notification.NewAggregate("delivery1", "org1", "instance"), delivery),
+						),
+					),
+				),
+			},
+			args{
+				id:            "delivery1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"resend already requested, precondition error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewDeliveryFailedEvent(ctx, notification.NewAggregate("delivery1", "org1", "instance"), delivery),
+						),
+						eventFromEventPusher(
+							notification.NewResendRequestedEvent(ctx, notification.NewAggregate("delivery1", "org1", "instance"), triggeringEvent),
+						),
+					),
+				),
+			},
+			args{
+				id:            "delivery1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"failed, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							notification.NewDeliveryFailedEvent(ctx, notification.NewAggregate("delivery1", "org1", "instance"), delivery),
+						),
+					),
+					expectPush(
+						notification.NewResendRequestedEvent(ctx, notification.NewAggregate("delivery1", "org1", "instance"), triggeringEvent),
+					),
+				),
+			},
+			args{
+				id: "delivery1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.ResendNotification(ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func Test_deliveryErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			"zitadel error",
+			zerrors.ThrowUnknown(errors.New("calling url returned 500: body"), "HTTPSMS-Wc7nj", "sms gateway didn't return a success status"),
+			"Unknown:HTTPSMS-Wc7nj",
+		},
+		{
+			"wrapped deadline",
+			zerrors.ThrowUnavailable(context.DeadlineExceeded, "HTTPSMS-Ho2ks", "could not send message"),
+			"DeadlineExceeded:HTTPSMS-Ho2ks",
+		},
+		{
+			"plain error",
+			errors.New("550 mailbox of user@example.com unavailable"),
+			"Unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deliveryErrorCode(tt.err))
+		})
+	}
+}
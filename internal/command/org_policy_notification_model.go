@@ -55,13 +55,16 @@ func (wm *OrgNotificationPolicyWriteModel) Query() *eventstore.SearchQueryBuilde
 func (wm *OrgNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange, securityAlerts bool,
 ) (*org.NotificationPolicyChangedEvent, bool) {
 
 	changes := make([]policy.NotificationPolicyChanges, 0)
 	if wm.PasswordChange != passwordChange {
 		changes = append(changes, policy.ChangePasswordChange(passwordChange))
 	}
+	if wm.SecurityAlerts != securityAlerts {
+		changes = append(changes, policy.ChangeSecurityAlerts(securityAlerts))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
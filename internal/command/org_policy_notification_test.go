@@ -21,6 +21,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 		ctx            context.Context
 		orgID          string
 		passwordChange bool
+		securityAlerts bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -58,6 +59,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -82,6 +84,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 						org.NewNotificationPolicyAddedEvent(context.Background(),
 							&org.NewAggregate("org1").Aggregate,
 							true,
+							false,
 						),
 					),
 				),
@@ -107,6 +110,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 						org.NewNotificationPolicyAddedEvent(context.Background(),
 							&org.NewAggregate("org1").Aggregate,
 							false,
+							false,
 						),
 					),
 				),
@@ -128,7 +132,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange, tt.args.securityAlerts)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -150,6 +154,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 		ctx            context.Context
 		orgID          string
 		passwordChange bool
+		securityAlerts bool
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -203,6 +208,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -227,6 +233,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
@@ -252,7 +259,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange, tt.args.securityAlerts)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -324,6 +331,7 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
 							),
 						),
 					),
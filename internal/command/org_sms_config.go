@@ -0,0 +1,322 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddOrgSMSConfigTwilio adds a Twilio config to the organization.
+func (c *Commands) AddOrgSMSConfigTwilio(ctx context.Context, orgID string, config *twilio.Config) (string, *domain.ObjectDetails, error) {
+	if orgID == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "SMS-Ur5bq", "Errors.ResourceOwnerMissing")
+	}
+	if err := c.checkOrgExists(ctx, orgID); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	var token *crypto.CryptoValue
+	if config.Token != "" {
+		token, err = crypto.Encrypt([]byte(config.Token), c.smsEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMSConfigTwilioAddedEvent(
+		ctx,
+		orgAgg,
+		id,
+		config.SID,
+		config.SenderNumber,
+		token))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeOrgSMSConfigTwilio(ctx context.Context, orgID, id string, config *twilio.Config) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Lw3nz", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Qa8ty", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.Twilio == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ej6vm", "Errors.SMSConfig.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewTwilioChangedEvent(
+		ctx,
+		orgAgg,
+		id,
+		config.SID,
+		config.SenderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Pf2wl", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeOrgSMSConfigTwilioToken(ctx context.Context, orgID, id, token string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Vz1cd", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Hg4rx", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.Twilio == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ob9si", "Errors.SMSConfig.NotFound")
+	}
+	newToken, err := crypto.Encrypt([]byte(token), c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	changedEvent, err := org.NewSMSConfigTwilioChangedEvent(ctx, orgAgg, id, []org.SMSConfigTwilioChanges{org.ChangeSMSConfigTwilioToken(newToken)})
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// AddOrgSMSConfigHTTP adds a config to the organization, which sends the SMS through an HTTP API.
+func (c *Commands) AddOrgSMSConfigHTTP(ctx context.Context, orgID string, config *httpsms.Config) (string, *domain.ObjectDetails, error) {
+	if orgID == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "SMS-Ky7mf", "Errors.ResourceOwnerMissing")
+	}
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	if err := c.checkOrgExists(ctx, orgID); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	headers, err := c.encryptSMSHTTPHeaders(config.Headers)
+	if err != nil {
+		return "", nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMSConfigHTTPAddedEvent(
+		ctx,
+		orgAgg,
+		id,
+		config.Endpoint,
+		config.Method,
+		headers,
+		config.BodyTemplate,
+		config.ContentType,
+		config.SenderID,
+		config.SuccessStatusCodes,
+		config.SuccessJSONPath,
+		config.SuccessJSONValue,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ChangeOrgSMSConfigHTTP changes the http config of the organization, the headers are kept if none are set.
+func (c *Commands) ChangeOrgSMSConfigHTTP(ctx context.Context, orgID, id string, config *httpsms.Config) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Xe2gp", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Dn5ja", "Errors.IDMissing")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Wt3kh", "Errors.SMSConfig.NotFound")
+	}
+	headers, err := c.encryptSMSHTTPHeaders(config.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(
+		ctx,
+		orgAgg,
+		id,
+		config,
+		headers)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ci7qe", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ActivateOrgSMSConfig activates the SMS config of the organization,
+// an active config of the organization is used instead of the config of the instance.
+func (c *Commands) ActivateOrgSMSConfig(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Gm6ow", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Tb8ul", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rq1ae", "Errors.SMSConfig.NotFound")
+	}
+	if smsConfigWriteModel.State == domain.SMSConfigStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Fy4bn", "Errors.SMSConfig.AlreadyActive")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMSConfigActivatedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) DeactivateOrgSMSConfig(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Iv9pr", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Ox2fc", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Jd5wm", "Errors.SMSConfig.NotFound")
+	}
+	if smsConfigWriteModel.State == domain.SMSConfigStateInactive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Mu7hz", "Errors.SMSConfig.AlreadyDeactivated")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMSConfigDeactivatedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveOrgSMSConfig(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Bh3zs", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Aw6kd", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getOrgSMSConfig(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Sc8yt", "Errors.SMSConfig.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMSConfigRemovedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) getOrgSMSConfig(ctx context.Context, orgID, id string) (*OrgSMSConfigWriteModel, error) {
+	writeModel := NewOrgSMSConfigWriteModel(orgID, id)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
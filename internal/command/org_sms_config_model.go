@@ -0,0 +1,213 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgSMSConfigWriteModel struct {
+	eventstore.WriteModel
+
+	ID     string
+	Twilio *TwilioConfig
+	HTTP   *HTTPConfig
+	State  domain.SMSConfigState
+}
+
+func NewOrgSMSConfigWriteModel(orgID, id string) *OrgSMSConfigWriteModel {
+	return &OrgSMSConfigWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		ID: id,
+	}
+}
+
+func (wm *OrgSMSConfigWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.SMSConfigTwilioAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Twilio = &TwilioConfig{
+				SID:          e.SID,
+				Token:        e.Token,
+				SenderNumber: e.SenderNumber,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *org.SMSConfigTwilioChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.SID != nil {
+				wm.Twilio.SID = *e.SID
+			}
+			if e.SenderNumber != nil {
+				wm.Twilio.SenderNumber = *e.SenderNumber
+			}
+			if e.Token != nil {
+				wm.Twilio.Token = e.Token
+			}
+		case *org.SMSConfigHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP = &HTTPConfig{
+				Endpoint:           e.Endpoint,
+				Method:             e.Method,
+				Headers:            e.Headers,
+				BodyTemplate:       e.BodyTemplate,
+				ContentType:        e.ContentType,
+				SenderID:           e.SenderID,
+				SuccessStatusCodes: e.SuccessStatusCodes,
+				SuccessJSONPath:    e.SuccessJSONPath,
+				SuccessJSONValue:   e.SuccessJSONValue,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *org.SMSConfigHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceHTTPChanged(e)
+		case *org.SMSConfigActivatedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.State = domain.SMSConfigStateActive
+		case *org.SMSConfigDeactivatedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *org.SMSConfigRemovedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceRemoved()
+		case *org.OrgRemovedEvent:
+			wm.reduceRemoved()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgSMSConfigWriteModel) reduceHTTPChanged(e *org.SMSConfigHTTPChangedEvent) {
+	if e.Endpoint != nil {
+		wm.HTTP.Endpoint = *e.Endpoint
+	}
+	if e.Method != nil {
+		wm.HTTP.Method = *e.Method
+	}
+	if e.Headers != nil {
+		wm.HTTP.Headers = e.Headers
+	}
+	if e.BodyTemplate != nil {
+		wm.HTTP.BodyTemplate = *e.BodyTemplate
+	}
+	if e.ContentType != nil {
+		wm.HTTP.ContentType = *e.ContentType
+	}
+	if e.SenderID != nil {
+		wm.HTTP.SenderID = *e.SenderID
+	}
+	if e.SuccessStatusCodes != nil {
+		wm.HTTP.SuccessStatusCodes = *e.SuccessStatusCodes
+	}
+	if e.SuccessJSONPath != nil {
+		wm.HTTP.SuccessJSONPath = *e.SuccessJSONPath
+	}
+	if e.SuccessJSONValue != nil {
+		wm.HTTP.SuccessJSONValue = *e.SuccessJSONValue
+	}
+}
+
+func (wm *OrgSMSConfigWriteModel) reduceRemoved() {
+	wm.Twilio = nil
+	wm.HTTP = nil
+	wm.State = domain.SMSConfigStateRemoved
+}
+
+func (wm *OrgSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.SMSConfigTwilioAddedEventType,
+			org.SMSConfigTwilioChangedEventType,
+			org.SMSConfigHTTPAddedEventType,
+			org.SMSConfigHTTPChangedEventType,
+			org.SMSConfigActivatedEventType,
+			org.SMSConfigDeactivatedEventType,
+			org.SMSConfigRemovedEventType,
+			org.OrgRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgSMSConfigWriteModel) NewTwilioChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id, sid, senderNumber string) (*org.SMSConfigTwilioChangedEvent, bool, error) {
+	changes := make([]org.SMSConfigTwilioChanges, 0)
+	if wm.Twilio.SID != sid {
+		changes = append(changes, org.ChangeSMSConfigTwilioSID(sid))
+	}
+	if wm.Twilio.SenderNumber != senderNumber {
+		changes = append(changes, org.ChangeSMSConfigTwilioSenderNumber(senderNumber))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := org.NewSMSConfigTwilioChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+// NewHTTPChangedEvent returns the changes of the http config,
+// the headers are changed if they are set, as they can't be compared encrypted.
+func (wm *OrgSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, config *httpsms.Config, headers *crypto.CryptoValue) (*org.SMSConfigHTTPChangedEvent, bool, error) {
+	changes := make([]org.SMSConfigHTTPChanges, 0)
+	if wm.HTTP.Endpoint != config.Endpoint {
+		changes = append(changes, org.ChangeSMSConfigHTTPEndpoint(config.Endpoint))
+	}
+	if wm.HTTP.Method != config.Method {
+		changes = append(changes, org.ChangeSMSConfigHTTPMethod(config.Method))
+	}
+	if headers != nil {
+		changes = append(changes, org.ChangeSMSConfigHTTPHeaders(headers))
+	}
+	if wm.HTTP.BodyTemplate != config.BodyTemplate {
+		changes = append(changes, org.ChangeSMSConfigHTTPBodyTemplate(config.BodyTemplate))
+	}
+	if wm.HTTP.ContentType != config.ContentType {
+		changes = append(changes, org.ChangeSMSConfigHTTPContentType(config.ContentType))
+	}
+	if wm.HTTP.SenderID != config.SenderID {
+		changes = append(changes, org.ChangeSMSConfigHTTPSenderID(config.SenderID))
+	}
+	if !slices.Equal(wm.HTTP.SuccessStatusCodes, config.SuccessStatusCodes) {
+		changes = append(changes, org.ChangeSMSConfigHTTPSuccessStatusCodes(config.SuccessStatusCodes))
+	}
+	if wm.HTTP.SuccessJSONPath != config.SuccessJSONPath {
+		changes = append(changes, org.ChangeSMSConfigHTTPSuccessJSONPath(config.SuccessJSONPath))
+	}
+	if wm.HTTP.SuccessJSONValue != config.SuccessJSONValue {
+		changes = append(changes, org.ChangeSMSConfigHTTPSuccessJSONValue(config.SuccessJSONValue))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := org.NewSMSConfigHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
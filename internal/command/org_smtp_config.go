@@ -0,0 +1,299 @@
+package command
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddOrgSMTPConfig adds a SMTP config to the organization,
+// the sender address must be on a verified domain of the organization.
+func (c *Commands) AddOrgSMTPConfig(ctx context.Context, orgID string, config *smtp.Config) (string, *domain.ObjectDetails, error) {
+	if orgID == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Fq3nv", "Errors.ResourceOwnerMissing")
+	}
+	senderDomain, hostAndPort, err := trimOrgSMTPConfig(config)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := c.checkOrgExists(ctx, orgID); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smtpPassword, err := c.encryptSMTPPassword(config.SMTP.Password)
+	if err != nil {
+		return "", nil, err
+	}
+
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, senderDomain)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = checkOrgSenderAddress(smtpConfigWriteModel); err != nil {
+		return "", nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigAddedEvent(
+		ctx,
+		orgAgg,
+		id,
+		strings.TrimSpace(config.Description),
+		config.Tls,
+		config.From,
+		config.FromName,
+		config.ReplyToAddress,
+		hostAndPort,
+		config.SMTP.User,
+		smtpPassword,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// ChangeOrgSMTPConfig changes the SMTP config of the organization, the password is kept if none is set.
+func (c *Commands) ChangeOrgSMTPConfig(ctx context.Context, orgID, id string, config *smtp.Config) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Wd7kz", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Gt2xm", "Errors.IDMissing")
+	}
+	senderDomain, hostAndPort, err := trimOrgSMTPConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	smtpPassword, err := c.encryptSMTPPassword(config.SMTP.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, senderDomain)
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ux4pe", "Errors.SMTPConfig.NotFound")
+	}
+	if err = checkOrgSenderAddress(smtpConfigWriteModel); err != nil {
+		return nil, err
+	}
+	if smtpConfigWriteModel.DKIM != nil {
+		if err = checkDKIMDomain(smtpConfigWriteModel.DKIM.Domain, config.From); err != nil {
+			return nil, err
+		}
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	changedEvent, hasChanged, err := smtpConfigWriteModel.NewChangedEvent(
+		ctx,
+		orgAgg,
+		id,
+		strings.TrimSpace(config.Description),
+		config.Tls,
+		config.From,
+		config.FromName,
+		config.ReplyToAddress,
+		hostAndPort,
+		config.SMTP.User,
+		smtpPassword,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Bn6fo", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// ChangeOrgSMTPConfigPassword sets the password of the SMTP config of the organization.
+func (c *Commands) ChangeOrgSMTPConfigPassword(ctx context.Context, orgID, id, password string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ry8cb", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Mh5qs", "Errors.IDMissing")
+	}
+	if password == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Jw2ud", "Errors.Invalid.Argument")
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ko3vd", "Errors.SMTPConfig.NotFound")
+	}
+	smtpPassword, err := c.encryptSMTPPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	changedEvent, err := org.NewSMTPConfigChangedEvent(ctx, orgAgg, id, []org.SMTPConfigChanges{org.ChangeSMTPConfigSMTPPassword(smtpPassword)})
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// ActivateOrgSMTPConfig activates the SMTP config of the organization,
+// active configs of the organization are used instead of the configs of the instance.
+func (c *Commands) ActivateOrgSMTPConfig(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ak2rw", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Te9hy", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Zp4ol", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.State == domain.SMTPConfigStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Dq7iu", "Errors.SMTPConfig.AlreadyActive")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigActivatedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) DeactivateOrgSMTPConfig(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Cs5gn", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ew1jt", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Hv8sa", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.State == domain.SMTPConfigStateInactive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Yl2mc", "Errors.SMTPConfig.AlreadyDeactivated")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigDeactivatedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveOrgSMTPConfig(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Nb4wf", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Vi6ok", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Sx3by", "Errors.SMTPConfig.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigRemovedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// trimOrgSMTPConfig trims the values of the config, validates the host
+// and returns the domain of the sender address and the trimmed host
+func trimOrgSMTPConfig(config *smtp.Config) (senderDomain, hostAndPort string, err error) {
+	config.From = strings.TrimSpace(config.From)
+	if config.From == "" {
+		return "", "", zerrors.ThrowInvalidArgument(nil, "ORG-Jz7wk", "Errors.Invalid.Argument")
+	}
+	config.ReplyToAddress = strings.TrimSpace(config.ReplyToAddress)
+	hostAndPort = strings.TrimSpace(config.SMTP.Host)
+	if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
+		return "", "", zerrors.ThrowInvalidArgument(nil, "ORG-Uy5rb", "Errors.Invalid.Argument")
+	}
+	fromSplitted := strings.Split(config.From, "@")
+	return fromSplitted[len(fromSplitted)-1], hostAndPort, nil
+}
+
+func (c *Commands) encryptSMTPPassword(password string) (*crypto.CryptoValue, error) {
+	if password == "" {
+		return nil, nil
+	}
+	return crypto.Encrypt([]byte(password), c.smtpEncryption)
+}
+
+// checkOrgSenderAddress ensures that organizations only send emails from their own verified domains
+func checkOrgSenderAddress(writeModel *OrgSMTPConfigWriteModel) error {
+	if !writeModel.domainVerified {
+		return zerrors.ThrowInvalidArgument(nil, "ORG-Xk9pd", "Errors.SMTPConfig.SenderAddressNotVerifiedOrgDomain")
+	}
+	return nil
+}
+
+func (c *Commands) getOrgSMTPConfig(ctx context.Context, orgID, id, domain string) (*OrgSMTPConfigWriteModel, error) {
+	writeModel := NewOrgSMTPConfigWriteModel(orgID, id, domain)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/otpsend"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// otpSendLimitAttempts is the maximum number of times a send is recorded
+// if codes were sent concurrently in the same scope.
+const otpSendLimitAttempts = 5
+
+// checkOTPSendLimit checks the configured send limits for a code which will be sent to the recipient of the user
+// and records the send for every limit.
+// If a limit is reached, the limit reached event is pushed and a resource exhausted error
+// containing the time after which another code can be requested is returned.
+// As the events expect the sequences of the scopes, they are re-evaluated if another code was recorded in the meantime.
+func (c *Commands) checkOTPSendLimit(ctx context.Context, userID string, notificationType domain.NotificationType, recipient string) (err error) {
+	for i := 0; i < otpSendLimitAttempts; i++ {
+		err = c.recordOTPSend(ctx, userID, notificationType, recipient)
+		if !eventstore.IsConcurrentChange(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *Commands) recordOTPSend(ctx context.Context, userID string, notificationType domain.NotificationType, recipient string) error {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	limits := []struct {
+		scope domain.OTPSendLimitScope
+		key   string
+		limit sd.SendLimit
+	}{
+		{domain.OTPSendLimitScopeUser, userID, c.otpSendLimits.User},
+		{domain.OTPSendLimitScopeRecipient, recipient, c.otpSendLimits.Recipient},
+		{domain.OTPSendLimitScopeInstance, instanceID, c.otpSendLimits.Instance},
+	}
+	var recorded []eventstore.Command
+	now := time.Now()
+	for _, l := range limits {
+		if !l.limit.Enabled() || l.key == "" {
+			continue
+		}
+		agg := otpsend.NewAggregate(l.scope, l.key, instanceID)
+		writeModel := NewOTPSendLimitWriteModel(agg.ID, l.limit, now)
+		if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if coolDown := writeModel.CoolDownRemaining(); coolDown > 0 {
+			return zerrors.ThrowResourceExhaustedRetryAfter(nil, "COMMAND-Tq3vxk8Lme", "Errors.User.Code.SendLimitReached", coolDown)
+		}
+		exceeded, retryAfter := writeModel.Exceeded()
+		if !exceeded {
+			recorded = append(recorded, otpsend.NewRecordedEvent(ctx, agg, writeModel.sequence, notificationType))
+			continue
+		}
+		if !writeModel.LimitReached() {
+			if _, err := c.eventstore.Push(ctx, otpsend.NewLimitReachedEvent(ctx, agg, writeModel.sequence, retryAfter)); err != nil {
+				return err
+			}
+		}
+		return zerrors.ThrowResourceExhaustedRetryAfter(nil, "COMMAND-b7Rk2mWq9s", "Errors.User.Code.SendLimitReached", retryAfter)
+	}
+	if len(recorded) == 0 {
+		return nil
+	}
+	_, err := c.eventstore.Push(ctx, recorded...)
+	return err
+}
+
+// checkUserOTPSendLimit checks the send limits for a code sent to the verified phone number or email address of the user,
+// e.g. an OTP challenge, and records the send.
+func (c *Commands) checkUserOTPSendLimit(ctx context.Context, userID, resourceOwner string, notificationType domain.NotificationType) error {
+	if !c.otpSendLimits.Enabled() {
+		return nil
+	}
+	var recipient string
+	switch notificationType {
+	case domain.NotificationTypeSms:
+		writeModel, err := c.phoneWriteModelByID(ctx, userID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		recipient = string(writeModel.Phone)
+	case domain.NotificationTypeEmail:
+		writeModel, err := c.emailWriteModel(ctx, userID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		recipient = string(writeModel.Email)
+	}
+	return c.checkOTPSendLimit(ctx, userID, notificationType, recipient)
+}
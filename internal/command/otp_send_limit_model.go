@@ -0,0 +1,102 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/otpsend"
+)
+
+// OTPSendLimitWriteModel reduces the latest events of a send limit scope,
+// to count the codes sent during the interval of the limit and check the cool-down.
+type OTPSendLimitWriteModel struct {
+	eventstore.WriteModel
+
+	limit sd.SendLimit
+	now   time.Time
+
+	// sequence is the latest sequence of the scope, the next event of the scope is only pushed if it didn't change
+	sequence uint64
+	latest   eventstore.Event
+	sent     []time.Time
+	// limitReached is set if the limit reached event is the latest event of the scope
+	limitReached time.Time
+}
+
+func NewOTPSendLimitWriteModel(aggregateID string, limit sd.SendLimit, now time.Time) *OTPSendLimitWriteModel {
+	return &OTPSendLimitWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID: aggregateID,
+		},
+		limit: limit,
+		now:   now,
+	}
+}
+
+func (wm *OTPSendLimitWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if e, ok := event.(*otpsend.RecordedEvent); ok && e.CreatedAt().After(wm.now.Add(-wm.limit.Interval)) {
+			wm.sent = append(wm.sent, e.CreatedAt())
+		}
+		if event.Sequence() > wm.sequence {
+			wm.sequence = event.Sequence()
+			wm.latest = event
+		}
+	}
+}
+
+func (wm *OTPSendLimitWriteModel) Reduce() error {
+	slices.SortFunc(wm.sent, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	if reached, ok := wm.latest.(*otpsend.LimitReachedEvent); ok {
+		wm.limitReached = reached.CreatedAt()
+	}
+	return nil
+}
+
+func (wm *OTPSendLimitWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderDesc().
+		// the limit is only reached again after further codes were sent,
+		// so the latest codes are always within twice the amount of events
+		Limit(2*wm.limit.Amount).
+		AddQuery().
+		AggregateTypes(otpsend.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			otpsend.RecordedEventType,
+			otpsend.LimitReachedEventType,
+		).
+		Builder()
+}
+
+// LimitReached returns if the limit was reached and no code was sent since.
+func (wm *OTPSendLimitWriteModel) LimitReached() bool {
+	return !wm.limitReached.IsZero()
+}
+
+// CoolDownRemaining returns the remaining time of the cool-down after the limit was reached.
+func (wm *OTPSendLimitWriteModel) CoolDownRemaining() time.Duration {
+	if wm.limitReached.IsZero() {
+		return 0
+	}
+	return max(wm.limitReached.Add(wm.limit.CoolDown).Sub(wm.now), 0)
+}
+
+// Exceeded returns if no further codes can be sent and the time until the next code can be sent.
+// The cool-down only starts if the limit wasn't already reached before.
+func (wm *OTPSendLimitWriteModel) Exceeded() (bool, time.Duration) {
+	if uint64(len(wm.sent)) < wm.limit.Amount {
+		return false, 0
+	}
+	// the oldest code which needs to leave the interval, so another code can be sent
+	oldest := wm.sent[uint64(len(wm.sent))-wm.limit.Amount]
+	retryAfter := oldest.Add(wm.limit.Interval).Sub(wm.now)
+	if wm.LimitReached() {
+		return true, retryAfter
+	}
+	return true, max(retryAfter, wm.limit.CoolDown)
+}
@@ -0,0 +1,344 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/otpsend"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func otpSendEvent(event eventstore.Command, sequence uint64, creationDate time.Time) *repository.Event {
+	e := eventFromEventPusher(event)
+	e.Seq = sequence
+	e.CreationDate = creationDate
+	return e
+}
+
+func TestCommands_checkOTPSendLimit(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	userAgg := otpsend.NewAggregate(domain.OTPSendLimitScopeUser, "user1", "instance1")
+	recipientAgg := otpsend.NewAggregate(domain.OTPSendLimitScopeRecipient, "user@example.com", "instance1")
+	instanceAgg := otpsend.NewAggregate(domain.OTPSendLimitScopeInstance, "instance1", "instance1")
+	type fields struct {
+		eventstore    func(*testing.T) *eventstore.Eventstore
+		otpSendLimits sd.OTPSendLimits
+	}
+	type args struct {
+		notificationType domain.NotificationType
+		recipient        string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no limits, nothing recorded",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{},
+		},
+		{
+			name: "filter error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilterError(zerrors.ThrowInternal(nil, "id", "filter failed")),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					User: sd.SendLimit{Amount: 1, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{
+				err: zerrors.IsInternal,
+			},
+		},
+		{
+			name: "user limit not reached, recorded with sequence",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, userAgg, 0, domain.NotificationTypeSms), 1, time.Now()),
+					),
+					expectPush(
+						otpsend.NewRecordedEvent(ctx, userAgg, 1, domain.NotificationTypeSms),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					User: sd.SendLimit{Amount: 2, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{},
+		},
+		{
+			name: "sends outside of interval ignored, recorded",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, userAgg, 1, domain.NotificationTypeSms), 2, time.Now().Add(-2*time.Hour)),
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, userAgg, 0, domain.NotificationTypeSms), 1, time.Now().Add(-3*time.Hour)),
+					),
+					expectPush(
+						otpsend.NewRecordedEvent(ctx, userAgg, 2, domain.NotificationTypeSms),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					User: sd.SendLimit{Amount: 1, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{},
+		},
+		{
+			name: "user limit reached, limit reached pushed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, userAgg, 0, domain.NotificationTypeSms), 1, time.Now()),
+					),
+					expectPush(
+						otpsend.NewLimitReachedEvent(ctx, userAgg, 1, 2*time.Hour),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					User: sd.SendLimit{Amount: 1, Interval: time.Hour, CoolDown: 2 * time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{
+				err: func(err error) bool {
+					retryAfter, ok := zerrors.RetryAfter(err)
+					return errors.Is(err, zerrors.ThrowResourceExhausted(nil, "COMMAND-b7Rk2mWq9s", "Errors.User.Code.SendLimitReached")) &&
+						ok && retryAfter == 2*time.Hour
+				},
+			},
+		},
+		{
+			name: "limit still exceeded after cool-down, not pushed again",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						otpSendEvent(otpsend.NewLimitReachedEvent(ctx, userAgg, 1, time.Minute), 2, time.Now().Add(-10*time.Minute)),
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, userAgg, 0, domain.NotificationTypeSms), 1, time.Now().Add(-30*time.Minute)),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					User: sd.SendLimit{Amount: 1, Interval: time.Hour, CoolDown: time.Minute},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{
+				err: func(err error) bool {
+					retryAfter, ok := zerrors.RetryAfter(err)
+					return errors.Is(err, zerrors.ThrowResourceExhausted(nil, "COMMAND-b7Rk2mWq9s", "Errors.User.Code.SendLimitReached")) &&
+						ok && retryAfter > 29*time.Minute && retryAfter <= 30*time.Minute
+				},
+			},
+		},
+		{
+			name: "recipient in cool-down, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						otpSendEvent(otpsend.NewLimitReachedEvent(ctx, recipientAgg, 5, time.Hour), 6, time.Now()),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					Recipient: sd.SendLimit{Amount: 5, Interval: time.Hour, CoolDown: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeEmail,
+				recipient:        "User@Example.com",
+			},
+			res: res{
+				err: func(err error) bool {
+					retryAfter, ok := zerrors.RetryAfter(err)
+					return errors.Is(err, zerrors.ThrowResourceExhausted(nil, "COMMAND-Tq3vxk8Lme", "Errors.User.Code.SendLimitReached")) &&
+						ok && retryAfter > 0 && retryAfter <= time.Hour
+				},
+			},
+		},
+		{
+			name: "all limits, recorded for every scope",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, instanceAgg, 41, domain.NotificationTypeSms), 42, time.Now()),
+					),
+					expectPush(
+						otpsend.NewRecordedEvent(ctx, userAgg, 0, domain.NotificationTypeEmail),
+						otpsend.NewRecordedEvent(ctx, recipientAgg, 0, domain.NotificationTypeEmail),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 42, domain.NotificationTypeEmail),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					User:      sd.SendLimit{Amount: 5, Interval: time.Hour},
+					Recipient: sd.SendLimit{Amount: 5, Interval: time.Hour},
+					Instance:  sd.SendLimit{Amount: 100, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeEmail,
+				recipient:        "user@example.com",
+			},
+			res: res{},
+		},
+		{
+			name: "concurrent send, re-evaluated and recorded",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+					expectFilter(
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms), 1, time.Now()),
+					),
+					expectPush(
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 1, domain.NotificationTypeSms),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					Instance: sd.SendLimit{Amount: 100, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{},
+		},
+		{
+			name: "concurrent send, re-evaluated and limit reached",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+					expectFilter(
+						otpSendEvent(otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms), 1, time.Now()),
+					),
+					expectPush(
+						otpsend.NewLimitReachedEvent(ctx, instanceAgg, 1, 2*time.Hour),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					Instance: sd.SendLimit{Amount: 1, Interval: time.Hour, CoolDown: 2 * time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowResourceExhausted(nil, "COMMAND-b7Rk2mWq9s", "Errors.User.Code.SendLimitReached"))
+				},
+			},
+		},
+		{
+			name: "concurrent sends on every attempt, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+					expectFilter(),
+					expectPushFailed(eventstore.ThrowConcurrentChange(),
+						otpsend.NewRecordedEvent(ctx, instanceAgg, 0, domain.NotificationTypeSms),
+					),
+				),
+				otpSendLimits: sd.OTPSendLimits{
+					Instance: sd.SendLimit{Amount: 100, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+				recipient:        "+41791234567",
+			},
+			res: res{
+				err: eventstore.IsConcurrentChange,
+			},
+		},
+		{
+			name: "recipient limit without recipient, nothing recorded",
+			fields: fields{
+				eventstore: expectEventstore(),
+				otpSendLimits: sd.OTPSendLimits{
+					Recipient: sd.SendLimit{Amount: 5, Interval: time.Hour},
+				},
+			},
+			args: args{
+				notificationType: domain.NotificationTypeSms,
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:    tt.fields.eventstore(t),
+				otpSendLimits: tt.fields.otpSendLimits,
+			}
+			err := c.checkOTPSendLimit(ctx, "user1", tt.args.notificationType, tt.args.recipient)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ type NotificationPolicyWriteModel struct {
 	eventstore.WriteModel
 
 	PasswordChange bool
+	SecurityAlerts bool
 	State          domain.PolicyState
 }
 
@@ -18,11 +19,15 @@ func (wm *NotificationPolicyWriteModel) Reduce() error {
 		switch e := event.(type) {
 		case *policy.NotificationPolicyAddedEvent:
 			wm.PasswordChange = e.PasswordChange
+			wm.SecurityAlerts = e.SecurityAlerts
 			wm.State = domain.PolicyStateActive
 		case *policy.NotificationPolicyChangedEvent:
 			if e.PasswordChange != nil {
 				wm.PasswordChange = *e.PasswordChange
 			}
+			if e.SecurityAlerts != nil {
+				wm.SecurityAlerts = *e.SecurityAlerts
+			}
 		case *policy.NotificationPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
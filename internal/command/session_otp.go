@@ -38,6 +38,8 @@ func (c *Commands) createOTPSMSChallenge(returnCode bool, dst *string) SessionCo
 		}
 		if returnCode {
 			*dst = code.Plain
+		} else if err = c.checkUserOTPSendLimit(ctx, cmd.sessionWriteModel.UserID, "", domain.NotificationTypeSms); err != nil {
+			return err
 		}
 		cmd.OTPSMSChallenged(ctx, code.Crypted, code.Expiry, returnCode)
 		return nil
@@ -91,6 +93,8 @@ func (c *Commands) createOTPEmailChallenge(returnCode bool, urlTmpl string, dst
 		}
 		if returnCode {
 			*dst = code.Plain
+		} else if err = c.checkUserOTPSendLimit(ctx, cmd.sessionWriteModel.UserID, "", domain.NotificationTypeEmail); err != nil {
+			return err
 		}
 		cmd.OTPEmailChallenged(ctx, code.Crypted, code.Expiry, returnCode, urlTmpl)
 		return nil
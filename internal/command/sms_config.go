@@ -2,9 +2,12 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -110,6 +113,103 @@ func (c *Commands) ChangeSMSConfigTwilioToken(ctx context.Context, instanceID, i
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
 
+func (c *Commands) AddSMSConfigHTTP(ctx context.Context, instanceID string, config *httpsms.Config) (string, *domain.ObjectDetails, error) {
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	headers, err := c.encryptSMSHTTPHeaders(config.Headers)
+	if err != nil {
+		return "", nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Endpoint,
+		config.Method,
+		headers,
+		config.BodyTemplate,
+		config.ContentType,
+		config.SenderID,
+		config.SuccessStatusCodes,
+		config.SuccessJSONPath,
+		config.SuccessJSONValue,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ChangeSMSConfigHTTP changes the http config, the headers are kept if none are set.
+func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, instanceID, id string, config *httpsms.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Vb3uq", "Errors.IDMissing")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-k1Tzb", "Errors.SMSConfig.NotFound")
+	}
+	headers, err := c.encryptSMSHTTPHeaders(config.Headers)
+	if err != nil {
+		return nil, err
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config,
+		headers)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gq8xc", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) encryptSMSHTTPHeaders(headers http.Header) (*crypto.CryptoValue, error) {
+	if headers == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(headers)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Ry5fn", "Errors.Internal")
+	}
+	return crypto.Encrypt(value, c.smsEncryption)
+}
+
 func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
 	if id == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-dn93n", "Errors.IDMissing")
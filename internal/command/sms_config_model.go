@@ -2,10 +2,12 @@ package command
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
@@ -14,6 +16,7 @@ type IAMSMSConfigWriteModel struct {
 
 	ID     string
 	Twilio *TwilioConfig
+	HTTP   *HTTPConfig
 	State  domain.SMSConfigState
 }
 
@@ -23,6 +26,18 @@ type TwilioConfig struct {
 	SenderNumber string
 }
 
+type HTTPConfig struct {
+	Endpoint           string
+	Method             string
+	Headers            *crypto.CryptoValue
+	BodyTemplate       string
+	ContentType        string
+	SenderID           string
+	SuccessStatusCodes []int
+	SuccessJSONPath    string
+	SuccessJSONValue   string
+}
+
 func NewIAMSMSConfigWriteModel(instanceID, id string) *IAMSMSConfigWriteModel {
 	return &IAMSMSConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
@@ -62,6 +77,27 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio.Token = e.Token
+		case *instance.SMSConfigHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP = &HTTPConfig{
+				Endpoint:           e.Endpoint,
+				Method:             e.Method,
+				Headers:            e.Headers,
+				BodyTemplate:       e.BodyTemplate,
+				ContentType:        e.ContentType,
+				SenderID:           e.SenderID,
+				SuccessStatusCodes: e.SuccessStatusCodes,
+				SuccessJSONPath:    e.SuccessJSONPath,
+				SuccessJSONValue:   e.SuccessJSONValue,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceHTTPChanged(e)
 		case *instance.SMSConfigActivatedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -77,11 +113,43 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio = nil
+			wm.HTTP = nil
 			wm.State = domain.SMSConfigStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
+
+func (wm *IAMSMSConfigWriteModel) reduceHTTPChanged(e *instance.SMSConfigHTTPChangedEvent) {
+	if e.Endpoint != nil {
+		wm.HTTP.Endpoint = *e.Endpoint
+	}
+	if e.Method != nil {
+		wm.HTTP.Method = *e.Method
+	}
+	if e.Headers != nil {
+		wm.HTTP.Headers = e.Headers
+	}
+	if e.BodyTemplate != nil {
+		wm.HTTP.BodyTemplate = *e.BodyTemplate
+	}
+	if e.ContentType != nil {
+		wm.HTTP.ContentType = *e.ContentType
+	}
+	if e.SenderID != nil {
+		wm.HTTP.SenderID = *e.SenderID
+	}
+	if e.SuccessStatusCodes != nil {
+		wm.HTTP.SuccessStatusCodes = *e.SuccessStatusCodes
+	}
+	if e.SuccessJSONPath != nil {
+		wm.HTTP.SuccessJSONPath = *e.SuccessJSONPath
+	}
+	if e.SuccessJSONValue != nil {
+		wm.HTTP.SuccessJSONValue = *e.SuccessJSONValue
+	}
+}
+
 func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
@@ -92,6 +160,8 @@ func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMSConfigTwilioAddedEventType,
 			instance.SMSConfigTwilioChangedEventType,
 			instance.SMSConfigTwilioTokenChangedEventType,
+			instance.SMSConfigHTTPAddedEventType,
+			instance.SMSConfigHTTPChangedEventType,
 			instance.SMSConfigActivatedEventType,
 			instance.SMSConfigDeactivatedEventType,
 			instance.SMSConfigRemovedEventType).
@@ -118,3 +188,45 @@ func (wm *IAMSMSConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate
 	}
 	return changeEvent, true, nil
 }
+
+// NewHTTPChangedEvent returns the changes of the http config,
+// the headers are changed if they are set, as they can't be compared encrypted.
+func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, config *httpsms.Config, headers *crypto.CryptoValue) (*instance.SMSConfigHTTPChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigHTTPChanges, 0)
+	if wm.HTTP.Endpoint != config.Endpoint {
+		changes = append(changes, instance.ChangeSMSConfigHTTPEndpoint(config.Endpoint))
+	}
+	if wm.HTTP.Method != config.Method {
+		changes = append(changes, instance.ChangeSMSConfigHTTPMethod(config.Method))
+	}
+	if headers != nil {
+		changes = append(changes, instance.ChangeSMSConfigHTTPHeaders(headers))
+	}
+	if wm.HTTP.BodyTemplate != config.BodyTemplate {
+		changes = append(changes, instance.ChangeSMSConfigHTTPBodyTemplate(config.BodyTemplate))
+	}
+	if wm.HTTP.ContentType != config.ContentType {
+		changes = append(changes, instance.ChangeSMSConfigHTTPContentType(config.ContentType))
+	}
+	if wm.HTTP.SenderID != config.SenderID {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSenderID(config.SenderID))
+	}
+	if !slices.Equal(wm.HTTP.SuccessStatusCodes, config.SuccessStatusCodes) {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSuccessStatusCodes(config.SuccessStatusCodes))
+	}
+	if wm.HTTP.SuccessJSONPath != config.SuccessJSONPath {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSuccessJSONPath(config.SuccessJSONPath))
+	}
+	if wm.HTTP.SuccessJSONValue != config.SuccessJSONValue {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSuccessJSONValue(config.SuccessJSONValue))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
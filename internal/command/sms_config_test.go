@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -259,6 +261,385 @@ func TestCommandSide_ChangeSMSConfigTwilio(t *testing.T) {
 	}
 }
 
+func TestCommandSide_AddSMSConfigHTTP(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		sms        *httpsms.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid endpoint, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					Endpoint: "invalid",
+					Method:   http.MethodPost,
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid template, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					Endpoint:     "https://example.com/sms",
+					Method:       http.MethodPost,
+					BodyTemplate: "{{.Phone",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add sms config http, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						instance.NewSMSConfigHTTPAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							"https://example.com/sms",
+							http.MethodPost,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(`{"Authorization":["Bearer token"]}`),
+							},
+							`{"to": {{json .Phone}}, "text": {{json .Text}}}`,
+							"application/json",
+							"sender",
+							[]int{http.StatusCreated},
+							"status",
+							"queued",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &httpsms.Config{
+					Endpoint:           "https://example.com/sms",
+					Method:             http.MethodPost,
+					Headers:            http.Header{"Authorization": {"Bearer token"}},
+					BodyTemplate:       `{"to": {{json .Phone}}, "text": {{json .Text}}}`,
+					ContentType:        "application/json",
+					SenderID:           "sender",
+					SuccessStatusCodes: []int{http.StatusCreated},
+					SuccessJSONPath:    "status",
+					SuccessJSONValue:   "queued",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				idGenerator:   tt.fields.idGenerator,
+				smsEncryption: tt.fields.alg,
+			}
+			_, got, err := r.AddSMSConfigHTTP(tt.args.ctx, tt.args.instanceID, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigHTTP(t *testing.T) {
+	smsConfigHTTPAddedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			instance.NewSMSConfigHTTPAddedEvent(
+				context.Background(),
+				&instance.NewAggregate("INSTANCE").Aggregate,
+				"providerid",
+				"https://example.com/sms",
+				http.MethodPost,
+				&crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte(`{"Authorization":["Bearer token"]}`),
+				},
+				`{{.Text}}`,
+				"",
+				"",
+				nil,
+				"",
+				"",
+			),
+		)
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		id         string
+		sms        *httpsms.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id empty, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sms not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					Endpoint: "https://example.com/sms",
+					Method:   http.MethodPost,
+				},
+				instanceID: "INSTANCE",
+				id:         "id",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "sms config twilio, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"sid",
+								"senderName",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("token"),
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					Endpoint: "https://example.com/sms",
+					Method:   http.MethodPost,
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						smsConfigHTTPAddedEvent(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					Endpoint:     "https://example.com/sms",
+					Method:       http.MethodPost,
+					BodyTemplate: `{{.Text}}`,
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "sms config http change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						smsConfigHTTPAddedEvent(),
+					),
+					expectPush(
+						newSMSConfigHTTPChangedEvent(
+							context.Background(),
+							"providerid",
+							instance.ChangeSMSConfigHTTPMethod(http.MethodPut),
+							instance.ChangeSMSConfigHTTPHeaders(&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(`{"Authorization":["Bearer token2"]}`),
+							}),
+							instance.ChangeSMSConfigHTTPSuccessStatusCodes([]int{http.StatusAccepted}),
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					Endpoint:           "https://example.com/sms",
+					Method:             http.MethodPut,
+					Headers:            http.Header{"Authorization": {"Bearer token2"}},
+					BodyTemplate:       `{{.Text}}`,
+					SuccessStatusCodes: []int{http.StatusAccepted},
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "sms config http headers cleared, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						smsConfigHTTPAddedEvent(),
+					),
+					expectPush(
+						newSMSConfigHTTPChangedEvent(
+							context.Background(),
+							"providerid",
+							instance.ChangeSMSConfigHTTPHeaders(&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(`{}`),
+							}),
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &httpsms.Config{
+					Endpoint:     "https://example.com/sms",
+					Method:       http.MethodPost,
+					Headers:      http.Header{},
+					BodyTemplate: `{{.Text}}`,
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				smsEncryption: tt.fields.alg,
+			}
+			got, err := r.ChangeSMSConfigHTTP(tt.args.ctx, tt.args.instanceID, tt.args.id, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_ActivateSMSConfigTwilio(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -599,3 +980,12 @@ func newSMSConfigTwilioChangedEvent(ctx context.Context, id, sid, senderName str
 	)
 	return event
 }
+
+func newSMSConfigHTTPChangedEvent(ctx context.Context, id string, changes ...instance.SMSConfigHTTPChanges) *instance.SMSConfigHTTPChangedEvent {
+	event, _ := instance.NewSMSConfigHTTPChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		id,
+		changes,
+	)
+	return event
+}
@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 	"net"
+	"net/http"
 	"strings"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -10,6 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -110,7 +113,7 @@ func (c *Commands) ChangeSMTPConfig(ctx context.Context, instanceID string, id s
 		return nil, err
 	}
 
-	if !smtpConfigWriteModel.State.Exists() {
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.HTTP != nil {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-7j8gv", "Errors.SMTPConfig.NotFound")
 	}
 
@@ -118,6 +121,11 @@ func (c *Commands) ChangeSMTPConfig(ctx context.Context, instanceID string, id s
 	if err != nil {
 		return nil, err
 	}
+	if smtpConfigWriteModel.DKIM != nil {
+		if err = checkDKIMDomain(smtpConfigWriteModel.DKIM.Domain, from); err != nil {
+			return nil, err
+		}
+	}
 
 	iamAgg := InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
 
@@ -158,7 +166,7 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, instanceID, id
 	if err != nil {
 		return nil, err
 	}
-	if smtpConfigWriteModel.State != domain.SMTPConfigStateActive {
+	if smtpConfigWriteModel.State != domain.SMTPConfigStateActive || smtpConfigWriteModel.HTTP != nil {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-3n9ls", "Errors.SMTPConfig.NotFound")
 	}
 
@@ -186,13 +194,175 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, instanceID, id
 	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
 }
 
-func (c *Commands) ActivateSMTPConfig(ctx context.Context, instanceID, id, activatedId string) (*domain.ObjectDetails, error) {
+// AddSMTPConfigHTTP adds a config which sends the emails through an HTTP API instead of SMTP.
+func (c *Commands) AddSMTPConfigHTTP(ctx context.Context, instanceID, description string, config *httpemail.Config) (string, *domain.ObjectDetails, error) {
+	senderDomain, err := trimHTTPEmailConfig(config)
+	if err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, senderDomain)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = checkSenderAddress(smtpConfigWriteModel); err != nil {
+		return "", nil, err
+	}
+	headers, err := c.encryptSMTPHTTPHeaders(config.Headers)
+	if err != nil {
+		return "", nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMTPConfigHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		strings.TrimSpace(description),
+		config.SenderAddress,
+		config.SenderName,
+		config.ReplyToAddress,
+		config.Endpoint,
+		headers,
+		config.BodyTemplate,
+		config.ContentType,
+		config.SuccessStatusCodes,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// ChangeSMTPConfigHTTP changes the http config, the headers are kept if none are set.
+func (c *Commands) ChangeSMTPConfigHTTP(ctx context.Context, instanceID, id, description string, config *httpemail.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Qw7fk", "Errors.IDMissing")
+	}
+	senderDomain, err := trimHTTPEmailConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, senderDomain)
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.HTTP == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Bx8zt", "Errors.SMTPConfig.NotFound")
+	}
+	if err = checkSenderAddress(smtpConfigWriteModel); err != nil {
+		return nil, err
+	}
+	headers, err := c.encryptSMTPHTTPHeaders(config.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	changedEvent, hasChanged, err := smtpConfigWriteModel.NewHTTPChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		strings.TrimSpace(description),
+		config,
+		headers,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hr2lq", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// trimHTTPEmailConfig trims the addresses of the config, validates it and returns the domain of the sender address
+func trimHTTPEmailConfig(config *httpemail.Config) (string, error) {
+	config.SenderAddress = strings.TrimSpace(config.SenderAddress)
+	if config.SenderAddress == "" {
+		return "", zerrors.ThrowInvalidArgument(nil, "INST-Lm4tq", "Errors.Invalid.Argument")
+	}
+	config.ReplyToAddress = strings.TrimSpace(config.ReplyToAddress)
+	config.Endpoint = strings.TrimSpace(config.Endpoint)
+	if err := config.Validate(); err != nil {
+		return "", err
+	}
+	senderSplitted := strings.Split(config.SenderAddress, "@")
+	return senderSplitted[len(senderSplitted)-1], nil
+}
+
+func (c *Commands) encryptSMTPHTTPHeaders(headers http.Header) (*crypto.CryptoValue, error) {
+	if headers == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(headers)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Cw9px", "Errors.Internal")
+	}
+	return crypto.Encrypt(value, c.smtpEncryption)
+}
+
+// SetSMTPConfigPriority sets the priority of the config,
+// active configs are tried in ascending order of their priority until the email is sent.
+func (c *Commands) SetSMTPConfigPriority(ctx context.Context, instanceID, id string, priority uint32) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Jb5ek", "Errors.IDMissing")
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Tq6nr", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.Priority == priority {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Vy3ha", "Errors.NoChangesFound")
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMTPConfigPriorityChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		priority))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// ActivateSMTPConfig activates the config, the configs of the activatedIDs are deactivated.
+// Configs which are kept active are used as failover according to their priority.
+func (c *Commands) ActivateSMTPConfig(ctx context.Context, instanceID, id string, activatedIDs ...string) (*domain.ObjectDetails, error) {
 	if id == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-nm56k", "Errors.IDMissing")
 	}
 
-	if len(activatedId) > 0 {
-		_, err := c.DeactivateSMTPConfig(ctx, instanceID, activatedId)
+	for _, activatedID := range activatedIDs {
+		if activatedID == "" || activatedID == id {
+			continue
+		}
+		_, err := c.DeactivateSMTPConfig(ctx, instanceID, activatedID)
 		if err != nil {
 			return nil, err
 		}
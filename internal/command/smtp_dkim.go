@@ -0,0 +1,260 @@
+package command
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// dkimLabels matches the dot separated labels of DKIM selectors and domains
+var dkimLabels = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?)*$`)
+
+// SetSMTPConfigDKIM sets the key the emails sent by the SMTP config of the instance are signed with.
+// The returned DNS record has to be published, so receivers can verify the signatures.
+func (c *Commands) SetSMTPConfigDKIM(ctx context.Context, instanceID, id string, dkim *smtp.DKIM) (*smtp.DKIMRecord, *domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Lr4nx", "Errors.IDMissing")
+	}
+	privateKey, record, err := c.prepareSMTPConfigDKIM(dkim)
+	if err != nil {
+		return nil, nil, err
+	}
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, nil, zerrors.ThrowNotFound(nil, "COMMAND-Jm5be", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.HTTP != nil {
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wc8ro", "Errors.SMTPConfig.DKIM.HTTPNotSupported")
+	}
+	if err = checkDKIMDomain(dkim.Domain, smtpConfigWriteModel.SenderAddress); err != nil {
+		return nil, nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMTPConfigDKIMSetEvent(
+		ctx,
+		iamAgg,
+		id,
+		dkim.Selector,
+		dkim.Domain,
+		privateKey,
+	))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// RemoveSMTPConfigDKIM removes the DKIM key of the SMTP config of the instance, the emails are no longer signed.
+func (c *Commands) RemoveSMTPConfigDKIM(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Fy2kd", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ot6vm", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.DKIM == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Bk1zs", "Errors.SMTPConfig.DKIM.NotFound")
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMTPConfigDKIMRemovedEvent(ctx, iamAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// VerifySMTPConfigDKIM checks that the DNS record of the DKIM key of the SMTP config of the instance is published.
+func (c *Commands) VerifySMTPConfigDKIM(ctx context.Context, instanceID, id string) (*smtp.DKIMRecord, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Zu3pf", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Qe9hc", "Errors.SMTPConfig.NotFound")
+	}
+	return c.verifySMTPConfigDKIM(ctx, smtpConfigWriteModel.DKIM)
+}
+
+// SetOrgSMTPConfigDKIM sets the key the emails sent by the SMTP config of the organization are signed with.
+// The returned DNS record has to be published, so receivers can verify the signatures.
+func (c *Commands) SetOrgSMTPConfigDKIM(ctx context.Context, orgID, id string, dkim *smtp.DKIM) (*smtp.DKIMRecord, *domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ha7sv", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ug4mt", "Errors.IDMissing")
+	}
+	privateKey, record, err := c.prepareSMTPConfigDKIM(dkim)
+	if err != nil {
+		return nil, nil, err
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, dkim.Domain)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, nil, zerrors.ThrowNotFound(nil, "COMMAND-Ri2xe", "Errors.SMTPConfig.NotFound")
+	}
+	if err = checkDKIMDomain(dkim.Domain, smtpConfigWriteModel.SenderAddress); err != nil {
+		return nil, nil, err
+	}
+	// organizations only sign emails for their own verified domains
+	if !smtpConfigWriteModel.domainVerified {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "ORG-Nv6qe", "Errors.SMTPConfig.DKIM.DomainNotVerifiedOrgDomain")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigDKIMSetEvent(
+		ctx,
+		orgAgg,
+		id,
+		dkim.Selector,
+		dkim.Domain,
+		privateKey,
+	))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// RemoveOrgSMTPConfigDKIM removes the DKIM key of the SMTP config of the organization, the emails are no longer signed.
+func (c *Commands) RemoveOrgSMTPConfigDKIM(ctx context.Context, orgID, id string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ep6wa", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Dn8gq", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Vh3lo", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.DKIM == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Kx5ru", "Errors.SMTPConfig.DKIM.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewSMTPConfigDKIMRemovedEvent(ctx, orgAgg, id))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smtpConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// VerifyOrgSMTPConfigDKIM checks that the DNS record of the DKIM key of the SMTP config of the organization is published.
+func (c *Commands) VerifyOrgSMTPConfigDKIM(ctx context.Context, orgID, id string) (*smtp.DKIMRecord, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Gt9yc", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Mb2wi", "Errors.IDMissing")
+	}
+	smtpConfigWriteModel, err := c.getOrgSMTPConfig(ctx, orgID, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Px7cj", "Errors.SMTPConfig.NotFound")
+	}
+	return c.verifySMTPConfigDKIM(ctx, smtpConfigWriteModel.DKIM)
+}
+
+// prepareSMTPConfigDKIM validates the config and returns the encrypted private key
+// and the DNS record of its public key
+func (c *Commands) prepareSMTPConfigDKIM(dkim *smtp.DKIM) (*crypto.CryptoValue, *smtp.DKIMRecord, error) {
+	if dkim == nil {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Sa1qk", "Errors.Invalid.Argument")
+	}
+	dkim.Selector = strings.TrimSpace(dkim.Selector)
+	dkim.Domain = strings.ToLower(strings.TrimSpace(dkim.Domain))
+	if !dkimLabels.MatchString(dkim.Selector) {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Yd4ol", "Errors.SMTPConfig.DKIM.InvalidSelector")
+	}
+	if !dkimLabels.MatchString(dkim.Domain) {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Ci6tn", "Errors.SMTPConfig.DKIM.InvalidDomain")
+	}
+	key, err := smtp.ParseDKIMPrivateKey(dkim.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	record, err := smtp.NewDKIMRecord(dkim.Selector, dkim.Domain, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKey, err := crypto.Encrypt(dkim.PrivateKey, c.smtpEncryption)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, record, nil
+}
+
+// checkDKIMDomain ensures that the emails are only signed for the domain of the sender address or one of its parent domains,
+// otherwise receivers can't align the signature with the sender
+func checkDKIMDomain(dkimDomain, senderAddress string) error {
+	senderDomain := strings.ToLower(senderAddress[strings.LastIndex(senderAddress, "@")+1:])
+	if senderDomain != dkimDomain && !strings.HasSuffix(senderDomain, "."+dkimDomain) {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-Wq3hz", "Errors.SMTPConfig.DKIM.DomainMismatch")
+	}
+	return nil
+}
+
+func (c *Commands) verifySMTPConfigDKIM(ctx context.Context, dkim *SMTPDKIMConfig) (*smtp.DKIMRecord, error) {
+	if dkim == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ah8zv", "Errors.SMTPConfig.DKIM.NotFound")
+	}
+	privateKey, err := crypto.Decrypt(dkim.PrivateKey, c.smtpEncryption)
+	if err != nil {
+		return nil, err
+	}
+	key, err := smtp.ParseDKIMPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	record, err := smtp.NewDKIMRecord(dkim.Selector, dkim.Domain, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = smtp.LookupDKIMRecord(ctx, c.dkimRecordLookup, dkim.Selector, dkim.Domain, key); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
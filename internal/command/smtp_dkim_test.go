@@ -0,0 +1,495 @@
+package command
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func newTestDKIMKey(t *testing.T) ([]byte, *smtp.DKIMRecord) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	record, err := smtp.NewDKIMRecord("zitadel", "domain.ch", key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), record
+}
+
+func TestCommandSide_SetSMTPConfigDKIM(t *testing.T) {
+	privateKey, record := newTestDKIMKey(t)
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		id   string
+		dkim *smtp.DKIM
+	}
+	type res struct {
+		record *smtp.DKIMRecord
+		want   *domain.ObjectDetails
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid selector, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: "zitadel;", Domain: "domain.ch", PrivateKey: privateKey},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid domain, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: "zitadel", Domain: "-domain.ch", PrivateKey: privateKey},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid private key, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: "zitadel", Domain: "domain.ch", PrivateKey: []byte("key")},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "config not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: "zitadel", Domain: "domain.ch", PrivateKey: privateKey},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "http config, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(), &instance.NewAggregate("INSTANCE").Aggregate,
+								"configid", "test", "from@domain.ch", "name", "", "https://api.example.com/mail", nil, `{{.Text}}`, "", nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: "zitadel", Domain: "domain.ch", PrivateKey: privateKey},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "other domain than sender, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(), &instance.NewAggregate("INSTANCE").Aggregate,
+								"configid", "test", true, "from@domain.ch", "name", "", "host:587", "user", nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: "zitadel", Domain: "other.ch", PrivateKey: privateKey},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set dkim, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(), &instance.NewAggregate("INSTANCE").Aggregate,
+								"configid", "test", true, "from@domain.ch", "name", "", "host:587", "user", nil,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigDKIMSetEvent(context.Background(), &instance.NewAggregate("INSTANCE").Aggregate,
+							"configid", "zitadel", "domain.ch",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    privateKey,
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				id:   "configid",
+				dkim: &smtp.DKIM{Selector: " zitadel ", Domain: "Domain.ch", PrivateKey: privateKey},
+			},
+			res: res{
+				record: record,
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				smtpEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			gotRecord, got, err := r.SetSMTPConfigDKIM(authz.WithInstanceID(context.Background(), "INSTANCE"), "INSTANCE", tt.args.id, tt.args.dkim)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.record, gotRecord)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgSMTPConfigDKIM(t *testing.T) {
+	privateKey, record := newTestDKIMKey(t)
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		record *smtp.DKIMRecord
+		want   *domain.ObjectDetails
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "domain not verified, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSMTPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "test", true, "from@mail.domain.ch", "name", "", "host:587", "user", nil),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "parent domain of sender, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainVerifiedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "Domain.ch"),
+						),
+						eventFromEventPusher(
+							org.NewSMTPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "test", true, "from@mail.domain.ch", "name", "", "host:587", "user", nil),
+						),
+					),
+					expectPush(
+						org.NewSMTPConfigDKIMSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"configid", "zitadel", "domain.ch",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    privateKey,
+							},
+						),
+					),
+				),
+			},
+			res: res{
+				record: record,
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				smtpEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			gotRecord, got, err := r.SetOrgSMTPConfigDKIM(context.Background(), "org1", "configid", &smtp.DKIM{Selector: "zitadel", Domain: "domain.ch", PrivateKey: privateKey})
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.record, gotRecord)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgSMTPConfigDKIM(t *testing.T) {
+	privateKey, _ := newTestDKIMKey(t)
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "config not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "dkim not set, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSMTPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "test", true, "from@domain.ch", "name", "", "host:587", "user", nil),
+						),
+						eventFromEventPusher(
+							org.NewSMTPConfigDKIMSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "zitadel", "domain.ch", &crypto.CryptoValue{Crypted: privateKey}),
+						),
+						eventFromEventPusher(
+							org.NewSMTPConfigDKIMRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove dkim, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSMTPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "test", true, "from@domain.ch", "name", "", "host:587", "user", nil),
+						),
+						eventFromEventPusher(
+							org.NewSMTPConfigDKIMSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "zitadel", "domain.ch", &crypto.CryptoValue{Crypted: privateKey}),
+						),
+					),
+					expectPush(
+						org.NewSMTPConfigDKIMRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid"),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RemoveOrgSMTPConfigDKIM(context.Background(), "org1", "configid")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_VerifyOrgSMTPConfigDKIM(t *testing.T) {
+	privateKey, record := newTestDKIMKey(t)
+	_, otherRecord := newTestDKIMKey(t)
+	dkimSet := func() eventstore.Event {
+		return eventFromEventPusher(
+			org.NewSMTPConfigDKIMSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "zitadel", "domain.ch",
+				&crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    privateKey,
+				},
+			),
+		)
+	}
+	smtpConfigAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			org.NewSMTPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "configid", "test", true, "from@domain.ch", "name", "", "host:587", "user", nil),
+		)
+	}
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		records    map[string][]string
+	}
+	type res struct {
+		record *smtp.DKIMRecord
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "dkim not set, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded(),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "record not published, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded(),
+						dkimSet(),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "other key published, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded(),
+						dkimSet(),
+					),
+				),
+				records: map[string][]string{
+					record.Name: {otherRecord.Value},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "record published, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded(),
+						dkimSet(),
+					),
+				),
+				records: map[string][]string{
+					record.Name: {"v=spf1 -all", record.Value},
+				},
+			},
+			res: res{
+				record: record,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				smtpEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				dkimRecordLookup: func(_ context.Context, name string) ([]string, error) {
+					return tt.fields.records[name], nil
+				},
+			}
+			got, err := r.VerifyOrgSMTPConfigDKIM(context.Background(), "org1", "configid")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.record, got)
+		})
+	}
+}
@@ -50,6 +50,9 @@ func (c *Commands) ChangeHumanEmail(ctx context.Context, email *domain.Email, em
 		if err != nil {
 			return nil, err
 		}
+		if err = c.checkOTPSendLimit(ctx, userAgg.ID, domain.NotificationTypeEmail, string(email.EmailAddress)); err != nil {
+			return nil, err
+		}
 		events = append(events, user.NewHumanEmailCodeAddedEvent(ctx, userAgg, emailCode.Code, emailCode.Expiry, ""))
 	}
 
@@ -125,6 +128,9 @@ func (c *Commands) CreateHumanEmailVerificationCode(ctx context.Context, userID,
 	if authRequestID == "" {
 		authRequestID = existingEmail.AuthRequestID
 	}
+	if err = c.checkOTPSendLimit(ctx, userAgg.ID, domain.NotificationTypeEmail, string(existingEmail.Email)); err != nil {
+		return nil, err
+	}
 	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanEmailCodeAddedEvent(ctx, userAgg, emailCode.Code, emailCode.Expiry, authRequestID))
 	if err != nil {
 		return nil, err
@@ -41,6 +41,12 @@ func (c *Commands) ResendInitialMail(ctx context.Context, userID string, email d
 	if authRequestID == "" {
 		authRequestID = existingCode.AuthRequestID
 	}
+	if email == "" {
+		email = existingCode.Email
+	}
+	if err = c.checkOTPSendLimit(ctx, userAgg.ID, domain.NotificationTypeEmail, string(email)); err != nil {
+		return nil, err
+	}
 	events = append(events, user.NewHumanInitialCodeAddedEvent(ctx, userAgg, initCode.Code, initCode.Expiry, authRequestID))
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
@@ -318,6 +318,7 @@ func (c *Commands) HumanSendOTPSMS(ctx context.Context, userID, resourceOwner st
 		smsWriteModel,
 		domain.SecretGeneratorTypeOTPSMS,
 		c.defaultSecretGenerators.OTPSMS,
+		domain.NotificationTypeSms,
 		codeAddedEvent,
 	)
 }
@@ -443,6 +444,7 @@ func (c *Commands) HumanSendOTPEmail(ctx context.Context, userID, resourceOwner
 		smsWriteModel,
 		domain.SecretGeneratorTypeOTPEmail,
 		c.defaultSecretGenerators.OTPEmail,
+		domain.NotificationTypeEmail,
 		codeAddedEvent,
 	)
 }
@@ -487,6 +489,7 @@ func (c *Commands) sendHumanOTP(
 	writeModelByID func(ctx context.Context, userID string, resourceOwner string) (OTPWriteModel, error),
 	secretGeneratorType domain.SecretGeneratorType,
 	defaultSecretGenerator *crypto.GeneratorConfig,
+	notificationType domain.NotificationType,
 	codeAddedEvent func(ctx context.Context, aggregate *eventstore.Aggregate, code *crypto.CryptoValue, expiry time.Duration, info *user.AuthRequestInfo) eventstore.Command,
 ) (err error) {
 	if userID == "" {
@@ -508,6 +511,9 @@ func (c *Commands) sendHumanOTP(
 	if err != nil {
 		return err
 	}
+	if err = c.checkUserOTPSendLimit(ctx, userID, resourceOwner, notificationType); err != nil {
+		return err
+	}
 	userAgg := &user.NewAggregate(userID, resourceOwner).Aggregate
 	_, err = c.eventstore.Push(ctx, codeAddedEvent(ctx, userAgg, value, gen.Expiry(), authRequestDomainToAuthRequestInfo(authRequest)))
 	return err
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddPasswordExpiryWarning adds the warning that the password of the user expires soon, so the user is notified.
+// The warning is added if the current password expires within the warning period of the password age policy
+// and only once per password, otherwise nothing is done.
+func (c *Commands) AddPasswordExpiryWarning(ctx context.Context, orgID, userID string, policy *domain.PasswordAgePolicy) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xq4ne", "Errors.User.UserIDMissing")
+	}
+	if policy == nil || policy.MaxAgeDays == 0 || policy.ExpireWarnDays == 0 {
+		return nil
+	}
+	writeModel, err := c.passwordExpiryWriteModel(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(writeModel.UserState) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Vk8ds", "Errors.User.NotFound")
+	}
+	if writeModel.UserState != domain.UserStateActive ||
+		!writeModel.HasPassword ||
+		writeModel.SecretChangeRequired ||
+		writeModel.WarningAdded {
+		return nil
+	}
+	expirationDate := writeModel.ExpirationDate(policy)
+	if time.Now().Before(expirationDate.AddDate(0, 0, -int(policy.ExpireWarnDays))) {
+		return nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanPasswordExpiryWarningAddedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel), expirationDate))
+	return err
+}
+
+// PasswordExpiryWarningSent records that the user was notified about the expiry of the password
+func (c *Commands) PasswordExpiryWarningSent(ctx context.Context, orgID, userID string) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Hd2ro", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.passwordExpiryWriteModel(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(writeModel.UserState) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Ay7tm", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanPasswordExpiryWarningSentEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel)))
+	return err
+}
+
+func (c *Commands) passwordExpiryWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordExpiryWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanPasswordExpiryWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
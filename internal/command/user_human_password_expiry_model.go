@@ -0,0 +1,114 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanPasswordExpiryWriteModel reduces the expiry cycle of the current password of the user,
+// which starts with setting the password and ends with the next password change.
+type HumanPasswordExpiryWriteModel struct {
+	eventstore.WriteModel
+
+	HasPassword          bool
+	SecretChangeRequired bool
+	PasswordChangeDate   time.Time
+	WarningAdded         bool
+
+	UserState domain.UserState
+}
+
+func NewHumanPasswordExpiryWriteModel(userID, resourceOwner string) *HumanPasswordExpiryWriteModel {
+	return &HumanPasswordExpiryWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanPasswordExpiryWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			wm.setPassword(crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.ChangeRequired, e.CreatedAt())
+			wm.UserState = domain.UserStateActive
+		case *user.HumanRegisteredEvent:
+			wm.setPassword(crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.ChangeRequired, e.CreatedAt())
+			wm.UserState = domain.UserStateActive
+		case *user.HumanInitialCodeAddedEvent:
+			wm.UserState = domain.UserStateInitial
+		case *user.HumanInitializedCheckSucceededEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanEmailVerifiedEvent:
+			if wm.UserState == domain.UserStateInitial {
+				wm.UserState = domain.UserStateActive
+			}
+		case *user.HumanPasswordChangedEvent:
+			wm.setPassword(crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.ChangeRequired, e.CreatedAt())
+		case *user.HumanPasswordExpiryWarningAddedEvent:
+			wm.WarningAdded = true
+		case *user.UserLockedEvent:
+			wm.UserState = domain.UserStateLocked
+		case *user.UserUnlockedEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserDeactivatedEvent:
+			wm.UserState = domain.UserStateInactive
+		case *user.UserReactivatedEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// setPassword starts a new expiry cycle
+func (wm *HumanPasswordExpiryWriteModel) setPassword(encodedHash string, changeRequired bool, changeDate time.Time) {
+	wm.HasPassword = encodedHash != ""
+	wm.SecretChangeRequired = changeRequired
+	wm.PasswordChangeDate = changeDate
+	wm.WarningAdded = false
+}
+
+func (wm *HumanPasswordExpiryWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanInitialCodeAddedType,
+			user.HumanInitializedCheckSucceededType,
+			user.HumanEmailVerifiedType,
+			user.HumanPasswordChangedType,
+			user.HumanPasswordExpiryWarningAddedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+			user.UserRemovedType,
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.UserV1InitialCodeAddedType,
+			user.UserV1InitializedCheckSucceededType,
+			user.UserV1EmailVerifiedType,
+			user.UserV1PasswordChangedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// ExpirationDate returns the date the password expires with the maximum age of the policy
+func (wm *HumanPasswordExpiryWriteModel) ExpirationDate(policy *domain.PasswordAgePolicy) time.Time {
+	return wm.PasswordChangeDate.AddDate(0, 0, int(policy.MaxAgeDays))
+}
@@ -44,6 +44,9 @@ func (c *Commands) ChangeHumanPhone(ctx context.Context, phone *domain.Phone, re
 		if err != nil {
 			return nil, err
 		}
+		if err = c.checkOTPSendLimit(ctx, userAgg.ID, domain.NotificationTypeSms, string(phone.PhoneNumber)); err != nil {
+			return nil, err
+		}
 		events = append(events, user.NewHumanPhoneCodeAddedEvent(ctx, userAgg, phoneCode.Code, phoneCode.Expiry))
 	}
 
@@ -125,6 +128,9 @@ func (c *Commands) CreateHumanPhoneVerificationCode(ctx context.Context, userID,
 	}
 
 	userAgg := UserAggregateFromWriteModel(&existingPhone.WriteModel)
+	if err = c.checkOTPSendLimit(ctx, userAgg.ID, domain.NotificationTypeSms, string(existingPhone.Phone)); err != nil {
+		return nil, err
+	}
 	if err = c.pushAppendAndReduce(ctx, existingPhone, user.NewHumanPhoneCodeAddedEvent(ctx, userAgg, phoneCode.Code, phoneCode.Expiry)); err != nil {
 		return nil, err
 	}
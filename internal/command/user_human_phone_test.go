@@ -9,11 +9,13 @@ import (
 	"go.uber.org/mock/gomock"
 	"golang.org/x/text/language"
 
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/otpsend"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -619,6 +621,7 @@ func TestCommandSide_CreateVerificationCodeHumanPhone(t *testing.T) {
 	type fields struct {
 		eventstore     *eventstore.Eventstore
 		userEncryption crypto.EncryptionAlgorithm
+		otpSendLimits  sd.OTPSendLimits
 	}
 	type args struct {
 		ctx           context.Context
@@ -776,12 +779,77 @@ func TestCommandSide_CreateVerificationCodeHumanPhone(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "send limit reached, resource exhausted error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPhoneChangedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"+411234567",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSecretGeneratorAddedEvent(context.Background(),
+								&instance.NewAggregate("instanceID").Aggregate,
+								domain.SecretGeneratorTypeVerifyPhoneCode,
+								8,
+								time.Hour,
+								true,
+								true,
+								true,
+								true,
+							)),
+					),
+					expectFilter(
+						otpSendEvent(
+							otpsend.NewLimitReachedEvent(context.Background(),
+								otpsend.NewAggregate(domain.OTPSendLimitScopeUser, "user1", "instanceID"),
+								1,
+								time.Hour,
+							), 2, time.Now(),
+						),
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlgWithCode(gomock.NewController(t), "12345678"),
+				otpSendLimits: sd.OTPSendLimits{
+					User: sd.SendLimit{Amount: 1, Interval: time.Hour, CoolDown: time.Hour},
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsResourceExhausted,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore:     tt.fields.eventstore,
 				userEncryption: tt.fields.userEncryption,
+				otpSendLimits:  tt.fields.otpSendLimits,
 			}
 			got, err := r.CreateHumanPhoneVerificationCode(tt.args.ctx, tt.args.userID, tt.args.resourceOwner)
 			if tt.res.err == nil {
@@ -0,0 +1,27 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SecurityAlertSent records that the user was notified with the security alert of the messageType
+// about the event with the triggeringSequence
+func (c *Commands) SecurityAlertSent(ctx context.Context, orgID, userID, messageType string, triggeringSequence uint64) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Wb3qe", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Jz8nr", "Errors.User.NotFound")
+	}
+
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanSecurityAlertSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), messageType, triggeringSequence))
+	return err
+}
@@ -129,6 +129,11 @@ func (c *Commands) changeUserEmailWithGeneratorEvents(ctx context.Context, userI
 	if err = cmd.AddGeneratedCode(ctx, gen, urlTmpl, returnCode); err != nil {
 		return nil, err
 	}
+	if !returnCode {
+		if err = c.recordEmailCodeSend(ctx, cmd); err != nil {
+			return nil, err
+		}
+	}
 	return cmd, nil
 }
 
@@ -148,9 +153,26 @@ func (c *Commands) resendUserEmailCodeWithGeneratorEvents(ctx context.Context, u
 	if err = cmd.AddGeneratedCode(ctx, gen, urlTmpl, returnCode); err != nil {
 		return nil, err
 	}
+	if !returnCode {
+		if err = c.recordEmailCodeSend(ctx, cmd); err != nil {
+			return nil, err
+		}
+	}
 	return cmd, nil
 }
 
+// recordEmailCodeSend checks the send limits for the code sent to the (changed) email address
+// and records the send.
+func (c *Commands) recordEmailCodeSend(ctx context.Context, cmd *UserEmailEvents) error {
+	email := cmd.model.Email
+	for _, event := range cmd.events {
+		if changed, ok := event.(*user.HumanEmailChangedEvent); ok {
+			email = changed.EmailAddress
+		}
+	}
+	return c.checkOTPSendLimit(ctx, cmd.aggregate.ID, domain.NotificationTypeEmail, string(email))
+}
+
 func (c *Commands) VerifyUserEmail(ctx context.Context, userID, code string, alg crypto.EncryptionAlgorithm) (*domain.ObjectDetails, error) {
 	config, err := cryptoGeneratorConfig(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeVerifyEmailCode) //nolint:staticcheck
 	if err != nil {
@@ -91,6 +91,11 @@ func (c *Commands) changeUserPhoneWithGenerator(ctx context.Context, userID, pho
 	if err = cmd.AddGeneratedCode(ctx, gen, returnCode); err != nil {
 		return nil, err
 	}
+	if !returnCode {
+		if err = c.recordPhoneCodeSend(ctx, cmd); err != nil {
+			return nil, err
+		}
+	}
 	return cmd.Push(ctx)
 }
 
@@ -113,9 +118,26 @@ func (c *Commands) resendUserPhoneCodeWithGenerator(ctx context.Context, userID
 	if err = cmd.AddGeneratedCode(ctx, gen, returnCode); err != nil {
 		return nil, err
 	}
+	if !returnCode {
+		if err = c.recordPhoneCodeSend(ctx, cmd); err != nil {
+			return nil, err
+		}
+	}
 	return cmd.Push(ctx)
 }
 
+// recordPhoneCodeSend checks the send limits for the code sent to the (changed) phone number
+// and records the send.
+func (c *Commands) recordPhoneCodeSend(ctx context.Context, cmd *UserPhoneEvents) error {
+	phone := cmd.model.Phone
+	for _, event := range cmd.events {
+		if changed, ok := event.(*user.HumanPhoneChangedEvent); ok {
+			phone = changed.PhoneNumber
+		}
+	}
+	return c.checkOTPSendLimit(ctx, cmd.aggregate.ID, domain.NotificationTypeSms, string(phone))
+}
+
 func (c *Commands) VerifyUserPhone(ctx context.Context, userID, code string, alg crypto.EncryptionAlgorithm) (*domain.ObjectDetails, error) {
 	config, err := cryptoGeneratorConfig(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeVerifyPhoneCode) //nolint:staticcheck
 	if err != nil {
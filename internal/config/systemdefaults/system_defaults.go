@@ -14,6 +14,7 @@ type SystemDefaults struct {
 	DomainVerification DomainVerification
 	Notifications      Notifications
 	KeyConfig          KeyConfig
+	OTPSendLimits      OTPSendLimits
 }
 
 type SecretGenerators struct {
@@ -44,3 +45,30 @@ type KeyConfig struct {
 	CertificateSize     int
 	CertificateLifetime time.Duration
 }
+
+// OTPSendLimits restrict how often codes are sent by SMS or email
+type OTPSendLimits struct {
+	// User limits the codes sent to a single user
+	User SendLimit
+	// Recipient limits the codes sent to a single phone number or email address
+	Recipient SendLimit
+	// Instance limits the codes sent to all users of an instance
+	Instance SendLimit
+}
+
+// Enabled returns true if any of the limits is enabled
+func (l OTPSendLimits) Enabled() bool {
+	return l.User.Enabled() || l.Recipient.Enabled() || l.Instance.Enabled()
+}
+
+type SendLimit struct {
+	// Amount of codes which can be sent during the Interval, 0 disables the limit
+	Amount   uint64
+	Interval time.Duration
+	// CoolDown is the time no codes are sent after the limit was reached
+	CoolDown time.Duration
+}
+
+func (l SendLimit) Enabled() bool {
+	return l.Amount > 0 && l.Interval > 0
+}
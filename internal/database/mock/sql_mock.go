@@ -63,6 +63,15 @@ func ExpectCommit(err error) expectation {
 	}
 }
 
+func ExpectRollback(err error) expectation {
+	return func(m sqlmock.Sqlmock) {
+		e := m.ExpectRollback()
+		if err != nil {
+			e.WillReturnError(err)
+		}
+	}
+}
+
 type ExecOpt func(e *sqlmock.ExpectedExec) *sqlmock.ExpectedExec
 
 func WithExecArgs(args ...driver.Value) ExecOpt {
@@ -17,6 +17,12 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	EmailChangedMessageType             = "EmailChanged"
+	NewUserAgentLoginMessageType        = "NewUserAgentLogin"
+	UserLockedMessageType               = "UserLocked"
+	PasswordExpiryWarningMessageType    = "PasswordExpiryWarning"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -71,5 +77,11 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailOTPMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == EmailChangedMessageType ||
+		textType == NewUserAgentLoginMessageType ||
+		textType == UserLockedMessageType ||
+		textType == PasswordExpiryWarningMessageType
 }
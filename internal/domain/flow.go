@@ -155,12 +155,17 @@ func AllFunctions() []string {
 	functions := make([]string, 0)
 	for _, flowType := range AllFlowTypes() {
 		for _, triggerType := range flowType.TriggerTypes() {
-			functions = append(functions, flowType.LocalizationKey()+"."+triggerType.LocalizationKey())
+			functions = append(functions, FunctionName(flowType, triggerType))
 		}
 	}
 	return functions
 }
 
+// FunctionName returns the name of the function used as condition of a function execution
+func FunctionName(flowType FlowType, triggerType TriggerType) string {
+	return flowType.LocalizationKey() + "." + triggerType.LocalizationKey()
+}
+
 func FunctionExists() func(string) bool {
 	functions := AllFunctions()
 	return func(s string) bool {
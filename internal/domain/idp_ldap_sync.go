@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// LDAPSyncMaxReportEntries limits the amount of entries stored in a report of an LDAP synchronization,
+	// the counts of the report always include all users
+	LDAPSyncMaxReportEntries = 1000
+	// LDAPSyncDefaultMaxDeactivationPercent is used if no maximum share of deactivations is set on the synchronization
+	LDAPSyncDefaultMaxDeactivationPercent = 10
+)
+
+type LDAPSyncAction int32
+
+const (
+	LDAPSyncActionUnspecified LDAPSyncAction = iota
+	LDAPSyncActionCreated
+	LDAPSyncActionUpdated
+	LDAPSyncActionDeactivated
+	LDAPSyncActionFailed
+)
+
+type LDAPSyncReport struct {
+	DryRun      bool                   `json:"dryRun,omitempty"`
+	StartedAt   time.Time              `json:"startedAt,omitempty"`
+	FinishedAt  time.Time              `json:"finishedAt,omitempty"`
+	Found       uint32                 `json:"found,omitempty"`
+	Created     uint32                 `json:"created,omitempty"`
+	Updated     uint32                 `json:"updated,omitempty"`
+	Deactivated uint32                 `json:"deactivated,omitempty"`
+	Failed      uint32                 `json:"failed,omitempty"`
+	Entries     []*LDAPSyncReportEntry `json:"entries,omitempty"`
+	Truncated   bool                   `json:"truncated,omitempty"`
+	// DeactivationLimitExceeded is set if the synchronization would have deactivated more than the allowed share of the linked users,
+	// in which case it was run as dry run
+	DeactivationLimitExceeded bool `json:"deactivationLimitExceeded,omitempty"`
+}
+
+type LDAPSyncReportEntry struct {
+	Action         LDAPSyncAction `json:"action"`
+	UserID         string         `json:"userId,omitempty"`
+	ExternalUserID string         `json:"externalUserId,omitempty"`
+	Username       string         `json:"username,omitempty"`
+	Error          string         `json:"error,omitempty"`
+}
+
+// LDAPSyncLinkedUser is a user linked to an LDAP provider with the attributes compared by the synchronization
+type LDAPSyncLinkedUser struct {
+	UserID            string
+	ResourceOwner     string
+	ExternalUserID    string
+	Username          string
+	State             UserState
+	FirstName         string
+	LastName          string
+	NickName          string
+	DisplayName       string
+	PreferredLanguage language.Tag
+	Email             EmailAddress
+	IsEmailVerified   bool
+	Phone             PhoneNumber
+	IsPhoneVerified   bool
+}
+
+// Add counts the entry and appends it to the report as long as the maximum of entries is not reached
+func (r *LDAPSyncReport) Add(entry *LDAPSyncReportEntry) {
+	switch entry.Action {
+	case LDAPSyncActionCreated:
+		r.Created++
+	case LDAPSyncActionUpdated:
+		r.Updated++
+	case LDAPSyncActionDeactivated:
+		r.Deactivated++
+	case LDAPSyncActionFailed:
+		r.Failed++
+	case LDAPSyncActionUnspecified:
+		return
+	}
+	if len(r.Entries) >= LDAPSyncMaxReportEntries {
+		r.Truncated = true
+		return
+	}
+	r.Entries = append(r.Entries, entry)
+}
+
+// HasChanges returns if the synchronization changed (or on a dry run would have changed) users
+// or couldn't synchronize all of them
+func (r *LDAPSyncReport) HasChanges() bool {
+	return r.Created > 0 || r.Updated > 0 || r.Deactivated > 0 || r.Failed > 0 || r.DeactivationLimitExceeded
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDAPSyncReport_Add(t *testing.T) {
+	type args struct {
+		entries []*LDAPSyncReportEntry
+	}
+	tests := []struct {
+		name string
+		args args
+		want *LDAPSyncReport
+	}{
+		{
+			name: "unspecified, ignored",
+			args: args{
+				entries: []*LDAPSyncReportEntry{
+					{Action: LDAPSyncActionUnspecified},
+				},
+			},
+			want: &LDAPSyncReport{},
+		},
+		{
+			name: "counted",
+			args: args{
+				entries: []*LDAPSyncReportEntry{
+					{Action: LDAPSyncActionCreated},
+					{Action: LDAPSyncActionUpdated},
+					{Action: LDAPSyncActionDeactivated},
+					{Action: LDAPSyncActionFailed},
+				},
+			},
+			want: &LDAPSyncReport{
+				Created:     1,
+				Updated:     1,
+				Deactivated: 1,
+				Failed:      1,
+				Entries: []*LDAPSyncReportEntry{
+					{Action: LDAPSyncActionCreated},
+					{Action: LDAPSyncActionUpdated},
+					{Action: LDAPSyncActionDeactivated},
+					{Action: LDAPSyncActionFailed},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := new(LDAPSyncReport)
+			for _, entry := range tt.args.entries {
+				report.Add(entry)
+			}
+			assert.Equal(t, tt.want, report)
+		})
+	}
+}
+
+func TestLDAPSyncReport_Add_truncated(t *testing.T) {
+	report := new(LDAPSyncReport)
+	for i := 0; i <= LDAPSyncMaxReportEntries; i++ {
+		report.Add(&LDAPSyncReportEntry{Action: LDAPSyncActionDeactivated})
+	}
+	assert.Equal(t, uint32(LDAPSyncMaxReportEntries+1), report.Deactivated)
+	assert.Len(t, report.Entries, LDAPSyncMaxReportEntries)
+	assert.True(t, report.Truncated)
+}
+
+func TestLDAPSyncReport_HasChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *LDAPSyncReport
+		want   bool
+	}{
+		{
+			name:   "found only, no changes",
+			report: &LDAPSyncReport{Found: 10},
+			want:   false,
+		},
+		{
+			name:   "updated, changes",
+			report: &LDAPSyncReport{Found: 10, Updated: 1},
+			want:   true,
+		},
+		{
+			name:   "failed, changes",
+			report: &LDAPSyncReport{Found: 10, Failed: 1},
+			want:   true,
+		},
+		{
+			name:   "deactivation limit exceeded, changes",
+			report: &LDAPSyncReport{DryRun: true, DeactivationLimitExceeded: true},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.report.HasChanges())
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 type NotificationType int32
 
 const (
@@ -31,3 +33,51 @@ const (
 
 	notificationProviderTypeCount
 )
+
+type NotificationDeliveryState int32
+
+const (
+	NotificationDeliveryStateUnspecified NotificationDeliveryState = iota
+	NotificationDeliveryStateDelivered
+	NotificationDeliveryStateFailed
+	NotificationDeliveryStateResendRequested
+
+	notificationDeliveryStateCount
+)
+
+func (s NotificationDeliveryState) Exists() bool {
+	return s > NotificationDeliveryStateUnspecified && s < notificationDeliveryStateCount
+}
+
+// OTPSendLimitScope defines for whom the sent codes are counted
+type OTPSendLimitScope int32
+
+const (
+	OTPSendLimitScopeUnspecified OTPSendLimitScope = iota
+	OTPSendLimitScopeUser
+	OTPSendLimitScopeRecipient
+	OTPSendLimitScopeInstance
+
+	otpSendLimitScopeCount
+)
+
+// MaskEmail keeps the first character of the local part and the domain of the address, e.g. `j***@example.com`
+func MaskEmail(email string) string {
+	local, host, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return maskAll(email)
+	}
+	return local[:1] + "***@" + host
+}
+
+// MaskPhone keeps the country prefix and the last two digits of the number, e.g. `+41*******67`
+func MaskPhone(phone string) string {
+	if len(phone) <= 5 {
+		return maskAll(phone)
+	}
+	return phone[:3] + strings.Repeat("*", len(phone)-5) + phone[len(phone)-2:]
+}
+
+func maskAll(value string) string {
+	return strings.Repeat("*", len(value))
+}
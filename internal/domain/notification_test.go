@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"john.doe@example.com", "j***@example.com"},
+		{"j@example.com", "j***@example.com"},
+		{"@example.com", "************"},
+		{"invalid", "*******"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskEmail(tt.email))
+		})
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"+41791234567", "+41*******67"},
+		{"+4112", "*****"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskPhone(tt.phone))
+		})
+	}
+}
@@ -6,6 +6,7 @@ const (
 	TargetTypeWebhook TargetType = iota
 	TargetTypeCall
 	TargetTypeAsync
+	TargetTypeGRPC
 )
 
 type TargetState int32
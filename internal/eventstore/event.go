@@ -2,6 +2,7 @@ package eventstore
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"time"
 
@@ -33,6 +34,26 @@ type Command interface {
 	UniqueConstraints() []*UniqueConstraint
 }
 
+// SequenceAsserter can be implemented by a [Command] which is based on the state of its aggregate.
+// The push fails if the sequence of the aggregate changed since the state was read.
+type SequenceAsserter interface {
+	Command
+	// ExpectedSequence is the sequence of the aggregate before the command is pushed
+	ExpectedSequence() uint64
+}
+
+const concurrentChangeID = "V3-Rq7mv"
+
+// ThrowConcurrentChange returns the error of a push whose [SequenceAsserter] doesn't match the sequence of its aggregate
+func ThrowConcurrentChange() error {
+	return zerrors.ThrowPreconditionFailed(nil, concurrentChangeID, "Errors.ConcurrentChange")
+}
+
+// IsConcurrentChange reports whether the push failed because the aggregate changed since its state was read
+func IsConcurrentChange(err error) bool {
+	return errors.Is(err, &zerrors.ZitadelError{ID: concurrentChangeID})
+}
+
 // Event is a stored activity
 type Event interface {
 	action
@@ -117,6 +117,12 @@ func (m *MockRepository) ExpectPush(expectedCommands []eventstore.Command, sleep
 				if !assert.ElementsMatch(m.MockPusher.ctrl.T, expectedCommand.UniqueConstraints(), commands[i].UniqueConstraints()) {
 					m.MockPusher.ctrl.T.Errorf("invalid command.UniqueConstraints [%d]: expected: %#v got: %#v", i, expectedCommand.UniqueConstraints(), commands[i].UniqueConstraints())
 				}
+				if expectedAsserter, ok := expectedCommand.(eventstore.SequenceAsserter); ok {
+					gotAsserter, ok := commands[i].(eventstore.SequenceAsserter)
+					if !ok || !assert.Equal(m.MockPusher.ctrl.T, expectedAsserter.ExpectedSequence(), gotAsserter.ExpectedSequence()) {
+						m.MockPusher.ctrl.T.Errorf("invalid command.ExpectedSequence [%d]: expected: %#v got: %#v", i, expectedCommand, commands[i])
+					}
+				}
 			}
 			events := make([]eventstore.Event, len(commands))
 			for i, command := range commands {
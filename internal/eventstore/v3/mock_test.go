@@ -42,6 +42,18 @@ func (m *mockCommand) UniqueConstraints() []*eventstore.UniqueConstraint {
 	return m.constraints
 }
 
+var _ eventstore.SequenceAsserter = (*mockSequenceCommand)(nil)
+
+type mockSequenceCommand struct {
+	mockCommand
+	expectedSequence uint64
+}
+
+// ExpectedSequence implements [eventstore.SequenceAsserter]
+func (m *mockSequenceCommand) ExpectedSequence() uint64 {
+	return m.expectedSequence
+}
+
 func mockEvent(aggregate *eventstore.Aggregate, sequence uint64, payload Payload) eventstore.Event {
 	return &event{
 		aggregate: aggregate,
@@ -109,6 +109,9 @@ func mapCommands(commands []eventstore.Command, sequences []*latestSequence) (ev
 			// added return for linting
 			return nil, nil, nil, nil
 		}
+		if asserter, ok := command.(eventstore.SequenceAsserter); ok && asserter.ExpectedSequence() != sequence.sequence {
+			return nil, nil, nil, eventstore.ThrowConcurrentChange()
+		}
 		sequence.sequence++
 
 		events[i], err = commandToEvent(sequence, command)
@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/database/cockroach"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 func Test_mapCommands(t *testing.T) {
@@ -210,6 +211,77 @@ func Test_mapCommands(t *testing.T) {
 				err: func(t *testing.T, err error) {},
 			},
 		},
+		{
+			name: "expected sequence",
+			args: args{
+				commands: []eventstore.Command{
+					&mockSequenceCommand{
+						mockCommand: mockCommand{
+							aggregate: mockAggregate("V3-VEIvq"),
+						},
+						expectedSequence: 5,
+					},
+				},
+				sequences: []*latestSequence{
+					{
+						aggregate: mockAggregate("V3-VEIvq"),
+						sequence:  5,
+					},
+				},
+			},
+			want: want{
+				events: []eventstore.Event{
+					mockEvent(
+						mockAggregate("V3-VEIvq"),
+						6,
+						nil,
+					),
+				},
+				placeHolders: []string{
+					"($1, $2, $3, $4, $5, $6, $7, $8, $9, hlc_to_timestamp(cluster_logical_timestamp()), cluster_logical_timestamp(), $10)",
+				},
+				args: []any{
+					"instance",
+					"ro",
+					eventstore.AggregateType("type"),
+					"V3-VEIvq",
+					1,
+					"creator",
+					eventstore.EventType("event.type"),
+					Payload(nil),
+					uint64(6),
+					0,
+				},
+				err: func(t *testing.T, err error) {},
+			},
+		},
+		{
+			name: "sequence changed",
+			args: args{
+				commands: []eventstore.Command{
+					&mockSequenceCommand{
+						mockCommand: mockCommand{
+							aggregate: mockAggregate("V3-VEIvq"),
+						},
+						expectedSequence: 4,
+					},
+				},
+				sequences: []*latestSequence{
+					{
+						aggregate: mockAggregate("V3-VEIvq"),
+						sequence:  5,
+					},
+				},
+			},
+			want: want{
+				events:       []eventstore.Event{},
+				placeHolders: []string{},
+				args:         []any{},
+				err: func(t *testing.T, err error) {
+					assert.True(t, zerrors.IsPreconditionFailed(err))
+				},
+			},
+		},
 		{
 			name: "missing sequence",
 			args: args{
@@ -0,0 +1,118 @@
+package execution
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/zitadel/oidc/v3/pkg/crypto"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const jwtLifetime = time.Minute
+
+// SignerFunc returns the signer of the instance in the context,
+// which is used to sign the JWT sent to targets with JWT authentication.
+type SignerFunc func(ctx context.Context) (jose.Signer, error)
+
+var (
+	jwtSigner SignerFunc
+
+	transports = newTargetClients(maxTargetClients, (*http.Transport).CloseIdleConnections)
+)
+
+// SetJWTSigner sets the signer used for the JWT authentication of targets.
+func SetJWTSigner(signer SignerFunc) {
+	jwtSigner = signer
+}
+
+// setAuthentication adds the custom headers and the JWT of the target to the request.
+func setAuthentication(ctx context.Context, req *http.Request, target Target) error {
+	for key, value := range target.GetHeaders() {
+		req.Header.Set(key, value)
+	}
+	if !target.IsJWTAuthentication() {
+		return nil
+	}
+	token, err := targetJWT(ctx, target.GetEndpoint())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", oidc.PrefixBearer+token)
+	return nil
+}
+
+// targetJWT creates a short-lived JWT signed by the key of the instance,
+// with the target endpoint as audience.
+func targetJWT(ctx context.Context, audience string) (string, error) {
+	if jwtSigner == nil {
+		return "", zerrors.ThrowPreconditionFailed(nil, "EXEC-x1n8w2c5qj", "Errors.Execution.JWTSignerMissing")
+	}
+	signer, err := jwtSigner(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	return crypto.Sign(&oidc.JWTTokenRequest{
+		Issuer:    http_util.ComposedOrigin(ctx),
+		Subject:   authz.GetInstance(ctx).InstanceID(),
+		Audience:  []string{audience},
+		IssuedAt:  oidc.FromTime(now),
+		ExpiresAt: oidc.FromTime(now.Add(jwtLifetime)),
+	}, signer)
+}
+
+// httpClient returns the client used to call the target,
+// a client certificate and custom root CAs result in a dedicated transport of the target,
+// which is reused until the TLS configuration of the target changes.
+func httpClient(ctx context.Context, target Target) (*http.Client, error) {
+	certificate, key := target.GetClientCertificate()
+	rootCAs := target.GetRootCAs()
+	if len(certificate) == 0 && len(rootCAs) == 0 {
+		return http.DefaultClient, nil
+	}
+
+	transport, err := transports.get(
+		targetKey{authz.GetInstance(ctx).InstanceID(), target.GetTargetID()},
+		fingerprint(certificate, key, rootCAs),
+		func() (*http.Transport, error) {
+			tlsConfig, err := targetTLSConfig(certificate, key, rootCAs)
+			if err != nil {
+				return nil, err
+			}
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = tlsConfig
+			return transport, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+func targetTLSConfig(certificate, key, rootCAs []byte) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(certificate) > 0 {
+		cert, err := tls.X509KeyPair(certificate, key)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "EXEC-b8tq3m6dzr", "Errors.Target.InvalidClientCertificate")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if len(rootCAs) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(rootCAs) {
+			return nil, zerrors.ThrowInternal(nil, "EXEC-p4vy7e1khs", "Errors.Target.InvalidRootCAs")
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
@@ -0,0 +1,171 @@
+package execution
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+func Test_call_authentication(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		signer     SignerFunc
+		target     *mockTarget
+		wantHeader http.Header
+		wantJWT    bool
+		wantErr    bool
+	}{
+		{
+			name: "headers",
+			target: &mockTarget{
+				Timeout: time.Minute,
+				Headers: map[string]string{"X-Api-Key": "secret"},
+			},
+			wantHeader: http.Header{"X-Api-Key": []string{"secret"}},
+		},
+		{
+			name: "jwt without signer",
+			target: &mockTarget{
+				Timeout:           time.Minute,
+				JWTAuthentication: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "jwt",
+			signer: func(context.Context) (jose.Signer, error) {
+				return signer, nil
+			},
+			target: &mockTarget{
+				Timeout:           time.Minute,
+				JWTAuthentication: true,
+			},
+			wantJWT: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetJWTSigner(tt.signer)
+			t.Cleanup(func() { SetJWTSigner(nil) })
+
+			var sent http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = r.Header
+			}))
+			defer server.Close()
+			tt.target.Endpoint = server.URL
+
+			_, err := call(authz.NewMockContext("instance", "", ""), tt.target, []byte("{}"))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for key := range tt.wantHeader {
+				assert.Equal(t, tt.wantHeader.Get(key), sent.Get(key))
+			}
+			if !tt.wantJWT {
+				assert.Empty(t, sent.Get("Authorization"))
+				return
+			}
+			token, err := jwt.ParseSigned(strings.TrimPrefix(sent.Get("Authorization"), "Bearer "), []jose.SignatureAlgorithm{jose.RS256})
+			require.NoError(t, err)
+			claims := new(jwt.Claims)
+			require.NoError(t, token.Claims(&key.PublicKey, claims))
+			assert.Equal(t, "instance", claims.Subject)
+			assert.Equal(t, jwt.Audience{server.URL}, claims.Audience)
+			assert.NoError(t, claims.Validate(jwt.Expected{Time: time.Now()}))
+		})
+	}
+}
+
+func Test_call_mTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	clientCertificate, clientKey := testClientCertificate(t)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	rootCAs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		target  *mockTarget
+		wantErr bool
+	}{
+		{
+			name: "unknown authority",
+			target: &mockTarget{
+				Endpoint:          server.URL,
+				Timeout:           time.Minute,
+				ClientCertificate: clientCertificate,
+				ClientKey:         clientKey,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing client certificate",
+			target: &mockTarget{
+				Endpoint: server.URL,
+				Timeout:  time.Minute,
+				RootCAs:  rootCAs,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ok",
+			target: &mockTarget{
+				Endpoint:          server.URL,
+				Timeout:           time.Minute,
+				ClientCertificate: clientCertificate,
+				ClientKey:         clientKey,
+				RootCAs:           rootCAs,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := call(context.Background(), tt.target, []byte("{}"))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func testClientCertificate(t *testing.T) (certificate, key []byte) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+}
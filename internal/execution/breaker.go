@@ -0,0 +1,206 @@
+package execution
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	circuitOpenedCounter            = "execution_target_circuit_opened"
+	circuitOpenedCounterDescription = "Amount of times the circuit of a target was opened"
+	rejectedCallsCounter            = "execution_target_calls_rejected"
+	rejectedCallsDescription        = "Calls to targets rejected by the circuit breaker or the max concurrency"
+	openCircuitsObserver            = "execution_target_open_circuits"
+	openCircuitsDescription         = "Amount of targets with an open or half-open circuit"
+	runningCallsObserver            = "execution_target_running_calls"
+	runningCallsDescription         = "Calls to targets currently running"
+
+	rejectReasonCircuitOpen    = "circuit_open"
+	rejectReasonMaxConcurrency = "max_concurrency"
+)
+
+type CircuitState int
+
+const (
+	// CircuitStateClosed lets all calls through
+	CircuitStateClosed CircuitState = iota
+	// CircuitStateOpen rejects all calls until the open duration of the target is over
+	CircuitStateOpen
+	// CircuitStateHalfOpen lets a single call through to probe the target
+	CircuitStateHalfOpen
+)
+
+// TargetState is the state of the circuit breaker and the concurrency of a target in this process
+type TargetState struct {
+	Circuit             CircuitState
+	ConsecutiveFailures uint16
+	RunningCalls        uint16
+}
+
+// targetGuard protects a target with a circuit breaker and a limit of concurrent calls,
+// the state is held in memory per process.
+type targetGuard struct {
+	mu       sync.Mutex
+	state    CircuitState
+	failures uint16
+	openedAt time.Time
+	probing  bool
+	running  uint16
+}
+
+var (
+	// guards are held per target like the clients, so a changed target starts with a closed circuit
+	guards     = newTargetClients(maxTargetClients, func(*targetGuard) {})
+	guardsOnce sync.Once
+	guardNow   = time.Now
+)
+
+// GetTargetState returns the state of the target in this process
+func GetTargetState(instanceID, targetID string) TargetState {
+	g, ok := guards.peek(targetKey{instanceID, targetID})
+	if !ok {
+		return TargetState{}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return TargetState{
+		Circuit:             g.state,
+		ConsecutiveFailures: g.failures,
+		RunningCalls:        g.running,
+	}
+}
+
+// getGuard returns the guard of the target, a changed endpoint or protection of the target results in a new guard
+func getGuard(key targetKey, target Target, failureThreshold uint16, openDuration time.Duration, maxConcurrency uint16) *targetGuard {
+	g, _ := guards.get(key,
+		fingerprint(
+			[]byte(target.GetEndpoint()),
+			binary.BigEndian.AppendUint16(nil, failureThreshold),
+			binary.BigEndian.AppendUint64(nil, uint64(openDuration)),
+			binary.BigEndian.AppendUint16(nil, maxConcurrency),
+		),
+		func() (*targetGuard, error) {
+			return new(targetGuard), nil
+		},
+	)
+	return g
+}
+
+// acquire checks if the target can be called,
+// the returned function must be called with the result of the call.
+func acquire(ctx context.Context, target Target) (release func(success bool), err error) {
+	failureThreshold, openDuration := target.GetCircuitBreaker()
+	maxConcurrency := target.GetMaxConcurrency()
+	if failureThreshold == 0 && maxConcurrency == 0 {
+		return func(bool) {}, nil
+	}
+	key := targetKey{authz.GetInstance(ctx).InstanceID(), target.GetTargetID()}
+	g := getGuard(key, target, failureThreshold, openDuration, maxConcurrency)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var probe bool
+	if failureThreshold > 0 {
+		switch g.state {
+		case CircuitStateOpen:
+			if guardNow().Before(g.openedAt.Add(openDuration)) {
+				addRejectedCall(ctx, rejectReasonCircuitOpen)
+				return nil, zerrors.ThrowUnavailable(nil, "EXEC-3h9tvb1xqe", "Errors.Execution.CircuitOpen")
+			}
+			g.state = CircuitStateHalfOpen
+			fallthrough
+		case CircuitStateHalfOpen:
+			if g.probing {
+				addRejectedCall(ctx, rejectReasonCircuitOpen)
+				return nil, zerrors.ThrowUnavailable(nil, "EXEC-u0d4wm7kcp", "Errors.Execution.CircuitOpen")
+			}
+			probe = true
+		case CircuitStateClosed:
+		}
+	}
+	if maxConcurrency > 0 && g.running >= maxConcurrency {
+		addRejectedCall(ctx, rejectReasonMaxConcurrency)
+		return nil, zerrors.ThrowResourceExhausted(nil, "EXEC-5n2jzq8yfa", "Errors.Execution.MaxConcurrency")
+	}
+	g.running++
+	g.probing = g.probing || probe
+	return func(success bool) {
+		g.release(ctx, success, probe, failureThreshold)
+	}, nil
+}
+
+func (g *targetGuard) release(ctx context.Context, success, probe bool, failureThreshold uint16) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.running--
+	if probe {
+		g.probing = false
+	}
+	if failureThreshold == 0 {
+		return
+	}
+	if success {
+		g.state = CircuitStateClosed
+		g.failures = 0
+		return
+	}
+	g.failures++
+	if g.state == CircuitStateHalfOpen || (g.state == CircuitStateClosed && g.failures >= failureThreshold) {
+		g.state = CircuitStateOpen
+		g.openedAt = guardNow()
+		err := metrics.AddCount(ctx, circuitOpenedCounter, 1, nil)
+		logging.OnError(err).Debug("unable to count opened circuit")
+	}
+}
+
+// addRejectedCall counts the rejected call by its reason,
+// the IDs of the instance and the target are not added as labels to keep the cardinality of the metrics bounded.
+func addRejectedCall(ctx context.Context, reason string) {
+	err := metrics.AddCount(ctx, rejectedCallsCounter, 1, map[string]attribute.Value{
+		"reason": attribute.StringValue(reason),
+	})
+	logging.OnError(err).Debug("unable to count rejected call")
+}
+
+// registerGuardMetrics registers the metrics of the circuit breakers and the concurrency of the targets
+func registerGuardMetrics() {
+	guardsOnce.Do(func() {
+		err := metrics.RegisterCounter(circuitOpenedCounter, circuitOpenedCounterDescription)
+		logging.WithFields("metric", circuitOpenedCounter).OnError(err).Panic("unable to register counter")
+		err = metrics.RegisterCounter(rejectedCallsCounter, rejectedCallsDescription)
+		logging.WithFields("metric", rejectedCallsCounter).OnError(err).Panic("unable to register counter")
+		err = metrics.RegisterValueObserver(openCircuitsObserver, openCircuitsDescription, observeGuards(func(s TargetState) int64 {
+			if s.Circuit == CircuitStateClosed {
+				return 0
+			}
+			return 1
+		}))
+		logging.WithFields("metric", openCircuitsObserver).OnError(err).Panic("unable to register observer")
+		err = metrics.RegisterValueObserver(runningCallsObserver, runningCallsDescription, observeGuards(func(s TargetState) int64 {
+			return int64(s.RunningCalls)
+		}))
+		logging.WithFields("metric", runningCallsObserver).OnError(err).Panic("unable to register observer")
+	})
+}
+
+// observeGuards observes the sum of the values of the targets in this process
+func observeGuards(value func(TargetState) int64) metric.Int64Callback {
+	return func(_ context.Context, observer metric.Int64Observer) error {
+		var sum int64
+		for _, key := range guards.keys() {
+			sum += value(GetTargetState(key.instanceID, key.targetID))
+		}
+		observer.Observe(sum)
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_acquire(t *testing.T) {
+	now := time.Now()
+	guardNow = func() time.Time { return now }
+	defer func() { guardNow = time.Now }()
+
+	type step struct {
+		after   time.Duration
+		success bool
+		err     func(error) bool
+		state   TargetState
+	}
+	tests := []struct {
+		name   string
+		target *mockTarget
+		steps  []step
+	}{
+		{
+			name:   "no breaker and concurrency, ok",
+			target: &mockTarget{TargetID: "none"},
+			steps: []step{
+				{success: false},
+				{success: false},
+				{success: true},
+			},
+		},
+		{
+			name:   "failures below threshold, closed",
+			target: &mockTarget{TargetID: "below", FailureThreshold: 3, OpenDuration: time.Minute},
+			steps: []step{
+				{success: false, state: TargetState{Circuit: CircuitStateClosed, ConsecutiveFailures: 1}},
+				{success: false, state: TargetState{Circuit: CircuitStateClosed, ConsecutiveFailures: 2}},
+				{success: true, state: TargetState{Circuit: CircuitStateClosed}},
+			},
+		},
+		{
+			name:   "failures reach threshold, open",
+			target: &mockTarget{TargetID: "open", FailureThreshold: 2, OpenDuration: time.Minute},
+			steps: []step{
+				{success: false, state: TargetState{Circuit: CircuitStateClosed, ConsecutiveFailures: 1}},
+				{success: false, state: TargetState{Circuit: CircuitStateOpen, ConsecutiveFailures: 2}},
+				{err: zerrors.IsUnavailable, state: TargetState{Circuit: CircuitStateOpen, ConsecutiveFailures: 2}},
+			},
+		},
+		{
+			name:   "probe after open duration succeeds, closed",
+			target: &mockTarget{TargetID: "probe-ok", FailureThreshold: 1, OpenDuration: time.Minute},
+			steps: []step{
+				{success: false, state: TargetState{Circuit: CircuitStateOpen, ConsecutiveFailures: 1}},
+				{after: time.Minute, success: true, state: TargetState{Circuit: CircuitStateClosed}},
+			},
+		},
+		{
+			name:   "probe after open duration fails, open again",
+			target: &mockTarget{TargetID: "probe-fail", FailureThreshold: 1, OpenDuration: time.Minute},
+			steps: []step{
+				{success: false, state: TargetState{Circuit: CircuitStateOpen, ConsecutiveFailures: 1}},
+				{after: time.Minute, success: false, state: TargetState{Circuit: CircuitStateOpen, ConsecutiveFailures: 2}},
+				{err: zerrors.IsUnavailable, state: TargetState{Circuit: CircuitStateOpen, ConsecutiveFailures: 2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithInstanceID(context.Background(), "instance")
+			for _, s := range tt.steps {
+				now = now.Add(s.after)
+				release, err := acquire(ctx, tt.target)
+				if s.err != nil {
+					assert.True(t, s.err(err))
+				} else {
+					require.NoError(t, err)
+					release(s.success)
+				}
+				assert.Equal(t, s.state, GetTargetState("instance", tt.target.TargetID))
+			}
+		})
+	}
+}
+
+func Test_acquire_concurrency(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	target := &mockTarget{TargetID: "concurrency", MaxConcurrency: 2}
+
+	release1, err := acquire(ctx, target)
+	require.NoError(t, err)
+	release2, err := acquire(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, TargetState{RunningCalls: 2}, GetTargetState("instance", target.TargetID))
+
+	_, err = acquire(ctx, target)
+	assert.True(t, zerrors.IsResourceExhausted(err))
+
+	release1(true)
+	release3, err := acquire(ctx, target)
+	require.NoError(t, err)
+	release2(true)
+	release3(true)
+	assert.Equal(t, TargetState{}, GetTargetState("instance", target.TargetID))
+}
+
+func Test_acquire_halfOpenSingleProbe(t *testing.T) {
+	now := time.Now()
+	guardNow = func() time.Time { return now }
+	defer func() { guardNow = time.Now }()
+
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	target := &mockTarget{TargetID: "single-probe", FailureThreshold: 1, OpenDuration: time.Second}
+
+	release, err := acquire(ctx, target)
+	require.NoError(t, err)
+	release(false)
+
+	now = now.Add(time.Second)
+	probe, err := acquire(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, CircuitStateHalfOpen, GetTargetState("instance", target.TargetID).Circuit)
+
+	_, err = acquire(ctx, target)
+	assert.True(t, zerrors.IsUnavailable(err))
+	probe(true)
+	assert.Equal(t, TargetState{}, GetTargetState("instance", target.TargetID))
+}
+
+func Test_acquire_changedAndRemovedTarget(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	target := &mockTarget{TargetID: "changed", FailureThreshold: 1, OpenDuration: time.Minute}
+
+	release, err := acquire(ctx, target)
+	require.NoError(t, err)
+	release(false)
+	assert.Equal(t, CircuitStateOpen, GetTargetState("instance", target.TargetID).Circuit)
+
+	target.Endpoint = "https://changed.example.com"
+	release, err = acquire(ctx, target)
+	require.NoError(t, err, "changed target must start with a closed circuit")
+	release(false)
+	assert.Equal(t, CircuitStateOpen, GetTargetState("instance", target.TargetID).Circuit)
+
+	RemoveTarget("instance", target.TargetID)
+	assert.Equal(t, TargetState{}, GetTargetState("instance", target.TargetID))
+	assert.NotContains(t, guards.keys(), targetKey{"instance", target.TargetID})
+}
@@ -0,0 +1,88 @@
+package execution
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2"
+	"github.com/zitadel/logging"
+)
+
+// maxTargetClients is the maximum of clients held per type of client in this process,
+// the least recently used client is closed if it is exceeded.
+const maxTargetClients = 1000
+
+type targetKey struct {
+	instanceID string
+	targetID   string
+}
+
+// targetClients holds a client per target, which is reused as long as the configuration of the target doesn't change.
+// Clients of changed and removed targets are closed.
+type targetClients[C any] struct {
+	mu    sync.Mutex
+	cache *lru.Cache[targetKey, *targetClient[C]]
+}
+
+type targetClient[C any] struct {
+	fingerprint [sha256.Size]byte
+	client      C
+}
+
+func newTargetClients[C any](size int, closeClient func(C)) *targetClients[C] {
+	cache, err := lru.NewWithEvict(size, func(_ targetKey, c *targetClient[C]) {
+		closeClient(c.client)
+	})
+	logging.OnError(err).Panic("unable to create target client cache")
+	return &targetClients[C]{cache: cache}
+}
+
+// get returns the client of the target,
+// a new client is created if none exists or the fingerprint of the configuration of the target changed.
+func (c *targetClients[C]) get(key targetKey, fingerprint [sha256.Size]byte, create func() (C, error)) (client C, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.cache.Get(key); ok {
+		if cached.fingerprint == fingerprint {
+			return cached.client, nil
+		}
+		c.cache.Remove(key)
+	}
+	client, err = create()
+	if err != nil {
+		return client, err
+	}
+	c.cache.Add(key, &targetClient[C]{fingerprint: fingerprint, client: client})
+	return client, nil
+}
+
+// peek returns the client of the target without creating it
+func (c *targetClients[C]) peek(key targetKey) (C, bool) {
+	cached, ok := c.cache.Peek(key)
+	if !ok {
+		var client C
+		return client, false
+	}
+	return cached.client, true
+}
+
+// keys returns the targets with a client
+func (c *targetClients[C]) keys() []targetKey {
+	return c.cache.Keys()
+}
+
+// remove closes the client of the target
+func (c *targetClients[C]) remove(key targetKey) {
+	c.cache.Remove(key)
+}
+
+// fingerprint hashes the values separated, so distinct values result in distinct fingerprints
+func fingerprint(values ...[]byte) (id [sha256.Size]byte) {
+	hash := sha256.New()
+	for _, value := range values {
+		hash.Write(value)
+		hash.Write([]byte{0})
+	}
+	copy(id[:], hash.Sum(nil))
+	return id
+}
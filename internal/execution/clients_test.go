@@ -0,0 +1,45 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClient struct {
+	id     int
+	closed bool
+}
+
+func Test_targetClients(t *testing.T) {
+	clients := newTargetClients(2, func(c *testClient) {
+		c.closed = true
+	})
+	var created int
+	create := func() (*testClient, error) {
+		created++
+		return &testClient{id: created}, nil
+	}
+	key := targetKey{"instance", "target"}
+
+	first, err := clients.get(key, fingerprint([]byte("config")), create)
+	require.NoError(t, err)
+	reused, err := clients.get(key, fingerprint([]byte("config")), create)
+	require.NoError(t, err)
+	assert.Same(t, first, reused, "unchanged target must reuse the client")
+
+	changed, err := clients.get(key, fingerprint([]byte("changed")), create)
+	require.NoError(t, err)
+	assert.NotSame(t, first, changed)
+	assert.True(t, first.closed, "client of the changed target must be closed")
+
+	clients.remove(key)
+	assert.True(t, changed.closed, "client of the removed target must be closed")
+
+	for i := range 3 {
+		_, err = clients.get(targetKey{"instance", string(rune('a' + i))}, fingerprint(), create)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, clients.cache.Len(), "clients must be bounded")
+}
@@ -0,0 +1,167 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/hashicorp/golang-lru/v2"
+	"github.com/zitadel/logging"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// conditionVarPayload is the complete body sent to the targets
+	conditionVarPayload = "payload"
+	// conditionVarRequest is the request message of request and response executions
+	conditionVarRequest = "request"
+	// conditionVarResponse is the response message of response executions
+	conditionVarResponse = "response"
+	conditionVarUserID   = "user_id"
+	conditionVarOrgID    = "org_id"
+	// conditionVarHeaders are the incoming headers with lower case keys
+	conditionVarHeaders = "headers"
+
+	// maxConditionPrograms is the maximum of compiled conditions held in this process,
+	// the least recently used program is removed if it is exceeded.
+	maxConditionPrograms = 1000
+	// maxConditionCost limits the cost of the evaluation of a condition,
+	// so expressions like comprehensions over large payloads are aborted.
+	maxConditionCost = 100_000
+)
+
+var (
+	conditionEnv      *cel.Env
+	conditionEnvErr   error
+	conditionEnvOnce  sync.Once
+	conditionPrograms = newConditionPrograms(maxConditionPrograms)
+)
+
+func newConditionPrograms(size int) *lru.Cache[string, cel.Program] {
+	cache, err := lru.New[string, cel.Program](size)
+	logging.OnError(err).Panic("unable to create condition program cache")
+	return cache
+}
+
+func getConditionEnv() (*cel.Env, error) {
+	conditionEnvOnce.Do(func() {
+		conditionEnv, conditionEnvErr = cel.NewEnv(
+			cel.Variable(conditionVarPayload, cel.DynType),
+			cel.Variable(conditionVarRequest, cel.DynType),
+			cel.Variable(conditionVarResponse, cel.DynType),
+			cel.Variable(conditionVarUserID, cel.StringType),
+			cel.Variable(conditionVarOrgID, cel.StringType),
+			cel.Variable(conditionVarHeaders, cel.MapType(cel.StringType, cel.StringType)),
+			// numbers of the JSON payload are doubles, so they can be compared to integer literals
+			cel.CrossTypeNumericComparisons(true),
+		)
+	})
+	return conditionEnv, conditionEnvErr
+}
+
+// CompileCondition checks if the CEL expression is valid and results in a boolean
+func CompileCondition(condition string) error {
+	_, err := conditionProgram(condition)
+	return err
+}
+
+func conditionProgram(condition string) (cel.Program, error) {
+	if program, ok := conditionPrograms.Get(condition); ok {
+		return program, nil
+	}
+	env, err := getConditionEnv()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-a4ux9cq2pz", "Errors.Internal")
+	}
+	ast, issues := env.Compile(condition)
+	if issues.Err() != nil {
+		return nil, zerrors.ThrowInvalidArgument(issues.Err(), "EXEC-w8r1hy6vjn", "Errors.Execution.ConditionInvalid")
+	}
+	// the type of fields of the payload is only known on evaluation
+	if !ast.OutputType().IsExactType(types.BoolType) && !ast.OutputType().IsExactType(types.DynType) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "EXEC-0dn5kfe1tq", "Errors.Execution.ConditionInvalid")
+	}
+	program, err := env.Program(ast, cel.CostLimit(maxConditionCost))
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "EXEC-m2z8bgj7ox", "Errors.Execution.ConditionInvalid")
+	}
+	conditionPrograms.Add(condition, program)
+	return program, nil
+}
+
+// conditionsMatch evaluates the conditions against the body sent to the target,
+// the authenticated user, the organization and the incoming headers.
+// All conditions must evaluate to true, a condition which does not result in a boolean returns an error.
+func conditionsMatch(ctx context.Context, conditions []string, body []byte) (bool, error) {
+	if len(conditions) == 0 {
+		return true, nil
+	}
+	activation, err := conditionActivation(ctx, body)
+	if err != nil {
+		return false, err
+	}
+	for _, condition := range conditions {
+		program, err := conditionProgram(condition)
+		if err != nil {
+			return false, err
+		}
+		out, _, err := program.ContextEval(ctx, activation)
+		if err != nil {
+			return false, zerrors.ThrowPreconditionFailed(err, "EXEC-y5t1nwq8cs", "Errors.Execution.ConditionInvalid")
+		}
+		match, ok := out.Value().(bool)
+		if !ok {
+			return false, zerrors.ThrowPreconditionFailed(nil, "EXEC-c3j9vx0rlh", "Errors.Execution.ConditionInvalid")
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func conditionActivation(ctx context.Context, body []byte) (map[string]any, error) {
+	payload := make(map[string]any)
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, zerrors.ThrowInternal(err, "EXEC-e6gp2mz4ab", "Errors.Internal")
+		}
+	}
+	ctxData := authz.GetCtxData(ctx)
+	return map[string]any{
+		conditionVarPayload:  payload,
+		conditionVarRequest:  mapOrEmpty(payload[conditionVarRequest]),
+		conditionVarResponse: mapOrEmpty(payload[conditionVarResponse]),
+		conditionVarUserID:   ctxData.UserID,
+		conditionVarOrgID:    ctxData.OrgID,
+		conditionVarHeaders:  incomingHeaders(ctx),
+	}, nil
+}
+
+// mapOrEmpty returns an empty map if the value is not set,
+// so the existence of fields can be checked with `has()`
+func mapOrEmpty(value any) any {
+	if value == nil {
+		return map[string]any{}
+	}
+	return value
+}
+
+func incomingHeaders(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return headers
+	}
+	for key, values := range md {
+		if len(values) > 0 {
+			headers[key] = values[0]
+		}
+	}
+	return headers
+}
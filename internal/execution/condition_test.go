@@ -0,0 +1,228 @@
+package execution
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCompileCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		wantErr   bool
+	}{
+		{
+			name:      "syntax error",
+			condition: "org_id ==",
+			wantErr:   true,
+		},
+		{
+			name:      "unknown variable",
+			condition: `project_id == "project"`,
+			wantErr:   true,
+		},
+		{
+			name:      "no boolean",
+			condition: "org_id",
+			wantErr:   true,
+		},
+		{
+			name:      "org",
+			condition: `org_id == "org"`,
+		},
+		{
+			name:      "request field",
+			condition: `has(request.organization) && request.organization.org_id == "org"`,
+		},
+		{
+			name:      "headers",
+			condition: `"x-fraud-check" in headers`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompileCondition(tt.condition)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_conditionsMatch(t *testing.T) {
+	ctx := authz.SetCtxData(
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-custom", "value")),
+		authz.CtxData{UserID: "user", OrgID: "org"},
+	)
+	body := []byte(`{"request":{"organization":{"org_id":"org"},"age":21},"userID":"user"}`)
+
+	tests := []struct {
+		name       string
+		conditions []string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name: "no conditions",
+			want: true,
+		},
+		{
+			name:       "org, match",
+			conditions: []string{`org_id == "org"`},
+			want:       true,
+		},
+		{
+			name:       "user, no match",
+			conditions: []string{`user_id == "other"`},
+			want:       false,
+		},
+		{
+			name:       "request field, match",
+			conditions: []string{`request.organization.org_id == "org" && request.age > 18`},
+			want:       true,
+		},
+		{
+			name:       "payload, match",
+			conditions: []string{`payload.userID == user_id`},
+			want:       true,
+		},
+		{
+			name:       "headers, match",
+			conditions: []string{`headers["x-custom"] == "value"`},
+			want:       true,
+		},
+		{
+			name:       "missing response checked with has, no match",
+			conditions: []string{`has(response.user)`},
+			want:       false,
+		},
+		{
+			name:       "all conditions must match",
+			conditions: []string{`org_id == "org"`, `user_id == "other"`},
+			want:       false,
+		},
+		{
+			name:       "missing field, error",
+			conditions: []string{`request.missing == "value"`},
+			wantErr:    true,
+		},
+		{
+			name:       "no boolean on evaluation, error",
+			conditions: []string{`request.age`},
+			wantErr:    true,
+		},
+		{
+			name:       "cost limit exceeded, error",
+			conditions: []string{`[0,1,2,3,4,5,6,7,8,9].all(a, [0,1,2,3,4,5,6,7,8,9].all(b, [0,1,2,3,4,5,6,7,8,9].all(c, [0,1,2,3,4,5,6,7,8,9].all(d, [0,1,2,3,4,5,6,7,8,9].all(e, true)))))`},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conditionsMatch(ctx, tt.conditions, body)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type mockContextInfo struct {
+	*mockContextInfoRequest
+}
+
+func (c *mockContextInfo) SetHTTPResponseBody([]byte) error {
+	return nil
+}
+
+func (c *mockContextInfo) GetContent() interface{} {
+	return c.Request
+}
+
+func TestCallTargets_conditions(t *testing.T) {
+	var called atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called.Add(1)
+	}))
+	defer server.Close()
+
+	ctx := authz.SetCtxData(context.Background(), authz.CtxData{OrgID: "org"})
+	tests := []struct {
+		name       string
+		target     *mockTarget
+		wantCalled int32
+		wantErr    bool
+	}{
+		{
+			name: "condition matches, called",
+			target: &mockTarget{
+				TargetType: domain.TargetTypeWebhook,
+				Endpoint:   server.URL,
+				Timeout:    time.Minute,
+				Conditions: []string{`org_id == "org"`},
+			},
+			wantCalled: 1,
+		},
+		{
+			name: "condition does not match, skipped",
+			target: &mockTarget{
+				TargetType: domain.TargetTypeWebhook,
+				Endpoint:   server.URL,
+				Timeout:    time.Minute,
+				Conditions: []string{`org_id == "other"`},
+			},
+			wantCalled: 0,
+		},
+		{
+			name: "condition not evaluable, skipped",
+			target: &mockTarget{
+				TargetType: domain.TargetTypeWebhook,
+				Endpoint:   server.URL,
+				Timeout:    time.Minute,
+				Conditions: []string{`request.missing == "value"`},
+			},
+			wantCalled: 0,
+		},
+		{
+			name: "condition not evaluable with interrupt, error",
+			target: &mockTarget{
+				TargetType:       domain.TargetTypeWebhook,
+				Endpoint:         server.URL,
+				Timeout:          time.Minute,
+				InterruptOnError: true,
+				Conditions:       []string{`request.missing == "value"`},
+			},
+			wantCalled: 0,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called.Store(0)
+			_, err := CallTargets(ctx, []Target{tt.target}, &mockContextInfo{newMockContextInfoRequest("content")})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalled, called.Load())
+		})
+	}
+}
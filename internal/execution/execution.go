@@ -8,12 +8,18 @@ import (
 	"time"
 
 	"github.com/zitadel/logging"
+	"google.golang.org/grpc/status"
 
 	"github.com/zitadel/zitadel/internal/domain"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
+	"github.com/zitadel/zitadel/pkg/actions"
 )
 
+// maxResponseSize limits the size of the response body of a target
+const maxResponseSize = 1 << 20
+
 type ContextInfo interface {
 	GetHTTPRequestBody() []byte
 	GetContent() interface{}
@@ -21,11 +27,21 @@ type ContextInfo interface {
 }
 
 type Target interface {
+	GetExecutionID() string
 	GetTargetID() string
 	IsInterruptOnError() bool
 	GetEndpoint() string
 	GetTargetType() domain.TargetType
 	GetTimeout() time.Duration
+	GetSigningKeys() []string
+	GetHeaders() map[string]string
+	IsJWTAuthentication() bool
+	GetClientCertificate() (certificate, key []byte)
+	GetRootCAs() []byte
+	GetMaxConcurrency() uint16
+	GetCircuitBreaker() (failureThreshold uint16, openDuration time.Duration)
+	GetConditions() []string
+	GetMutableFields() []string
 }
 
 // CallTargets call a list of targets in order with handling of error and responses
@@ -38,6 +54,18 @@ func CallTargets(
 	defer span.EndWithError(err)
 
 	for _, target := range targets {
+		match, err := conditionsMatch(ctx, target.GetConditions(), info.GetHTTPRequestBody())
+		if err != nil {
+			logging.WithFields("target", target.GetTargetID(), "execution", target.GetExecutionID()).WithError(err).Info("unable to evaluate condition")
+			// a condition which can't be evaluated is handled like a failed call
+			if target.IsInterruptOnError() {
+				return nil, err
+			}
+			continue
+		}
+		if !match {
+			continue
+		}
 		// call the type of target
 		resp, err := CallTarget(ctx, target, info)
 		// handle error if interrupt is set
@@ -45,6 +73,19 @@ func CallTargets(
 			return nil, err
 		}
 		if len(resp) > 0 {
+			// the changes are checked before they are applied, so a rejected response doesn't change anything
+			changes, err := responseChanges(info.GetContent(), resp)
+			if err != nil {
+				return nil, err
+			}
+			err = checkMutableFields(changes, target.GetMutableFields())
+			logMutation(ctx, target, changes, err)
+			if err != nil {
+				if target.IsInterruptOnError() {
+					return nil, err
+				}
+				continue
+			}
 			// error in unmarshalling
 			if err := info.SetHTTPResponseBody(resp); err != nil {
 				return nil, err
@@ -54,6 +95,18 @@ func CallTargets(
 	return info.GetContent(), nil
 }
 
+// QueryExecutionTargetsForFunction returns the targets of the execution set for the function
+func QueryExecutionTargetsForFunction(ctx context.Context, queries Queries, function string) (_ []Target, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer span.EndWithError(err)
+
+	executionTargets, err := queries.TargetsByExecutionID(ctx, []string{exec_repo.ID(domain.ExecutionTypeFunction, function)})
+	if err != nil {
+		return nil, err
+	}
+	return executionTargetsToTargets(executionTargets), nil
+}
+
 type ContextInfoRequest interface {
 	GetHTTPRequestBody() []byte
 }
@@ -70,13 +123,17 @@ func CallTarget(
 	switch target.GetTargetType() {
 	// get request, ignore response and return request and error for handling in list of targets
 	case domain.TargetTypeWebhook:
-		return nil, webhook(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody())
+		return nil, webhook(ctx, target, info.GetHTTPRequestBody())
 	// get request, return response and error
-	case domain.TargetTypeCall:
-		return call(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody())
+	case domain.TargetTypeCall, domain.TargetTypeGRPC:
+		return call(ctx, target, info.GetHTTPRequestBody())
 	case domain.TargetTypeAsync:
+		// persist the call if an outbox is set, so it's retried on failure
+		if outbox != nil {
+			return nil, outbox.Enqueue(ctx, target, info.GetHTTPRequestBody())
+		}
 		go func(target Target, info ContextInfoRequest) {
-			if _, err := call(ctx, target.GetEndpoint(), target.GetTimeout(), info.GetHTTPRequestBody()); err != nil {
+			if _, err := call(ctx, target, info.GetHTTPRequestBody()); err != nil {
 				logging.WithFields("target", target.GetTargetID()).OnError(err).Info(err)
 			}
 		}(target, info)
@@ -87,36 +144,108 @@ func CallTarget(
 }
 
 // webhook call a webhook, ignore the response but return the errror
-func webhook(ctx context.Context, url string, timeout time.Duration, body []byte) error {
-	_, err := call(ctx, url, timeout, body)
+func webhook(ctx context.Context, target Target, body []byte) error {
+	_, err := call(ctx, target, body)
 	return err
 }
 
-// call function to do a post HTTP request to a desired url with timeout
-func call(ctx context.Context, url string, timeout time.Duration, body []byte) (_ []byte, err error) {
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+// Response is the response of a target
+type Response struct {
+	StatusCode int
+	Body       []byte
+	Took       time.Duration
+
+	// grpcStatus is the status returned by a gRPC target
+	grpcStatus *status.Status
+}
+
+// TestTarget calls the target with the body and returns the response independent of the status,
+// the call is not logged as it's not part of an execution.
+func TestTarget(ctx context.Context, target Target, body []byte) (*Response, error) {
+	return send(ctx, target, body)
+}
+
+// RemoveTarget closes the clients and resets the circuit breaker of the target held in this process,
+// it's called as soon as the target is changed or removed.
+func RemoveTarget(instanceID, targetID string) {
+	transports.remove(targetKey{instanceID, targetID})
+	grpcConns.remove(targetKey{instanceID, targetID})
+	guards.remove(targetKey{instanceID, targetID})
+}
+
+// call function to do a post HTTP request to the endpoint of the target with timeout, or to call the ExecutionTargetService of gRPC targets,
+// the body is signed with all signing keys and the authentication of the target is added
+func call(ctx context.Context, target Target, body []byte) (_ []byte, err error) {
+	release, err := acquire(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := send(ctx, target, body)
+	release(err == nil && isSuccess(resp.StatusCode))
+	logCall(ctx, target, resp, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for success between 200 and 299, redirect 300 to 399 is handled by the client, return error with statusCode >= 400
+	if isSuccess(resp.StatusCode) {
+		return resp.Body, nil
+	}
+	if resp.grpcStatus != nil {
+		return nil, grpcStatusToError(resp.grpcStatus)
+	}
+	return nil, zerrors.ThrowUnknown(nil, "EXEC-dra6yamk98", "Errors.Execution.Failed")
+}
+
+func isSuccess(statusCode int) bool {
+	return statusCode >= 200 && statusCode <= 299
+}
+
+func send(ctx context.Context, target Target, body []byte) (_ *Response, err error) {
+	ctx, cancel := context.WithTimeout(ctx, target.GetTimeout())
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() {
 		cancel()
 		span.EndWithError(err)
 	}()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if target.GetTargetType() == domain.TargetTypeGRPC {
+		return sendGRPC(ctx, target, body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.GetEndpoint(), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
+	if err := setAuthentication(ctx, req, target); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json")
+	if signingKeys := target.GetSigningKeys(); len(signingKeys) > 0 {
+		req.Header.Set(actions.SigningHeader, actions.ComputeSignatureHeader(time.Now(), body, signingKeys...))
+	}
 
-	client := http.DefaultClient
+	client, err := httpClient(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Check for success between 200 and 299, redirect 300 to 399 is handled by the client, return error with statusCode >= 400
-	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		return io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
 	}
-	return nil, zerrors.ThrowUnknown(nil, "EXEC-dra6yamk98", "Errors.Execution.Failed")
+	if len(respBody) > maxResponseSize {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "EXEC-Rb8tq", "Errors.Execution.ResponseTooLarge")
+	}
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+		Took:       time.Since(start),
+	}, nil
 }
@@ -1,6 +1,7 @@
 package execution
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/actions"
 )
 
 var _ Target = &mockTarget{}
@@ -25,8 +27,23 @@ type mockTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	SigningKey       string
+
+	Headers           map[string]string
+	JWTAuthentication bool
+	ClientCertificate []byte
+	ClientKey         []byte
+	RootCAs           []byte
+	MaxConcurrency    uint16
+	FailureThreshold  uint16
+	OpenDuration      time.Duration
+	Conditions        []string
+	MutableFields     []string
 }
 
+func (e *mockTarget) GetExecutionID() string {
+	return e.ExecutionID
+}
 func (e *mockTarget) GetTargetID() string {
 	return e.TargetID
 }
@@ -42,6 +59,37 @@ func (e *mockTarget) GetTargetType() domain.TargetType {
 func (e *mockTarget) GetTimeout() time.Duration {
 	return e.Timeout
 }
+func (e *mockTarget) GetSigningKeys() []string {
+	if e.SigningKey == "" {
+		return nil
+	}
+	return []string{e.SigningKey}
+}
+func (e *mockTarget) GetHeaders() map[string]string {
+	return e.Headers
+}
+func (e *mockTarget) IsJWTAuthentication() bool {
+	return e.JWTAuthentication
+}
+func (e *mockTarget) GetClientCertificate() ([]byte, []byte) {
+	return e.ClientCertificate, e.ClientKey
+}
+func (e *mockTarget) GetRootCAs() []byte {
+	return e.RootCAs
+}
+func (e *mockTarget) GetMaxConcurrency() uint16 {
+	return e.MaxConcurrency
+}
+func (e *mockTarget) GetCircuitBreaker() (uint16, time.Duration) {
+	return e.FailureThreshold, e.OpenDuration
+}
+func (e *mockTarget) GetConditions() []string {
+	return e.Conditions
+}
+
+func (e *mockTarget) GetMutableFields() []string {
+	return e.MutableFields
+}
 
 func Test_Call(t *testing.T) {
 	type args struct {
@@ -131,7 +179,7 @@ func Test_Call(t *testing.T) {
 
 func testCall(ctx context.Context, timeout time.Duration, body []byte) func(string) ([]byte, error) {
 	return func(url string) ([]byte, error) {
-		return call(ctx, url, timeout, body)
+		return call(ctx, &mockTarget{Endpoint: url, Timeout: timeout}, body)
 	}
 }
 
@@ -325,6 +373,25 @@ func Test_CallTarget(t *testing.T) {
 				body: []byte("{\"request\":\"content2\"}"),
 			},
 		},
+		{
+			"request response, too large",
+			args{
+				ctx:    context.Background(),
+				sleep:  time.Second,
+				method: http.MethodPost,
+				info:   newMockContextInfoRequest("content1"),
+				target: &mockTarget{
+					TargetType: domain.TargetTypeCall,
+					Timeout:    time.Minute,
+				},
+				body:       []byte("{\"request\":{\"request\":\"content1\"}}"),
+				respBody:   bytes.Repeat([]byte("a"), maxResponseSize+1),
+				statusCode: http.StatusOK,
+			},
+			res{
+				wantErr: true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -345,3 +412,66 @@ func Test_CallTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_CallTarget_signed(t *testing.T) {
+	info := newMockContextInfoRequest("content1")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		sentBody, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if err := actions.ValidatePayload(sentBody, r.Header.Get(actions.SigningHeader), "signingkey"); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err = io.WriteString(w, "{\"request\":\"content2\"}")
+		require.NoError(t, err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		target  *mockTarget
+		wantErr bool
+	}{
+		{
+			"not signed, error",
+			&mockTarget{
+				TargetType: domain.TargetTypeCall,
+				Timeout:    time.Minute,
+				Endpoint:   server.URL,
+			},
+			true,
+		},
+		{
+			"wrong signing key, error",
+			&mockTarget{
+				TargetType: domain.TargetTypeCall,
+				Timeout:    time.Minute,
+				Endpoint:   server.URL,
+				SigningKey: "other",
+			},
+			true,
+		},
+		{
+			"signed, ok",
+			&mockTarget{
+				TargetType: domain.TargetTypeCall,
+				Timeout:    time.Minute,
+				Endpoint:   server.URL,
+				SigningKey: "signingkey",
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CallTarget(context.Background(), tt.target, info)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package execution
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	target_pb "github.com/zitadel/zitadel/pkg/grpc/action/target/v1"
+)
+
+// GRPCServiceName is the service gRPC targets have to implement,
+// defined in proto/zitadel/action/target/v1/execution_target_service.proto
+const GRPCServiceName = "zitadel.action.target.v1.ExecutionTargetService"
+
+// grpcMethod is a method of the ExecutionTargetService
+type grpcMethod struct {
+	name string
+	// newRequest returns the message the body of the execution is unmarshalled into
+	newRequest  func() proto.Message
+	newResponse func() proto.Message
+	// responseBody returns the part of the response, which is handled like the body of the response of a REST target
+	responseBody func(response proto.Message) proto.Message
+}
+
+var (
+	grpcMethodHandleRequest = &grpcMethod{
+		name:        "/" + GRPCServiceName + "/HandleRequest",
+		newRequest:  func() proto.Message { return new(target_pb.HandleRequestRequest) },
+		newResponse: func() proto.Message { return new(target_pb.HandleRequestResponse) },
+		responseBody: func(response proto.Message) proto.Message {
+			return response.(*target_pb.HandleRequestResponse).GetRequest()
+		},
+	}
+	grpcMethodHandleResponse = &grpcMethod{
+		name:        "/" + GRPCServiceName + "/HandleResponse",
+		newRequest:  func() proto.Message { return new(target_pb.HandleResponseRequest) },
+		newResponse: func() proto.Message { return new(target_pb.HandleResponseResponse) },
+		responseBody: func(response proto.Message) proto.Message {
+			return response.(*target_pb.HandleResponseResponse).GetResponse()
+		},
+	}
+	grpcMethodHandleFunction = &grpcMethod{
+		name:         "/" + GRPCServiceName + "/HandleFunction",
+		newRequest:   func() proto.Message { return new(target_pb.HandleFunctionRequest) },
+		newResponse:  func() proto.Message { return new(target_pb.HandleFunctionResponse) },
+		responseBody: func(response proto.Message) proto.Message { return response },
+	}
+	grpcMethodHandleEvent = &grpcMethod{
+		name:         "/" + GRPCServiceName + "/HandleEvent",
+		newRequest:   func() proto.Message { return new(target_pb.HandleEventRequest) },
+		newResponse:  func() proto.Message { return new(emptypb.Empty) },
+		responseBody: func(response proto.Message) proto.Message { return response },
+	}
+)
+
+var grpcConns = newTargetClients(maxTargetClients, func(conn *grpc.ClientConn) {
+	err := conn.Close()
+	logging.OnError(err).Debug("unable to close connection to target")
+})
+
+// grpcMethodOfExecution returns the method of the ExecutionTargetService matching the type of the execution,
+// calls without execution, like tests of a target, are sent to HandleRequest.
+func grpcMethodOfExecution(executionID string) *grpcMethod {
+	executionType, _, _ := strings.Cut(executionID, "/")
+	switch executionType {
+	case domain.ExecutionTypeResponse.String():
+		return grpcMethodHandleResponse
+	case domain.ExecutionTypeFunction.String():
+		return grpcMethodHandleFunction
+	case domain.ExecutionTypeEvent.String():
+		return grpcMethodHandleEvent
+	default:
+		return grpcMethodHandleRequest
+	}
+}
+
+// sendGRPC calls the ExecutionTargetService of the target with the body mapped to the request message of the method,
+// a status other than OK results in a response with the matching HTTP status code.
+func sendGRPC(ctx context.Context, target Target, body []byte) (*Response, error) {
+	method := grpcMethodOfExecution(target.GetExecutionID())
+	payload := method.newRequest()
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, payload); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "EXEC-r5bm2xq8zt", "Errors.Execution.Failed")
+	}
+	md, err := grpcMetadata(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpcConn(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	out := method.newResponse()
+	start := time.Now()
+	err = conn.Invoke(metadata.NewOutgoingContext(ctx, md), method.name, payload, out)
+	took := time.Since(start)
+	// return the error of the context, so timeouts are handled the same way as for HTTP targets
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		s, ok := status.FromError(err)
+		if !ok {
+			return nil, err
+		}
+		return &Response{
+			StatusCode: runtime.HTTPStatusFromCode(s.Code()),
+			Body:       []byte(s.Message()),
+			Took:       took,
+			grpcStatus: s,
+		}, nil
+	}
+	respBody, err := protojson.Marshal(method.responseBody(out))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-z0wq7hd3lk", "Errors.Internal")
+	}
+	return &Response{
+		StatusCode: runtime.HTTPStatusFromCode(codes.OK),
+		Body:       respBody,
+		Took:       took,
+	}, nil
+}
+
+// grpcMetadata returns the custom headers and the JWT of the target as metadata.
+func grpcMetadata(ctx context.Context, target Target) (metadata.MD, error) {
+	md := metadata.New(target.GetHeaders())
+	if !target.IsJWTAuthentication() {
+		return md, nil
+	}
+	token, err := targetJWT(ctx, target.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	md.Set("authorization", oidc.PrefixBearer+token)
+	return md, nil
+}
+
+// grpcConn returns the connection to the endpoint of the target,
+// the connection is reused until the endpoint or TLS configuration of the target changes.
+// An endpoint with the scheme https is called over TLS, http is only meant for unencrypted connections in a private network.
+func grpcConn(ctx context.Context, target Target) (*grpc.ClientConn, error) {
+	endpoint, err := url.Parse(target.GetEndpoint())
+	if err != nil || endpoint.Host == "" {
+		return nil, zerrors.ThrowInternal(err, "EXEC-j3d8pv1qwn", "Errors.Target.InvalidURL")
+	}
+	certificate, key := target.GetClientCertificate()
+	rootCAs := target.GetRootCAs()
+
+	return grpcConns.get(
+		targetKey{authz.GetInstance(ctx).InstanceID(), target.GetTargetID()},
+		fingerprint([]byte(endpoint.Scheme+"://"+endpoint.Host), certificate, key, rootCAs),
+		func() (*grpc.ClientConn, error) {
+			creds := insecure.NewCredentials()
+			if endpoint.Scheme == "https" {
+				tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+				if len(certificate) > 0 || len(rootCAs) > 0 {
+					if tlsConfig, err = targetTLSConfig(certificate, key, rootCAs); err != nil {
+						return nil, err
+					}
+				}
+				creds = credentials.NewTLS(tlsConfig)
+			}
+			conn, err := grpc.NewClient(endpoint.Host, grpc.WithTransportCredentials(creds))
+			if err != nil {
+				return nil, zerrors.ThrowInternal(err, "EXEC-x7c4nf0gse", "Errors.Internal")
+			}
+			return conn, nil
+		},
+	)
+}
+
+// grpcStatusToError maps the status returned by a gRPC target to the matching error,
+// so the code is passed on to the caller of the execution.
+func grpcStatusToError(s *status.Status) error {
+	err := s.Err()
+	switch s.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return zerrors.ThrowInvalidArgument(err, "EXEC-6u1mwz9dqf", "Errors.Execution.Failed")
+	case codes.NotFound:
+		return zerrors.ThrowNotFound(err, "EXEC-2h7kq4xbvn", "Errors.Execution.Failed")
+	case codes.AlreadyExists:
+		return zerrors.ThrowAlreadyExists(err, "EXEC-9fjz3c6mtl", "Errors.Execution.Failed")
+	case codes.PermissionDenied:
+		return zerrors.ThrowPermissionDenied(err, "EXEC-o8yn1vg5rk", "Errors.Execution.Failed")
+	case codes.Unauthenticated:
+		return zerrors.ThrowUnauthenticated(err, "EXEC-e4tp8ld2wx", "Errors.Execution.Failed")
+	case codes.FailedPrecondition, codes.Aborted:
+		return zerrors.ThrowPreconditionFailed(err, "EXEC-k1bs6rq0zh", "Errors.Execution.Failed")
+	case codes.ResourceExhausted:
+		return zerrors.ThrowResourceExhausted(err, "EXEC-w5gx9mj3ca", "Errors.Execution.Failed")
+	case codes.Unimplemented:
+		return zerrors.ThrowUnimplemented(err, "EXEC-n3ru7fk1yp", "Errors.Execution.Failed")
+	case codes.Unavailable:
+		return zerrors.ThrowUnavailable(err, "EXEC-c8vl2oe6qd", "Errors.Execution.Failed")
+	case codes.DeadlineExceeded, codes.Canceled:
+		return zerrors.ThrowDeadlineExceeded(err, "EXEC-y2dn5tb9xm", "Errors.Execution.Failed")
+	case codes.Internal, codes.DataLoss:
+		return zerrors.ThrowInternal(err, "EXEC-q6hc0wz4ju", "Errors.Execution.Failed")
+	case codes.OK, codes.Unknown:
+		return zerrors.ThrowUnknown(err, "EXEC-f9ka3pm7ns", "Errors.Execution.Failed")
+	}
+	return zerrors.ThrowUnknown(err, "EXEC-t4xe8bq2gv", "Errors.Execution.Failed")
+}
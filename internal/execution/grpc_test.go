@@ -0,0 +1,227 @@
+package execution
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	target_pb "github.com/zitadel/zitadel/pkg/grpc/action/target/v1"
+)
+
+type grpcCall struct {
+	method  string
+	md      metadata.MD
+	payload proto.Message
+}
+
+// testGRPCServer starts a server handling any method of the ExecutionTargetService with the handler
+func testGRPCServer(t *testing.T, handler func(call *grpcCall) (proto.Message, error)) (endpoint string, calls chan *grpcCall) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	calls = make(chan *grpcCall, 1)
+	methods := make(map[string]*grpcMethod)
+	for _, method := range []*grpcMethod{grpcMethodHandleRequest, grpcMethodHandleResponse, grpcMethodHandleFunction, grpcMethodHandleEvent} {
+		methods[method.name] = method
+	}
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		name, _ := grpc.MethodFromServerStream(stream)
+		method, ok := methods[name]
+		if !ok {
+			return status.Error(codes.Unimplemented, name)
+		}
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		payload := method.newRequest()
+		if err := stream.RecvMsg(payload); err != nil {
+			return err
+		}
+		call := &grpcCall{method: name, md: md, payload: payload}
+		calls <- call
+		resp, err := handler(call)
+		if err != nil {
+			return err
+		}
+		return stream.SendMsg(resp)
+	}))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return "http://" + listener.Addr().String(), calls
+}
+
+func Test_call_grpc(t *testing.T) {
+	tests := []struct {
+		name        string
+		executionID string
+		body        string
+		headers     map[string]string
+		handler     func(call *grpcCall) (proto.Message, error)
+		wantMethod  *grpcMethod
+		wantPayload proto.Message
+		wantBody    string
+		wantErr     func(error) bool
+	}{
+		{
+			name:        "request, ok",
+			executionID: "request/zitadel.user.v2beta.UserService/AddHumanUser",
+			body:        `{"fullMethod":"/zitadel.user.v2beta.UserService/AddHumanUser","instanceID":"instance","userID":"user","request":{"username":"user1"}}`,
+			headers:     map[string]string{"x-api-key": "secret"},
+			handler: func(call *grpcCall) (proto.Message, error) {
+				request, err := structpb.NewStruct(map[string]any{"username": "user2"})
+				return &target_pb.HandleRequestResponse{Request: request}, err
+			},
+			wantMethod: grpcMethodHandleRequest,
+			wantPayload: &target_pb.HandleRequestRequest{
+				FullMethod: "/zitadel.user.v2beta.UserService/AddHumanUser",
+				InstanceId: "instance",
+				UserId:     "user",
+				Request:    mustStruct(t, map[string]any{"username": "user1"}),
+			},
+			wantBody: `{"username":"user2"}`,
+		},
+		{
+			name:        "response, ok",
+			executionID: "response/zitadel.user.v2beta.UserService/AddHumanUser",
+			body:        `{"fullMethod":"/zitadel.user.v2beta.UserService/AddHumanUser","request":{"username":"user1"},"response":{"userId":"user1"}}`,
+			handler: func(call *grpcCall) (proto.Message, error) {
+				return &target_pb.HandleResponseResponse{Response: call.payload.(*target_pb.HandleResponseRequest).GetResponse()}, nil
+			},
+			wantMethod: grpcMethodHandleResponse,
+			wantPayload: &target_pb.HandleResponseRequest{
+				FullMethod: "/zitadel.user.v2beta.UserService/AddHumanUser",
+				Request:    mustStruct(t, map[string]any{"username": "user1"}),
+				Response:   mustStruct(t, map[string]any{"userId": "user1"}),
+			},
+			wantBody: `{"userId":"user1"}`,
+		},
+		{
+			name:        "function, ok",
+			executionID: "function/preuserinfo",
+			body:        `{"function":"function/preuserinfo","user":{"id":"user1"},"user_grants":[{"projectId":"project1"}],"unknown":"ignored"}`,
+			handler: func(call *grpcCall) (proto.Message, error) {
+				return &target_pb.HandleFunctionResponse{
+					SetUserMetadata: []*target_pb.Metadata{{Key: "key", Value: []byte("value")}},
+					AppendClaims:    []*target_pb.Claim{{Key: "claim", Value: structpb.NewStringValue("value")}},
+					AppendLogClaims: []string{"log"},
+				}, nil
+			},
+			wantMethod: grpcMethodHandleFunction,
+			wantPayload: &target_pb.HandleFunctionRequest{
+				Function:   "function/preuserinfo",
+				User:       mustStruct(t, map[string]any{"id": "user1"}),
+				UserGrants: []*structpb.Struct{mustStruct(t, map[string]any{"projectId": "project1"})},
+			},
+			wantBody: `{"set_user_metadata":[{"key":"key","value":"dmFsdWU="}],"append_claims":[{"key":"claim","value":"value"}],"append_log_claims":["log"]}`,
+		},
+		{
+			name:        "event, ok",
+			executionID: "event/user.human.added",
+			body:        `{"aggregateID":"user1","aggregateType":"user","sequence":2,"event_type":"user.human.added","created_at":"2024-01-01T00:00:00+01:00","event_payload":{"userName":"user1"}}`,
+			handler: func(call *grpcCall) (proto.Message, error) {
+				return new(emptypb.Empty), nil
+			},
+			wantMethod: grpcMethodHandleEvent,
+			wantPayload: &target_pb.HandleEventRequest{
+				AggregateId:   "user1",
+				AggregateType: "user",
+				Sequence:      2,
+				EventType:     "user.human.added",
+				CreatedAt:     timestamppb.New(time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)),
+				EventPayload:  mustStruct(t, map[string]any{"userName": "user1"}),
+			},
+			wantBody: `{}`,
+		},
+		{
+			name:        "event, permission denied",
+			executionID: "event/user.human.added",
+			body:        `{"aggregateID":"user1"}`,
+			handler: func(call *grpcCall) (proto.Message, error) {
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			},
+			wantMethod:  grpcMethodHandleEvent,
+			wantPayload: &target_pb.HandleEventRequest{AggregateId: "user1"},
+			wantErr:     zerrors.IsPermissionDenied,
+		},
+		{
+			name:        "test without execution, invalid argument",
+			executionID: "",
+			body:        `{"request":{"username":"user1"}}`,
+			handler: func(call *grpcCall) (proto.Message, error) {
+				return nil, status.Error(codes.InvalidArgument, "invalid")
+			},
+			wantMethod:  grpcMethodHandleRequest,
+			wantPayload: &target_pb.HandleRequestRequest{Request: mustStruct(t, map[string]any{"username": "user1"})},
+			wantErr:     zerrors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, calls := testGRPCServer(t, tt.handler)
+			target := &mockTarget{
+				ExecutionID: tt.executionID,
+				TargetType:  domain.TargetTypeGRPC,
+				Endpoint:    endpoint,
+				Timeout:     time.Minute,
+				Headers:     tt.headers,
+			}
+			body, err := call(context.Background(), target, []byte(tt.body))
+			received := <-calls
+			assert.Equal(t, tt.wantMethod.name, received.method)
+			assert.True(t, proto.Equal(tt.wantPayload, received.payload), "unexpected payload: %v", received.payload)
+			for key, value := range tt.headers {
+				assert.Equal(t, []string{value}, received.md.Get(key))
+			}
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+func Test_call_grpc_invalidBody(t *testing.T) {
+	target := &mockTarget{
+		ExecutionID: "event/user.human.added",
+		TargetType:  domain.TargetTypeGRPC,
+		Endpoint:    "http://localhost:1",
+		Timeout:     time.Minute,
+	}
+	_, err := call(context.Background(), target, []byte(`{"sequence":"no number"}`))
+	assert.True(t, zerrors.IsErrorInvalidArgument(err))
+}
+
+func mustStruct(t *testing.T, fields map[string]any) *structpb.Struct {
+	s, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+	return s
+}
+
+func Test_call_grpc_timeout(t *testing.T) {
+	endpoint, _ := testGRPCServer(t, func(*grpcCall) (proto.Message, error) {
+		time.Sleep(time.Second)
+		return new(target_pb.HandleRequestResponse), nil
+	})
+	target := &mockTarget{
+		TargetType: domain.TargetTypeGRPC,
+		Endpoint:   endpoint,
+		Timeout:    100 * time.Millisecond,
+	}
+	_, err := call(context.Background(), target, []byte(`{}`))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,371 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	exec_repo "github.com/zitadel/zitadel/internal/repository/execution"
+)
+
+const (
+	EventHandlerProjectionTable = "projections.execution_handler"
+	EventHandlerUserID          = "EXECUTION"
+
+	eventGroupSeparator = "."
+	eventGroupSuffix    = ".*"
+)
+
+type HandlerConfig struct {
+	// MaxEventAge prevents calling targets for events older than the duration,
+	// e.g. the past events of an instance when the handler is started for the first time.
+	// If set to 0, all events are dispatched.
+	MaxEventAge time.Duration
+	// RefreshInterval defines how often the executions set on events are reloaded,
+	// the handler is restarted if the event types with executions changed.
+	// Events of types which get their first execution are only dispatched
+	// if they are newer than the last event handled before the restart.
+	RefreshInterval time.Duration
+}
+
+var runner *eventHandlerRunner
+
+// Register prepares the handler which calls the targets of the executions set on events,
+// the calls are persisted in the outbox and executed by it.
+func Register(
+	ctx context.Context,
+	executionsCustomConfig projection.CustomConfig,
+	handlerConfig *HandlerConfig,
+	eventTypes []string,
+	queries HandlerQueries,
+	outbox *Outbox,
+) {
+	registerGuardMetrics()
+	runner = &eventHandlerRunner{
+		config:        projection.ApplyCustomConfig(executionsCustomConfig),
+		handlerConfig: handlerConfig,
+		eventTypes:    eventTypes,
+		queries:       queries,
+		outbox:        outbox,
+	}
+}
+
+func Start(ctx context.Context) {
+	if runner == nil {
+		return
+	}
+	go runner.run(ctx)
+}
+
+type Queries interface {
+	TargetsByExecutionID(ctx context.Context, ids []string) (execution []*query.ExecutionTarget, err error)
+}
+
+type HandlerQueries interface {
+	Queries
+	EventExecutionIDs(ctx context.Context) (map[string][]string, error)
+}
+
+// eventExecutions are the IDs of the executions set on events per instance
+type eventExecutions map[string]map[string]struct{}
+
+func newEventExecutions(ids map[string][]string) eventExecutions {
+	executions := make(eventExecutions, len(ids))
+	for instanceID, instanceIDs := range ids {
+		executions[instanceID] = make(map[string]struct{}, len(instanceIDs))
+		for _, id := range instanceIDs {
+			executions[instanceID][id] = struct{}{}
+		}
+	}
+	return executions
+}
+
+// has returns if any of the execution IDs is set on the instance
+func (e eventExecutions) has(instanceID string, ids []string) bool {
+	for _, id := range ids {
+		if _, ok := e[instanceID][id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// eventTypes returns the event types with an execution set on any instance
+func (e eventExecutions) eventTypes(eventTypes []string) []string {
+	all := make(map[string]struct{})
+	for _, ids := range e {
+		for id := range ids {
+			all[id] = struct{}{}
+		}
+	}
+	handled := make([]string, 0)
+	for _, eventType := range eventTypes {
+		for _, id := range idsForEventType(eventType) {
+			if _, ok := all[id]; ok {
+				handled = append(handled, eventType)
+				break
+			}
+		}
+	}
+	return handled
+}
+
+// eventHandlerRunner runs the event handler for the event types with executions
+// and restarts it if the event types changed.
+type eventHandlerRunner struct {
+	config        handler.Config
+	handlerConfig *HandlerConfig
+	// eventTypes are all event types of the eventstore
+	eventTypes []string
+	queries    HandlerQueries
+	outbox     *Outbox
+
+	executions atomic.Pointer[eventExecutions]
+	handled    []string
+	cancel     context.CancelFunc
+}
+
+func (r *eventHandlerRunner) run(ctx context.Context) {
+	r.refresh(ctx)
+	if r.handlerConfig == nil || r.handlerConfig.RefreshInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.handlerConfig.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// refresh reloads the executions set on events and restarts the handler if the event types with executions changed.
+// The handler only registers reducers for these event types, so the other events are not read at all.
+// The restarted handler continues from the position of the last handled event,
+// so events of newly handled types which are older than that position are not dispatched.
+func (r *eventHandlerRunner) refresh(ctx context.Context) {
+	ids, err := r.queries.EventExecutionIDs(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query executions on events")
+		return
+	}
+	executions := newEventExecutions(ids)
+	r.executions.Store(&executions)
+
+	eventTypes := executions.eventTypes(r.eventTypes)
+	if r.cancel != nil && slices.Equal(eventTypes, r.handled) {
+		return
+	}
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+	r.handled = eventTypes
+	if len(eventTypes) == 0 {
+		return
+	}
+	var handlerCtx context.Context
+	handlerCtx, r.cancel = context.WithCancel(ctx)
+	NewEventHandler(handlerCtx, r.config, r.handlerConfig, eventTypes, r.queries, r.outbox, r.currentExecutions).Start(handlerCtx)
+}
+
+func (r *eventHandlerRunner) currentExecutions() eventExecutions {
+	if executions := r.executions.Load(); executions != nil {
+		return *executions
+	}
+	return nil
+}
+
+type eventHandler struct {
+	queries     Queries
+	outbox      *Outbox
+	executions  func() eventExecutions
+	eventTypes  []string
+	maxEventAge time.Duration
+	now         func() time.Time
+}
+
+// NewEventHandler returns a handler which persists the calls to the targets of the executions set on events in the outbox.
+// The handler keeps track of the processed position like any other projection
+// and only processes an instance on one replica at a time.
+// The calls are persisted in the transaction of the handler, so they are only persisted once per event.
+func NewEventHandler(
+	ctx context.Context,
+	config handler.Config,
+	handlerConfig *HandlerConfig,
+	eventTypes []string,
+	queries Queries,
+	outbox *Outbox,
+	executions func() eventExecutions,
+) *handler.Handler {
+	h := &eventHandler{
+		queries:    queries,
+		outbox:     outbox,
+		executions: executions,
+		eventTypes: eventTypes,
+		now:        time.Now,
+	}
+	if handlerConfig != nil {
+		h.maxEventAge = handlerConfig.MaxEventAge
+	}
+	return handler.NewHandler(ctx, &config, h)
+}
+
+func (h *eventHandler) Name() string {
+	return EventHandlerProjectionTable
+}
+
+func (h *eventHandler) Reducers() []handler.AggregateReducer {
+	aggReducers := make([]handler.AggregateReducer, 0)
+	aggIndex := make(map[eventstore.AggregateType]int)
+	for _, eventType := range h.eventTypes {
+		aggregateType := eventstore.AggregateTypeFromEventType(eventstore.EventType(eventType))
+		if aggregateType == "" {
+			continue
+		}
+		i, ok := aggIndex[aggregateType]
+		if !ok {
+			i = len(aggReducers)
+			aggIndex[aggregateType] = i
+			aggReducers = append(aggReducers, handler.AggregateReducer{Aggregate: aggregateType})
+		}
+		aggReducers[i].EventReducers = append(aggReducers[i].EventReducers, handler.EventReducer{
+			Event:  eventstore.EventType(eventType),
+			Reduce: h.reduce,
+		})
+	}
+	return aggReducers
+}
+
+func (h *eventHandler) reduce(e eventstore.Event) (*handler.Statement, error) {
+	if h.maxEventAge > 0 && e.CreatedAt().Before(h.now().Add(-h.maxEventAge)) {
+		return handler.NewNoOpStatement(e), nil
+	}
+	ids := idsForEventType(string(e.Type()))
+	// the targets are only queried if an execution is set for the event on the instance
+	if !h.executions().has(e.Aggregate().InstanceID, ids) {
+		return handler.NewNoOpStatement(e), nil
+	}
+	return handler.NewStatement(e, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(e.Aggregate())
+		targets, err := h.queries.TargetsByExecutionID(ctx, ids)
+		if err != nil {
+			return err
+		}
+		return h.enqueue(ctx, ex, executionTargetsToTargets(targets), ContextInfoFromEvent(e))
+	}), nil
+}
+
+// enqueue persists the calls to the targets with matching conditions in the outbox,
+// the targets are called by the outbox independent of their type.
+// A target whose conditions can't be evaluated is skipped, as the event would fail on every retry
+// and block the handler for the whole instance.
+func (h *eventHandler) enqueue(ctx context.Context, ex handler.Executer, targets []Target, info ContextInfo) error {
+	body := info.GetHTTPRequestBody()
+	for _, target := range targets {
+		match, err := conditionsMatch(ctx, target.GetConditions(), body)
+		if err != nil {
+			logging.WithFields("target", target.GetTargetID(), "execution", target.GetExecutionID()).WithError(err).Warn("unable to evaluate condition, target skipped")
+			continue
+		}
+		if !match {
+			continue
+		}
+		if err := h.outbox.enqueue(ctx, ex.Exec, target, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandlerContext returns the context used to query and call the targets of an event
+func HandlerContext(aggregate *eventstore.Aggregate) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), aggregate.InstanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: EventHandlerUserID, OrgID: aggregate.ResourceOwner})
+}
+
+// idsForEventType returns the execution IDs matching the event type ordered from the most to the least specific, for example:
+// [ "event/user.human.added",
+// "event/user.human.*",
+// "event/user.*",
+// "event" ]
+func idsForEventType(eventType string) []string {
+	ids := []string{exec_repo.ID(domain.ExecutionTypeEvent, eventType)}
+	parts := strings.Split(eventType, eventGroupSeparator)
+	for i := len(parts) - 1; i > 0; i-- {
+		ids = append(ids, exec_repo.ID(domain.ExecutionTypeEvent, strings.Join(parts[:i], eventGroupSeparator)+eventGroupSuffix))
+	}
+	return append(ids, exec_repo.IDAll(domain.ExecutionTypeEvent))
+}
+
+func executionTargetsToTargets(executionTargets []*query.ExecutionTarget) []Target {
+	targets := make([]Target, len(executionTargets))
+	for i, target := range executionTargets {
+		targets[i] = target
+	}
+	return targets
+}
+
+var _ ContextInfo = &ContextInfoEvent{}
+
+type ContextInfoEvent struct {
+	AggregateID   string          `json:"aggregateID,omitempty"`
+	AggregateType string          `json:"aggregateType,omitempty"`
+	ResourceOwner string          `json:"resourceOwner,omitempty"`
+	InstanceID    string          `json:"instanceID,omitempty"`
+	Version       string          `json:"version,omitempty"`
+	Sequence      uint64          `json:"sequence,omitempty"`
+	EventType     string          `json:"event_type,omitempty"`
+	CreatedAt     time.Time       `json:"created_at,omitempty"`
+	UserID        string          `json:"userID,omitempty"`
+	EventPayload  json.RawMessage `json:"event_payload,omitempty"`
+}
+
+func ContextInfoFromEvent(e eventstore.Event) *ContextInfoEvent {
+	info := &ContextInfoEvent{
+		AggregateID:   e.Aggregate().ID,
+		AggregateType: string(e.Aggregate().Type),
+		ResourceOwner: e.Aggregate().ResourceOwner,
+		InstanceID:    e.Aggregate().InstanceID,
+		Version:       string(e.Aggregate().Version),
+		Sequence:      e.Sequence(),
+		EventType:     string(e.Type()),
+		CreatedAt:     e.CreatedAt(),
+		UserID:        e.Creator(),
+	}
+	if data := e.DataAsBytes(); json.Valid(data) {
+		info.EventPayload = data
+	}
+	return info
+}
+
+func (c *ContextInfoEvent) GetHTTPRequestBody() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// SetHTTPResponseBody ignores the response, as events can not be changed
+func (c *ContextInfoEvent) SetHTTPResponseBody([]byte) error {
+	return nil
+}
+
+func (c *ContextInfoEvent) GetContent() interface{} {
+	return c.EventPayload
+}
@@ -0,0 +1,336 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/target"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_idsForEventType(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		want      []string
+	}{
+		{
+			"single part",
+			"event",
+			[]string{"event/event", "event"},
+		},
+		{
+			"multiple parts",
+			"user.human.added",
+			[]string{"event/user.human.added", "event/user.human.*", "event/user.*", "event"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, idsForEventType(tt.eventType))
+		})
+	}
+}
+
+type mockQueries struct {
+	ids     []string
+	targets []*query.ExecutionTarget
+	err     error
+}
+
+func (q *mockQueries) TargetsByExecutionID(ctx context.Context, ids []string) ([]*query.ExecutionTarget, error) {
+	if authz.GetInstance(ctx).InstanceID() != "instance" {
+		return nil, nil
+	}
+	q.ids = ids
+	return q.targets, q.err
+}
+
+func Test_eventHandler_reduce(t *testing.T) {
+	now := time.Now()
+	event := &repository.Event{
+		AggregateID:   "user",
+		AggregateType: "user",
+		ResourceOwner: sql.NullString{String: "org", Valid: true},
+		InstanceID:    "instance",
+		Version:       "v2",
+		Seq:           1,
+		Typ:           "user.human.added",
+		CreationDate:  now,
+		EditorUser:    "editor",
+		Data:          []byte(`{"userName":"username"}`),
+	}
+	body := ContextInfoFromEvent(event).GetHTTPRequestBody()
+	allIDs := []string{"event/user.human.added", "event/user.human.*", "event/user.*", "event"}
+
+	type fields struct {
+		targets     []*query.ExecutionTarget
+		err         error
+		executions  eventExecutions
+		maxEventAge time.Duration
+	}
+	type res struct {
+		noOp    bool
+		ids     []string
+		wantErr bool
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		expectations func() *mock.SQLMock
+		ids          []string
+		res          res
+	}{
+		{
+			"event too old, ignored",
+			fields{
+				executions:  newEventExecutions(map[string][]string{"instance": {"event"}}),
+				maxEventAge: time.Nanosecond,
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			nil,
+			res{
+				noOp: true,
+			},
+		},
+		{
+			"no execution on instance, ignored",
+			fields{
+				executions: newEventExecutions(map[string][]string{"other": {"event"}}),
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			nil,
+			res{
+				noOp: true,
+			},
+		},
+		{
+			"query error",
+			fields{
+				executions: newEventExecutions(map[string][]string{"instance": {"event/user.*"}}),
+				err:        io.ErrClosedPipe,
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			nil,
+			res{
+				ids:     allIDs,
+				wantErr: true,
+			},
+		},
+		{
+			"no targets",
+			fields{
+				executions: newEventExecutions(map[string][]string{"instance": {"event/user.*"}}),
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			nil,
+			res{
+				ids: allIDs,
+			},
+		},
+		{
+			"targets, enqueued",
+			fields{
+				executions: newEventExecutions(map[string][]string{"instance": {"event/user.human.added"}}),
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "event/user.human.added", TargetID: "target1", TargetType: domain.TargetTypeWebhook},
+					{ExecutionID: "event/user.human.added", TargetID: "target2", TargetType: domain.TargetTypeCall, InterruptOnError: true},
+				},
+				maxEventAge: time.Minute,
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(enqueueOutboxStmt,
+						mock.WithExecArgs("instance", "id1", "event/user.human.added", "target1", body, now),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(enqueueOutboxStmt,
+						mock.WithExecArgs("instance", "id2", "event/user.human.added", "target2", body, now),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+			[]string{"id1", "id2"},
+			res{
+				ids: allIDs,
+			},
+		},
+		{
+			"condition not matching, ignored",
+			fields{
+				executions: newEventExecutions(map[string][]string{"instance": {"event"}}),
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "event", TargetID: "target1", TargetType: domain.TargetTypeWebhook, Conditions: []string{`payload.resourceOwner == "other"`}},
+					{ExecutionID: "event", TargetID: "target2", TargetType: domain.TargetTypeWebhook, Conditions: []string{`payload.resourceOwner == "org"`}},
+				},
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(enqueueOutboxStmt,
+						mock.WithExecArgs("instance", "id1", "event", "target2", body, now),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+			[]string{"id1"},
+			res{
+				ids: allIDs,
+			},
+		},
+		{
+			"condition invalid, skipped",
+			fields{
+				executions: newEventExecutions(map[string][]string{"instance": {"event"}}),
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "event", TargetID: "target1", TargetType: domain.TargetTypeCall, InterruptOnError: true, Conditions: []string{`payload.resourceOwner ==`}},
+					{ExecutionID: "event", TargetID: "target2", TargetType: domain.TargetTypeWebhook},
+				},
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(enqueueOutboxStmt,
+						mock.WithExecArgs("instance", "id1", "event", "target2", body, now),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+			[]string{"id1"},
+			res{
+				ids: allIDs,
+			},
+		},
+		{
+			"enqueue failed, error",
+			fields{
+				executions: newEventExecutions(map[string][]string{"instance": {"event"}}),
+				targets: []*query.ExecutionTarget{
+					{ExecutionID: "event", TargetID: "target1", TargetType: domain.TargetTypeWebhook},
+				},
+			},
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(enqueueOutboxStmt,
+						mock.WithExecArgs("instance", "id1", "event", "target1", body, now),
+						mock.WithExecErr(io.ErrClosedPipe),
+					),
+				)
+			},
+			[]string{"id1"},
+			res{
+				ids:     allIDs,
+				wantErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &mockQueries{targets: tt.fields.targets, err: tt.fields.err}
+			dbMock := tt.expectations()
+			h := &eventHandler{
+				queries: queries,
+				outbox: &Outbox{
+					idGenerator: id_mock.NewIDGeneratorExpectIDs(t, tt.ids...),
+					now: func() time.Time {
+						return now
+					},
+				},
+				executions: func() eventExecutions {
+					return tt.fields.executions
+				},
+				maxEventAge: tt.fields.maxEventAge,
+				now: func() time.Time {
+					return now.Add(time.Second)
+				},
+			}
+			stmt, err := h.reduce(event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.noOp, stmt.Execute == nil)
+			if stmt.Execute != nil {
+				err = stmt.Execute(dbMock.DB, EventHandlerProjectionTable)
+			}
+			if tt.res.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.res.ids, queries.ids)
+			dbMock.Assert(t)
+		})
+	}
+}
+
+func Test_eventExecutions_eventTypes(t *testing.T) {
+	eventTypes := []string{"user.human.added", "user.human.changed", "user.machine.added", "org.added", "instance.added"}
+	tests := []struct {
+		name string
+		ids  map[string][]string
+		want []string
+	}{
+		{
+			"no executions",
+			nil,
+			[]string{},
+		},
+		{
+			"event types of all instances",
+			map[string][]string{
+				"instance1": {"event/user.human.added"},
+				"instance2": {"event/org.*"},
+			},
+			[]string{"user.human.added", "org.added"},
+		},
+		{
+			"group",
+			map[string][]string{
+				"instance1": {"event/user.human.*"},
+			},
+			[]string{"user.human.added", "user.human.changed"},
+		},
+		{
+			"all",
+			map[string][]string{
+				"instance1": {"event"},
+			},
+			eventTypes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newEventExecutions(tt.ids).eventTypes(eventTypes))
+		})
+	}
+}
+
+func Test_eventHandler_Reducers(t *testing.T) {
+	h := &eventHandler{eventTypes: []string{string(target.AddedEventType), "unknown.added", string(user.HumanAddedType), string(target.RemovedEventType)}}
+	reducers := h.Reducers()
+	require.Len(t, reducers, 2)
+	assert.Equal(t, eventstore.AggregateType(target.AggregateType), reducers[0].Aggregate)
+	require.Len(t, reducers[0].EventReducers, 2)
+	assert.Equal(t, target.AddedEventType, reducers[0].EventReducers[0].Event)
+	assert.Equal(t, target.RemovedEventType, reducers[0].EventReducers[1].Event)
+	assert.Equal(t, eventstore.AggregateType(user.AggregateType), reducers[1].Aggregate)
+	require.Len(t, reducers[1].EventReducers, 1)
+	assert.Equal(t, user.HumanAddedType, reducers[1].EventReducers[0].Event)
+}
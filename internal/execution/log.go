@@ -0,0 +1,48 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+var logstoreService *logstore.Service[*record.TargetLog]
+
+// SetLogstoreService sets the service used to log the calls to targets
+func SetLogstoreService(svc *logstore.Service[*record.TargetLog]) {
+	logstoreService = svc
+}
+
+// logCall logs the call to the target with the outcome and duration
+func logCall(ctx context.Context, target Target, resp *Response, err error) {
+	if logstoreService == nil || !logstoreService.Enabled() {
+		return
+	}
+	r := &record.TargetLog{
+		LogDate:     time.Now(),
+		InstanceID:  authz.GetInstance(ctx).InstanceID(),
+		ExecutionID: target.GetExecutionID(),
+		TargetID:    target.GetTargetID(),
+		Outcome:     record.TargetOutcomeSuccess,
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		r.Outcome = record.TargetOutcomeTimeout
+		r.Took = target.GetTimeout()
+		r.Error = err.Error()
+	case err != nil:
+		r.Outcome = record.TargetOutcomeFailed
+		r.Error = err.Error()
+	default:
+		r.Took = resp.Took
+		r.StatusCode = resp.StatusCode
+		if !isSuccess(resp.StatusCode) {
+			r.Outcome = record.TargetOutcomeFailed
+		}
+	}
+	logstoreService.Handle(ctx, r)
+}
@@ -0,0 +1,110 @@
+package execution
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+func Test_call_log(t *testing.T) {
+	type want struct {
+		outcome    record.TargetOutcome
+		statusCode int
+		err        bool
+	}
+	tests := []struct {
+		name       string
+		timeout    time.Duration
+		sleep      time.Duration
+		statusCode int
+		want       want
+	}{
+		{
+			name:       "success",
+			timeout:    time.Minute,
+			statusCode: http.StatusOK,
+			want: want{
+				outcome:    record.TargetOutcomeSuccess,
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "failed",
+			timeout:    time.Minute,
+			statusCode: http.StatusBadRequest,
+			want: want{
+				outcome:    record.TargetOutcomeFailed,
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:       "timeout",
+			timeout:    100 * time.Millisecond,
+			sleep:      time.Second,
+			statusCode: http.StatusOK,
+			want: want{
+				outcome: record.TargetOutcomeTimeout,
+				err:     true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs []*record.TargetLog
+			emitter, err := logstore.NewEmitter[*record.TargetLog](context.Background(), clock.NewMock(), &logstore.EmitterConfig{Enabled: true},
+				logstore.LogEmitterFunc[*record.TargetLog](func(_ context.Context, bulk []*record.TargetLog) error {
+					logs = append(logs, bulk...)
+					return nil
+				}),
+			)
+			require.NoError(t, err)
+			SetLogstoreService(logstore.New[*record.TargetLog](nil, nil, emitter))
+			t.Cleanup(func() { SetLogstoreService(nil) })
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tt.sleep)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			_, err = call(authz.NewMockContext("instance", "", ""), &mockTarget{
+				ExecutionID: "execution",
+				TargetID:    "target",
+				Endpoint:    server.URL,
+				Timeout:     tt.timeout,
+			}, []byte("{}"))
+			if tt.want.err {
+				assert.Error(t, err)
+			}
+			require.Len(t, logs, 1)
+			assert.Equal(t, "instance", logs[0].InstanceID)
+			assert.Equal(t, "execution", logs[0].ExecutionID)
+			assert.Equal(t, "target", logs[0].TargetID)
+			assert.Equal(t, tt.want.outcome, logs[0].Outcome)
+			assert.Equal(t, tt.want.statusCode, logs[0].StatusCode)
+		})
+	}
+}
+
+func TestTestTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	resp, err := TestTarget(context.Background(), &mockTarget{Endpoint: server.URL, Timeout: time.Minute}, []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "invalid\n", string(resp.Body))
+	assert.Positive(t, resp.Took)
+}
@@ -0,0 +1,150 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// mutableFieldPath is a path of field names separated by dots, e.g. `user.profile.given_name`
+var mutableFieldPath = regexp.MustCompile(`^[a-zA-Z0-9_]+(\.[a-zA-Z0-9_]+)*$`)
+
+// ValidateMutableFields checks the paths of the fields, which the targets of an execution are allowed to change.
+// The paths use the JSON names of the fields sent to the targets.
+func ValidateMutableFields(paths []string) error {
+	for _, path := range paths {
+		if !mutableFieldPath.MatchString(path) {
+			return zerrors.ThrowInvalidArgument(nil, "EXEC-3m0cuz8rwe", "Errors.Execution.InvalidMutableField")
+		}
+	}
+	return nil
+}
+
+// responseChanges returns the fields the response of a target changes on the current content.
+// As the response is unmarshalled into the content, fields missing in the response are not changed
+// and objects are compared field by field, all other values are replaced.
+func responseChanges(content interface{}, response []byte) ([]*record.FieldChange, error) {
+	var newValue any
+	decoder := json.NewDecoder(bytes.NewReader(response))
+	decoder.UseNumber()
+	if err := decoder.Decode(&newValue); err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "EXEC-w0yq5r1kbs", "Errors.Execution.InvalidResponse")
+	}
+	current, err := json.Marshal(content)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-7sd0ijq3hf", "Errors.Internal")
+	}
+	var oldValue any
+	decoder = json.NewDecoder(bytes.NewReader(current))
+	decoder.UseNumber()
+	if err := decoder.Decode(&oldValue); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-b4i9nl6vwo", "Errors.Internal")
+	}
+	return appendChanges(nil, "", oldValue, newValue), nil
+}
+
+func appendChanges(changes []*record.FieldChange, path string, oldValue, newValue any) []*record.FieldChange {
+	if newObject, ok := newValue.(map[string]any); ok {
+		oldObject, _ := oldValue.(map[string]any)
+		keys := make([]string, 0, len(newObject))
+		for key := range newObject {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			changes = appendChanges(changes, joinFieldPath(path, key), oldObject[key], newObject[key])
+		}
+		return changes
+	}
+	if reflect.DeepEqual(oldValue, newValue) {
+		return changes
+	}
+	return append(changes, &record.FieldChange{
+		Path:     path,
+		OldValue: marshalFieldValue(oldValue),
+		NewValue: marshalFieldValue(newValue),
+	})
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func marshalFieldValue(value any) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// checkMutableFields returns an error if a change is not part of the mutable fields,
+// changes of nested fields are allowed if the parent field is mutable.
+// If no mutable fields are defined, all fields can be changed.
+func checkMutableFields(changes []*record.FieldChange, mutableFields []string) error {
+	if len(mutableFields) == 0 {
+		return nil
+	}
+	rejected := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if !isMutableField(change.Path, mutableFields) {
+			rejected = append(rejected, change.Path)
+		}
+	}
+	if len(rejected) == 0 {
+		return nil
+	}
+	return zerrors.ThrowPreconditionFailed(
+		errors.New("fields not mutable: "+strings.Join(rejected, ", ")),
+		"EXEC-f6ke0tz2qa",
+		"Errors.Execution.FieldNotMutable",
+	)
+}
+
+func isMutableField(path string, mutableFields []string) bool {
+	for _, field := range mutableFields {
+		if path == field || strings.HasPrefix(path, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// logMutation logs the changes of the response of the target and if they were rejected
+func logMutation(ctx context.Context, target Target, changes []*record.FieldChange, err error) {
+	if logstoreService == nil || !logstoreService.Enabled() {
+		return
+	}
+	if len(changes) == 0 && err == nil {
+		return
+	}
+	r := &record.TargetLog{
+		LogDate:     time.Now(),
+		InstanceID:  authz.GetInstance(ctx).InstanceID(),
+		ExecutionID: target.GetExecutionID(),
+		TargetID:    target.GetTargetID(),
+		Outcome:     record.TargetOutcomeMutated,
+		Changes:     changes,
+	}
+	if err != nil {
+		r.Outcome = record.TargetOutcomeRejected
+		r.Error = err.Error()
+	}
+	logstoreService.Handle(ctx, r)
+}
@@ -0,0 +1,304 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestValidateMutableFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{
+			name: "no paths",
+		},
+		{
+			name:  "valid paths",
+			paths: []string{"user", "user.profile.given_name"},
+		},
+		{
+			name:    "empty path, error",
+			paths:   []string{""},
+			wantErr: true,
+		},
+		{
+			name:    "empty segment, error",
+			paths:   []string{"user..profile"},
+			wantErr: true,
+		},
+		{
+			name:    "wildcard, error",
+			paths:   []string{"user.*"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMutableFields(tt.paths)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+type mutableProfile struct {
+	GivenName  string `json:"given_name,omitempty"`
+	FamilyName string `json:"family_name,omitempty"`
+}
+
+type mutableRequest struct {
+	Email   string          `json:"email,omitempty"`
+	Roles   []string        `json:"roles,omitempty"`
+	Profile *mutableProfile `json:"profile,omitempty"`
+}
+
+func Test_responseChanges(t *testing.T) {
+	content := &mutableRequest{
+		Email:   "old@example.com",
+		Roles:   []string{"user"},
+		Profile: &mutableProfile{GivenName: "given"},
+	}
+	tests := []struct {
+		name     string
+		response string
+		want     []*record.FieldChange
+		wantErr  bool
+	}{
+		{
+			name:     "unchanged",
+			response: `{"email":"old@example.com","profile":{"given_name":"given"}}`,
+			want:     nil,
+		},
+		{
+			name:     "missing fields are not changed",
+			response: `{}`,
+			want:     nil,
+		},
+		{
+			name:     "changed field",
+			response: `{"email":"new@example.com"}`,
+			want: []*record.FieldChange{
+				{Path: "email", OldValue: json.RawMessage(`"old@example.com"`), NewValue: json.RawMessage(`"new@example.com"`)},
+			},
+		},
+		{
+			name:     "nested fields",
+			response: `{"profile":{"given_name":"given","family_name":"family"}}`,
+			want: []*record.FieldChange{
+				{Path: "profile.family_name", NewValue: json.RawMessage(`"family"`)},
+			},
+		},
+		{
+			name:     "array replaced",
+			response: `{"roles":["user","admin"]}`,
+			want: []*record.FieldChange{
+				{Path: "roles", OldValue: json.RawMessage(`["user"]`), NewValue: json.RawMessage(`["user","admin"]`)},
+			},
+		},
+		{
+			name:     "field removed",
+			response: `{"profile":null}`,
+			want: []*record.FieldChange{
+				{Path: "profile", OldValue: json.RawMessage(`{"given_name":"given"}`)},
+			},
+		},
+		{
+			name:     "invalid json, error",
+			response: `{"email":`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := responseChanges(content, []byte(tt.response))
+			if tt.wantErr {
+				assert.True(t, zerrors.IsPreconditionFailed(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_checkMutableFields(t *testing.T) {
+	changes := []*record.FieldChange{
+		{Path: "email"},
+		{Path: "profile.given_name"},
+	}
+	tests := []struct {
+		name          string
+		mutableFields []string
+		wantErr       bool
+	}{
+		{
+			name: "no mutable fields, all allowed",
+		},
+		{
+			name:          "all fields mutable",
+			mutableFields: []string{"email", "profile.given_name"},
+		},
+		{
+			name:          "parent field mutable",
+			mutableFields: []string{"email", "profile"},
+		},
+		{
+			name:          "field not mutable, error",
+			mutableFields: []string{"email"},
+			wantErr:       true,
+		},
+		{
+			name:          "prefix of field name, error",
+			mutableFields: []string{"email", "profile.given"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMutableFields(changes, tt.mutableFields)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsPreconditionFailed(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+var _ ContextInfo = &mockMutableContextInfo{}
+
+type mockMutableContextInfo struct {
+	Request *mutableRequest `json:"request"`
+}
+
+func (c *mockMutableContextInfo) GetHTTPRequestBody() []byte {
+	data, _ := json.Marshal(c)
+	return data
+}
+
+func (c *mockMutableContextInfo) SetHTTPResponseBody(resp []byte) error {
+	return json.Unmarshal(resp, c.Request)
+}
+
+func (c *mockMutableContextInfo) GetContent() interface{} {
+	return c.Request
+}
+
+func TestCallTargets_mutableFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"email":"new@example.com","profile":{"given_name":"changed"}}`))
+	}))
+	defer server.Close()
+
+	type want struct {
+		request *mutableRequest
+		outcome record.TargetOutcome
+		changes int
+		err     bool
+	}
+	tests := []struct {
+		name             string
+		mutableFields    []string
+		interruptOnError bool
+		want             want
+	}{
+		{
+			name: "no mutable fields, applied",
+			want: want{
+				request: &mutableRequest{Email: "new@example.com", Profile: &mutableProfile{GivenName: "changed"}},
+				outcome: record.TargetOutcomeMutated,
+				changes: 2,
+			},
+		},
+		{
+			name:          "mutable fields, applied",
+			mutableFields: []string{"email", "profile"},
+			want: want{
+				request: &mutableRequest{Email: "new@example.com", Profile: &mutableProfile{GivenName: "changed"}},
+				outcome: record.TargetOutcomeMutated,
+				changes: 2,
+			},
+		},
+		{
+			name:          "field not mutable, ignored",
+			mutableFields: []string{"email"},
+			want: want{
+				request: &mutableRequest{Email: "old@example.com", Profile: &mutableProfile{GivenName: "given"}},
+				outcome: record.TargetOutcomeRejected,
+				changes: 2,
+			},
+		},
+		{
+			name:             "field not mutable with interrupt, error",
+			mutableFields:    []string{"email"},
+			interruptOnError: true,
+			want: want{
+				request: &mutableRequest{Email: "old@example.com", Profile: &mutableProfile{GivenName: "given"}},
+				outcome: record.TargetOutcomeRejected,
+				changes: 2,
+				err:     true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs []*record.TargetLog
+			emitter, err := logstore.NewEmitter[*record.TargetLog](context.Background(), clock.NewMock(), &logstore.EmitterConfig{Enabled: true},
+				logstore.LogEmitterFunc[*record.TargetLog](func(_ context.Context, bulk []*record.TargetLog) error {
+					logs = append(logs, bulk...)
+					return nil
+				}),
+			)
+			require.NoError(t, err)
+			SetLogstoreService(logstore.New[*record.TargetLog](nil, nil, emitter))
+			t.Cleanup(func() { SetLogstoreService(nil) })
+
+			info := &mockMutableContextInfo{
+				Request: &mutableRequest{Email: "old@example.com", Profile: &mutableProfile{GivenName: "given"}},
+			}
+			_, err = CallTargets(authz.NewMockContext("instance", "", ""), []Target{
+				&mockTarget{
+					ExecutionID:      "execution",
+					TargetID:         "target",
+					TargetType:       domain.TargetTypeCall,
+					Endpoint:         server.URL,
+					Timeout:          time.Minute,
+					InterruptOnError: tt.interruptOnError,
+					MutableFields:    tt.mutableFields,
+				},
+			}, info)
+			if tt.want.err {
+				assert.True(t, zerrors.IsPreconditionFailed(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want.request, info.Request)
+			// the call and the mutation are logged
+			require.Len(t, logs, 2)
+			assert.Equal(t, record.TargetOutcomeSuccess, logs[0].Outcome)
+			assert.Equal(t, tt.want.outcome, logs[1].Outcome)
+			assert.Equal(t, "execution", logs[1].ExecutionID)
+			assert.Equal(t, "target", logs[1].TargetID)
+			assert.Len(t, logs[1].Changes, tt.want.changes)
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	enqueueOutboxStmt = `INSERT INTO system.execution_outbox (instance_id, id, execution_id, target_id, payload, next_attempt_at, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $6)`
+	// claimOutboxStmt increments the attempts and leases the due calls before they are executed,
+	// so a call is not executed twice by multiple replicas and is retried if the process stops during the call.
+	claimOutboxStmt = `UPDATE system.execution_outbox SET attempts = attempts + 1, next_attempt_at = $1 + $2::INTERVAL` +
+		` WHERE dead_lettered_at IS NULL AND next_attempt_at <= $1 AND (instance_id, id) IN (` +
+		`SELECT instance_id, id FROM system.execution_outbox WHERE dead_lettered_at IS NULL AND next_attempt_at <= $1 ORDER BY next_attempt_at LIMIT $3)` +
+		` RETURNING instance_id, id, execution_id, target_id, payload, attempts`
+	// leaseOutboxStmt extends the lease of a claimed call to cover the timeout of its target,
+	// the call is not updated if it was claimed again in the meantime.
+	leaseOutboxStmt      = `UPDATE system.execution_outbox SET next_attempt_at = $1 WHERE instance_id = $2 AND id = $3 AND attempts = $4 AND dead_lettered_at IS NULL`
+	deleteOutboxStmt     = `DELETE FROM system.execution_outbox WHERE instance_id = $1 AND id = $2`
+	failOutboxStmt       = `UPDATE system.execution_outbox SET last_error = $1, next_attempt_at = $2 WHERE instance_id = $3 AND id = $4`
+	deadLetterOutboxStmt = `UPDATE system.execution_outbox SET last_error = $1, dead_lettered_at = $2 WHERE instance_id = $3 AND id = $4`
+	replayOutboxStmt     = `UPDATE system.execution_outbox SET attempts = 0, next_attempt_at = $1, last_error = NULL, dead_lettered_at = NULL WHERE instance_id = $2 AND id = $3 AND dead_lettered_at IS NOT NULL`
+	deadLettersStmt      = `SELECT id, target_id, payload, attempts, last_error, creation_date, dead_lettered_at, COUNT(*) OVER ()` +
+		` FROM system.execution_outbox WHERE instance_id = $1 AND dead_lettered_at IS NOT NULL AND ($2 = '' OR target_id = $2)` +
+		` ORDER BY dead_lettered_at DESC LIMIT $3 OFFSET $4`
+)
+
+type OutboxConfig struct {
+	// Interval defines how often the outbox is checked for due calls
+	Interval time.Duration
+	// BulkLimit is the maximum of calls executed per interval
+	BulkLimit uint16
+	// Lease is the time a claimed call is reserved for its execution in addition to the timeout of the target,
+	// so it's not claimed again while it is executed
+	Lease time.Duration
+	// MinBackoff is the delay until the first retry of a failed call, the delay is doubled for every further retry
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between two retries
+	MaxBackoff time.Duration
+	// DefaultMaxAttempts is used for targets without a maximum of attempts
+	DefaultMaxAttempts uint8
+}
+
+type OutboxQueries interface {
+	ExecutionTargetByID(ctx context.Context, id string) (*query.ExecutionTarget, error)
+}
+
+// Outbox persists the calls to async targets, so they are retried with an exponential backoff
+// until they succeed or the maximum of attempts of the target is reached.
+// Calls which reached the maximum of attempts are kept as dead letters and can be replayed.
+type Outbox struct {
+	client      *database.DB
+	queries     OutboxQueries
+	config      *OutboxConfig
+	idGenerator id.Generator
+	now         func() time.Time
+}
+
+func NewOutbox(client *database.DB, queries OutboxQueries, config *OutboxConfig) *Outbox {
+	return &Outbox{
+		client:      client,
+		queries:     queries,
+		config:      config,
+		idGenerator: id.SonyFlakeGenerator(),
+		now:         time.Now,
+	}
+}
+
+var outbox *Outbox
+
+// SetOutbox defines the outbox used for calls to async targets,
+// without an outbox the calls are executed once in the background.
+func SetOutbox(o *Outbox) {
+	outbox = o
+}
+
+// Start checks for due calls in the defined interval until the context is done
+func (o *Outbox) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(o.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := o.executeDue(ctx)
+				logging.OnError(err).Warn("unable to execute calls of execution outbox")
+			}
+		}
+	}()
+}
+
+// Enqueue persists the call to the target with the body, to be executed by the outbox
+func (o *Outbox) Enqueue(ctx context.Context, target Target, body []byte) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return o.enqueue(ctx, func(query string, args ...any) (sql.Result, error) {
+		return o.client.ExecContext(ctx, query, args...)
+	}, target, body)
+}
+
+// enqueue persists the call with the exec function,
+// which allows to persist the call in the transaction of the caller
+func (o *Outbox) enqueue(ctx context.Context, exec func(string, ...any) (sql.Result, error), target Target, body []byte) error {
+	id, err := o.idGenerator.Next()
+	if err != nil {
+		return err
+	}
+	_, err = exec(enqueueOutboxStmt,
+		authz.GetInstance(ctx).InstanceID(),
+		id,
+		target.GetExecutionID(),
+		target.GetTargetID(),
+		body,
+		o.now(),
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EXEC-7ogq1ksbnb", "Errors.Internal")
+	}
+	return nil
+}
+
+type outboxCall struct {
+	instanceID  string
+	id          string
+	executionID string
+	targetID    string
+	payload     []byte
+	attempts    uint8
+}
+
+func (o *Outbox) executeDue(ctx context.Context) (err error) {
+	calls := make([]*outboxCall, 0, o.config.BulkLimit)
+	err = o.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				call := new(outboxCall)
+				if err := rows.Scan(&call.instanceID, &call.id, &call.executionID, &call.targetID, &call.payload, &call.attempts); err != nil {
+					return err
+				}
+				calls = append(calls, call)
+			}
+			return rows.Err()
+		},
+		claimOutboxStmt,
+		o.now(),
+		o.config.Lease,
+		o.config.BulkLimit,
+	)
+	if err != nil {
+		return err
+	}
+	for _, call := range calls {
+		o.execute(authz.WithInstanceID(ctx, call.instanceID), call)
+	}
+	return nil
+}
+
+func (o *Outbox) execute(ctx context.Context, c *outboxCall) {
+	target, err := o.queries.ExecutionTargetByID(ctx, c.targetID)
+	if zerrors.IsNotFound(err) {
+		// the target was removed in the meantime, so the call can't be executed anymore
+		_, err = o.client.ExecContext(ctx, deleteOutboxStmt, c.instanceID, c.id)
+		logging.WithFields("instance", c.instanceID, "id", c.id).OnError(err).Warn("unable to remove call of removed target")
+		return
+	}
+	if err == nil {
+		leased, leaseErr := o.lease(ctx, c, target.Timeout)
+		if leaseErr != nil || !leased {
+			logging.WithFields("instance", c.instanceID, "id", c.id).OnError(leaseErr).Warn("unable to lease call")
+			return
+		}
+		// the target is queried without execution, the execution of the enqueued call is used for the log
+		target.ExecutionID = c.executionID
+		_, err = call(ctx, target, c.payload)
+	}
+	if err == nil {
+		_, err = o.client.ExecContext(ctx, deleteOutboxStmt, c.instanceID, c.id)
+		logging.WithFields("instance", c.instanceID, "id", c.id).OnError(err).Warn("unable to remove executed call")
+		return
+	}
+
+	maxAttempts := o.config.DefaultMaxAttempts
+	if target != nil && target.MaxAttempts > 0 {
+		maxAttempts = target.MaxAttempts
+	}
+	if c.attempts < maxAttempts {
+		_, err = o.client.ExecContext(ctx, failOutboxStmt, err.Error(), o.now().Add(o.backoff(c.attempts)), c.instanceID, c.id)
+		logging.WithFields("instance", c.instanceID, "id", c.id).OnError(err).Warn("unable to update failed call")
+		return
+	}
+	logging.WithFields("instance", c.instanceID, "target", c.targetID, "id", c.id).WithError(err).Info("call moved to dead letters")
+	_, err = o.client.ExecContext(ctx, deadLetterOutboxStmt, err.Error(), o.now(), c.instanceID, c.id)
+	logging.WithFields("instance", c.instanceID, "id", c.id).OnError(err).Warn("unable to move call to dead letters")
+}
+
+// lease extends the lease of the call to the timeout of the target,
+// as the claimed calls are executed one after another and the timeout of the target might exceed the lease of the claim.
+// It returns false if the call was claimed again in the meantime.
+func (o *Outbox) lease(ctx context.Context, c *outboxCall, timeout time.Duration) (bool, error) {
+	result, err := o.client.ExecContext(ctx, leaseOutboxStmt, o.now().Add(timeout+o.config.Lease), c.instanceID, c.id, c.attempts)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// backoff returns the delay until the next attempt after the failed attempt,
+// starting with the [OutboxConfig.MinBackoff] and doubled for every further attempt up to the [OutboxConfig.MaxBackoff]
+func (o *Outbox) backoff(attempts uint8) time.Duration {
+	backoff := o.config.MinBackoff
+	for i := uint8(1); i < attempts && backoff < o.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, o.config.MaxBackoff)
+}
+
+type DeadLetters struct {
+	Count       uint64
+	DeadLetters []*DeadLetter
+}
+
+type DeadLetter struct {
+	ID             string
+	TargetID       string
+	Payload        []byte
+	Attempts       uint8
+	LastError      string
+	CreationDate   time.Time
+	DeadLetteredAt time.Time
+}
+
+// DeadLetters returns the calls of the instance which reached the maximum of attempts,
+// optionally filtered by the target.
+func (o *Outbox) DeadLetters(ctx context.Context, targetID string, offset, limit uint64) (_ *DeadLetters, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	deadLetters := &DeadLetters{DeadLetters: make([]*DeadLetter, 0)}
+	err = o.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				var (
+					deadLetter = new(DeadLetter)
+					lastError  sql.NullString
+				)
+				if err := rows.Scan(
+					&deadLetter.ID,
+					&deadLetter.TargetID,
+					&deadLetter.Payload,
+					&deadLetter.Attempts,
+					&lastError,
+					&deadLetter.CreationDate,
+					&deadLetter.DeadLetteredAt,
+					&deadLetters.Count,
+				); err != nil {
+					return err
+				}
+				deadLetter.LastError = lastError.String
+				deadLetters.DeadLetters = append(deadLetters.DeadLetters, deadLetter)
+			}
+			return rows.Err()
+		},
+		deadLettersStmt,
+		authz.GetInstance(ctx).InstanceID(),
+		targetID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXEC-2v8ydt5x9w", "Errors.Internal")
+	}
+	return deadLetters, nil
+}
+
+// ReplayDeadLetter resets the attempts of the dead letter, so it's executed again by the outbox
+func (o *Outbox) ReplayDeadLetter(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "EXEC-0ftb9wd5mj", "Errors.IDMissing")
+	}
+	result, err := o.client.ExecContext(ctx, replayOutboxStmt, o.now(), authz.GetInstance(ctx).InstanceID(), id)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EXEC-r3kq8c6n1z", "Errors.Internal")
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return zerrors.ThrowNotFound(err, "EXEC-ym4j2xw8sd", "Errors.Execution.DeadLetterNotFound")
+	}
+	return nil
+}
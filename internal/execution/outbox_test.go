@@ -0,0 +1,268 @@
+package execution
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type mockOutboxQueries struct {
+	target *query.ExecutionTarget
+	err    error
+}
+
+func (q *mockOutboxQueries) ExecutionTargetByID(context.Context, string) (*query.ExecutionTarget, error) {
+	return q.target, q.err
+}
+
+func TestOutbox_execute(t *testing.T) {
+	now := time.Now()
+	type fields struct {
+		target *query.ExecutionTarget
+		err    error
+		status int
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		attempts     uint8
+		expectations func() *mock.SQLMock
+	}{
+		{
+			"target removed, call removed",
+			fields{
+				err:    zerrors.ThrowNotFound(nil, "QUERY-q2n6u9xw1t", "Errors.Target.NotFound"),
+				status: http.StatusOK,
+			},
+			1,
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(deleteOutboxStmt,
+						mock.WithExecArgs("instance", "id"),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+		},
+		{
+			"call ok, call removed",
+			fields{
+				status: http.StatusOK,
+			},
+			1,
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(leaseOutboxStmt,
+						mock.WithExecArgs(now.Add(time.Second+time.Minute), "instance", "id", uint8(1)),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(deleteOutboxStmt,
+						mock.WithExecArgs("instance", "id"),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+		},
+		{
+			"call leased by other replica, skipped",
+			fields{
+				status: http.StatusOK,
+			},
+			1,
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(leaseOutboxStmt,
+						mock.WithExecArgs(now.Add(time.Second+time.Minute), "instance", "id", uint8(1)),
+						mock.WithExecNoRowsAffected(),
+					),
+				)
+			},
+		},
+		{
+			"call failed, retried",
+			fields{
+				status: http.StatusInternalServerError,
+			},
+			1,
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(leaseOutboxStmt,
+						mock.WithExecArgs(now.Add(time.Second+time.Minute), "instance", "id", uint8(1)),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(failOutboxStmt,
+						mock.WithExecArgs("ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed", now.Add(time.Second), "instance", "id"),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+		},
+		{
+			"call failed, default max attempts reached",
+			fields{
+				status: http.StatusInternalServerError,
+			},
+			2,
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(leaseOutboxStmt,
+						mock.WithExecArgs(now.Add(time.Second+time.Minute), "instance", "id", uint8(2)),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(deadLetterOutboxStmt,
+						mock.WithExecArgs("ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed", now, "instance", "id"),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+		},
+		{
+			"call failed, max attempts of target reached",
+			fields{
+				target: &query.ExecutionTarget{MaxAttempts: 1},
+				status: http.StatusInternalServerError,
+			},
+			1,
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(leaseOutboxStmt,
+						mock.WithExecArgs(now.Add(time.Second+time.Minute), "instance", "id", uint8(1)),
+						mock.WithExecRowsAffected(1),
+					),
+					mock.ExcpectExec(deadLetterOutboxStmt,
+						mock.WithExecArgs("ID=EXEC-dra6yamk98 Message=Errors.Execution.Failed", now, "instance", "id"),
+						mock.WithExecRowsAffected(1),
+					),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.fields.status)
+			}))
+			defer server.Close()
+
+			var target *query.ExecutionTarget
+			if tt.fields.err == nil {
+				target = &query.ExecutionTarget{TargetType: domain.TargetTypeAsync, Endpoint: server.URL, Timeout: time.Second}
+				if tt.fields.target != nil {
+					target.MaxAttempts = tt.fields.target.MaxAttempts
+				}
+			}
+			dbMock := tt.expectations()
+			o := &Outbox{
+				client:  &database.DB{DB: dbMock.DB},
+				queries: &mockOutboxQueries{target: target, err: tt.fields.err},
+				config:  &OutboxConfig{DefaultMaxAttempts: 2, Lease: time.Minute, MinBackoff: time.Second, MaxBackoff: time.Hour},
+				now: func() time.Time {
+					return now
+				},
+			}
+			o.execute(authz.WithInstanceID(context.Background(), "instance"), &outboxCall{
+				instanceID: "instance",
+				id:         "id",
+				targetID:   "target",
+				payload:    []byte("{}"),
+				attempts:   tt.attempts,
+			})
+			dbMock.Assert(t)
+		})
+	}
+}
+
+func TestOutbox_backoff(t *testing.T) {
+	o := &Outbox{config: &OutboxConfig{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}}
+	tests := []struct {
+		attempts uint8
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 255, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(int(tt.attempts)), func(t *testing.T) {
+			assert.Equal(t, tt.want, o.backoff(tt.attempts))
+		})
+	}
+}
+
+func TestOutbox_ReplayDeadLetter(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		id           string
+		expectations func() *mock.SQLMock
+		wantErr      func(error) bool
+	}{
+		{
+			"id missing",
+			"",
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t)
+			},
+			zerrors.IsErrorInvalidArgument,
+		},
+		{
+			"not found",
+			"id",
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(replayOutboxStmt,
+						mock.WithExecArgs(now, "instance", "id"),
+						mock.WithExecNoRowsAffected(),
+					),
+				)
+			},
+			zerrors.IsNotFound,
+		},
+		{
+			"replayed",
+			"id",
+			func() *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExcpectExec(replayOutboxStmt,
+						mock.WithExecArgs(now, "instance", "id"),
+						mock.WithExecRowsAffected(driver.RowsAffected(1)),
+					),
+				)
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.expectations()
+			o := &Outbox{
+				client: &database.DB{DB: dbMock.DB},
+				now: func() time.Time {
+					return now
+				},
+			}
+			err := o.ReplayDeadLetter(authz.WithInstanceID(context.Background(), "instance"), tt.id)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			dbMock.Assert(t)
+		})
+	}
+}
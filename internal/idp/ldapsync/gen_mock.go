@@ -0,0 +1,4 @@
+package ldapsync
+
+//go:generate mockgen -package mock -destination ./mock/queries.mock.go github.com/zitadel/zitadel/internal/idp/ldapsync Queries
+//go:generate mockgen -package mock -destination ./mock/commands.mock.go github.com/zitadel/zitadel/internal/idp/ldapsync Commands
@@ -0,0 +1,189 @@
+// Package ldapsync periodically synchronizes the users linked to LDAP identity providers with their directories.
+package ldapsync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SyncerProjectionTable = "projections.ldap_syncer"
+	SyncerUserID          = "LDAP_SYNC"
+)
+
+type Config struct {
+	Enabled bool
+}
+
+type Queries interface {
+	LDAPProviderSyncsDue(ctx context.Context, instanceIDs []string, now time.Time) ([]*query.LDAPProviderSync, error)
+	LDAPProviderLinkedUsers(ctx context.Context, idpID string) ([]*domain.LDAPSyncLinkedUser, error)
+	InstanceByID(ctx context.Context) (authz.Instance, error)
+}
+
+type Commands interface {
+	SyncInstanceLDAPProvider(ctx context.Context, id string, dryRun bool, linkedUsers []*domain.LDAPSyncLinkedUser) (*domain.LDAPSyncReport, error)
+	SyncOrgLDAPProvider(ctx context.Context, resourceOwner, id string, dryRun bool, linkedUsers []*domain.LDAPSyncLinkedUser) (*domain.LDAPSyncReport, error)
+}
+
+var syncerHandler *handler.Handler
+
+// Register prepares the syncer if it is enabled
+func Register(
+	ctx context.Context,
+	customConfig projection.CustomConfig,
+	config Config,
+	commands Commands,
+	queries Queries,
+) {
+	if !config.Enabled {
+		return
+	}
+	syncerHandler = NewSyncer(ctx, projection.ApplyCustomConfig(customConfig), commands, queries)
+}
+
+func Start(ctx context.Context) {
+	if syncerHandler == nil {
+		return
+	}
+	syncerHandler.Start(ctx)
+}
+
+// syncer runs the due synchronizations of the LDAP providers,
+// if the synchronization is enabled on the provider and its interval has passed.
+type syncer struct {
+	commands Commands
+	queries  Queries
+
+	// lastRuns contains the start of the last synchronization run by this syncer per provider.
+	// The synced event, which sets the last synchronization of the provider, is only pushed if the synchronization changed users,
+	// so unchanged providers are not synchronized again before their interval passed.
+	lastRuns   map[string]time.Time
+	lastRunsMu sync.Mutex
+}
+
+func NewSyncer(
+	ctx context.Context,
+	handlerCfg handler.Config,
+	commands Commands,
+	queries Queries,
+) *handler.Handler {
+	syncer := &syncer{
+		commands: commands,
+		queries:  queries,
+		lastRuns: make(map[string]time.Time),
+	}
+	handlerCfg.TriggerWithoutEvents = syncer.syncProviders
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		syncer,
+	)
+}
+
+func (*syncer) Name() string {
+	return SyncerProjectionTable
+}
+
+func (s *syncer) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: s.syncProviders,
+		}},
+	}}
+}
+
+func (s *syncer) syncProviders(event eventstore.Event) (*handler.Statement, error) {
+	ctx := call.WithTimestamp(context.Background())
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "LDAPS-Xr5mq", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		syncs, err := s.queries.LDAPProviderSyncsDue(ctx, scheduledEvent.InstanceIDs, scheduledEvent.Timestamp)
+		if err != nil {
+			return err
+		}
+		var errs, runs int
+		for _, sync := range syncs {
+			if !s.isDue(sync, scheduledEvent.Timestamp) {
+				continue
+			}
+			runs++
+			if err = s.syncProvider(sync); err != nil {
+				errs++
+				logging.WithFields("instance", sync.InstanceID, "idp", sync.IDPID).WithError(err).Warn("ldap synchronization failed")
+				continue
+			}
+			s.setLastRun(sync, scheduledEvent.Timestamp)
+		}
+		if errs > 0 {
+			return fmt.Errorf("%d of %d ldap synchronizations failed", errs, runs)
+		}
+		return nil
+	}), nil
+}
+
+// isDue checks that the provider was not synchronized by this syncer during the current interval
+func (s *syncer) isDue(sync *query.LDAPProviderSync, now time.Time) bool {
+	s.lastRunsMu.Lock()
+	defer s.lastRunsMu.Unlock()
+	lastRun, ok := s.lastRuns[lastRunKey(sync)]
+	return !ok || !lastRun.Add(sync.Interval).After(now)
+}
+
+func (s *syncer) setLastRun(sync *query.LDAPProviderSync, now time.Time) {
+	s.lastRunsMu.Lock()
+	defer s.lastRunsMu.Unlock()
+	s.lastRuns[lastRunKey(sync)] = now
+}
+
+func lastRunKey(sync *query.LDAPProviderSync) string {
+	return sync.InstanceID + ":" + sync.IDPID
+}
+
+func (s *syncer) syncProvider(sync *query.LDAPProviderSync) error {
+	ctx := authz.WithInstanceID(context.Background(), sync.InstanceID)
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: SyncerUserID, OrgID: sync.ResourceOwner})
+	linkedUsers, err := s.queries.LDAPProviderLinkedUsers(ctx, sync.IDPID)
+	if err != nil {
+		return err
+	}
+	var report *domain.LDAPSyncReport
+	if sync.OwnerType == domain.IdentityProviderTypeOrg {
+		report, err = s.commands.SyncOrgLDAPProvider(ctx, sync.ResourceOwner, sync.IDPID, sync.DryRun, linkedUsers)
+	} else {
+		report, err = s.syncInstanceProvider(ctx, sync, linkedUsers)
+	}
+	if err != nil {
+		return err
+	}
+	logging.WithFields("instance", sync.InstanceID, "idp", sync.IDPID, "dryRun", report.DryRun, "found", report.Found, "created", report.Created, "updated", report.Updated, "deactivated", report.Deactivated, "failed", report.Failed, "deactivationLimitExceeded", report.DeactivationLimitExceeded).Info("ldap synchronization done")
+	return nil
+}
+
+func (s *syncer) syncInstanceProvider(ctx context.Context, sync *query.LDAPProviderSync, linkedUsers []*domain.LDAPSyncLinkedUser) (*domain.LDAPSyncReport, error) {
+	// the users created by the synchronization of an instance provider are added to the default organization
+	instance, err := s.queries.InstanceByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.commands.SyncInstanceLDAPProvider(authz.WithInstance(ctx, instance), sync.IDPID, sync.DryRun, linkedUsers)
+}
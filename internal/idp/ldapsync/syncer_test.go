@@ -0,0 +1,129 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+func Test_syncer_syncProviders(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		expect  func(*mock.MockQueries, *mock.MockCommands)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "query failed, error",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, now).Return(nil, errors.New("query failed"))
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "nothing due",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, now).Return([]*query.LDAPProviderSync{}, nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "providers synchronized",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, now).Return([]*query.LDAPProviderSync{
+					{IDPID: "idp1", InstanceID: "instance1", ResourceOwner: "instance1", OwnerType: domain.IdentityProviderTypeSystem, Enabled: true},
+					{IDPID: "idp2", InstanceID: "instance1", ResourceOwner: "org1", OwnerType: domain.IdentityProviderTypeOrg, Enabled: true, DryRun: true},
+				}, nil)
+				linkedUsers := []*domain.LDAPSyncLinkedUser{{UserID: "user1", ExternalUserID: "external1"}}
+				queries.EXPECT().LDAPProviderLinkedUsers(gomock.Any(), "idp1").Return(linkedUsers, nil)
+				queries.EXPECT().InstanceByID(gomock.Any()).Return(authz.GetInstance(authz.WithInstanceID(context.Background(), "instance1")), nil)
+				commands.EXPECT().SyncInstanceLDAPProvider(gomock.Any(), "idp1", false, linkedUsers).Return(&domain.LDAPSyncReport{Found: 1}, nil)
+				queries.EXPECT().LDAPProviderLinkedUsers(gomock.Any(), "idp2").Return([]*domain.LDAPSyncLinkedUser{}, nil)
+				commands.EXPECT().SyncOrgLDAPProvider(gomock.Any(), "org1", "idp2", true, []*domain.LDAPSyncLinkedUser{}).Return(&domain.LDAPSyncReport{DryRun: true}, nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "synchronization failed, others synchronized, error",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, now).Return([]*query.LDAPProviderSync{
+					{IDPID: "idp1", InstanceID: "instance1", ResourceOwner: "org1", OwnerType: domain.IdentityProviderTypeOrg, Enabled: true},
+					{IDPID: "idp2", InstanceID: "instance1", ResourceOwner: "org1", OwnerType: domain.IdentityProviderTypeOrg, Enabled: true},
+				}, nil)
+				queries.EXPECT().LDAPProviderLinkedUsers(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+				commands.EXPECT().SyncOrgLDAPProvider(gomock.Any(), "org1", "idp1", false, nil).Return(nil, errors.New("directory unavailable"))
+				commands.EXPECT().SyncOrgLDAPProvider(gomock.Any(), "org1", "idp2", false, nil).Return(&domain.LDAPSyncReport{}, nil)
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "instance query failed, error",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, now).Return([]*query.LDAPProviderSync{
+					{IDPID: "idp1", InstanceID: "instance1", ResourceOwner: "instance1", OwnerType: domain.IdentityProviderTypeSystem, Enabled: true},
+				}, nil)
+				queries.EXPECT().LDAPProviderLinkedUsers(gomock.Any(), "idp1").Return(nil, nil)
+				queries.EXPECT().InstanceByID(gomock.Any()).Return(nil, errors.New("query failed"))
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "linked users query failed, error",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, now).Return([]*query.LDAPProviderSync{
+					{IDPID: "idp1", InstanceID: "instance1", ResourceOwner: "org1", OwnerType: domain.IdentityProviderTypeOrg, Enabled: true},
+				}, nil)
+				queries.EXPECT().LDAPProviderLinkedUsers(gomock.Any(), "idp1").Return(nil, errors.New("query failed"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			tt.expect(queries, commands)
+			syncer := &syncer{
+				commands: commands,
+				queries:  queries,
+				lastRuns: make(map[string]time.Time),
+			}
+			stmt, err := syncer.syncProviders(pseudo.NewScheduledEvent(authz.WithInstanceID(context.Background(), "instance1"), now, "instance1"))
+			assert.NoError(t, err)
+			tt.wantErr(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_syncer_syncProviders_lastRun(t *testing.T) {
+	now := time.Now()
+	ctrl := gomock.NewController(t)
+	queries := mock.NewMockQueries(ctrl)
+	commands := mock.NewMockCommands(ctrl)
+	sync := &query.LDAPProviderSync{IDPID: "idp1", InstanceID: "instance1", ResourceOwner: "org1", OwnerType: domain.IdentityProviderTypeOrg, Enabled: true, Interval: time.Hour}
+	// the unchanged synchronization doesn't push the synced event, so the provider is still returned as due
+	queries.EXPECT().LDAPProviderSyncsDue(gomock.Any(), []string{"instance1"}, gomock.Any()).Return([]*query.LDAPProviderSync{sync}, nil).Times(3)
+	queries.EXPECT().LDAPProviderLinkedUsers(gomock.Any(), "idp1").Return(nil, nil).Times(2)
+	commands.EXPECT().SyncOrgLDAPProvider(gomock.Any(), "org1", "idp1", false, nil).Return(&domain.LDAPSyncReport{}, nil).Times(2)
+	syncer := &syncer{
+		commands: commands,
+		queries:  queries,
+		lastRuns: make(map[string]time.Time),
+	}
+	for _, at := range []time.Time{now, now.Add(30 * time.Minute), now.Add(time.Hour)} {
+		stmt, err := syncer.syncProviders(pseudo.NewScheduledEvent(authz.WithInstanceID(context.Background(), "instance1"), at, "instance1"))
+		assert.NoError(t, err)
+		assert.NoError(t, stmt.Execute(nil, ""))
+	}
+}
@@ -0,0 +1,132 @@
+package ldap
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DefaultPageSize is the amount of entries requested per page when searching the directory
+const DefaultPageSize uint32 = 500
+
+// SearchUsers returns all users of the directory below the base DN, which match the configured object classes
+// and have at least one of the configured user filter attributes set.
+// The directory is searched with the bind user in pages of pageSize entries.
+// The users are mapped with the configured attributes, the same way as on the login.
+// The search is aborted if the context is done.
+func (p *Provider) SearchUsers(ctx context.Context, pageSize uint32) (_ []*User, err error) {
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	var entries []*ldap.Entry
+	for _, server := range p.servers {
+		entries, err = trySearchUsers(ctx,
+			server,
+			p.startTLS,
+			p.bindDN,
+			p.bindPassword,
+			p.baseDN,
+			p.getNecessaryAttributes(),
+			p.userObjectClasses,
+			p.userFilters,
+			pageSize,
+			p.timeout,
+		)
+		// If the search was successful there's no need to try the next server
+		if err == nil {
+			break
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*User, len(entries))
+	for i, entry := range entries {
+		users[i], err = mapLDAPEntryToUser(
+			entry,
+			p.idAttribute,
+			p.firstNameAttribute,
+			p.lastNameAttribute,
+			p.displayNameAttribute,
+			p.nickNameAttribute,
+			p.preferredUsernameAttribute,
+			p.emailAttribute,
+			p.emailVerifiedAttribute,
+			p.phoneAttribute,
+			p.phoneVerifiedAttribute,
+			p.preferredLanguageAttribute,
+			p.avatarURLAttribute,
+			p.profileAttribute,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+func trySearchUsers(
+	ctx context.Context,
+	server string,
+	startTLS bool,
+	bindDN string,
+	bindPassword string,
+	baseDN string,
+	attributes []string,
+	objectClasses []string,
+	userFilters []string,
+	pageSize uint32,
+	timeout time.Duration,
+) ([]*ldap.Entry, error) {
+	conn, err := getConnection(server, startTLS, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// closing the connection aborts the running requests
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	if err := conn.Bind(bindDN, bindPassword); err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(timeout.Seconds()), false,
+		usersSearchQuery(objectClasses, userFilters),
+		attributes,
+		nil,
+	)
+	sr, err := conn.SearchWithPaging(searchRequest, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return sr.Entries, nil
+}
+
+// usersSearchQuery returns the filter for all entries with the object classes
+// and any of the user filter attributes
+func usersSearchQuery(objectClasses []string, userFilters []string) string {
+	queries := make([]string, 0, len(objectClasses)+1)
+	for _, class := range objectClasses {
+		queries = append(queries, "(objectClass="+class+")")
+	}
+	if len(userFilters) > 0 {
+		presentFilters := make([]string, len(userFilters))
+		for i, filter := range userFilters {
+			presentFilters[i] = "(" + filter + "=*)"
+		}
+		queries = append(queries, queriesOrToSearchQuery(presentFilters...))
+	}
+	if len(queries) == 0 {
+		return "(objectClass=*)"
+	}
+	return queriesAndToSearchQuery(queries...)
+}
@@ -603,6 +603,12 @@ func (s *Tester) CreateTarget(ctx context.Context, t *testing.T, name, endpoint
 		req.TargetType = &action.CreateTargetRequest_RestAsync{
 			RestAsync: &action.SetRESTAsync{},
 		}
+	case domain.TargetTypeGRPC:
+		req.TargetType = &action.CreateTargetRequest_Grpc{
+			Grpc: &action.SetGRPC{
+				InterruptOnError: interrupt,
+			},
+		}
 	}
 	target, err := s.Client.ActionV3.CreateTarget(ctx, req)
 	require.NoError(t, err)
@@ -1,8 +1,11 @@
 package logstore
 
+import "time"
+
 type Configs struct {
 	Access    *Config
 	Execution *Config
+	Target    *TargetConfig
 }
 
 type Config struct {
@@ -12,3 +15,13 @@ type Config struct {
 type StdConfig struct {
 	Enabled bool
 }
+
+// TargetConfig configures the logs of the calls to execution targets
+type TargetConfig struct {
+	Stdout   *StdConfig
+	Database *EmitterConfig
+	// Keep defines how long the logs are kept in the database
+	Keep time.Duration
+	// CleanupInterval defines how often the logs older than Keep are removed
+	CleanupInterval time.Duration
+}
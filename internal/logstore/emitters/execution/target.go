@@ -0,0 +1,94 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	targetLogTable        = "system.execution_logs"
+	cleanupTargetLogsStmt = "DELETE FROM " + targetLogTable + " WHERE log_date < $1"
+)
+
+var _ logstore.LogCleanupper[*record.TargetLog] = (*databaseTargetLogStorage)(nil)
+
+// databaseTargetLogStorage stores the logs of the calls to execution targets,
+// logs older than keep are removed periodically, see [databaseTargetLogStorage.StartCleanup].
+type databaseTargetLogStorage struct {
+	dbClient *database.DB
+	keep     time.Duration
+}
+
+func NewDatabaseTargetLogStorage(dbClient *database.DB, keep time.Duration) *databaseTargetLogStorage {
+	return &databaseTargetLogStorage{dbClient: dbClient, keep: keep}
+}
+
+func (l *databaseTargetLogStorage) Emit(ctx context.Context, bulk []*record.TargetLog) (err error) {
+	if len(bulk) == 0 {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	builder := sq.Insert(targetLogTable).
+		Columns("instance_id", "log_date", "execution_id", "target_id", "took", "outcome", "status_code", "error", "changes").
+		PlaceholderFormat(sq.Dollar)
+	for _, r := range bulk {
+		changes, err := marshalChanges(r.Changes)
+		if err != nil {
+			return err
+		}
+		builder = builder.Values(r.InstanceID, r.LogDate, r.ExecutionID, r.TargetID, r.Took, r.Outcome, r.StatusCode, r.Error, changes)
+	}
+	stmt, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = l.dbClient.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+// StartCleanup removes the logs older than keep in the interval until the context is done
+func (l *databaseTargetLogStorage) StartCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || l.keep <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := l.Cleanup(ctx, l.keep)
+				logging.OnError(err).Warn("unable to cleanup execution logs")
+			}
+		}
+	}()
+}
+
+// marshalChanges returns the changes of a mutation as JSON, or nil if the log has no changes
+func marshalChanges(changes []*record.FieldChange) ([]byte, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(changes)
+}
+
+func (l *databaseTargetLogStorage) Cleanup(ctx context.Context, keep time.Duration) error {
+	if keep <= 0 {
+		return nil
+	}
+	_, err := l.dbClient.ExecContext(ctx, cleanupTargetLogsStmt, time.Now().Add(-keep))
+	return err
+}
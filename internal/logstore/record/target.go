@@ -0,0 +1,46 @@
+package record
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TargetLog is the log of a call to an execution target
+type TargetLog struct {
+	LogDate     time.Time     `json:"logDate"`
+	Took        time.Duration `json:"took"`
+	InstanceID  string        `json:"instanceId"`
+	ExecutionID string        `json:"executionId,omitempty"`
+	TargetID    string        `json:"targetId"`
+	Outcome     TargetOutcome `json:"outcome"`
+	StatusCode  int           `json:"statusCode,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	// Changes of the response of the target to the request or response, if it's a mutation
+	Changes []*FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is a field of the request or response changed by a target,
+// the values are JSON encoded.
+type FieldChange struct {
+	Path     string          `json:"path"`
+	OldValue json.RawMessage `json:"oldValue,omitempty"`
+	NewValue json.RawMessage `json:"newValue,omitempty"`
+}
+
+type TargetOutcome uint8
+
+const (
+	TargetOutcomeUnspecified TargetOutcome = iota
+	TargetOutcomeSuccess
+	TargetOutcomeFailed
+	TargetOutcomeTimeout
+	// TargetOutcomeMutated is logged if the response of a target changed the request or response
+	TargetOutcomeMutated
+	// TargetOutcomeRejected is logged if the response of a target changed fields which are not mutable
+	TargetOutcomeRejected
+)
+
+func (t TargetLog) Normalize() *TargetLog {
+	t.Error = cutString(t.Error, 2000)
+	return &t
+}
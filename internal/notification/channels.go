@@ -5,10 +5,15 @@ import (
 
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	notification_channels "github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/telemetry/metrics"
@@ -27,14 +32,20 @@ type deliveryMetrics struct {
 	json  string
 }
 
+type deliveryCommands interface {
+	AddNotificationDelivery(ctx context.Context, delivery *command.NotificationDelivery) (*domain.ObjectDetails, error)
+}
+
 type channels struct {
 	q        *handlers.NotificationQueries
+	commands deliveryCommands
 	counters counters
 }
 
-func newChannels(q *handlers.NotificationQueries) *channels {
+func newChannels(q *handlers.NotificationQueries, commands deliveryCommands) *channels {
 	c := &channels{
-		q: q,
+		q:        q,
+		commands: commands,
 		counters: counters{
 			success: deliveryMetrics{
 				email: "successful_deliveries_email",
@@ -62,36 +73,39 @@ func registerCounter(counter, desc string) {
 	logging.WithFields("metric", counter).OnError(err).Panic("unable to register counter")
 }
 
+// Email returns the chain of the active SMTP providers ordered by their priority and the config of the primary provider
 func (c *channels) Email(ctx context.Context) (*senders.Chain, *smtp.Config, error) {
-	smtpCfg, err := c.q.GetSMTPConfig(ctx)
+	smtpCfgs, err := c.q.GetActiveSMTPConfigs(ctx, deliveryOrgID(ctx))
 	if err != nil {
 		return nil, nil, err
 	}
 	chain, err := senders.EmailChannels(
 		ctx,
-		smtpCfg,
+		smtpCfgs,
 		c.q.GetFileSystemProvider,
 		c.q.GetLogProvider,
 		c.counters.success.email,
 		c.counters.failed.email,
+		c.recordDelivery,
 	)
-	return chain, smtpCfg, err
+	return chain, smtpCfgs[0], err
 }
 
-func (c *channels) SMS(ctx context.Context) (*senders.Chain, *twilio.Config, error) {
-	twilioCfg, err := c.q.GetTwilioConfig(ctx)
+func (c *channels) SMS(ctx context.Context) (*senders.Chain, *sms.Config, error) {
+	smsCfg, err := c.q.GetActiveSMSConfig(ctx, deliveryOrgID(ctx))
 	if err != nil {
 		return nil, nil, err
 	}
 	chain, err := senders.SMSChannels(
 		ctx,
-		twilioCfg,
+		smsCfg,
 		c.q.GetFileSystemProvider,
 		c.q.GetLogProvider,
 		c.counters.success.sms,
 		c.counters.failed.sms,
+		c.recordDelivery,
 	)
-	return chain, twilioCfg, err
+	return chain, smsCfg, err
 }
 
 func (c *channels) Webhook(ctx context.Context, cfg webhook.Config) (*senders.Chain, error) {
@@ -104,3 +118,45 @@ func (c *channels) Webhook(ctx context.Context, cfg webhook.Config) (*senders.Ch
 		c.counters.failed.json,
 	)
 }
+
+// deliveryOrgID returns the organization of the notified user,
+// so the providers of the organization are preferred over the ones of the instance
+func deliveryOrgID(ctx context.Context) string {
+	metadata := senders.DeliveryMetadataFromContext(ctx)
+	if metadata == nil {
+		return ""
+	}
+	return metadata.ResourceOwner
+}
+
+// recordDelivery stores the result of a send attempt of a provider in the notification delivery log.
+// Failing to record the delivery must not fail the notification itself, so the error is only logged.
+func (c *channels) recordDelivery(ctx context.Context, message notification_channels.Message, provider string, sendErr error) {
+	delivery := &command.NotificationDelivery{
+		Provider:        provider,
+		TriggeringEvent: message.GetTriggeringEvent(),
+		Err:             sendErr,
+	}
+	switch msg := message.(type) {
+	case *messages.Email:
+		delivery.Channel = domain.NotificationTypeEmail
+		if len(msg.Recipients) > 0 {
+			delivery.Recipient = msg.Recipients[0]
+		}
+	case *messages.SMS:
+		delivery.Channel = domain.NotificationTypeSms
+		delivery.Recipient = msg.RecipientPhoneNumber
+	default:
+		return
+	}
+	if metadata := senders.DeliveryMetadataFromContext(ctx); metadata != nil {
+		delivery.UserID = metadata.UserID
+		delivery.ResourceOwner = metadata.ResourceOwner
+		delivery.MessageType = metadata.MessageType
+	}
+	_, err := c.commands.AddNotificationDelivery(ctx, delivery)
+	logging.WithFields(
+		"instance", authz.GetInstance(ctx).InstanceID(),
+		"triggering_event_type", message.GetTriggeringEvent().Type(),
+	).OnError(err).Error("unable to record notification delivery")
+}
@@ -0,0 +1,63 @@
+// Package httpapi contains the parts shared by the channels sending notifications to HTTP APIs.
+package httpapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"text/template"
+	"time"
+)
+
+const (
+	RequestTimeout = 10 * time.Second
+	// MaxResponseSize limits the response body read from the API
+	MaxResponseSize = 1 << 20
+)
+
+// Client doesn't use the default client, so calls to the APIs always time out
+var Client = &http.Client{Timeout: RequestTimeout}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// Template parses the template of the request body,
+// the function json encodes a value as JSON and missing keys result in an error.
+func Template(body string) (*template.Template, error) {
+	return template.New("body").Funcs(templateFuncs).Option("missingkey=error").Parse(body)
+}
+
+// ValidEndpoint checks that the endpoint is an absolute URL
+func ValidEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// ValidStatusCodes checks that all codes are HTTP status codes
+func ValidStatusCodes(codes []int) bool {
+	for _, code := range codes {
+		if code < 100 || code > 599 {
+			return false
+		}
+	}
+	return true
+}
+
+// SuccessStatus checks the status code of the response against the configured codes, all 2xx status codes if none are configured
+func SuccessStatus(codes []int, code int) bool {
+	if len(codes) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(codes, code)
+}
+
+// Drain reads the rest of the body, so the connection can be reused
+func Drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, MaxResponseSize))
+}
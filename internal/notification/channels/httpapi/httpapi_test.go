@@ -0,0 +1,40 @@
+package httpapi
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuccessStatus(t *testing.T) {
+	assert.True(t, SuccessStatus(nil, http.StatusAccepted))
+	assert.False(t, SuccessStatus(nil, http.StatusFound))
+	assert.True(t, SuccessStatus([]int{http.StatusFound}, http.StatusFound))
+	assert.False(t, SuccessStatus([]int{http.StatusCreated}, http.StatusOK))
+}
+
+func TestValidEndpoint(t *testing.T) {
+	assert.True(t, ValidEndpoint("https://example.com/send"))
+	assert.False(t, ValidEndpoint("example.com/send"))
+	assert.False(t, ValidEndpoint("https://"))
+}
+
+func TestValidStatusCodes(t *testing.T) {
+	assert.True(t, ValidStatusCodes(nil))
+	assert.True(t, ValidStatusCodes([]int{http.StatusOK, http.StatusAccepted}))
+	assert.False(t, ValidStatusCodes([]int{http.StatusOK, 600}))
+}
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := Template(`{"text": {{json .Text}}}`)
+	require.NoError(t, err)
+	body := new(bytes.Buffer)
+	require.NoError(t, tmpl.Execute(body, map[string]any{"Text": "say \"hi\""}))
+	assert.Equal(t, `{"text": "say \"hi\""}`, body.String())
+
+	err = tmpl.Execute(new(bytes.Buffer), map[string]any{})
+	assert.Error(t, err, "missing keys must result in an error")
+}
@@ -0,0 +1,161 @@
+package httpemail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	bodyTemplate, err := cfg.template()
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Debug("successfully initialized http email channel")
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		requestCtx, cancel := context.WithTimeout(ctx, httpapi.RequestTimeout)
+		defer cancel()
+		msg, ok := message.(*messages.Email)
+		if !ok {
+			return zerrors.ThrowInternal(nil, "HTTPEMAIL-Tn4ks", "message is not EmailMessage")
+		}
+		if msg.Content == "" || msg.Subject == "" || len(msg.Recipients) == 0 {
+			return zerrors.ThrowInternalf(nil, "HTTPEMAIL-Ye7hb", "subject, recipients and content must be set but got subject %s, recipients length %d and content length %d", msg.Subject, len(msg.Recipients), len(msg.Content))
+		}
+		msg.SenderEmail = cfg.SenderAddress
+		msg.SenderName = cfg.SenderName
+		msg.ReplyToAddress = cfg.ReplyToAddress
+		body := new(bytes.Buffer)
+		if err = bodyTemplate.Execute(body, bodyFromMessage(msg)); err != nil {
+			return zerrors.ThrowInternal(err, "HTTPEMAIL-Qc0rw", "could not render body")
+		}
+		req, err := http.NewRequestWithContext(requestCtx, http.MethodPost, cfg.Endpoint, body)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "HTTPEMAIL-Gu5xa", "could not create request")
+		}
+		if cfg.Headers != nil {
+			req.Header = cfg.Headers.Clone()
+		}
+		if cfg.ContentType != "" {
+			req.Header.Set("Content-Type", cfg.ContentType)
+		}
+		resp, err := httpapi.Client.Do(req)
+		if err != nil {
+			return zerrors.ThrowUnavailable(err, "HTTPEMAIL-Mb6yd", "could not send message")
+		}
+		defer resp.Body.Close()
+		httpapi.Drain(resp)
+		if err = checkResponse(&cfg, resp); err != nil {
+			return err
+		}
+		logging.WithFields("endpoint", cfg.Endpoint, "status", resp.StatusCode).Debug("email sent")
+		return nil
+	}), nil
+}
+
+func bodyFromMessage(msg *messages.Email) *Body {
+	body := &Body{
+		From:     msg.SenderEmail,
+		FromName: msg.SenderName,
+		ReplyTo:  msg.ReplyToAddress,
+		To:       msg.Recipients,
+		Subject:  msg.Subject,
+		Text:     msg.Content,
+	}
+	if isHTML(msg.Content) {
+		body.HTML = msg.Content
+		body.Text = textFromHTML(msg.Content)
+	}
+	return body
+}
+
+// checkResponse returns an unavailable error for server errors and rate limits,
+// so the next provider can be tried
+func checkResponse(cfg *Config, resp *http.Response) error {
+	if httpapi.SuccessStatus(cfg.SuccessStatusCodes, resp.StatusCode) {
+		return nil
+	}
+	err := fmt.Errorf("calling url %s returned %s", cfg.Endpoint, resp.Status)
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return zerrors.ThrowUnavailable(err, "HTTPEMAIL-Vr9ei", "email api is unavailable")
+	}
+	return zerrors.ThrowUnknown(err, "HTTPEMAIL-Ap1wz", "email api didn't return a success status")
+}
+
+func isHTML(content string) bool {
+	return strings.Contains(strings.ToLower(content), "<html")
+}
+
+// textFromHTML returns the visible text of the html document,
+// block elements and line breaks are separated by new lines
+func textFromHTML(content string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	var (
+		text    strings.Builder
+		skipped int
+	)
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return strings.TrimSpace(text.String())
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head:
+				// a self-closing head has no content and no end tag
+				if tokenType == html.StartTagToken {
+					skipped++
+				}
+			case atom.Script, atom.Style, atom.Title:
+				// the content is read as raw text until the end tag, even if the tag is self-closing
+				skipped++
+			case atom.Br, atom.P, atom.Div, atom.Tr, atom.H1, atom.H2, atom.H3, atom.Li:
+				newLine(&text)
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head, atom.Script, atom.Style, atom.Title:
+				if skipped > 0 {
+					skipped--
+				}
+			case atom.P, atom.Div, atom.Tr, atom.H1, atom.H2, atom.H3, atom.Li:
+				newLine(&text)
+			}
+		case html.TextToken:
+			if skipped > 0 {
+				continue
+			}
+			words := strings.Fields(string(tokenizer.Text()))
+			if len(words) == 0 {
+				continue
+			}
+			if text.Len() > 0 && !strings.HasSuffix(text.String(), "\n") {
+				text.WriteString(" ")
+			}
+			text.WriteString(strings.Join(words, " "))
+		}
+	}
+}
+
+func newLine(text *strings.Builder) {
+	if text.Len() > 0 && !strings.HasSuffix(text.String(), "\n") {
+		text.WriteString("\n")
+	}
+}
@@ -0,0 +1,240 @@
+package httpemail
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestInitChannel_HandleMessage(t *testing.T) {
+	type request struct {
+		contentType   string
+		authorization string
+		body          string
+	}
+	type args struct {
+		cfg     Config
+		status  int
+		message *messages.Email
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    request
+		wantErr func(error) bool
+	}{
+		{
+			"html content, ok",
+			args{
+				cfg: Config{
+					SenderAddress: "noreply@zitadel.example",
+					SenderName:    "ZITADEL",
+					Headers:       http.Header{"Authorization": []string{"Bearer token"}},
+					BodyTemplate:  `{"from":{{json .From}},"name":{{json .FromName}},"to":{{json .To}},"subject":{{json .Subject}},"html":{{json .HTML}},"text":{{json .Text}}}`,
+					ContentType:   "application/json",
+				},
+				status: http.StatusAccepted,
+				message: &messages.Email{
+					Recipients: []string{"user@example.com"},
+					Subject:    "Verify email",
+					Content:    `<html><head><style>p {}</style></head><body><p>Hello <b>user</b></p><p>Your code</p></body></html>`,
+				},
+			},
+			request{
+				contentType:   "application/json",
+				authorization: "Bearer token",
+				body:          `{"from":"noreply@zitadel.example","name":"ZITADEL","to":["user@example.com"],"subject":"Verify email","html":"<html><head><style>p {}</style></head><body><p>Hello <b>user</b></p><p>Your code</p></body></html>","text":"Hello user\nYour code"}`,
+			},
+			nil,
+		},
+		{
+			"text content, ok",
+			args{
+				cfg: Config{
+					BodyTemplate: `{{.Subject}}: {{.Text}}{{.HTML}}`,
+				},
+				status: http.StatusOK,
+				message: &messages.Email{
+					Recipients: []string{"user@example.com"},
+					Subject:    "Verify email",
+					Content:    "Your code",
+				},
+			},
+			request{
+				body: "Verify email: Your code",
+			},
+			nil,
+		},
+		{
+			"configured status, error",
+			args{
+				cfg: Config{
+					BodyTemplate:       `{{.Subject}}`,
+					SuccessStatusCodes: []int{http.StatusCreated},
+				},
+				status: http.StatusOK,
+				message: &messages.Email{
+					Recipients: []string{"user@example.com"},
+					Subject:    "Verify email",
+					Content:    "Your code",
+				},
+			},
+			request{
+				body: "Verify email",
+			},
+			zerrors.IsUnknown,
+		},
+		{
+			"server error, unavailable",
+			args{
+				cfg: Config{
+					BodyTemplate: `{{.Subject}}`,
+				},
+				status: http.StatusBadGateway,
+				message: &messages.Email{
+					Recipients: []string{"user@example.com"},
+					Subject:    "Verify email",
+					Content:    "Your code",
+				},
+			},
+			request{
+				body: "Verify email",
+			},
+			zerrors.IsUnavailable,
+		},
+		{
+			"missing recipients, error",
+			args{
+				cfg: Config{
+					BodyTemplate: `{{.Subject}}`,
+				},
+				message: &messages.Email{
+					Subject: "Verify email",
+					Content: "Your code",
+				},
+			},
+			request{},
+			zerrors.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, http.MethodPost, r.Method)
+				got = &request{
+					contentType:   r.Header.Get("Content-Type"),
+					authorization: r.Header.Get("Authorization"),
+					body:          string(body),
+				}
+				w.WriteHeader(tt.args.status)
+			}))
+			defer server.Close()
+			tt.args.cfg.Endpoint = server.URL
+
+			channel, err := InitChannel(context.Background(), tt.args.cfg)
+			require.NoError(t, err)
+			err = channel.HandleMessage(tt.args.message)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.want == (request{}) {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want.contentType, got.contentType)
+			if tt.want.contentType == "application/json" {
+				assert.JSONEq(t, tt.want.body, got.body)
+			} else {
+				assert.Equal(t, tt.want.body, got.body)
+			}
+			assert.Equal(t, tt.want.authorization, got.authorization)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			"valid",
+			Config{Endpoint: "https://api.example.com/v3/mail/send", BodyTemplate: `{{json .To}}`, SuccessStatusCodes: []int{http.StatusAccepted}},
+			false,
+		},
+		{
+			"relative endpoint, error",
+			Config{Endpoint: "/mail/send", BodyTemplate: `{{json .To}}`},
+			true,
+		},
+		{
+			"invalid template, error",
+			Config{Endpoint: "https://api.example.com", BodyTemplate: `{{json .To}`},
+			true,
+		},
+		{
+			"invalid status code, error",
+			Config{Endpoint: "https://api.example.com", BodyTemplate: `{{json .To}}`, SuccessStatusCodes: []int{600}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_textFromHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "paragraphs",
+			content: `<html><head><title>Title</title><style>p {}</style></head><body><p>Hello <b>user</b></p><div>Your code</div></body></html>`,
+			want:    "Hello user\nYour code",
+		},
+		{
+			name:    "self-closing head, content kept",
+			content: `<html><head/><body><p>Hello</p><br/>user</body></html>`,
+			want:    "Hello\nuser",
+		},
+		{
+			name:    "self-closing script, raw text until end tag skipped",
+			content: `<p>Hello</p><script/>alert(1)</script><p>user</p>`,
+			want:    "Hello\nuser",
+		},
+		{
+			name:    "end tag without start tag, script still skipped",
+			content: `</script><p>Hello</p><script>alert(1)</script>`,
+			want:    "Hello",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, textFromHTML(tt.content))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package httpemail
+
+import (
+	"net/http"
+	"text/template"
+
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+
+	// Endpoint is called with a POST request for every email
+	Endpoint string
+	// Headers are sent with every request, e.g. to authenticate against the API
+	Headers http.Header
+	// BodyTemplate is the template of the request body, see [Body]
+	BodyTemplate string
+	ContentType  string
+	// SuccessStatusCodes are the status codes of a successful response, all 2xx status codes if not set
+	SuccessStatusCodes []int
+}
+
+// Body is the data available in the BodyTemplate,
+// e.g. `{"from": {{json .From}}, "to": {{json .To}}, "subject": {{json .Subject}}, "html": {{json .HTML}}, "text": {{json .Text}}}`
+type Body struct {
+	From     string
+	FromName string
+	ReplyTo  string
+	To       []string
+	Subject  string
+	// HTML is the rendered content, empty if the content is plain text
+	HTML string
+	// Text is the content as plain text
+	Text string
+}
+
+func (c *Config) Validate() error {
+	if !httpapi.ValidEndpoint(c.Endpoint) {
+		return zerrors.ThrowInvalidArgument(nil, "HTTPEMAIL-Ws3mv", "Errors.SMTPConfig.HTTP.InvalidEndpoint")
+	}
+	if _, err := c.template(); err != nil {
+		return zerrors.ThrowInvalidArgument(err, "HTTPEMAIL-Kd8pe", "Errors.SMTPConfig.HTTP.InvalidTemplate")
+	}
+	if !httpapi.ValidStatusCodes(c.SuccessStatusCodes) {
+		return zerrors.ThrowInvalidArgument(nil, "HTTPEMAIL-Fz2qo", "Errors.SMTPConfig.HTTP.InvalidStatusCode")
+	}
+	return nil
+}
+
+func (c *Config) template() (*template.Template, error) {
+	return httpapi.Template(c.BodyTemplate)
+}
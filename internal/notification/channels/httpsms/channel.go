@@ -0,0 +1,145 @@
+package httpsms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	bodyTemplate, err := cfg.template()
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Debug("successfully initialized http sms channel")
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		requestCtx, cancel := context.WithTimeout(ctx, httpapi.RequestTimeout)
+		defer cancel()
+		msg, ok := message.(*messages.SMS)
+		if !ok {
+			return zerrors.ThrowInternal(nil, "HTTPSMS-x8Lwe", "message is not SMS")
+		}
+		content, err := msg.GetContent()
+		if err != nil {
+			return err
+		}
+		senderID := cfg.SenderID
+		if msg.SenderPhoneNumber != "" {
+			senderID = msg.SenderPhoneNumber
+		}
+		body := new(bytes.Buffer)
+		if err = bodyTemplate.Execute(body, &Body{
+			Phone:    msg.RecipientPhoneNumber,
+			Text:     content,
+			SenderID: senderID,
+		}); err != nil {
+			return zerrors.ThrowInternal(err, "HTTPSMS-Pq4ah", "could not render body")
+		}
+		req, err := http.NewRequestWithContext(requestCtx, cfg.Method, cfg.Endpoint, body)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "HTTPSMS-Rr0dz", "could not create request")
+		}
+		if cfg.Headers != nil {
+			req.Header = cfg.Headers.Clone()
+		}
+		if cfg.ContentType != "" {
+			req.Header.Set("Content-Type", cfg.ContentType)
+		}
+		resp, err := httpapi.Client.Do(req)
+		if err != nil {
+			return zerrors.ThrowUnavailable(err, "HTTPSMS-Ho2ks", "could not send message")
+		}
+		defer resp.Body.Close()
+		err = checkResponse(&cfg, resp)
+		httpapi.Drain(resp)
+		if err != nil {
+			return err
+		}
+		logging.WithFields("endpoint", cfg.Endpoint, "status", resp.StatusCode).Debug("sms sent")
+		return nil
+	}), nil
+}
+
+// checkResponse doesn't return the content of the response,
+// as the errors are logged and stored with the delivery of the message
+func checkResponse(cfg *Config, resp *http.Response) error {
+	if !httpapi.SuccessStatus(cfg.SuccessStatusCodes, resp.StatusCode) {
+		return zerrors.ThrowUnknown(fmt.Errorf("calling url %s returned %s", cfg.Endpoint, resp.Status), "HTTPSMS-Wc7nj", "sms gateway didn't return a success status")
+	}
+	if cfg.SuccessJSONPath == "" {
+		return nil
+	}
+	var body any
+	if err := json.NewDecoder(io.LimitReader(resp.Body, httpapi.MaxResponseSize)).Decode(&body); err != nil {
+		return zerrors.ThrowUnknown(nil, "HTTPSMS-b3Tsx", "sms gateway didn't return a JSON response")
+	}
+	value, ok := valueOfPath(body, cfg.SuccessJSONPath)
+	if !ok || !successValue(value, cfg.SuccessJSONValue) {
+		return zerrors.ThrowUnknown(fmt.Errorf("field %s of the response is not successful", cfg.SuccessJSONPath), "HTTPSMS-Lm9vq", "sms gateway didn't return a success response")
+	}
+	return nil
+}
+
+// valueOfPath returns the value of the dot separated path,
+// the elements of arrays are addressed by their index
+func valueOfPath(value any, path string) (any, bool) {
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			field, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = field
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func successValue(value any, expected string) bool {
+	if expected != "" {
+		switch v := value.(type) {
+		case string:
+			return v == expected
+		case nil:
+			return false
+		default:
+			data, err := json.Marshal(v)
+			return err == nil && string(data) == expected
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
@@ -0,0 +1,127 @@
+package httpsms
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkResponse(t *testing.T) {
+	type args struct {
+		cfg    *Config
+		status int
+		body   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"default status, ok",
+			args{
+				cfg:    &Config{},
+				status: http.StatusAccepted,
+			},
+			false,
+		},
+		{
+			"default status, error",
+			args{
+				cfg:    &Config{},
+				status: http.StatusBadRequest,
+			},
+			true,
+		},
+		{
+			"configured status, error",
+			args{
+				cfg:    &Config{SuccessStatusCodes: []int{http.StatusCreated}},
+				status: http.StatusOK,
+			},
+			true,
+		},
+		{
+			"no json, error",
+			args{
+				cfg:    &Config{SuccessJSONPath: "status"},
+				status: http.StatusOK,
+				body:   "ok",
+			},
+			true,
+		},
+		{
+			"json path missing, error",
+			args{
+				cfg:    &Config{SuccessJSONPath: "messages.1.status"},
+				status: http.StatusOK,
+				body:   `{"messages": [{"status": "queued"}]}`,
+			},
+			true,
+		},
+		{
+			"json path false, error",
+			args{
+				cfg:    &Config{SuccessJSONPath: "success"},
+				status: http.StatusOK,
+				body:   `{"success": false}`,
+			},
+			true,
+		},
+		{
+			"json path true, ok",
+			args{
+				cfg:    &Config{SuccessJSONPath: "success"},
+				status: http.StatusOK,
+				body:   `{"success": true}`,
+			},
+			false,
+		},
+		{
+			"json value wrong, error",
+			args{
+				cfg:    &Config{SuccessJSONPath: "messages.0.status", SuccessJSONValue: "queued"},
+				status: http.StatusOK,
+				body:   `{"messages": [{"status": "rejected"}]}`,
+			},
+			true,
+		},
+		{
+			"json value, ok",
+			args{
+				cfg:    &Config{SuccessJSONPath: "messages.0.status", SuccessJSONValue: "queued"},
+				status: http.StatusOK,
+				body:   `{"messages": [{"status": "queued"}]}`,
+			},
+			false,
+		},
+		{
+			"json number value, ok",
+			args{
+				cfg:    &Config{SuccessJSONPath: "code", SuccessJSONValue: "0"},
+				status: http.StatusOK,
+				body:   `{"code": 0}`,
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkResponse(tt.args.cfg, &http.Response{
+				StatusCode: tt.args.status,
+				Status:     http.StatusText(tt.args.status),
+				Body:       io.NopCloser(strings.NewReader(tt.args.body)),
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.NotContains(t, err.Error(), "rejected", "error must not contain the response")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package httpsms
+
+import (
+	"net/http"
+	"slices"
+	"text/template"
+
+	"github.com/zitadel/zitadel/internal/notification/channels/httpapi"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var methods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodGet}
+
+type Config struct {
+	Endpoint string
+	Method   string
+	// Headers are sent with every request, e.g. to authenticate against the gateway
+	Headers http.Header
+	// BodyTemplate is the template of the request body, see [Body]
+	BodyTemplate string
+	ContentType  string
+	SenderID     string
+	// SuccessStatusCodes are the status codes of a successful response, all 2xx status codes if not set
+	SuccessStatusCodes []int
+	// SuccessJSONPath is the dot separated path of a field in the JSON response, e.g. `messages.0.status`,
+	// which must have the value of SuccessJSONValue, or must not be empty, false or null if no value is set
+	SuccessJSONPath  string
+	SuccessJSONValue string
+}
+
+// Body is the data available in the BodyTemplate, e.g. `{"to": {{json .Phone}}, "text": {{json .Text}}}`
+type Body struct {
+	Phone    string
+	Text     string
+	SenderID string
+}
+
+func (c *Config) Validate() error {
+	if !httpapi.ValidEndpoint(c.Endpoint) {
+		return zerrors.ThrowInvalidArgument(nil, "HTTPSMS-e6Ld2", "Errors.SMSConfig.HTTP.InvalidEndpoint")
+	}
+	if !slices.Contains(methods, c.Method) {
+		return zerrors.ThrowInvalidArgument(nil, "HTTPSMS-Ujp3k", "Errors.SMSConfig.HTTP.InvalidMethod")
+	}
+	if _, err := c.template(); err != nil {
+		return zerrors.ThrowInvalidArgument(err, "HTTPSMS-s2Qfa", "Errors.SMSConfig.HTTP.InvalidTemplate")
+	}
+	if !httpapi.ValidStatusCodes(c.SuccessStatusCodes) {
+		return zerrors.ThrowInvalidArgument(nil, "HTTPSMS-Bq0xm", "Errors.SMSConfig.HTTP.InvalidStatusCode")
+	}
+	return nil
+}
+
+func (c *Config) template() (*template.Template, error) {
+	return httpapi.Template(c.BodyTemplate)
+}
@@ -12,6 +12,19 @@ func Wrap(
 	traceSpanName,
 	successMetricName,
 	failureMetricName string,
+) channels.NotificationChannel {
+	return WrapProvider(ctx, channel, traceSpanName, successMetricName, failureMetricName, "")
+}
+
+// WrapProvider is like Wrap, but additionally labels the metrics with the provider,
+// so the deliveries of multiple providers of the same channel can be distinguished
+func WrapProvider(
+	ctx context.Context,
+	channel channels.NotificationChannel,
+	traceSpanName,
+	successMetricName,
+	failureMetricName,
+	provider string,
 ) channels.NotificationChannel {
 	return traceMessages(
 		ctx,
@@ -20,6 +33,7 @@ func Wrap(
 			logMessages(ctx, channel),
 			successMetricName,
 			failureMetricName,
+			provider,
 		),
 		traceSpanName,
 	)
@@ -11,23 +11,26 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/metrics"
 )
 
-func countMessages(ctx context.Context, channel channels.NotificationChannel, successMetricName, errorMetricName string) channels.NotificationChannel {
+func countMessages(ctx context.Context, channel channels.NotificationChannel, successMetricName, errorMetricName, provider string) channels.NotificationChannel {
 	return channels.HandleMessageFunc(func(message channels.Message) error {
 		err := channel.HandleMessage(message)
 		metricName := successMetricName
 		if err != nil {
 			metricName = errorMetricName
 		}
-		addCount(ctx, metricName, message, err)
+		addCount(ctx, metricName, provider, message, err)
 		return err
 	})
 }
 
-func addCount(ctx context.Context, metricName string, message channels.Message, err error) {
+func addCount(ctx context.Context, metricName, provider string, message channels.Message, err error) {
 	labels := map[string]attribute.Value{
 		"triggering_event_typey": attribute.StringValue(string(message.GetTriggeringEvent().Type())),
 		"instance":               attribute.StringValue(authz.GetInstance(ctx).InstanceID()),
 	}
+	if provider != "" {
+		labels["provider"] = attribute.StringValue(provider)
+	}
 	if err != nil {
 		labels["error"] = attribute.StringValue(err.Error())
 	}
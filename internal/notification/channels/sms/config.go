@@ -0,0 +1,27 @@
+package sms
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+)
+
+// Config is the config of the active SMS provider, only one of the providers is set
+type Config struct {
+	ID           string
+	TwilioConfig *twilio.Config
+	HTTPConfig   *httpsms.Config
+}
+
+// SenderNumber returns the number or id the SMS are sent from
+func (c *Config) SenderNumber() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.TwilioConfig != nil:
+		return c.TwilioConfig.SenderNumber
+	case c.HTTPConfig != nil:
+		return c.HTTPConfig.SenderID
+	default:
+		return ""
+	}
+}
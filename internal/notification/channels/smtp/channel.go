@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 	"net/smtp"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -20,13 +21,22 @@ type Email struct {
 	senderAddress  string
 	senderName     string
 	replyToAddress string
+	dkim           *dkimSigner
 }
 
 func InitChannel(cfg *Config) (*Email, error) {
+	var dkim *dkimSigner
+	if cfg.DKIM != nil {
+		var err error
+		dkim, err = newDKIMSigner(cfg.DKIM)
+		if err != nil {
+			return nil, err
+		}
+	}
 	client, err := cfg.SMTP.connectToSMTP(cfg.Tls)
 	if err != nil {
 		logging.New().WithError(err).Error("could not connect to smtp")
-		return nil, err
+		return nil, zerrors.ThrowUnavailable(err, "EMAIL-Hd7wq", "could not connect to smtp")
 	}
 	logging.New().Debug("successfully initialized smtp email channel")
 	return &Email{
@@ -34,6 +44,7 @@ func InitChannel(cfg *Config) (*Email, error) {
 		senderName:     cfg.FromName,
 		senderAddress:  cfg.From,
 		replyToAddress: cfg.ReplyToAddress,
+		dkim:           dkim,
 	}, nil
 }
 
@@ -52,7 +63,7 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	emailMsg.ReplyToAddress = email.replyToAddress
 	// To && From
 	if err := email.smtpClient.Mail(emailMsg.SenderEmail); err != nil {
-		return zerrors.ThrowInternalf(err, "EMAIL-s3is3", "could not set sender: %v", emailMsg.SenderEmail)
+		return zerrors.ThrowUnavailablef(err, "EMAIL-s3is3", "could not set sender: %v", emailMsg.SenderEmail)
 	}
 	for _, recp := range append(append(emailMsg.Recipients, emailMsg.CC...), emailMsg.BCC...) {
 		if err := email.smtpClient.Rcpt(recp); err != nil {
@@ -60,25 +71,32 @@ func (email *Email) HandleMessage(message channels.Message) error {
 		}
 	}
 
-	// Data
-	w, err := email.smtpClient.Data()
+	content, err := emailMsg.GetContent()
 	if err != nil {
 		return err
 	}
+	if email.dkim != nil {
+		content, err = email.dkim.Sign(content, time.Now())
+		if err != nil {
+			return err
+		}
+	}
 
-	content, err := emailMsg.GetContent()
+	// Data
+	// errors until the message is accepted are returned as unavailable, so the next provider can be tried
+	w, err := email.smtpClient.Data()
 	if err != nil {
-		return err
+		return zerrors.ThrowUnavailable(err, "EMAIL-Tb9ur", "could not start data")
 	}
 
 	_, err = w.Write([]byte(content))
 	if err != nil {
-		return err
+		return zerrors.ThrowUnavailable(err, "EMAIL-Gk3vb", "could not write data")
 	}
 
 	err = w.Close()
 	if err != nil {
-		return err
+		return zerrors.ThrowUnavailable(err, "EMAIL-Xa6cm", "could not send data")
 	}
 
 	return email.smtpClient.Quit()
@@ -1,12 +1,30 @@
 package smtp
 
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
+)
+
 type Config struct {
+	// ID identifies the provider in the metrics
+	ID             string
 	Description    string
 	SMTP           SMTP
 	Tls            bool
 	From           string
 	FromName       string
 	ReplyToAddress string
+	// HTTP is set if the emails are sent through an HTTP API instead of SMTP
+	HTTP *httpemail.Config
+	// DKIM is set if the emails are signed
+	DKIM *DKIM
+}
+
+// DKIM signs the sent emails, so receivers can verify the emails are sent on behalf of the domain.
+type DKIM struct {
+	Selector string
+	Domain   string
+	// PrivateKey is the PEM encoded RSA or Ed25519 private key
+	PrivateKey []byte
 }
 
 type SMTP struct {
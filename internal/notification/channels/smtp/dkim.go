@@ -0,0 +1,308 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	dkimSignatureHeader = "DKIM-Signature"
+	dkimMinRSAKeyBits   = 1024
+)
+
+// dkimSignedHeaders are signed if they are present in the message
+var dkimSignedHeaders = []string{"from", "reply-to", "to", "cc", "subject", "date", "mime-version", "content-type"}
+
+// DKIMRecord is the DNS TXT record which has to be published, so the signatures can be verified
+type DKIMRecord struct {
+	Name  string
+	Value string
+}
+
+type dkimSigner struct {
+	selector string
+	domain   string
+	key      crypto.Signer
+}
+
+func newDKIMSigner(config *DKIM) (*dkimSigner, error) {
+	key, err := ParseDKIMPrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &dkimSigner{
+		selector: config.Selector,
+		domain:   config.Domain,
+		key:      key,
+	}, nil
+}
+
+// ParseDKIMPrivateKey parses a PEM encoded RSA (PKCS #1 or PKCS #8) or Ed25519 (PKCS #8) private key
+func ParseDKIMPrivateKey(privateKey []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Vd3kx", "Errors.SMTPConfig.DKIM.InvalidPrivateKey")
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SMTP-Pn7qa", "Errors.SMTPConfig.DKIM.InvalidPrivateKey")
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < dkimMinRSAKeyBits {
+			return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Hs4wm", "Errors.SMTPConfig.DKIM.KeyTooShort")
+		}
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Zb8ct", "Errors.SMTPConfig.DKIM.InvalidPrivateKey")
+	}
+}
+
+// NewDKIMRecord returns the DNS TXT record containing the public key of the private key
+func NewDKIMRecord(selector, domain string, key crypto.Signer) (*DKIMRecord, error) {
+	keyType, err := dkimKeyType(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	var publicKey []byte
+	switch k := key.Public().(type) {
+	case ed25519.PublicKey:
+		// Ed25519 keys are published as the plain key (RFC 8463)
+		publicKey = k
+	default:
+		publicKey, err = x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "SMTP-Tk2rf", "Errors.Internal")
+		}
+	}
+	return &DKIMRecord{
+		Name:  DKIMRecordName(selector, domain),
+		Value: "v=DKIM1; k=" + keyType + "; p=" + base64.StdEncoding.EncodeToString(publicKey),
+	}, nil
+}
+
+// DKIMRecordName returns the name of the DNS TXT record of the selector
+func DKIMRecordName(selector, domain string) string {
+	return selector + "._domainkey." + domain
+}
+
+// LookupDKIMRecord resolves the DNS TXT record of the selector using the lookup (e.g. [net.Resolver.LookupTXT])
+// and checks it against the public key of the private key
+func LookupDKIMRecord(ctx context.Context, lookupTXT func(ctx context.Context, name string) ([]string, error), selector, domain string, key crypto.Signer) error {
+	records, err := lookupTXT(ctx, DKIMRecordName(selector, domain))
+	if err != nil || len(records) == 0 {
+		return zerrors.ThrowNotFound(err, "SMTP-Rj5ne", "Errors.SMTPConfig.DKIM.RecordNotFound")
+	}
+	for _, record := range records {
+		if err = VerifyDKIMRecord(record, key.Public()); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// VerifyDKIMRecord checks the format of the published DNS TXT record (RFC 6376 section 3.6.1)
+// and that it contains the public key.
+func VerifyDKIMRecord(record string, publicKey crypto.PublicKey) error {
+	tags, err := parseDKIMTags(record)
+	if err != nil {
+		return err
+	}
+	if _, ok := tags["p"]; !ok {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-Nd5ri", "Errors.SMTPConfig.DKIM.RecordInvalid")
+	}
+	if version, ok := tags["v"]; ok && version != "DKIM1" {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-Ws6bd", "Errors.SMTPConfig.DKIM.RecordInvalid")
+	}
+	keyType, err := dkimKeyType(publicKey)
+	if err != nil {
+		return err
+	}
+	// rsa is the default key type
+	if k, ok := tags["k"]; (ok && k != keyType) || (!ok && keyType != "rsa") {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-Gm3yh", "Errors.SMTPConfig.DKIM.RecordKeyMismatch")
+	}
+	published, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(tags["p"]), ""))
+	if err != nil || len(published) == 0 {
+		return zerrors.ThrowInvalidArgument(err, "SMTP-Ka9vu", "Errors.SMTPConfig.DKIM.RecordInvalid")
+	}
+	switch k := publicKey.(type) {
+	case ed25519.PublicKey:
+		if !bytes.Equal(published, k) {
+			return zerrors.ThrowInvalidArgument(nil, "SMTP-Xe7pl", "Errors.SMTPConfig.DKIM.RecordKeyMismatch")
+		}
+	case *rsa.PublicKey:
+		parsed, err := x509.ParsePKIXPublicKey(published)
+		if err != nil {
+			// the key is also allowed to be published as RSAPublicKey (PKCS #1)
+			parsed, err = x509.ParsePKCS1PublicKey(published)
+		}
+		if err != nil || !k.Equal(parsed) {
+			return zerrors.ThrowInvalidArgument(err, "SMTP-Cq4ds", "Errors.SMTPConfig.DKIM.RecordKeyMismatch")
+		}
+	}
+	return nil
+}
+
+func parseDKIMTags(record string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(record, ";") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		name, value, found := strings.Cut(tag, "=")
+		if !found {
+			return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Bu2fo", "Errors.SMTPConfig.DKIM.RecordInvalid")
+		}
+		tags[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+func dkimKeyType(publicKey crypto.PublicKey) (string, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return "rsa", nil
+	case ed25519.PublicKey:
+		return "ed25519", nil
+	default:
+		return "", zerrors.ThrowInvalidArgument(nil, "SMTP-Lf8sa", "Errors.SMTPConfig.DKIM.InvalidPrivateKey")
+	}
+}
+
+// Sign adds the DKIM-Signature header to the message
+// using the relaxed canonicalization for the header and the body (RFC 6376 section 3.4).
+func (s *dkimSigner) Sign(message string, now time.Time) (string, error) {
+	// the message is sent with CRLF line endings, so it is signed with them
+	message = strings.ReplaceAll(strings.ReplaceAll(message, "\r\n", "\n"), "\n", "\r\n")
+	header, body, _ := strings.Cut(message, "\r\n\r\n")
+	headers := splitHeaders(header)
+
+	bodyHash := sha256.Sum256([]byte(relaxedBody(body)))
+
+	signedNames := make([]string, 0, len(dkimSignedHeaders))
+	var signedHeaders strings.Builder
+	for _, name := range dkimSignedHeaders {
+		field, ok := findHeader(headers, name)
+		if !ok {
+			continue
+		}
+		signedNames = append(signedNames, name)
+		signedHeaders.WriteString(relaxedHeader(field))
+	}
+
+	algorithm := "rsa-sha256"
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		algorithm = "ed25519-sha256"
+	}
+	signatureHeader := dkimSignatureHeader + ": v=1; a=" + algorithm + "; c=relaxed/relaxed; d=" + s.domain + "; s=" + s.selector +
+		"; t=" + strconv.FormatInt(now.Unix(), 10) + ";\r\n h=" + strings.Join(signedNames, ":") +
+		";\r\n bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]) + ";\r\n b="
+	// the signature header is signed without the value of the b tag and without the trailing CRLF
+	signedHeaders.WriteString(strings.TrimSuffix(relaxedHeader(signatureHeader), "\r\n"))
+
+	hash := sha256.Sum256([]byte(signedHeaders.String()))
+	var signature []byte
+	var err error
+	switch key := s.key.(type) {
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, hash[:])
+	default:
+		signature, err = s.key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	}
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "SMTP-Ey6gt", "could not sign email")
+	}
+	return signatureHeader + base64.StdEncoding.EncodeToString(signature) + "\r\n" + message, nil
+}
+
+// splitHeaders returns the header fields including their folded lines
+func splitHeaders(header string) []string {
+	lines := strings.Split(header, "\r\n")
+	fields := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if len(fields) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			fields[len(fields)-1] += "\r\n" + line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// findHeader returns the last header field of the name, as it is the one to sign (RFC 6376 section 5.4.2)
+func findHeader(fields []string, name string) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		fieldName, _, found := strings.Cut(fields[i], ":")
+		if found && strings.EqualFold(strings.TrimSpace(fieldName), name) {
+			return fields[i], true
+		}
+	}
+	return "", false
+}
+
+// relaxedHeader canonicalizes a header field (RFC 6376 section 3.4.2)
+func relaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.ReplaceAll(value, "\r\n", "")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.TrimSpace(compressWhitespace(value)) + "\r\n"
+}
+
+// relaxedBody canonicalizes the body (RFC 6376 section 3.4.4)
+func relaxedBody(body string) string {
+	lines := strings.Split(body, "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(compressWhitespace(line), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func compressWhitespace(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	whitespace := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' {
+			whitespace = true
+			continue
+		}
+		if whitespace {
+			b.WriteByte(' ')
+			whitespace = false
+		}
+		b.WriteRune(r)
+	}
+	if whitespace {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
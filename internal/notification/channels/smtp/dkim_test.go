@@ -0,0 +1,264 @@
+package smtp
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_relaxedCanonicalization(t *testing.T) {
+	// example of RFC 6376 section 3.4.5
+	headers := splitHeaders("A: X\r\nB : Y\t\r\n\tZ  ")
+	require.Len(t, headers, 2)
+	assert.Equal(t, "a:X\r\n", relaxedHeader(headers[0]))
+	assert.Equal(t, "b:Y Z\r\n", relaxedHeader(headers[1]))
+	assert.Equal(t, " C\r\nD E\r\n", relaxedBody(" C \r\nD \t E\r\n\r\n\r\n"))
+	assert.Equal(t, "", relaxedBody("\r\n\r\n"))
+}
+
+func TestParseDKIMPrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	shortKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		want    crypto.Signer
+		wantErr func(error) bool
+	}{
+		{
+			name:    "no pem, error",
+			key:     []byte("key"),
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "invalid key, error",
+			key:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}),
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "rsa key too short, error",
+			key:     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(shortKey)}),
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "rsa pkcs1, ok",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			want: rsaKey,
+		},
+		{
+			name: "rsa pkcs8, ok",
+			key:  pemPKCS8(t, rsaKey),
+			want: rsaKey,
+		},
+		{
+			name: "ed25519, ok",
+			key:  pemPKCS8(t, edKey),
+			want: edKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDKIMPrivateKey(tt.key)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(tt.want.Public()))
+		})
+	}
+}
+
+func TestVerifyDKIMRecord(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaRecord, err := NewDKIMRecord("zitadel", "example.com", rsaKey)
+	require.NoError(t, err)
+	edRecord, err := NewDKIMRecord("zitadel", "example.com", edKey)
+	require.NoError(t, err)
+	pkcs1 := base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey))
+
+	assert.Equal(t, "zitadel._domainkey.example.com", rsaRecord.Name)
+
+	tests := []struct {
+		name      string
+		record    string
+		publicKey crypto.PublicKey
+		wantErr   func(error) bool
+	}{
+		{
+			name:      "rsa record, ok",
+			record:    rsaRecord.Value,
+			publicKey: rsaKey.Public(),
+		},
+		{
+			name:      "ed25519 record, ok",
+			record:    edRecord.Value,
+			publicKey: edKey.Public(),
+		},
+		{
+			name:      "default key type and pkcs1 key, ok",
+			record:    "v=DKIM1; p=" + pkcs1,
+			publicKey: rsaKey.Public(),
+		},
+		{
+			name:      "split key, ok",
+			record:    "v=DKIM1; k=rsa; p=" + pkcs1[:20] + " " + pkcs1[20:],
+			publicKey: rsaKey.Public(),
+		},
+		{
+			name:      "no key, error",
+			record:    "v=DKIM1; k=rsa",
+			publicKey: rsaKey.Public(),
+			wantErr:   zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:      "revoked key, error",
+			record:    "v=DKIM1; k=rsa; p=",
+			publicKey: rsaKey.Public(),
+			wantErr:   zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:      "wrong version, error",
+			record:    strings.Replace(rsaRecord.Value, "DKIM1", "DKIM2", 1),
+			publicKey: rsaKey.Public(),
+			wantErr:   zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:      "malformed tag, error",
+			record:    "v=DKIM1; rsa; p=" + pkcs1,
+			publicKey: rsaKey.Public(),
+			wantErr:   zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:      "wrong key type, error",
+			record:    edRecord.Value,
+			publicKey: rsaKey.Public(),
+			wantErr:   zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:      "other key, error",
+			record:    rsaRecord.Value,
+			publicKey: otherKey.Public(),
+			wantErr:   zerrors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyDKIMRecord(tt.record, tt.publicKey)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_dkimSigner_Sign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	message := "From: ZITADEL <noreply@example.com>\r\n" +
+		"To: user@example.com\r\n" +
+		"Date: Mon, 02 Jan 2006 15:04:05 +0000\r\n" +
+		"Subject: Verify   email\r\n" +
+		"MIME-version: 1.0;\r\n" +
+		"Content-Type: text/html; charset=\"UTF-8\";\r\n\r\n" +
+		"\r\n<p>Hello user</p>  \n<p>Your code</p>\n\n"
+
+	tests := []struct {
+		name      string
+		key       crypto.Signer
+		algorithm string
+	}{
+		{
+			name:      "rsa",
+			key:       rsaKey,
+			algorithm: "rsa-sha256",
+		},
+		{
+			name:      "ed25519",
+			key:       edKey,
+			algorithm: "ed25519-sha256",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := &dkimSigner{selector: "zitadel", domain: "example.com", key: tt.key}
+			signed, err := signer.Sign(message, time.Unix(1136214245, 0))
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(signed, dkimSignatureHeader+":"))
+			for _, line := range strings.Split(signed, "\r\n") {
+				assert.NotContains(t, line, "\n")
+				assert.LessOrEqual(t, len(line), 998)
+			}
+
+			header, body, _ := strings.Cut(signed, "\r\n\r\n")
+			fields := splitHeaders(header)
+			signatureField := fields[0]
+			_, value, _ := strings.Cut(signatureField, ":")
+			tags, err := parseDKIMTags(strings.ReplaceAll(value, "\r\n", ""))
+			require.NoError(t, err)
+			assert.Equal(t, "1", tags["v"])
+			assert.Equal(t, tt.algorithm, tags["a"])
+			assert.Equal(t, "relaxed/relaxed", tags["c"])
+			assert.Equal(t, "example.com", tags["d"])
+			assert.Equal(t, "zitadel", tags["s"])
+			assert.Equal(t, "1136214245", tags["t"])
+			assert.Equal(t, "from:to:subject:date:mime-version:content-type", tags["h"])
+
+			bodyHash := sha256.Sum256([]byte("\r\n<p>Hello user</p>\r\n<p>Your code</p>\r\n"))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(bodyHash[:]), tags["bh"])
+
+			// verify the signature as a receiver would (RFC 6376 section 6.1.3)
+			var data strings.Builder
+			for _, name := range strings.Split(tags["h"], ":") {
+				field, ok := findHeader(fields[1:], name)
+				require.True(t, ok)
+				data.WriteString(relaxedHeader(field))
+			}
+			withoutSignature := signatureField[:strings.LastIndex(signatureField, "b=")+2]
+			data.WriteString(strings.TrimSuffix(relaxedHeader(withoutSignature), "\r\n"))
+			signature, err := base64.StdEncoding.DecodeString(tags["b"])
+			require.NoError(t, err)
+			hash := sha256.Sum256([]byte(data.String()))
+			switch key := tt.key.(type) {
+			case *rsa.PrivateKey:
+				assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+			case ed25519.PrivateKey:
+				assert.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), hash[:], signature))
+			}
+			assert.Equal(t, "\r\n<p>Hello user</p>  \r\n<p>Your code</p>\r\n\r\n", body)
+		})
+	}
+}
+
+func pemPKCS8(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/repository/milestone"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 )
@@ -18,6 +19,9 @@ type Commands interface {
 	UserDomainClaimedSent(ctx context.Context, orgID, userID string) error
 	HumanPasswordlessInitCodeSent(ctx context.Context, userID, resourceOwner, codeID string) error
 	PasswordChangeSent(ctx context.Context, orgID, userID string) error
+	PasswordExpiryWarningSent(ctx context.Context, orgID, userID string) error
+	AddPasswordExpiryWarning(ctx context.Context, orgID, userID string, policy *domain.PasswordAgePolicy) error
+	SecurityAlertSent(ctx context.Context, orgID, userID, messageType string, triggeringSequence uint64) error
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, msType milestone.Type, endpoints []string, primaryDomain string) error
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GetActiveSMSConfig reads the active SMS provider config of the organization.
+// If the organization has none, the active iam SMS provider config is used.
+func (n *NotificationQueries) GetActiveSMSConfig(ctx context.Context, orgID string) (*sms.Config, error) {
+	config, err := n.activeSMSConfig(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if config.TwilioConfig != nil {
+		token, err := crypto.DecryptString(config.TwilioConfig.Token, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &sms.Config{
+			ID: config.ID,
+			TwilioConfig: &twilio.Config{
+				SID:          config.TwilioConfig.SID,
+				Token:        token,
+				SenderNumber: config.TwilioConfig.SenderNumber,
+			},
+		}, nil
+	}
+	if config.HTTPConfig != nil {
+		var headers http.Header
+		if config.HTTPConfig.Headers != nil {
+			decrypted, err := crypto.Decrypt(config.HTTPConfig.Headers, n.SMSTokenCrypto)
+			if err != nil {
+				return nil, err
+			}
+			if err = json.Unmarshal(decrypted, &headers); err != nil {
+				return nil, zerrors.ThrowInternal(err, "HANDLER-Yq3sv", "Errors.Internal")
+			}
+		}
+		return &sms.Config{
+			ID: config.ID,
+			HTTPConfig: &httpsms.Config{
+				Endpoint:           config.HTTPConfig.Endpoint,
+				Method:             config.HTTPConfig.Method,
+				Headers:            headers,
+				BodyTemplate:       config.HTTPConfig.BodyTemplate,
+				ContentType:        config.HTTPConfig.ContentType,
+				SenderID:           config.HTTPConfig.SenderID,
+				SuccessStatusCodes: config.HTTPConfig.SuccessStatusCodes,
+				SuccessJSONPath:    config.HTTPConfig.SuccessJSONPath,
+				SuccessJSONValue:   config.HTTPConfig.SuccessJSONValue,
+			},
+		}, nil
+	}
+	return nil, zerrors.ThrowNotFound(nil, "HANDLER-8nfow", "Errors.SMSConfig.NotFound")
+}
+
+func (n *NotificationQueries) activeSMSConfig(ctx context.Context, orgID string) (*query.SMSConfig, error) {
+	active, err := query.NewSMSProviderStateQuery(domain.SMSConfigStateActive)
+	if err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if orgID != "" && orgID != instanceID {
+		orgOwner, err := query.NewSMSProviderResourceOwnerQuery(orgID)
+		if err != nil {
+			return nil, err
+		}
+		config, err := n.SMSProviderConfig(ctx, active, orgOwner)
+		if err == nil {
+			return config, nil
+		}
+		if !zerrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	instanceOwner, err := query.NewSMSProviderResourceOwnerQuery(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return n.SMSProviderConfig(ctx, active, instanceOwner)
+}
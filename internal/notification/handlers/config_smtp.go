@@ -2,32 +2,153 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-// GetSMTPConfig reads the iam SMTP provider config
-func (n *NotificationQueries) GetSMTPConfig(ctx context.Context) (*smtp.Config, error) {
-	config, err := n.SMTPConfigActive(ctx, authz.GetInstance(ctx).InstanceID())
+// GetActiveSMTPConfigs reads the active SMTP provider configs of the organization.
+// If the organization has none, the active iam SMTP provider configs ordered by their priority are used.
+func (n *NotificationQueries) GetActiveSMTPConfigs(ctx context.Context, orgID string) ([]*smtp.Config, error) {
+	configs, err := n.activeSMTPConfigs(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	smtpConfigs := make([]*smtp.Config, len(configs))
+	for i, config := range configs {
+		if config.HTTPConfig != nil {
+			smtpConfigs[i], err = n.httpEmailConfig(config)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
+		if err != nil {
+			return nil, err
+		}
+		dkim, err := n.dkimConfig(config.DKIM)
+		if err != nil {
+			return nil, err
+		}
+		smtpConfigs[i] = &smtp.Config{
+			ID:             config.ID,
+			Description:    config.Description,
+			From:           config.SenderAddress,
+			FromName:       config.SenderName,
+			ReplyToAddress: config.ReplyToAddress,
+			Tls:            config.TLS,
+			SMTP: smtp.SMTP{
+				Host:     config.Host,
+				User:     config.User,
+				Password: password,
+			},
+			DKIM: dkim,
+		}
+	}
+	return smtpConfigs, nil
+}
+
+func (n *NotificationQueries) activeSMTPConfigs(ctx context.Context, orgID string) ([]*query.SMTPConfig, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if orgID != "" && orgID != instanceID {
+		configs, err := n.SMTPConfigsActive(ctx, orgID)
+		if err != nil && !zerrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			configs, err = n.verifiedSenderConfigs(ctx, orgID, configs)
+			if err != nil {
+				return nil, err
+			}
+			if len(configs) > 0 {
+				return configs, nil
+			}
+		}
+	}
+	return n.SMTPConfigsActive(ctx, instanceID)
+}
+
+// verifiedSenderConfigs returns the SMTP configs of the organization whose sender address is on a verified domain of the organization.
+// The domain is checked when the config is set, but it might have been removed since.
+func (n *NotificationQueries) verifiedSenderConfigs(ctx context.Context, orgID string, configs []*query.SMTPConfig) ([]*query.SMTPConfig, error) {
+	orgIDQuery, err := query.NewOrgDomainOrgIDSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	verifiedQuery, err := query.NewOrgDomainVerifiedSearchQuery(true)
 	if err != nil {
 		return nil, err
 	}
-	password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
+	domains, err := n.SearchOrgDomains(ctx, &query.OrgDomainSearchQueries{Queries: []query.SearchQuery{orgIDQuery, verifiedQuery}}, false)
 	if err != nil {
 		return nil, err
 	}
+	verified := make([]*query.SMTPConfig, 0, len(configs))
+	for _, config := range configs {
+		senderDomain := config.SenderAddress[strings.LastIndex(config.SenderAddress, "@")+1:]
+		if slices.ContainsFunc(domains.Domains, func(domain *query.Domain) bool {
+			return strings.EqualFold(domain.Domain, senderDomain)
+		}) {
+			verified = append(verified, config)
+			continue
+		}
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "org", orgID, "config", config.ID).Warn("sender address of SMTP config is not on a verified domain of the organization, config skipped")
+	}
+	return verified, nil
+}
+
+func (n *NotificationQueries) httpEmailConfig(config *query.SMTPConfig) (*smtp.Config, error) {
+	var headers http.Header
+	if config.HTTPConfig.Headers != nil {
+		decrypted, err := crypto.Decrypt(config.HTTPConfig.Headers, n.SMTPPasswordCrypto)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(decrypted, &headers); err != nil {
+			return nil, zerrors.ThrowInternal(err, "HANDLER-Ux5mp", "Errors.Internal")
+		}
+	}
 	return &smtp.Config{
+		ID:             config.ID,
 		Description:    config.Description,
 		From:           config.SenderAddress,
 		FromName:       config.SenderName,
 		ReplyToAddress: config.ReplyToAddress,
-		Tls:            config.TLS,
-		SMTP: smtp.SMTP{
-			Host:     config.Host,
-			User:     config.User,
-			Password: password,
+		HTTP: &httpemail.Config{
+			SenderAddress:      config.SenderAddress,
+			SenderName:         config.SenderName,
+			ReplyToAddress:     config.ReplyToAddress,
+			Endpoint:           config.HTTPConfig.Endpoint,
+			Headers:            headers,
+			BodyTemplate:       config.HTTPConfig.BodyTemplate,
+			ContentType:        config.HTTPConfig.ContentType,
+			SuccessStatusCodes: config.HTTPConfig.SuccessStatusCodes,
 		},
 	}, nil
 }
+
+func (n *NotificationQueries) dkimConfig(config *query.SMTPDKIM) (*smtp.DKIM, error) {
+	if config == nil {
+		return nil, nil
+	}
+	privateKey, err := crypto.Decrypt(config.PrivateKey, n.SMTPPasswordCrypto)
+	if err != nil {
+		return nil, err
+	}
+	return &smtp.DKIM{
+		Selector:   config.Selector,
+		Domain:     config.Domain,
+		PrivateKey: privateKey,
+	}, nil
+}
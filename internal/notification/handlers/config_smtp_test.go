@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestNotificationQueries_activeSMTPConfigs(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	instanceConfig := &query.SMTPConfig{ID: "instance-config", SenderAddress: "noreply@instance.com"}
+	orgConfig := &query.SMTPConfig{ID: "org-config", SenderAddress: "noreply@Org.com"}
+	tests := []struct {
+		name    string
+		orgID   string
+		expect  func(queries *mock.MockQueriesMockRecorder)
+		want    []*query.SMTPConfig
+		wantErr bool
+	}{
+		{
+			name:  "instance, instance configs",
+			orgID: "instance",
+			expect: func(queries *mock.MockQueriesMockRecorder) {
+				queries.SMTPConfigsActive(gomock.Any(), "instance").Return([]*query.SMTPConfig{instanceConfig}, nil)
+			},
+			want: []*query.SMTPConfig{instanceConfig},
+		},
+		{
+			name:  "org without configs, instance configs",
+			orgID: "org",
+			expect: func(queries *mock.MockQueriesMockRecorder) {
+				queries.SMTPConfigsActive(gomock.Any(), "org").Return(nil, zerrors.ThrowNotFound(nil, "id", "not found"))
+				queries.SMTPConfigsActive(gomock.Any(), "instance").Return([]*query.SMTPConfig{instanceConfig}, nil)
+			},
+			want: []*query.SMTPConfig{instanceConfig},
+		},
+		{
+			name:  "org config on verified domain, org configs",
+			orgID: "org",
+			expect: func(queries *mock.MockQueriesMockRecorder) {
+				queries.SMTPConfigsActive(gomock.Any(), "org").Return([]*query.SMTPConfig{orgConfig}, nil)
+				queries.SearchOrgDomains(gomock.Any(), gomock.Any(), false).Return(&query.Domains{
+					Domains: []*query.Domain{{Domain: "org.com", OrgID: "org", IsVerified: true}},
+				}, nil)
+			},
+			want: []*query.SMTPConfig{orgConfig},
+		},
+		{
+			name:  "org domain removed, instance configs",
+			orgID: "org",
+			expect: func(queries *mock.MockQueriesMockRecorder) {
+				queries.SMTPConfigsActive(gomock.Any(), "org").Return([]*query.SMTPConfig{orgConfig}, nil)
+				queries.SearchOrgDomains(gomock.Any(), gomock.Any(), false).Return(&query.Domains{
+					Domains: []*query.Domain{{Domain: "other.com", OrgID: "org", IsVerified: true}},
+				}, nil)
+				queries.SMTPConfigsActive(gomock.Any(), "instance").Return([]*query.SMTPConfig{instanceConfig}, nil)
+			},
+			want: []*query.SMTPConfig{instanceConfig},
+		},
+		{
+			name:  "org domains query error, error",
+			orgID: "org",
+			expect: func(queries *mock.MockQueriesMockRecorder) {
+				queries.SMTPConfigsActive(gomock.Any(), "org").Return([]*query.SMTPConfig{orgConfig}, nil)
+				queries.SearchOrgDomains(gomock.Any(), gomock.Any(), false).Return(nil, zerrors.ThrowInternal(nil, "id", "internal"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := mock.NewMockQueries(gomock.NewController(t))
+			tt.expect(queries.EXPECT())
+			n := &NotificationQueries{Queries: queries}
+			got, err := n.activeSMTPConfigs(ctx, tt.orgID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	domain "github.com/zitadel/zitadel/internal/domain"
 	milestone "github.com/zitadel/zitadel/internal/repository/milestone"
 	quota "github.com/zitadel/zitadel/internal/repository/quota"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,20 @@ func (m *MockCommands) EXPECT() *MockCommandsMockRecorder {
 	return m.recorder
 }
 
+// AddPasswordExpiryWarning mocks base method.
+func (m *MockCommands) AddPasswordExpiryWarning(arg0 context.Context, arg1, arg2 string, arg3 *domain.PasswordAgePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPasswordExpiryWarning", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPasswordExpiryWarning indicates an expected call of AddPasswordExpiryWarning.
+func (mr *MockCommandsMockRecorder) AddPasswordExpiryWarning(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPasswordExpiryWarning", reflect.TypeOf((*MockCommands)(nil).AddPasswordExpiryWarning), arg0, arg1, arg2, arg3)
+}
+
 // HumanEmailVerificationCodeSent mocks base method.
 func (m *MockCommands) HumanEmailVerificationCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -195,6 +210,34 @@ func (mr *MockCommandsMockRecorder) PasswordCodeSent(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordCodeSent", reflect.TypeOf((*MockCommands)(nil).PasswordCodeSent), arg0, arg1, arg2)
 }
 
+// PasswordExpiryWarningSent mocks base method.
+func (m *MockCommands) PasswordExpiryWarningSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordExpiryWarningSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PasswordExpiryWarningSent indicates an expected call of PasswordExpiryWarningSent.
+func (mr *MockCommandsMockRecorder) PasswordExpiryWarningSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordExpiryWarningSent", reflect.TypeOf((*MockCommands)(nil).PasswordExpiryWarningSent), arg0, arg1, arg2)
+}
+
+// SecurityAlertSent mocks base method.
+func (m *MockCommands) SecurityAlertSent(arg0 context.Context, arg1, arg2, arg3 string, arg4 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityAlertSent", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityAlertSent indicates an expected call of SecurityAlertSent.
+func (mr *MockCommandsMockRecorder) SecurityAlertSent(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityAlertSent", reflect.TypeOf((*MockCommands)(nil).SecurityAlertSent), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UsageNotificationSent mocks base method.
 func (m *MockCommands) UsageNotificationSent(arg0 context.Context, arg1 *quota.NotificationDueEvent) error {
 	m.ctrl.T.Helper()
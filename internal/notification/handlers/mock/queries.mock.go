@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/zitadel/zitadel/internal/domain"
 	query "github.com/zitadel/zitadel/internal/query"
@@ -161,6 +162,21 @@ func (mr *MockQueriesMockRecorder) NotificationProviderByIDAndType(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationProviderByIDAndType", reflect.TypeOf((*MockQueries)(nil).NotificationProviderByIDAndType), arg0, arg1, arg2)
 }
 
+// PasswordExpiryWarningsDue mocks base method.
+func (m *MockQueries) PasswordExpiryWarningsDue(arg0 context.Context, arg1 []string, arg2 time.Time, arg3 uint64) ([]*query.PasswordExpiryWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordExpiryWarningsDue", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*query.PasswordExpiryWarning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordExpiryWarningsDue indicates an expected call of PasswordExpiryWarningsDue.
+func (mr *MockQueriesMockRecorder) PasswordExpiryWarningsDue(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordExpiryWarningsDue", reflect.TypeOf((*MockQueries)(nil).PasswordExpiryWarningsDue), arg0, arg1, arg2, arg3)
+}
+
 // SMSProviderConfig mocks base method.
 func (m *MockQueries) SMSProviderConfig(arg0 context.Context, arg1 ...query.SearchQuery) (*query.SMSConfig, error) {
 	m.ctrl.T.Helper()
@@ -181,19 +197,34 @@ func (mr *MockQueriesMockRecorder) SMSProviderConfig(arg0 any, arg1 ...any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMSProviderConfig", reflect.TypeOf((*MockQueries)(nil).SMSProviderConfig), varargs...)
 }
 
-// SMTPConfigActive mocks base method.
-func (m *MockQueries) SMTPConfigActive(arg0 context.Context, arg1 string) (*query.SMTPConfig, error) {
+// SMTPConfigsActive mocks base method.
+func (m *MockQueries) SMTPConfigsActive(arg0 context.Context, arg1 string) ([]*query.SMTPConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMTPConfigsActive", arg0, arg1)
+	ret0, _ := ret[0].([]*query.SMTPConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMTPConfigsActive indicates an expected call of SMTPConfigsActive.
+func (mr *MockQueriesMockRecorder) SMTPConfigsActive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigsActive", reflect.TypeOf((*MockQueries)(nil).SMTPConfigsActive), arg0, arg1)
+}
+
+// SearchOrgDomains mocks base method.
+func (m *MockQueries) SearchOrgDomains(arg0 context.Context, arg1 *query.OrgDomainSearchQueries, arg2 bool) (*query.Domains, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SMTPConfigActive", arg0, arg1)
-	ret0, _ := ret[0].(*query.SMTPConfig)
+	ret := m.ctrl.Call(m, "SearchOrgDomains", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.Domains)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SMTPConfigActive indicates an expected call of SMTPConfigActive.
-func (mr *MockQueriesMockRecorder) SMTPConfigActive(arg0, arg1 any) *gomock.Call {
+// SearchOrgDomains indicates an expected call of SearchOrgDomains.
+func (mr *MockQueriesMockRecorder) SearchOrgDomains(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigActive", reflect.TypeOf((*MockQueries)(nil).SMTPConfigActive), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchOrgDomains", reflect.TypeOf((*MockQueries)(nil).SearchOrgDomains), arg0, arg1, arg2)
 }
 
 // SearchInstanceDomains mocks base method.
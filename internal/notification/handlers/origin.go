@@ -33,6 +33,12 @@ func (n *NotificationQueries) Origin(ctx context.Context, e eventstore.Event) (c
 		}
 		return enrichCtx(ctx, originURL.Hostname(), origin), nil
 	}
+	return n.PrimaryDomainOrigin(ctx)
+}
+
+// PrimaryDomainOrigin enriches the context with the primary domain of the instance as origin,
+// which is used for events which are not triggered by a request to a specific domain.
+func (n *NotificationQueries) PrimaryDomainOrigin(ctx context.Context) (context.Context, error) {
 	primary, err := query.NewInstanceDomainPrimarySearchQuery(true)
 	if err != nil {
 		return ctx, err
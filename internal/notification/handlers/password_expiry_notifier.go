@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	PasswordExpiryNotifierProjectionTable = "projections.password_expiry_notifier"
+)
+
+type PasswordExpiryNotifierConfig struct {
+	Enabled bool
+	// Limit is the maximum of warnings added per instance and run
+	Limit uint64
+}
+
+// passwordExpiryNotifier periodically adds the password expiry warnings of the users,
+// whose password expires within the warning period of the password age policy.
+// The warnings are sent by the user notifier.
+type passwordExpiryNotifier struct {
+	cfg      PasswordExpiryNotifierConfig
+	commands Commands
+	queries  *NotificationQueries
+}
+
+func NewPasswordExpiryNotifier(
+	ctx context.Context,
+	notifierCfg PasswordExpiryNotifierConfig,
+	handlerCfg handler.Config,
+	commands Commands,
+	queries *NotificationQueries,
+) *handler.Handler {
+	notifier := &passwordExpiryNotifier{
+		cfg:      notifierCfg,
+		commands: commands,
+		queries:  queries,
+	}
+	handlerCfg.TriggerWithoutEvents = notifier.addWarnings
+	return handler.NewHandler(
+		ctx,
+		&handlerCfg,
+		notifier,
+	)
+}
+
+func (*passwordExpiryNotifier) Name() string {
+	return PasswordExpiryNotifierProjectionTable
+}
+
+func (p *passwordExpiryNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: p.addWarnings,
+		}},
+	}}
+}
+
+func (p *passwordExpiryNotifier) addWarnings(event eventstore.Event) (*handler.Statement, error) {
+	ctx := call.WithTimestamp(context.Background())
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Fp2xr", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		warnings, err := p.queries.PasswordExpiryWarningsDue(ctx, scheduledEvent.InstanceIDs, scheduledEvent.Timestamp, p.cfg.Limit)
+		if err != nil {
+			return err
+		}
+		var errs int
+		for _, warning := range warnings {
+			if err = p.addWarning(warning); err != nil {
+				errs++
+				logging.WithFields("instance", warning.InstanceID, "user", warning.UserID).WithError(err).Warn("adding password expiry warning failed")
+			}
+		}
+		if errs > 0 {
+			return fmt.Errorf("adding %d of %d password expiry warnings failed", errs, len(warnings))
+		}
+		return nil
+	}), nil
+}
+
+func (p *passwordExpiryNotifier) addWarning(warning *query.PasswordExpiryWarning) error {
+	ctx := HandlerContext(&eventstore.Aggregate{
+		InstanceID:    warning.InstanceID,
+		ResourceOwner: warning.ResourceOwner,
+	})
+	return p.commands.AddPasswordExpiryWarning(ctx, warning.ResourceOwner, warning.UserID, warning.Policy)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+func Test_passwordExpiryNotifier_addWarnings(t *testing.T) {
+	now := time.Now()
+	policy := &domain.PasswordAgePolicy{
+		MaxAgeDays:     90,
+		ExpireWarnDays: 10,
+	}
+	tests := []struct {
+		name    string
+		expect  func(*mock.MockQueries, *mock.MockCommands)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "query failed, error",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().PasswordExpiryWarningsDue(gomock.Any(), []string{"instance1"}, now, uint64(100)).Return(nil, errors.New("query failed"))
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "warnings added",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().PasswordExpiryWarningsDue(gomock.Any(), []string{"instance1"}, now, uint64(100)).Return([]*query.PasswordExpiryWarning{
+					{InstanceID: "instance1", ResourceOwner: orgID, UserID: userID, Policy: policy},
+					{InstanceID: "instance1", ResourceOwner: orgID, UserID: "user2", Policy: policy},
+				}, nil)
+				commands.EXPECT().AddPasswordExpiryWarning(gomock.Any(), orgID, userID, policy).Return(nil)
+				commands.EXPECT().AddPasswordExpiryWarning(gomock.Any(), orgID, "user2", policy).Return(nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "warning failed, others added, error",
+			expect: func(queries *mock.MockQueries, commands *mock.MockCommands) {
+				queries.EXPECT().PasswordExpiryWarningsDue(gomock.Any(), []string{"instance1"}, now, uint64(100)).Return([]*query.PasswordExpiryWarning{
+					{InstanceID: "instance1", ResourceOwner: orgID, UserID: userID, Policy: policy},
+					{InstanceID: "instance1", ResourceOwner: orgID, UserID: "user2", Policy: policy},
+				}, nil)
+				commands.EXPECT().AddPasswordExpiryWarning(gomock.Any(), orgID, userID, policy).Return(errors.New("push failed"))
+				commands.EXPECT().AddPasswordExpiryWarning(gomock.Any(), orgID, "user2", policy).Return(nil)
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			tt.expect(queries, commands)
+			notifier := &passwordExpiryNotifier{
+				cfg: PasswordExpiryNotifierConfig{
+					Enabled: true,
+					Limit:   100,
+				},
+				commands: commands,
+				queries:  &NotificationQueries{Queries: queries},
+			}
+			stmt, err := notifier.addWarnings(pseudo.NewScheduledEvent(authz.WithInstanceID(context.Background(), "instance1"), now, "instance1"))
+			assert.NoError(t, err)
+			tt.wantErr(t, stmt.Execute(nil, ""))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PreviewMessage renders the message of the messageType in the language with sample data,
+// using the custom texts, the mail template and the label policy of the organization.
+// If orgID is empty, the ones of the instance are used.
+func PreviewMessage(ctx context.Context, queries Queries, orgID, messageType string, lang language.Tag) (*types.MessagePreview, error) {
+	if !domain.IsMessageTextType(messageType) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "HANDL-Ju3xn", "Errors.CustomMessageText.Invalid")
+	}
+	if orgID == "" {
+		orgID = authz.GetInstance(ctx).InstanceID()
+	}
+	colors, err := queries.ActiveLabelPolicyByOrg(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	template, err := queries.MailTemplateByOrg(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := translatorWithOrgTexts(ctx, queries, orgID, messageType)
+	if err != nil {
+		return nil, err
+	}
+	return types.RenderMessagePreview(ctx, string(template.Template), translator, colors, orgID, messageType, lang)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestPreviewMessage(t *testing.T) {
+	type args struct {
+		orgID       string
+		messageType string
+	}
+	tests := []struct {
+		name    string
+		queries func(*mock.MockQueries)
+		args    args
+		want    *types.MessagePreview
+		wantErr func(error) bool
+	}{
+		{
+			name:    "invalid message type, invalid argument error",
+			queries: func(*mock.MockQueries) {},
+			args: args{
+				orgID:       orgID,
+				messageType: "Unknown",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "invalid template, invalid argument error",
+			queries: func(queries *mock.MockQueries) {
+				expectPreviewQueries(queries, orgID, "{{.Unknown}}")
+			},
+			args: args{
+				orgID:       orgID,
+				messageType: "InitCode",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "email with sample data, ok",
+			queries: func(queries *mock.MockQueries) {
+				expectPreviewQueries(queries, orgID, "{{.Greeting}} {{.URL}} {{.LogoURL}}")
+			},
+			args: args{
+				orgID:       orgID,
+				messageType: "InitCode",
+			},
+			want: &types.MessagePreview{
+				Subject: "Initialize User",
+				HTML:    "Hello John Doe, " + eventOrigin + "/ui/login/user/init?authRequestID=&code=A1B2C3&loginname=john.doe%40example.com&orgID=" + orgID + "&passwordset=true&userID=123456789012345678 " + eventOrigin + assetsPath + "/" + policyID + "/" + logoURL,
+				Text:    "This user was created. Use the username john.doe@example.com to login. Please click the button below to finish the initialization process. (Code A1B2C3) If you didn't ask for this mail, please ignore it.",
+			},
+		},
+		{
+			name: "sms without org, instance texts used",
+			queries: func(queries *mock.MockQueries) {
+				expectPreviewQueries(queries, "instanceID", "{{.Text}}")
+			},
+			args: args{
+				messageType: "VerifyPhone",
+			},
+			want: &types.MessagePreview{
+				Subject: "Verify phone",
+				HTML:    "A new phone number has been added. Please use the following code to verify it A1B2C3",
+				Text:    "A new phone number has been added. Please use the following code to verify it A1B2C3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			tt.queries(queries)
+			ctx := http_util.WithComposedOrigin(authz.WithInstanceID(context.Background(), "instanceID"), eventOrigin)
+
+			got, err := PreviewMessage(ctx, queries, tt.args.orgID, tt.args.messageType, language.English)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func expectPreviewQueries(queries *mock.MockQueries, orgID, template string) {
+	queries.EXPECT().ActiveLabelPolicyByOrg(gomock.Any(), orgID, false).Return(&query.LabelPolicy{
+		ID: policyID,
+		Light: query.Theme{
+			LogoURL: logoURL,
+		},
+	}, nil)
+	queries.EXPECT().MailTemplateByOrg(gomock.Any(), orgID, false).Return(&query.MailTemplate{Template: []byte(template)}, nil)
+	queries.EXPECT().GetInstanceRestrictions(gomock.Any()).Return(query.Restrictions{
+		AllowedLanguages: []language.Tag{language.English},
+	}, nil)
+	queries.EXPECT().GetDefaultLanguage(gomock.Any()).Return(language.English)
+	queries.EXPECT().CustomTextListByTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(&query.CustomTexts{}, nil)
+}
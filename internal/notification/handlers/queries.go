@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/text/language"
 
@@ -22,9 +23,11 @@ type Queries interface {
 	SearchMilestones(ctx context.Context, instanceIDs []string, queries *query.MilestonesSearchQueries) (*query.Milestones, error)
 	NotificationProviderByIDAndType(ctx context.Context, aggID string, providerType domain.NotificationProviderType) (*query.DebugNotificationProvider, error)
 	SMSProviderConfig(ctx context.Context, queries ...query.SearchQuery) (*query.SMSConfig, error)
-	SMTPConfigActive(ctx context.Context, resourceOwner string) (*query.SMTPConfig, error)
+	SMTPConfigsActive(ctx context.Context, resourceOwner string) ([]*query.SMTPConfig, error)
+	SearchOrgDomains(ctx context.Context, queries *query.OrgDomainSearchQueries, withOwnerRemoved bool) (*query.Domains, error)
 	GetDefaultLanguage(ctx context.Context) language.Tag
 	GetInstanceRestrictions(ctx context.Context) (restrictions query.Restrictions, err error)
+	PasswordExpiryWarningsDue(ctx context.Context, instanceIDs []string, now time.Time, limit uint64) ([]*query.PasswordExpiryWarning, error)
 }
 
 type NotificationQueries struct {
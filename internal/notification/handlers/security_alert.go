@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// previousEmail reduces the verified email address of the user before the event
+type previousEmail struct {
+	event eventstore.Event
+
+	email    domain.EmailAddress
+	verified bool
+}
+
+func (p *previousEmail) Reduce() error {
+	return nil
+}
+
+func (p *previousEmail) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if event.Sequence() >= p.event.Sequence() {
+			continue
+		}
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			p.email, p.verified = e.EmailAddress, false
+		case *user.HumanRegisteredEvent:
+			p.email, p.verified = e.EmailAddress, false
+		case *user.HumanEmailChangedEvent:
+			p.email, p.verified = e.EmailAddress, false
+		case *user.HumanEmailVerifiedEvent:
+			p.verified = true
+		}
+	}
+}
+
+func (p *previousEmail) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(p.event.Aggregate().InstanceID).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(p.event.Aggregate().ID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1EmailVerifiedType,
+			user.HumanEmailVerifiedType,
+		).
+		Builder()
+}
+
+// PreviousVerifiedEmail returns the email address the user had verified before the event.
+// An empty address is returned if the previous address was not verified.
+func (n *NotificationQueries) PreviousVerifiedEmail(ctx context.Context, event eventstore.Event) (domain.EmailAddress, error) {
+	previous := &previousEmail{
+		event: event,
+	}
+	err := n.es.FilterToQueryReducer(ctx, previous)
+	if err != nil {
+		return "", err
+	}
+	if !previous.verified {
+		return "", nil
+	}
+	return previous.email, nil
+}
+
+// knownUserAgentLogins is the number of the latest logins of the user
+// in which the user agent must occur to be known
+const knownUserAgentLogins = 100
+
+// knownUserAgent checks if the user logged in with the user agent before the event
+type knownUserAgent struct {
+	event       eventstore.Event
+	userAgentID string
+
+	known bool
+	// loggedIn is set if the user logged in with any user agent before the event
+	loggedIn bool
+}
+
+func (k *knownUserAgent) Reduce() error {
+	return nil
+}
+
+func (k *knownUserAgent) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if event.Sequence() >= k.event.Sequence() {
+			continue
+		}
+		k.loggedIn = true
+		if authRequestInfo(event).UserAgentID == k.userAgentID {
+			k.known = true
+		}
+	}
+}
+
+func (k *knownUserAgent) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(k.event.Aggregate().InstanceID).
+		CreationDateBefore(k.event.CreatedAt()).
+		OrderDesc().
+		Limit(knownUserAgentLogins).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(k.event.Aggregate().ID).
+		EventTypes(
+			user.UserV1PasswordCheckSucceededType,
+			user.HumanPasswordCheckSucceededType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+		).
+		Builder()
+}
+
+// IsNewUserAgentLogin checks if the user agent of the login of the event is not used by the latest logins of the user.
+// The first login of the user at all is not considered as new user agent login.
+func (n *NotificationQueries) IsNewUserAgentLogin(ctx context.Context, event eventstore.Event, userAgentID string) (bool, error) {
+	known := &knownUserAgent{
+		event:       event,
+		userAgentID: userAgentID,
+	}
+	err := n.es.FilterToQueryReducer(ctx, known)
+	if err != nil {
+		return false, err
+	}
+	return known.loggedIn && !known.known, nil
+}
+
+// lockedByLockoutPolicy checks if the user was locked together with a failed check,
+// which is the case if the lockout policy locked the user.
+// The failed check is pushed together with the locked event and therefore has the same position.
+type lockedByLockoutPolicy struct {
+	event eventstore.Event
+
+	locked bool
+}
+
+func (l *lockedByLockoutPolicy) Reduce() error {
+	return nil
+}
+
+func (l *lockedByLockoutPolicy) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if event.Position() == l.event.Position() {
+			l.locked = true
+		}
+	}
+}
+
+func (l *lockedByLockoutPolicy) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(l.event.Aggregate().InstanceID).
+		// only the failed checks around the locked event are needed
+		PositionAfter(l.event.Position()-1).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(l.event.Aggregate().ID).
+		EventTypes(
+			user.HumanPasswordCheckFailedType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanOTPSMSCheckFailedType,
+			user.HumanOTPEmailCheckFailedType,
+		).
+		Builder()
+}
+
+// IsLockedByLockoutPolicy checks if the user was locked by the event because of too many failed checks
+func (n *NotificationQueries) IsLockedByLockoutPolicy(ctx context.Context, event eventstore.Event) (bool, error) {
+	locked := &lockedByLockoutPolicy{
+		event: event,
+	}
+	err := n.es.FilterToQueryReducer(ctx, locked)
+	if err != nil {
+		return false, err
+	}
+	return locked.locked, nil
+}
+
+func authRequestInfo(event eventstore.Event) *user.AuthRequestInfo {
+	var info *user.AuthRequestInfo
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	}
+	if info == nil {
+		return new(user.AuthRequestInfo)
+	}
+	return info
+}
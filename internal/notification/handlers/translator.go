@@ -10,20 +10,26 @@ import (
 )
 
 func (n *NotificationQueries) GetTranslatorWithOrgTexts(ctx context.Context, orgID, textType string) (*i18n.Translator, error) {
-	restrictions, err := n.Queries.GetInstanceRestrictions(ctx)
+	return translatorWithOrgTexts(ctx, n.Queries, orgID, textType)
+}
+
+// translatorWithOrgTexts returns the notification translator with the custom texts of the instance,
+// which are overwritten by the custom texts of the organization
+func translatorWithOrgTexts(ctx context.Context, queries Queries, orgID, textType string) (*i18n.Translator, error) {
+	restrictions, err := queries.GetInstanceRestrictions(ctx)
 	if err != nil {
 		return nil, err
 	}
-	translator, err := i18n.NewNotificationTranslator(n.GetDefaultLanguage(ctx), restrictions.AllowedLanguages)
+	translator, err := i18n.NewNotificationTranslator(queries.GetDefaultLanguage(ctx), restrictions.AllowedLanguages)
 	if err != nil {
 		return nil, err
 	}
 
-	allCustomTexts, err := n.CustomTextListByTemplate(ctx, authz.GetInstance(ctx).InstanceID(), textType, false)
+	allCustomTexts, err := queries.CustomTextListByTemplate(ctx, authz.GetInstance(ctx).InstanceID(), textType, false)
 	if err != nil {
 		return translator, nil
 	}
-	customTexts, err := n.CustomTextListByTemplate(ctx, orgID, textType, false)
+	customTexts, err := queries.CustomTextListByTemplate(ctx, orgID, textType, false)
 	if err != nil {
 		return translator, nil
 	}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// TriggeringEvent loads the event referenced by a notification delivery
+func (n *NotificationQueries) TriggeringEvent(ctx context.Context, triggeringEvent *notification.TriggeringEvent) (eventstore.Event, error) {
+	if triggeringEvent == nil || triggeringEvent.Sequence == 0 {
+		return nil, zerrors.ThrowNotFound(nil, "HANDL-Vq7dk", "Errors.Notification.Delivery.NotFound")
+	}
+	events, err := n.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(triggeringEvent.ResourceOwner).
+		SequenceGreater(triggeringEvent.Sequence-1).
+		Limit(1).
+		AddQuery().
+		AggregateTypes(triggeringEvent.AggregateType).
+		AggregateIDs(triggeringEvent.AggregateID).
+		EventTypes(triggeringEvent.EventType).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 || events[0].Sequence() != triggeringEvent.Sequence {
+		return nil, zerrors.ThrowNotFound(nil, "HANDL-Mw2ps", "Errors.Notification.Delivery.NotFound")
+	}
+	return events[0], nil
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/zitadel/logging"
+
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -13,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -106,6 +109,66 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailCodeAddedType,
 					Reduce: u.reduceOTPEmailCodeAdded,
 				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanOTPSMSAddedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanOTPEmailAddedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanOTPSMSRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanOTPEmailRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceEmailChanged,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceNewUserAgentLogin,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceNewUserAgentLogin,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceUserLocked,
+				},
+				{
+					Event:  user.HumanPasswordExpiryWarningAddedType,
+					Reduce: u.reducePasswordExpiryWarningAdded,
+				},
 			},
 		},
 		{
@@ -121,6 +184,15 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 				},
 			},
 		},
+		{
+			Aggregate: notification.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  notification.ResendRequestedEventType,
+					Reduce: u.reduceNotificationResendRequested,
+				},
+			},
+		},
 	}
 }
 
@@ -283,7 +355,7 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		}
 		notify := types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e)
 		if e.NotificationType == domain.NotificationTypeSms {
-			notify = types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, e)
+			notify = types.SendSMS(ctx, u.channels, translator, notifyUser, colors, e)
 		}
 		err = notify.SendPasswordCode(ctx, notifyUser, code, e.URLTemplate, e.AuthRequestID)
 		if err != nil {
@@ -373,7 +445,7 @@ func (u *userNotifier) reduceOTPSMS(
 	if err != nil {
 		return nil, err
 	}
-	notify := types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, event)
+	notify := types.SendSMS(ctx, u.channels, translator, notifyUser, colors, event)
 	err = notify.SendOTPSMSCode(ctx, plainCode, expiry)
 	if err != nil {
 		return nil, err
@@ -667,6 +739,254 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 	}), nil
 }
 
+// reducePasswordExpiryWarningAdded warns the user that the password expires soon.
+// The warning is not sent anymore if the password was changed in the meantime.
+func (u *userNotifier) reducePasswordExpiryWarningAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordExpiryWarningAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Wc7nt", "reduce.wrong.event.type %s", user.HumanPasswordExpiryWarningAddedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil,
+			user.HumanPasswordExpiryWarningSentType,
+			user.UserV1PasswordChangedType, user.HumanPasswordChangedType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordExpiryWarningMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.PrimaryDomainOrigin(ctx)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+			SendPasswordExpiryWarning(ctx, notifyUser, e.ExpirationDate)
+		if err != nil {
+			return err
+		}
+		return u.commands.PasswordExpiryWarningSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (u *userNotifier) reduceMFAAdded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanOTPVerifiedEvent,
+		*user.HumanU2FVerifiedEvent,
+		*user.HumanPasswordlessVerifiedEvent,
+		*user.HumanOTPSMSAddedEvent,
+		*user.HumanOTPEmailAddedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Nf4wq", "reduce.wrong.event.type %v", []eventstore.EventType{
+			user.HumanMFAOTPVerifiedType,
+			user.HumanU2FTokenVerifiedType,
+			user.HumanPasswordlessTokenVerifiedType,
+			user.HumanOTPSMSAddedType,
+			user.HumanOTPEmailAddedType,
+		})
+	}
+	return u.securityAlertStatement(event, domain.MFAAddedMessageType, nil,
+		func(ctx context.Context, notify types.Notify, notifyUser *query.NotifyUser) error {
+			return notify.SendMFAAdded(ctx, notifyUser)
+		},
+	)
+}
+
+func (u *userNotifier) reduceMFARemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanOTPRemovedEvent,
+		*user.HumanU2FRemovedEvent,
+		*user.HumanPasswordlessRemovedEvent,
+		*user.HumanOTPSMSRemovedEvent,
+		*user.HumanOTPEmailRemovedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Pq8vd", "reduce.wrong.event.type %v", []eventstore.EventType{
+			user.HumanMFAOTPRemovedType,
+			user.HumanU2FTokenRemovedType,
+			user.HumanPasswordlessTokenRemovedType,
+			user.HumanOTPSMSRemovedType,
+			user.HumanOTPEmailRemovedType,
+		})
+	}
+	return u.securityAlertStatement(event, domain.MFARemovedMessageType, nil,
+		func(ctx context.Context, notify types.Notify, notifyUser *query.NotifyUser) error {
+			return notify.SendMFARemoved(ctx, notifyUser)
+		},
+	)
+}
+
+// reduceEmailChanged notifies the user on the previous verified email address,
+// so the owner of the address is informed even if someone else changed it.
+func (u *userNotifier) reduceEmailChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Hd2uy", "reduce.wrong.event.type %s", user.HumanEmailChangedType)
+	}
+	return u.securityAlertStatement(e, domain.EmailChangedMessageType,
+		func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, error) {
+			previous, err := u.queries.PreviousVerifiedEmail(ctx, e)
+			if err != nil || previous == "" || previous == e.EmailAddress {
+				return nil, err
+			}
+			recipient := *notifyUser
+			recipient.VerifiedEmail = string(previous)
+			return &recipient, nil
+		},
+		func(ctx context.Context, notify types.Notify, notifyUser *query.NotifyUser) error {
+			return notify.SendEmailChanged(ctx, notifyUser)
+		},
+	)
+}
+
+// reduceNewUserAgentLogin notifies the user if the login was done with a user agent
+// the user has not logged in with before.
+func (u *userNotifier) reduceNewUserAgentLogin(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent,
+		*user.HumanPasswordlessCheckSucceededEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Wm7xk", "reduce.wrong.event.type %v", []eventstore.EventType{
+			user.HumanPasswordCheckSucceededType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+		})
+	}
+	info := authRequestInfo(event)
+	if info.UserAgentID == "" {
+		return handler.NewNoOpStatement(event), nil
+	}
+	return u.securityAlertStatement(event, domain.NewUserAgentLoginMessageType,
+		func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, error) {
+			isNew, err := u.queries.IsNewUserAgentLogin(ctx, event, info.UserAgentID)
+			if err != nil || !isNew {
+				return nil, err
+			}
+			return notifyUser, nil
+		},
+		func(ctx context.Context, notify types.Notify, notifyUser *query.NotifyUser) error {
+			browserInfo := info.BrowserInfo
+			if browserInfo == nil {
+				browserInfo = new(user.BrowserInfo)
+			}
+			return notify.SendNewUserAgentLogin(ctx, notifyUser, browserInfo.UserAgent, browserInfo.RemoteIP)
+		},
+	)
+}
+
+// reduceUserLocked notifies the user if the lockout policy locked the user
+// because of too many failed checks.
+func (u *userNotifier) reduceUserLocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLockedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Rz6bm", "reduce.wrong.event.type %s", user.UserLockedType)
+	}
+	return u.securityAlertStatement(e, domain.UserLockedMessageType,
+		func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, error) {
+			locked, err := u.queries.IsLockedByLockoutPolicy(ctx, e)
+			if err != nil || !locked {
+				return nil, err
+			}
+			return notifyUser, nil
+		},
+		func(ctx context.Context, notify types.Notify, notifyUser *query.NotifyUser) error {
+			return notify.SendUserLocked(ctx, notifyUser)
+		},
+	)
+}
+
+// securityAlertRecipient returns the user the alert is sent to.
+// If no user is returned, the alert is not sent.
+type securityAlertRecipient func(ctx context.Context, notifyUser *query.NotifyUser) (*query.NotifyUser, error)
+
+type securityAlertSender func(ctx context.Context, notify types.Notify, notifyUser *query.NotifyUser) error
+
+// securityAlertStatement sends the security alert of the messageType to the user of the event,
+// if security alerts are enabled by the notification policy of the organization.
+func (u *userNotifier) securityAlertStatement(event eventstore.Event, messageType string, recipient securityAlertRecipient, send securityAlertSender) (*handler.Statement, error) {
+	ctx := HandlerContext(event.Aggregate())
+	notificationPolicy, err := u.queries.NotificationPolicyByOrg(ctx, true, event.Aggregate().ResourceOwner, false)
+	if zerrors.IsNotFound(err) {
+		return handler.NewNoOpStatement(event), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !notificationPolicy.SecurityAlerts {
+		return handler.NewNoOpStatement(event), nil
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"messageType": messageType, "triggeringSequence": event.Sequence()}, user.HumanSecurityAlertSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		if recipient != nil {
+			notifyUser, err = recipient(ctx, notifyUser)
+			if err != nil {
+				return err
+			}
+		}
+		if notifyUser == nil || notifyUser.VerifiedEmail == "" {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, event.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+		template, err := u.queries.MailTemplateByOrg(ctx, event.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+		if err != nil {
+			return err
+		}
+		if _, ok := event.(OriginEvent); ok {
+			ctx, err = u.queries.Origin(ctx, event)
+		} else {
+			ctx, err = u.queries.PrimaryDomainOrigin(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		err = send(ctx, types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, event), notifyUser)
+		if err != nil {
+			return err
+		}
+		return u.commands.SecurityAlertSent(ctx, event.Aggregate().ResourceOwner, event.Aggregate().ID, messageType, event.Sequence())
+	}), nil
+}
+
 func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPhoneCodeAddedEvent)
 	if !ok {
@@ -709,7 +1029,7 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 		if err != nil {
 			return err
 		}
-		err = types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, e).
+		err = types.SendSMS(ctx, u.channels, translator, notifyUser, colors, e).
 			SendPhoneVerificationCode(ctx, code)
 		if err != nil {
 			return err
@@ -718,6 +1038,56 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	}), nil
 }
 
+// reduceNotificationResendRequested sends the message of a failed delivery again
+// by reducing the event which originally triggered the notification.
+// Messages which were sent in the meantime or are expired are not sent again.
+func (u *userNotifier) reduceNotificationResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.ResendRequestedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Rk4vq", "reduce.wrong.event.type %s", notification.ResendRequestedEventType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		triggeringEvent, err := u.queries.TriggeringEvent(ctx, e.TriggeringEvent)
+		if zerrors.IsNotFound(err) {
+			logging.WithFields("instance", event.Aggregate().InstanceID, "notification", event.Aggregate().ID).
+				OnError(err).Warn("unable to resend notification")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		reduce := u.reducer(triggeringEvent)
+		if reduce == nil {
+			return zerrors.ThrowInvalidArgumentf(nil, "HANDL-Zp8ct", "reduce.wrong.event.type %s", triggeringEvent.Type())
+		}
+		stmt, err := reduce(triggeringEvent)
+		if err != nil {
+			return err
+		}
+		if stmt.Execute == nil {
+			return nil
+		}
+		return stmt.Execute(ex, projectionName)
+	}), nil
+}
+
+// reducer returns the reduce func of the event or nil if the event is not handled by the user notifier
+func (u *userNotifier) reducer(event eventstore.Event) handler.Reduce {
+	for _, aggregateReducer := range u.Reducers() {
+		if aggregateReducer.Aggregate != event.Aggregate().Type {
+			continue
+		}
+		for _, eventReducer := range aggregateReducer.EventReducers {
+			if eventReducer.Event == event.Type() {
+				return eventReducer.Reduce
+			}
+		}
+	}
+	return nil
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreatedAt().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
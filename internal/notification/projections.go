@@ -17,8 +17,9 @@ var projections []*handler.Handler
 
 func Register(
 	ctx context.Context,
-	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig projection.CustomConfig,
+	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, passwordExpiryHandlerCustomConfig projection.CustomConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	passwordExpiryCfg handlers.PasswordExpiryNotifierConfig,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
@@ -30,12 +31,15 @@ func Register(
 	userEncryption, smtpEncryption, smsEncryption crypto.EncryptionAlgorithm,
 ) {
 	q := handlers.NewNotificationQueries(queries, es, externalDomain, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption)
-	c := newChannels(q)
+	c := newChannels(q, commands)
 	projections = append(projections, handlers.NewUserNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), commands, q, c, otpEmailTmpl))
 	projections = append(projections, handlers.NewQuotaNotifier(ctx, projection.ApplyCustomConfig(quotaHandlerCustomConfig), commands, q, c))
 	if telemetryCfg.Enabled {
 		projections = append(projections, handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c))
 	}
+	if passwordExpiryCfg.Enabled {
+		projections = append(projections, handlers.NewPasswordExpiryNotifier(ctx, passwordExpiryCfg, projection.ApplyCustomConfig(passwordExpiryHandlerCustomConfig), commands, q))
+	}
 }
 
 func Start(ctx context.Context) {
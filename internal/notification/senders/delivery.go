@@ -0,0 +1,43 @@
+package senders
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+// DeliveryRecorder is called after each attempt of a provider to send a message
+type DeliveryRecorder func(ctx context.Context, message channels.Message, provider string, err error)
+
+// DeliveryMetadata describes a notification beyond its message,
+// so the delivery attempts can be recorded for the notified user
+type DeliveryMetadata struct {
+	UserID        string
+	ResourceOwner string
+	MessageType   string
+}
+
+type deliveryMetadataKey struct{}
+
+func WithDeliveryMetadata(ctx context.Context, metadata *DeliveryMetadata) context.Context {
+	return context.WithValue(ctx, deliveryMetadataKey{}, metadata)
+}
+
+// DeliveryMetadataFromContext returns the metadata set by WithDeliveryMetadata or nil
+func DeliveryMetadataFromContext(ctx context.Context) *DeliveryMetadata {
+	metadata, _ := ctx.Value(deliveryMetadataKey{}).(*DeliveryMetadata)
+	return metadata
+}
+
+// recordDeliveries calls the recorder with the result of every message handled by the channel.
+// If no recorder is provided, the channel is returned as is.
+func recordDeliveries(ctx context.Context, channel channels.NotificationChannel, provider string, recorder DeliveryRecorder) channels.NotificationChannel {
+	if recorder == nil {
+		return channel
+	}
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		err := channel.HandleMessage(message)
+		recorder(ctx, message, provider, err)
+		return err
+	})
+}
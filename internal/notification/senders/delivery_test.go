@@ -0,0 +1,60 @@
+package senders
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+func TestRecordDeliveries(t *testing.T) {
+	sendErr := errors.New("send failed")
+	tests := []struct {
+		name     string
+		result   error
+		metadata *DeliveryMetadata
+	}{
+		{
+			name:   "delivered",
+			result: nil,
+			metadata: &DeliveryMetadata{
+				UserID:        "user1",
+				ResourceOwner: "org1",
+				MessageType:   "VerifyEmail",
+			},
+		},
+		{
+			name:   "failed",
+			result: sendErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = WithDeliveryMetadata(ctx, tt.metadata)
+			}
+			message := &messages.Email{}
+			var recorded int
+			channel := recordDeliveries(
+				ctx,
+				channels.HandleMessageFunc(func(channels.Message) error { return tt.result }),
+				"provider1",
+				func(ctx context.Context, gotMessage channels.Message, provider string, err error) {
+					recorded++
+					assert.Same(t, message, gotMessage)
+					assert.Equal(t, "provider1", provider)
+					assert.ErrorIs(t, err, tt.result)
+					assert.Equal(t, tt.metadata, DeliveryMetadataFromContext(ctx))
+				},
+			)
+			err := channel.HandleMessage(message)
+			assert.ErrorIs(t, err, tt.result)
+			assert.Equal(t, 1, recorded)
+		})
+	}
+}
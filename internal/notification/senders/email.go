@@ -3,43 +3,78 @@ package senders
 import (
 	"context"
 
-	"github.com/zitadel/logging"
-
-	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 )
 
-const smtpSpanName = "smtp.NotificationChannel"
+const (
+	smtpSpanName      = "smtp.NotificationChannel"
+	httpEmailSpanName = "httpemail.NotificationChannel"
+)
 
+// EmailChannels chains the SMTP and HTTP API providers as failover in the order of the emailConfigs, followed by the debug channels.
+// The providers connect to their server only when a message is sent, so an unreachable server is skipped in favour of the next one.
+// Every attempt of a provider is passed to the recordDelivery func, if provided.
 func EmailChannels(
 	ctx context.Context,
-	emailConfig *smtp.Config,
+	emailConfigs []*smtp.Config,
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
+	recordDelivery DeliveryRecorder,
 ) (chain *Chain, err error) {
-	channels := make([]channels.NotificationChannel, 0, 3)
-	p, err := smtp.InitChannel(emailConfig)
-	logging.WithFields(
-		"instance", authz.GetInstance(ctx).InstanceID(),
-	).OnError(err).Debug("initializing SMTP channel failed")
-	if err == nil {
-		channels = append(
-			channels,
-			instrumenting.Wrap(
+	providers := make([]channels.NotificationChannel, 0, len(emailConfigs))
+	for _, emailConfig := range emailConfigs {
+		channel, spanName := smtpChannel(emailConfig), smtpSpanName
+		if emailConfig.HTTP != nil {
+			channel, spanName = httpEmailChannel(ctx, emailConfig.HTTP), httpEmailSpanName
+		}
+		providers = append(
+			providers,
+			recordDeliveries(
 				ctx,
-				p,
-				smtpSpanName,
-				successMetricName,
-				failureMetricName,
+				instrumenting.WrapProvider(
+					ctx,
+					channel,
+					spanName,
+					successMetricName,
+					failureMetricName,
+					emailConfig.ID,
+				),
+				emailConfig.ID,
+				recordDelivery,
 			),
 		)
 	}
+	channels := make([]channels.NotificationChannel, 0, 3)
+	if len(providers) > 0 {
+		channels = append(channels, FailoverChannels(providers...))
+	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return ChainChannels(channels...), nil
 }
+
+func smtpChannel(emailConfig *smtp.Config) channels.NotificationChannel {
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		p, err := smtp.InitChannel(emailConfig)
+		if err != nil {
+			return err
+		}
+		return p.HandleMessage(message)
+	})
+}
+
+func httpEmailChannel(ctx context.Context, emailConfig *httpemail.Config) channels.NotificationChannel {
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		p, err := httpemail.InitChannel(ctx, *emailConfig)
+		if err != nil {
+			return err
+		}
+		return p.HandleMessage(message)
+	})
+}
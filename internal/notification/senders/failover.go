@@ -0,0 +1,29 @@
+package senders
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var _ channels.NotificationChannel = (*Failover)(nil)
+
+type Failover struct {
+	channels []channels.NotificationChannel
+}
+
+func FailoverChannels(channel ...channels.NotificationChannel) *Failover {
+	return &Failover{channels: channel}
+}
+
+// HandleMessage sends the message to the channels in the same order they were provided to FailoverChannels()
+// until one of them succeeds. The next channel is only tried if the error is unavailable (e.g. a transport error),
+// any other error is returned immediately.
+func (f *Failover) HandleMessage(message channels.Message) (err error) {
+	for i := range f.channels {
+		err = f.channels[i].HandleMessage(message)
+		if !zerrors.IsUnavailable(err) {
+			return err
+		}
+	}
+	return err
+}
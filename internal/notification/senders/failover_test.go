@@ -0,0 +1,61 @@
+package senders
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestFailover_HandleMessage(t *testing.T) {
+	unavailable := zerrors.ThrowUnavailable(nil, "TEST-Bk2sa", "unavailable")
+	invalid := errors.New("invalid")
+	tests := []struct {
+		name      string
+		results   []error
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "first succeeds",
+			results:   []error{nil, nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "first unavailable, second succeeds",
+			results:   []error{unavailable, nil},
+			wantCalls: 2,
+		},
+		{
+			name:      "first fails, no failover",
+			results:   []error{invalid, nil},
+			wantErr:   invalid,
+			wantCalls: 1,
+		},
+		{
+			name:      "all unavailable",
+			results:   []error{unavailable, unavailable},
+			wantErr:   unavailable,
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			providers := make([]channels.NotificationChannel, len(tt.results))
+			for i, result := range tt.results {
+				providers[i] = channels.HandleMessageFunc(func(channels.Message) error {
+					calls++
+					return result
+				})
+			}
+			err := FailoverChannels(providers...).HandleMessage(&messages.Email{})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
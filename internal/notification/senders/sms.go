@@ -3,36 +3,75 @@ package senders
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpsms"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 )
 
-const twilioSpanName = "twilio.NotificationChannel"
+const (
+	twilioSpanName  = "twilio.NotificationChannel"
+	httpSMSSpanName = "httpsms.NotificationChannel"
+)
 
+// SMSChannels chains the active SMS provider, followed by the debug channels.
+// Every attempt of the provider is passed to the recordDelivery func, if provided.
 func SMSChannels(
 	ctx context.Context,
-	twilioConfig *twilio.Config,
+	smsConfig *sms.Config,
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	successMetricName,
 	failureMetricName string,
+	recordDelivery DeliveryRecorder,
 ) (chain *Chain, err error) {
 	channels := make([]channels.NotificationChannel, 0, 3)
-	if twilioConfig != nil {
+	if smsConfig != nil && smsConfig.TwilioConfig != nil {
 		channels = append(
 			channels,
-			instrumenting.Wrap(
+			recordDeliveries(
 				ctx,
-				twilio.InitChannel(*twilioConfig),
-				twilioSpanName,
-				successMetricName,
-				failureMetricName,
+				instrumenting.Wrap(
+					ctx,
+					twilio.InitChannel(*smsConfig.TwilioConfig),
+					twilioSpanName,
+					successMetricName,
+					failureMetricName,
+				),
+				smsConfig.ID,
+				recordDelivery,
 			),
 		)
 	}
+	if smsConfig != nil && smsConfig.HTTPConfig != nil {
+		httpChannel, err := httpsms.InitChannel(ctx, *smsConfig.HTTPConfig)
+		logging.WithFields(
+			"instance", authz.GetInstance(ctx).InstanceID(),
+		).OnError(err).Debug("initializing HTTP SMS channel failed")
+		if err == nil {
+			channels = append(
+				channels,
+				recordDeliveries(
+					ctx,
+					instrumenting.Wrap(
+						ctx,
+						httpChannel,
+						httpSMSSpanName,
+						successMetricName,
+						failureMetricName,
+					),
+					smsConfig.ID,
+					recordDelivery,
+				),
+			)
+		}
+	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return ChainChannels(channels...), nil
 }
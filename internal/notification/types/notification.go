@@ -5,8 +5,8 @@ import (
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/templates"
@@ -22,7 +22,7 @@ type Notify func(
 
 type ChannelChains interface {
 	Email(context.Context) (*senders.Chain, *smtp.Config, error)
-	SMS(context.Context) (*senders.Chain, *twilio.Config, error)
+	SMS(context.Context) (*senders.Chain, *sms.Config, error)
 	Webhook(context.Context, webhook.Config) (*senders.Chain, error)
 }
 
@@ -48,7 +48,7 @@ func SendEmail(
 			return err
 		}
 		return generateEmail(
-			ctx,
+			withDeliveryMetadata(ctx, user, messageType),
 			channels,
 			user,
 			data.Subject,
@@ -59,7 +59,7 @@ func SendEmail(
 	}
 }
 
-func SendSMSTwilio(
+func SendSMS(
 	ctx context.Context,
 	channels ChannelChains,
 	translator *i18n.Translator,
@@ -76,7 +76,7 @@ func SendSMSTwilio(
 		args = mapNotifyUserToArgs(user, args)
 		data := GetTemplateData(ctx, translator, args, url, messageType, user.PreferredLanguage.String(), colors)
 		return generateSms(
-			ctx,
+			withDeliveryMetadata(ctx, user, messageType),
 			channels,
 			user,
 			data.Text,
@@ -103,3 +103,13 @@ func SendJSON(
 		)
 	}
 }
+
+// withDeliveryMetadata adds the notified user and the message type to the context,
+// so the delivery attempts of the channels can be recorded
+func withDeliveryMetadata(ctx context.Context, user *query.NotifyUser, messageType string) context.Context {
+	return senders.WithDeliveryMetadata(ctx, &senders.DeliveryMetadata{
+		UserID:        user.ID,
+		ResourceOwner: user.ResourceOwner,
+		MessageType:   messageType,
+	})
+}
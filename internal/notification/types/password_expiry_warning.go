@@ -0,0 +1,29 @@
+package types
+
+import (
+	"context"
+	"math"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendPasswordExpiryWarning(ctx context.Context, user *query.NotifyUser, expirationDate time.Time) error {
+	url := console.LoginHintLink(http_utils.ComposedOrigin(ctx), user.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["ExpirationDate"] = expirationDate.Format(time.DateOnly)
+	args["DaysLeft"] = daysLeft(expirationDate)
+	return notify(url, args, domain.PasswordExpiryWarningMessageType, true)
+}
+
+// daysLeft returns the number of started days until the expirationDate
+func daysLeft(expirationDate time.Time) int {
+	left := time.Until(expirationDate)
+	if left <= 0 {
+		return 0
+	}
+	return int(math.Ceil(left.Hours() / 24))
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"context"
+	"html"
+	"net"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	previewCode           = "A1B2C3"
+	previewCodeID         = "123456789012345678"
+	previewExpiry         = 5 * time.Minute
+	previewPasswordExpiry = 7 * 24 * time.Hour
+
+	previewUserAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0"
+)
+
+// MessagePreview is a message rendered with sample data, as it would be sent to a user
+type MessagePreview struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// RenderMessagePreview renders the message of the messageType in the language
+// with the mail template and the label policy, as it would be sent to a sample user of the organization.
+// The message is built the same way as the message sent to a real user,
+// but instead of sending it, the rendered subject, html and text are returned.
+func RenderMessagePreview(
+	ctx context.Context,
+	mailhtml string,
+	translator *i18n.Translator,
+	colors *query.LabelPolicy,
+	orgID,
+	messageType string,
+	lang language.Tag,
+) (*MessagePreview, error) {
+	user := previewUser(orgID, lang)
+	preview := new(MessagePreview)
+	notify := Notify(func(
+		url string,
+		args map[string]interface{},
+		messageType string,
+		_ bool,
+	) error {
+		args = mapNotifyUserToArgs(user, args)
+		data := GetTemplateData(ctx, translator, args, url, messageType, lang.String(), colors)
+		template, err := templates.GetParsedTemplate(mailhtml, data)
+		if err != nil {
+			return err
+		}
+		preview.Subject = data.Subject
+		preview.HTML = html.UnescapeString(template)
+		preview.Text = data.Text
+		return nil
+	})
+	var err error
+	switch messageType {
+	case domain.InitCodeMessageType:
+		err = notify.SendUserInitCode(ctx, user, previewCode, "")
+	case domain.PasswordResetMessageType:
+		err = notify.SendPasswordCode(ctx, user, previewCode, "", "")
+	case domain.VerifyEmailMessageType:
+		err = notify.SendEmailVerificationCode(ctx, user, previewCode, "", "")
+	case domain.VerifyPhoneMessageType:
+		err = notify.SendPhoneVerificationCode(ctx, previewCode)
+	case domain.VerifySMSOTPMessageType:
+		err = notify.SendOTPSMSCode(ctx, previewCode, previewExpiry)
+	case domain.VerifyEmailOTPMessageType:
+		err = notify.SendOTPEmailCode(ctx, "", previewCode, previewExpiry)
+	case domain.DomainClaimedMessageType:
+		err = notify.SendDomainClaimed(ctx, user, user.Username+"@temporary.example.com")
+	case domain.PasswordlessRegistrationMessageType:
+		err = notify.SendPasswordlessRegistrationLink(ctx, user, previewCode, previewCodeID, "")
+	case domain.PasswordChangeMessageType:
+		err = notify.SendPasswordChange(ctx, user)
+	case domain.MFAAddedMessageType:
+		err = notify.SendMFAAdded(ctx, user)
+	case domain.MFARemovedMessageType:
+		err = notify.SendMFARemoved(ctx, user)
+	case domain.EmailChangedMessageType:
+		err = notify.SendEmailChanged(ctx, user)
+	case domain.NewUserAgentLoginMessageType:
+		err = notify.SendNewUserAgentLogin(ctx, user, previewUserAgent, net.IPv4(192, 0, 2, 1))
+	case domain.UserLockedMessageType:
+		err = notify.SendUserLocked(ctx, user)
+	case domain.PasswordExpiryWarningMessageType:
+		err = notify.SendPasswordExpiryWarning(ctx, user, time.Now().Add(previewPasswordExpiry))
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "TYPES-Zc4ht", "Errors.CustomMessageText.Invalid")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "TYPES-Rb8wq", "Errors.CustomMessageText.Invalid")
+	}
+	return preview, nil
+}
+
+// previewUser returns the sample user the preview messages are rendered for
+func previewUser(orgID string, lang language.Tag) *query.NotifyUser {
+	return &query.NotifyUser{
+		ID:                 "123456789012345678",
+		ResourceOwner:      orgID,
+		Username:           "john.doe",
+		FirstName:          "John",
+		LastName:           "Doe",
+		NickName:           "Johnny",
+		DisplayName:        "John Doe",
+		PreferredLanguage:  lang,
+		LastEmail:          "john.doe@example.com",
+		VerifiedEmail:      "john.doe@example.com",
+		LastPhone:          "+41 79 123 45 67",
+		VerifiedPhone:      "+41 79 123 45 67",
+		PasswordSet:        true,
+		PreferredLoginName: "john.doe@example.com",
+		LoginNames:         []string{"john.doe@example.com"},
+	}
+}
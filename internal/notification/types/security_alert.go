@@ -0,0 +1,44 @@
+package types
+
+import (
+	"context"
+	"net"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendMFAAdded(ctx context.Context, user *query.NotifyUser) error {
+	return notify.sendSecurityAlert(ctx, user, make(map[string]interface{}), domain.MFAAddedMessageType)
+}
+
+func (notify Notify) SendMFARemoved(ctx context.Context, user *query.NotifyUser) error {
+	return notify.sendSecurityAlert(ctx, user, make(map[string]interface{}), domain.MFARemovedMessageType)
+}
+
+func (notify Notify) SendEmailChanged(ctx context.Context, user *query.NotifyUser) error {
+	return notify.sendSecurityAlert(ctx, user, make(map[string]interface{}), domain.EmailChangedMessageType)
+}
+
+func (notify Notify) SendNewUserAgentLogin(ctx context.Context, user *query.NotifyUser, userAgent string, remoteIP net.IP) error {
+	args := make(map[string]interface{})
+	args["UserAgent"] = userAgent
+	args["RemoteIP"] = ""
+	if remoteIP != nil {
+		args["RemoteIP"] = remoteIP.String()
+	}
+	return notify.sendSecurityAlert(ctx, user, args, domain.NewUserAgentLoginMessageType)
+}
+
+func (notify Notify) SendUserLocked(ctx context.Context, user *query.NotifyUser) error {
+	return notify.sendSecurityAlert(ctx, user, make(map[string]interface{}), domain.UserLockedMessageType)
+}
+
+// sendSecurityAlert sends the alert to the verified email address of the user only,
+// as an unverified address might have been set by someone else.
+func (notify Notify) sendSecurityAlert(ctx context.Context, user *query.NotifyUser, args map[string]interface{}, messageType string) error {
+	url := console.LoginHintLink(http_utils.ComposedOrigin(ctx), user.PreferredLoginName)
+	return notify(url, args, messageType, false)
+}
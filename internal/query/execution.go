@@ -11,6 +11,8 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -40,6 +42,14 @@ var (
 		name:  projection.ExecutionSequenceCol,
 		table: executionTable,
 	}
+	ExecutionColumnCondition = Column{
+		name:  projection.ExecutionConditionCol,
+		table: executionTable,
+	}
+	ExecutionColumnMutableFields = Column{
+		name:  projection.ExecutionMutableFieldsCol,
+		table: executionTable,
+	}
 
 	executionTargetsTable = table{
 		name:          projection.ExecutionTable + "_" + projection.ExecutionTargetSuffix,
@@ -65,6 +75,8 @@ var (
 	executionTargetsQuery string
 	//go:embed targets_by_execution_id.sql
 	TargetsByExecutionIDQuery string
+	//go:embed execution_target_by_id.sql
+	executionTargetByIDQuery string
 	//go:embed targets_by_execution_ids.sql
 	TargetsByExecutionIDsQuery string
 )
@@ -82,7 +94,9 @@ type Execution struct {
 	ID string
 	domain.ObjectDetails
 
-	Targets []*exec.Target
+	Targets       []*exec.Target
+	Condition     string
+	MutableFields []string
 }
 
 type ExecutionSearchQueries struct {
@@ -170,7 +184,7 @@ func (q *Queries) TargetsByExecutionID(ctx context.Context, ids []string) (execu
 
 	err = q.client.QueryContext(ctx,
 		func(rows *sql.Rows) error {
-			execution, err = scanExecutionTargets(rows)
+			execution, err = scanExecutionTargets(rows, q.targetEncryption)
 			return err
 		},
 		TargetsByExecutionIDQuery,
@@ -180,6 +194,30 @@ func (q *Queries) TargetsByExecutionID(ctx context.Context, ids []string) (execu
 	return execution, err
 }
 
+// ExecutionTargetByID query the target with the information needed to call it, independent of an execution
+func (q *Queries) ExecutionTargetByID(ctx context.Context, id string) (target *ExecutionTarget, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	var targets []*ExecutionTarget
+	err = q.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			targets, err = scanExecutionTargets(rows, q.targetEncryption)
+			return err
+		},
+		executionTargetByIDQuery,
+		authz.GetInstance(ctx).InstanceID(),
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-q2n6u9xw1t", "Errors.Target.NotFound")
+	}
+	return targets[0], nil
+}
+
 // TargetsByExecutionIDs query list of targets for best matches of 2 separate lists of IDs, combined for performance, for example:
 // [ "request/zitadel.action.v3alpha.ActionService/GetTargetByID",
 // "request/zitadel.action.v3alpha.ActionService",
@@ -199,7 +237,7 @@ func (q *Queries) TargetsByExecutionIDs(ctx context.Context, ids1, ids2 []string
 
 	err = q.client.QueryContext(ctx,
 		func(rows *sql.Rows) error {
-			execution, err = scanExecutionTargets(rows)
+			execution, err = scanExecutionTargets(rows, q.targetEncryption)
 			return err
 		},
 		TargetsByExecutionIDsQuery,
@@ -210,12 +248,60 @@ func (q *Queries) TargetsByExecutionIDs(ctx context.Context, ids1, ids2 []string
 	return execution, err
 }
 
+// EventExecutionIDs returns the IDs of the executions set on events grouped by the instances, for all instances
+func (q *Queries) EventExecutionIDs(ctx context.Context) (ids map[string][]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareEventExecutionIDsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Or{
+		sq.Eq{ExecutionColumnID.identifier(): exec.IDAll(domain.ExecutionTypeEvent)},
+		sq.Like{ExecutionColumnID.identifier(): exec.ID(domain.ExecutionTypeEvent, "") + "%"},
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-w9dkq3rcx5", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		ids, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-h3xv7nbm2p", "Errors.Internal")
+	}
+	return ids, nil
+}
+
+func prepareEventExecutionIDsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (map[string][]string, error)) {
+	return sq.Select(
+			ExecutionColumnInstanceID.identifier(),
+			ExecutionColumnID.identifier(),
+		).From(executionTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (map[string][]string, error) {
+			ids := make(map[string][]string)
+			for rows.Next() {
+				var instanceID, id string
+				if err := rows.Scan(&instanceID, &id); err != nil {
+					return nil, err
+				}
+				ids[instanceID] = append(ids[instanceID], id)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-k4r8tq2nfz", "Errors.Query.CloseRows")
+			}
+			return ids, nil
+		}
+}
+
 func prepareExecutionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Execution, error)) {
 	return sq.Select(
 			ExecutionColumnInstanceID.identifier(),
 			ExecutionColumnID.identifier(),
 			ExecutionColumnChangeDate.identifier(),
 			ExecutionColumnSequence.identifier(),
+			ExecutionColumnCondition.identifier(),
+			ExecutionColumnMutableFields.identifier(),
 			executionTargetsListCol.identifier(),
 		).From(executionTable.identifier()).
 			Join("(" + executionTargetsQuery + ") AS " + executionTargetsTableAlias.alias + " ON " +
@@ -232,6 +318,8 @@ func prepareExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			ExecutionColumnID.identifier(),
 			ExecutionColumnChangeDate.identifier(),
 			ExecutionColumnSequence.identifier(),
+			ExecutionColumnCondition.identifier(),
+			ExecutionColumnMutableFields.identifier(),
 			executionTargetsListCol.identifier(),
 			countColumn.identifier(),
 		).From(executionTable.identifier()).
@@ -252,12 +340,15 @@ type executionTarget struct {
 func scanExecution(row *sql.Row) (*Execution, error) {
 	execution := new(Execution)
 	targets := make([]byte, 0)
+	mutableFields := database.TextArray[string]{}
 
 	err := row.Scan(
 		&execution.ResourceOwner,
 		&execution.ID,
 		&execution.EventDate,
 		&execution.Sequence,
+		&execution.Condition,
+		&mutableFields,
 		&targets,
 	)
 	if err != nil {
@@ -266,6 +357,7 @@ func scanExecution(row *sql.Row) (*Execution, error) {
 		}
 		return nil, zerrors.ThrowInternal(err, "QUERY-f8sjvm4tb8", "Errors.Internal")
 	}
+	execution.MutableFields = mutableFields
 
 	executionTargets := make([]*executionTarget, 0)
 	if err := json.Unmarshal(targets, &executionTargets); err != nil {
@@ -311,12 +403,15 @@ func scanExecutions(rows *sql.Rows) (*Executions, error) {
 	for rows.Next() {
 		execution := new(Execution)
 		targets := make([]byte, 0)
+		mutableFields := database.TextArray[string]{}
 
 		err := rows.Scan(
 			&execution.ResourceOwner,
 			&execution.ID,
 			&execution.EventDate,
 			&execution.Sequence,
+			&execution.Condition,
+			&mutableFields,
 			&targets,
 			&count,
 		)
@@ -326,6 +421,7 @@ func scanExecutions(rows *sql.Rows) (*Executions, error) {
 			}
 			return nil, zerrors.ThrowInternal(err, "QUERY-tyw2ydsj84", "Errors.Internal")
 		}
+		execution.MutableFields = mutableFields
 
 		execution.Targets, err = executionTargetsUnmarshal(targets)
 		if err != nil {
@@ -354,6 +450,26 @@ type ExecutionTarget struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	MaxAttempts      uint8
+	SigningKey       string
+
+	PreviousSigningKey           string
+	PreviousSigningKeyExpiration time.Time
+
+	Headers           map[string]string
+	JWTAuthentication bool
+	ClientCertificate []byte
+	ClientKey         []byte
+	RootCAs           []byte
+
+	MaxConcurrency   uint16
+	FailureThreshold uint16
+	OpenDuration     time.Duration
+
+	// Conditions of the execution and the includes leading to the target
+	Conditions []string
+	// MutableFields of the matched execution, which the target is allowed to change
+	MutableFields []string
 }
 
 func (e *ExecutionTarget) GetExecutionID() string {
@@ -375,7 +491,43 @@ func (e *ExecutionTarget) GetTimeout() time.Duration {
 	return e.Timeout
 }
 
-func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
+// GetSigningKeys returns the signing key of the target,
+// and the previous signing key as long as the grace period of the rotation is not expired.
+func (e *ExecutionTarget) GetSigningKeys() []string {
+	if e.SigningKey == "" {
+		return nil
+	}
+	if e.PreviousSigningKey != "" && time.Now().Before(e.PreviousSigningKeyExpiration) {
+		return []string{e.SigningKey, e.PreviousSigningKey}
+	}
+	return []string{e.SigningKey}
+}
+func (e *ExecutionTarget) GetHeaders() map[string]string {
+	return e.Headers
+}
+func (e *ExecutionTarget) IsJWTAuthentication() bool {
+	return e.JWTAuthentication
+}
+func (e *ExecutionTarget) GetClientCertificate() (certificate, key []byte) {
+	return e.ClientCertificate, e.ClientKey
+}
+func (e *ExecutionTarget) GetRootCAs() []byte {
+	return e.RootCAs
+}
+func (e *ExecutionTarget) GetMaxConcurrency() uint16 {
+	return e.MaxConcurrency
+}
+func (e *ExecutionTarget) GetCircuitBreaker() (failureThreshold uint16, openDuration time.Duration) {
+	return e.FailureThreshold, e.OpenDuration
+}
+func (e *ExecutionTarget) GetConditions() []string {
+	return e.Conditions
+}
+func (e *ExecutionTarget) GetMutableFields() []string {
+	return e.MutableFields
+}
+
+func scanExecutionTargets(rows *sql.Rows, alg crypto.EncryptionAlgorithm) ([]*ExecutionTarget, error) {
 	targets := make([]*ExecutionTarget, 0)
 	for rows.Next() {
 		target := new(ExecutionTarget)
@@ -388,6 +540,19 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 			endpoint         = &sql.NullString{}
 			timeout          = &sql.NullInt64{}
 			interruptOnError = &sql.NullBool{}
+			maxAttempts      = &sql.NullInt16{}
+			signingKey       = &crypto.CryptoValue{}
+
+			previousSigningKey           = &crypto.CryptoValue{}
+			previousSigningKeyExpiration = &sql.NullTime{}
+			headers                      = &crypto.CryptoValue{}
+			jwtAuthentication            = &sql.NullBool{}
+			clientKey                    = &crypto.CryptoValue{}
+			maxConcurrency               = &sql.NullInt32{}
+			failureThreshold             = &sql.NullInt32{}
+			openDuration                 = &sql.NullInt64{}
+			conditions                   = database.TextArray[string]{}
+			mutableFields                = database.TextArray[string]{}
 		)
 
 		err := rows.Scan(
@@ -398,6 +563,20 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 			endpoint,
 			timeout,
 			interruptOnError,
+			maxAttempts,
+			signingKey,
+			previousSigningKey,
+			previousSigningKeyExpiration,
+			headers,
+			jwtAuthentication,
+			&target.ClientCertificate,
+			clientKey,
+			&target.RootCAs,
+			maxConcurrency,
+			failureThreshold,
+			openDuration,
+			&conditions,
+			&mutableFields,
 		)
 
 		if err != nil {
@@ -411,6 +590,28 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 		target.Endpoint = endpoint.String
 		target.Timeout = time.Duration(timeout.Int64)
 		target.InterruptOnError = interruptOnError.Bool
+		target.MaxAttempts = uint8(maxAttempts.Int16)
+		target.MaxConcurrency = uint16(maxConcurrency.Int32)
+		target.FailureThreshold = uint16(failureThreshold.Int32)
+		target.OpenDuration = time.Duration(openDuration.Int64)
+		target.Conditions = conditions
+		target.MutableFields = mutableFields
+		target.PreviousSigningKeyExpiration = previousSigningKeyExpiration.Time
+		if target.SigningKey, err = decryptTargetSigningKey(signingKey, alg); err != nil {
+			return nil, err
+		}
+		if target.PreviousSigningKey, err = decryptTargetSigningKey(previousSigningKey, alg); err != nil {
+			return nil, err
+		}
+		target.JWTAuthentication = jwtAuthentication.Bool
+		if target.Headers, err = decryptTargetHeaders(headers, alg); err != nil {
+			return nil, err
+		}
+		if len(clientKey.Crypted) > 0 {
+			if target.ClientKey, err = crypto.Decrypt(clientKey, alg); err != nil {
+				return nil, err
+			}
+		}
 
 		targets = append(targets, target)
 	}
@@ -421,3 +622,25 @@ func scanExecutionTargets(rows *sql.Rows) ([]*ExecutionTarget, error) {
 
 	return targets, nil
 }
+
+func decryptTargetSigningKey(signingKey *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (string, error) {
+	if signingKey == nil || len(signingKey.Crypted) == 0 {
+		return "", nil
+	}
+	return crypto.DecryptString(signingKey, alg)
+}
+
+func decryptTargetHeaders(headers *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (map[string]string, error) {
+	if headers == nil || len(headers.Crypted) == 0 {
+		return nil, nil
+	}
+	decrypted, err := crypto.Decrypt(headers, alg)
+	if err != nil {
+		return nil, err
+	}
+	decryptedHeaders := make(map[string]string)
+	if err := json.Unmarshal(decrypted, &decryptedHeaders); err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-u7x0pkz2ve", "Errors.Internal")
+	}
+	return decryptedHeaders, nil
+}
@@ -0,0 +1,169 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	executionLogTable = table{
+		name:          "system.execution_logs",
+		instanceIDCol: "instance_id",
+	}
+	ExecutionLogColumnInstanceID = Column{
+		name:  "instance_id",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnLogDate = Column{
+		name:  "log_date",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnExecutionID = Column{
+		name:  "execution_id",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnTargetID = Column{
+		name:  "target_id",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnTook = Column{
+		name:  "took",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnOutcome = Column{
+		name:  "outcome",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnStatusCode = Column{
+		name:  "status_code",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnError = Column{
+		name:  "error",
+		table: executionLogTable,
+	}
+	ExecutionLogColumnChanges = Column{
+		name:  "changes",
+		table: executionLogTable,
+	}
+)
+
+type ExecutionLogs struct {
+	SearchResponse
+	ExecutionLogs []*ExecutionLog
+}
+
+// ExecutionLog is the log of a call to a target in an execution
+type ExecutionLog struct {
+	LogDate     time.Time
+	ExecutionID string
+	TargetID    string
+	Took        time.Duration
+	Outcome     record.TargetOutcome
+	StatusCode  int
+	Error       string
+	// Changes of the request or response by the target, if the log is of a mutation
+	Changes []*record.FieldChange
+}
+
+type ExecutionLogSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *ExecutionLogSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchExecutionLogs returns the logs of the calls to targets of the instance
+func (q *Queries) SearchExecutionLogs(ctx context.Context, queries *ExecutionLogSearchQueries) (logs *ExecutionLogs, err error) {
+	eq := sq.Eq{
+		ExecutionLogColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareExecutionLogsQuery(ctx, q.client)
+	return genericRowsQuery[*ExecutionLogs](ctx, q.client, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func NewExecutionLogTargetIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(ExecutionLogColumnTargetID, value, TextEquals)
+}
+
+func NewExecutionLogExecutionIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(ExecutionLogColumnExecutionID, value, TextEquals)
+}
+
+func NewExecutionLogOutcomeSearchQuery(value record.TargetOutcome) (SearchQuery, error) {
+	return NewNumberQuery(ExecutionLogColumnOutcome, value, NumberEquals)
+}
+
+func NewExecutionLogLogDateSearchQuery(value time.Time, method TimestampComparison) (SearchQuery, error) {
+	return NewTimestampQuery(ExecutionLogColumnLogDate, value, method)
+}
+
+func prepareExecutionLogsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*ExecutionLogs, error)) {
+	return sq.Select(
+			ExecutionLogColumnLogDate.identifier(),
+			ExecutionLogColumnExecutionID.identifier(),
+			ExecutionLogColumnTargetID.identifier(),
+			ExecutionLogColumnTook.identifier(),
+			ExecutionLogColumnOutcome.identifier(),
+			ExecutionLogColumnStatusCode.identifier(),
+			ExecutionLogColumnError.identifier(),
+			ExecutionLogColumnChanges.identifier(),
+			countColumn.identifier(),
+		).From(executionLogTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ExecutionLogs, error) {
+			logs := make([]*ExecutionLog, 0)
+			var count uint64
+			for rows.Next() {
+				log := new(ExecutionLog)
+				var logErr sql.NullString
+				var changes []byte
+				err := rows.Scan(
+					&log.LogDate,
+					&log.ExecutionID,
+					&log.TargetID,
+					&log.Took,
+					&log.Outcome,
+					&log.StatusCode,
+					&logErr,
+					&changes,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				log.Error = logErr.String
+				if len(changes) > 0 {
+					if err := json.Unmarshal(changes, &log.Changes); err != nil {
+						return nil, zerrors.ThrowInternal(err, "QUERY-n2ok8xj5wd", "Errors.Internal")
+					}
+				}
+				logs = append(logs, log)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-3v0ye8ktsm", "Errors.Query.CloseRows")
+			}
+
+			return &ExecutionLogs{
+				ExecutionLogs: logs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
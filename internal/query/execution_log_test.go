@@ -0,0 +1,164 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+var (
+	prepareExecutionLogsStmt = `SELECT system.execution_logs.log_date,` +
+		` system.execution_logs.execution_id,` +
+		` system.execution_logs.target_id,` +
+		` system.execution_logs.took,` +
+		` system.execution_logs.outcome,` +
+		` system.execution_logs.status_code,` +
+		` system.execution_logs.error,` +
+		` system.execution_logs.changes,` +
+		` COUNT(*) OVER ()` +
+		` FROM system.execution_logs`
+	prepareExecutionLogsCols = []string{
+		"log_date",
+		"execution_id",
+		"target_id",
+		"took",
+		"outcome",
+		"status_code",
+		"error",
+		"changes",
+		"count",
+	}
+)
+
+func Test_ExecutionLogPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareExecutionLogsQuery no result",
+			prepare: prepareExecutionLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExecutionLogsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &ExecutionLogs{ExecutionLogs: []*ExecutionLog{}},
+		},
+		{
+			name:    "prepareExecutionLogsQuery multiple result",
+			prepare: prepareExecutionLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExecutionLogsStmt),
+					prepareExecutionLogsCols,
+					[][]driver.Value{
+						{
+							testNow,
+							"request",
+							"target",
+							time.Second,
+							record.TargetOutcomeSuccess,
+							200,
+							nil,
+							nil,
+						},
+						{
+							testNow,
+							"event",
+							"target",
+							5 * time.Second,
+							record.TargetOutcomeTimeout,
+							0,
+							"context deadline exceeded",
+							nil,
+						},
+						{
+							testNow,
+							"response",
+							"target",
+							0,
+							record.TargetOutcomeRejected,
+							0,
+							"fields not mutable: user.email",
+							[]byte(`[{"path":"user.email","oldValue":"old@example.com","newValue":"new@example.com"}]`),
+						},
+					},
+				),
+			},
+			object: &ExecutionLogs{
+				SearchResponse: SearchResponse{
+					Count: 3,
+				},
+				ExecutionLogs: []*ExecutionLog{
+					{
+						LogDate:     testNow,
+						ExecutionID: "request",
+						TargetID:    "target",
+						Took:        time.Second,
+						Outcome:     record.TargetOutcomeSuccess,
+						StatusCode:  200,
+					},
+					{
+						LogDate:     testNow,
+						ExecutionID: "event",
+						TargetID:    "target",
+						Took:        5 * time.Second,
+						Outcome:     record.TargetOutcomeTimeout,
+						Error:       "context deadline exceeded",
+					},
+					{
+						LogDate:     testNow,
+						ExecutionID: "response",
+						TargetID:    "target",
+						Outcome:     record.TargetOutcomeRejected,
+						Error:       "fields not mutable: user.email",
+						Changes: []*record.FieldChange{
+							{
+								Path:     "user.email",
+								OldValue: []byte(`"old@example.com"`),
+								NewValue: []byte(`"new@example.com"`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareExecutionLogsQuery sql err",
+			prepare: prepareExecutionLogsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareExecutionLogsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ExecutionLogs)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+SELECT '' AS execution_id, t.instance_id, t.id, t.target_type, t.endpoint, t.timeout, t.interrupt_on_error, t.max_attempts, t.signing_key, t.previous_signing_key, t.previous_signing_key_expiration, t.headers, t.jwt_authentication, t.client_certificate, t.client_key, t.root_cas, t.max_concurrency, t.failure_threshold, t.open_duration, NULL::TEXT[] AS conditions, NULL::TEXT[] AS mutable_fields
+FROM projections.targets2 t
+WHERE t.instance_id = $1
+  AND t.id = $2;
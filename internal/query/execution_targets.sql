@@ -7,5 +7,5 @@ SELECT instance_id,
                        'target' : target_id
                    )
            ) as targets
-FROM projections.executions1_targets
+FROM projections.executions3_targets
 GROUP BY instance_id, execution_id
\ No newline at end of file
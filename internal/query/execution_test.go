@@ -8,55 +8,72 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	exec "github.com/zitadel/zitadel/internal/repository/execution"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 var (
-	prepareExecutionsStmt = `SELECT projections.executions1.instance_id,` +
-		` projections.executions1.id,` +
-		` projections.executions1.change_date,` +
-		` projections.executions1.sequence,` +
+	prepareExecutionsStmt = `SELECT projections.executions3.instance_id,` +
+		` projections.executions3.id,` +
+		` projections.executions3.change_date,` +
+		` projections.executions3.sequence,` +
+		` projections.executions3.condition,` +
+		` projections.executions3.mutable_fields,` +
 		` execution_targets.targets,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.executions1` +
+		` FROM projections.executions3` +
 		` JOIN (` +
 		`SELECT instance_id, execution_id, JSONB_AGG( JSON_OBJECT( 'position' : position, 'include' : include, 'target' : target_id ) ) as targets` +
-		` FROM projections.executions1_targets` +
+		` FROM projections.executions3_targets` +
 		` GROUP BY instance_id, execution_id` +
 		`)` +
 		` AS execution_targets` +
-		` ON execution_targets.instance_id = projections.executions1.instance_id` +
-		` AND execution_targets.execution_id = projections.executions1.id`
+		` ON execution_targets.instance_id = projections.executions3.instance_id` +
+		` AND execution_targets.execution_id = projections.executions3.id`
 	prepareExecutionsCols = []string{
 		"instance_id",
 		"id",
 		"change_date",
 		"sequence",
+		"condition",
+		"mutable_fields",
 		"targets",
 		"count",
 	}
 
-	prepareExecutionStmt = `SELECT projections.executions1.instance_id,` +
-		` projections.executions1.id,` +
-		` projections.executions1.change_date,` +
-		` projections.executions1.sequence,` +
+	prepareExecutionStmt = `SELECT projections.executions3.instance_id,` +
+		` projections.executions3.id,` +
+		` projections.executions3.change_date,` +
+		` projections.executions3.sequence,` +
+		` projections.executions3.condition,` +
+		` projections.executions3.mutable_fields,` +
 		` execution_targets.targets` +
-		` FROM projections.executions1` +
+		` FROM projections.executions3` +
 		` JOIN (` +
 		`SELECT instance_id, execution_id, JSONB_AGG( JSON_OBJECT( 'position' : position, 'include' : include, 'target' : target_id ) ) as targets` +
-		` FROM projections.executions1_targets` +
+		` FROM projections.executions3_targets` +
 		` GROUP BY instance_id, execution_id` +
 		`)` +
 		` AS execution_targets` +
-		` ON execution_targets.instance_id = projections.executions1.instance_id` +
-		` AND execution_targets.execution_id = projections.executions1.id`
+		` ON execution_targets.instance_id = projections.executions3.instance_id` +
+		` AND execution_targets.execution_id = projections.executions3.id`
+	prepareEventExecutionIDsStmt = `SELECT projections.executions3.instance_id,` +
+		` projections.executions3.id` +
+		` FROM projections.executions3 AS OF SYSTEM TIME '-1 ms'`
+	prepareEventExecutionIDsCols = []string{
+		"instance_id",
+		"id",
+	}
+
 	prepareExecutionCols = []string{
 		"instance_id",
 		"id",
 		"change_date",
 		"sequence",
+		"condition",
+		"mutable_fields",
 		"targets",
 	}
 )
@@ -97,6 +114,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 							"id",
 							testNow,
 							uint64(20211109),
+							`org_id == "org"`,
+							database.TextArray[string]{"user.profile"},
 							[]byte(`[{"position" : 1, "target" : "target"}, {"position" : 2, "include" : "include"}]`),
 						},
 					},
@@ -114,6 +133,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 							ResourceOwner: "ro",
 							Sequence:      20211109,
 						},
+						Condition:     `org_id == "org"`,
+						MutableFields: []string{"user.profile"},
 						Targets: []*exec.Target{
 							{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
@@ -135,6 +156,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 							"id-1",
 							testNow,
 							uint64(20211109),
+							`org_id == "org"`,
+							database.TextArray[string]{"user.profile"},
 							[]byte(`[{"position" : 1, "target" : "target"}, {"position" : 2, "include" : "include"}]`),
 						},
 						{
@@ -142,6 +165,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 							"id-2",
 							testNow,
 							uint64(20211110),
+							"",
+							nil,
 							[]byte(`[{"position" : 2, "target" : "target"}, {"position" : 1, "include" : "include"}]`),
 						},
 					},
@@ -159,6 +184,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 							ResourceOwner: "ro",
 							Sequence:      20211109,
 						},
+						Condition:     `org_id == "org"`,
+						MutableFields: []string{"user.profile"},
 						Targets: []*exec.Target{
 							{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 							{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
@@ -171,6 +198,7 @@ func Test_ExecutionPrepares(t *testing.T) {
 							ResourceOwner: "ro",
 							Sequence:      20211110,
 						},
+						MutableFields: []string{},
 						Targets: []*exec.Target{
 							{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 							{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
@@ -226,6 +254,8 @@ func Test_ExecutionPrepares(t *testing.T) {
 						"id",
 						testNow,
 						uint64(20211109),
+						`org_id == "org"`,
+						database.TextArray[string]{"user.profile"},
 						[]byte(`[{"position" : 1, "target" : "target"}, {"position" : 2, "include" : "include"}]`),
 					},
 				),
@@ -237,12 +267,45 @@ func Test_ExecutionPrepares(t *testing.T) {
 					ResourceOwner: "ro",
 					Sequence:      20211109,
 				},
+				Condition:     `org_id == "org"`,
+				MutableFields: []string{"user.profile"},
 				Targets: []*exec.Target{
 					{Type: domain.ExecutionTargetTypeTarget, Target: "target"},
 					{Type: domain.ExecutionTargetTypeInclude, Target: "include"},
 				},
 			},
 		},
+		{
+			name:    "prepareEventExecutionIDsQuery no result",
+			prepare: prepareEventExecutionIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareEventExecutionIDsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: map[string][]string{},
+		},
+		{
+			name:    "prepareEventExecutionIDsQuery multiple results",
+			prepare: prepareEventExecutionIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareEventExecutionIDsStmt),
+					prepareEventExecutionIDsCols,
+					[][]driver.Value{
+						{"instance1", "event/user.human.added"},
+						{"instance1", "event/org.*"},
+						{"instance2", "event"},
+					},
+				),
+			},
+			object: map[string][]string{
+				"instance1": {"event/user.human.added", "event/org.*"},
+				"instance2": {"event"},
+			},
+		},
 		{
 			name:    "prepareExecutionQuery sql err",
 			prepare: prepareExecutionQuery,
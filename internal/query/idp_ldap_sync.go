@@ -0,0 +1,358 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	idpLDAPSyncTable = table{
+		name:          projection.IDPLDAPSyncTable,
+		instanceIDCol: projection.IDPLDAPSyncInstanceIDCol,
+	}
+	IDPLDAPSyncIDPIDCol = Column{
+		name:  projection.IDPLDAPSyncIDPIDCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncInstanceIDCol = Column{
+		name:  projection.IDPLDAPSyncInstanceIDCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncResourceOwnerCol = Column{
+		name:  projection.IDPLDAPSyncResourceOwnerCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncChangeDateCol = Column{
+		name:  projection.IDPLDAPSyncChangeDateCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncSequenceCol = Column{
+		name:  projection.IDPLDAPSyncSequenceCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncOwnerTypeCol = Column{
+		name:  projection.IDPLDAPSyncOwnerTypeCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncEnabledCol = Column{
+		name:  projection.IDPLDAPSyncEnabledCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncDryRunCol = Column{
+		name:  projection.IDPLDAPSyncDryRunCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncIntervalCol = Column{
+		name:  projection.IDPLDAPSyncIntervalCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncMaxDeactivationCol = Column{
+		name:  projection.IDPLDAPSyncMaxDeactivationCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncLastSyncDateCol = Column{
+		name:  projection.IDPLDAPSyncLastSyncDateCol,
+		table: idpLDAPSyncTable,
+	}
+	IDPLDAPSyncLastSyncReportCol = Column{
+		name:  projection.IDPLDAPSyncLastSyncReportCol,
+		table: idpLDAPSyncTable,
+	}
+)
+
+// LDAPProviderSync is the synchronization of an LDAP provider with its directory
+type LDAPProviderSync struct {
+	IDPID         string
+	InstanceID    string
+	ResourceOwner string
+	ChangeDate    time.Time
+	Sequence      uint64
+	OwnerType     domain.IdentityProviderType
+
+	Enabled                bool
+	DryRun                 bool
+	Interval               time.Duration
+	MaxDeactivationPercent uint32
+
+	LastSyncDate   time.Time
+	LastSyncReport *domain.LDAPSyncReport
+}
+
+// IsDue returns if the next scheduled synchronization is due at the time now
+func (s *LDAPProviderSync) IsDue(now time.Time) bool {
+	return s.Enabled && !s.LastSyncDate.Add(s.Interval).After(now)
+}
+
+// LDAPProviderSyncByIDAndResourceOwner returns the synchronization settings and the report of the last synchronization of the LDAP provider.
+// If the provider was never synchronized and has no settings, the returned synchronization is disabled.
+func (q *Queries) LDAPProviderSyncByIDAndResourceOwner(ctx context.Context, shouldTriggerBulk bool, id, resourceOwner string) (sync *LDAPProviderSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerIDPLDAPSyncProjection")
+		ctx, err = projection.IDPLDAPSyncProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("unable to trigger")
+		traceSpan.EndWithError(err)
+	}
+
+	query, scan := prepareLDAPProviderSyncQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		IDPLDAPSyncIDPIDCol.identifier():         id,
+		IDPLDAPSyncResourceOwnerCol.identifier(): resourceOwner,
+		IDPLDAPSyncInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Vd4wq", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		sync, err = scan(row)
+		return err
+	}, stmt, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// not configured is not an error
+		return &LDAPProviderSync{
+			IDPID:         id,
+			InstanceID:    authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: resourceOwner,
+		}, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Nq8xc", "Errors.Internal")
+	}
+	return sync, nil
+}
+
+// LDAPProviderSyncsDue returns the enabled synchronizations of the instances, which are due at the time now
+func (q *Queries) LDAPProviderSyncsDue(ctx context.Context, instanceIDs []string, now time.Time) (syncs []*LDAPProviderSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPProviderSyncsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		IDPLDAPSyncEnabledCol.identifier():    true,
+		IDPLDAPSyncInstanceIDCol.identifier(): instanceIDs,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Tm2bs", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		syncs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Hf3kx", "Errors.Internal")
+	}
+	due := make([]*LDAPProviderSync, 0, len(syncs))
+	for _, sync := range syncs {
+		if sync.IsDue(now) {
+			due = append(due, sync)
+		}
+	}
+	return due, nil
+}
+
+// LDAPProviderLinkedUsers returns the users linked to the LDAP provider with the attributes the synchronization compares with the directory
+func (q *Queries) LDAPProviderLinkedUsers(ctx context.Context, idpID string) (users []*domain.LDAPSyncLinkedUser, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPProviderLinkedUsersQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		IDPUserLinkIDPIDCol.identifier():        idpID,
+		IDPUserLinkInstanceIDCol.identifier():   authz.GetInstance(ctx).InstanceID(),
+		IDPUserLinkOwnerRemovedCol.identifier(): false,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Rb7nq", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		users, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Zc4wm", "Errors.Internal")
+	}
+	return users, nil
+}
+
+func prepareLDAPProviderLinkedUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*domain.LDAPSyncLinkedUser, error)) {
+	return sq.Select(
+			IDPUserLinkUserIDCol.identifier(),
+			IDPUserLinkResourceOwnerCol.identifier(),
+			IDPUserLinkExternalUserIDCol.identifier(),
+			UserUsernameCol.identifier(),
+			UserStateCol.identifier(),
+			HumanFirstNameCol.identifier(),
+			HumanLastNameCol.identifier(),
+			HumanNickNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			HumanPreferredLanguageCol.identifier(),
+			HumanEmailCol.identifier(),
+			HumanIsEmailVerifiedCol.identifier(),
+			HumanPhoneCol.identifier(),
+			HumanIsPhoneVerifiedCol.identifier(),
+		).
+			From(idpUserLinkTable.identifier()).
+			Join(join(UserIDCol, IDPUserLinkUserIDCol)).
+			LeftJoin(join(HumanUserIDCol, UserIDCol) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*domain.LDAPSyncLinkedUser, error) {
+			users := make([]*domain.LDAPSyncLinkedUser, 0)
+			for rows.Next() {
+				var (
+					user              domain.LDAPSyncLinkedUser
+					firstName         sql.NullString
+					lastName          sql.NullString
+					nickName          sql.NullString
+					displayName       sql.NullString
+					preferredLanguage sql.NullString
+					email             sql.NullString
+					isEmailVerified   sql.NullBool
+					phone             sql.NullString
+					isPhoneVerified   sql.NullBool
+				)
+				err := rows.Scan(
+					&user.UserID,
+					&user.ResourceOwner,
+					&user.ExternalUserID,
+					&user.Username,
+					&user.State,
+					&firstName,
+					&lastName,
+					&nickName,
+					&displayName,
+					&preferredLanguage,
+					&email,
+					&isEmailVerified,
+					&phone,
+					&isPhoneVerified,
+				)
+				if err != nil {
+					return nil, err
+				}
+				user.FirstName = firstName.String
+				user.LastName = lastName.String
+				user.NickName = nickName.String
+				user.DisplayName = displayName.String
+				if preferredLanguage.Valid {
+					user.PreferredLanguage = language.Make(preferredLanguage.String)
+				}
+				user.Email = domain.EmailAddress(email.String)
+				user.IsEmailVerified = isEmailVerified.Bool
+				user.Phone = domain.PhoneNumber(phone.String)
+				user.IsPhoneVerified = isPhoneVerified.Bool
+				users = append(users, &user)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Hs3yt", "Errors.Query.CloseRows")
+			}
+			return users, nil
+		}
+}
+
+func prepareLDAPProviderSyncColumns(ctx context.Context, db prepareDatabase) sq.SelectBuilder {
+	return sq.Select(
+		IDPLDAPSyncIDPIDCol.identifier(),
+		IDPLDAPSyncInstanceIDCol.identifier(),
+		IDPLDAPSyncResourceOwnerCol.identifier(),
+		IDPLDAPSyncChangeDateCol.identifier(),
+		IDPLDAPSyncSequenceCol.identifier(),
+		IDPLDAPSyncOwnerTypeCol.identifier(),
+		IDPLDAPSyncEnabledCol.identifier(),
+		IDPLDAPSyncDryRunCol.identifier(),
+		IDPLDAPSyncIntervalCol.identifier(),
+		IDPLDAPSyncMaxDeactivationCol.identifier(),
+		IDPLDAPSyncLastSyncDateCol.identifier(),
+		IDPLDAPSyncLastSyncReportCol.identifier(),
+	).
+		From(idpLDAPSyncTable.identifier() + db.Timetravel(call.Took(ctx))).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func prepareLDAPProviderSyncQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*LDAPProviderSync, error)) {
+	return prepareLDAPProviderSyncColumns(ctx, db),
+		func(row *sql.Row) (*LDAPProviderSync, error) {
+			scan := new(ldapProviderSyncScan)
+			if err := row.Scan(scan.destinations()...); err != nil {
+				return nil, err
+			}
+			return scan.result()
+		}
+}
+
+func prepareLDAPProviderSyncsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*LDAPProviderSync, error)) {
+	return prepareLDAPProviderSyncColumns(ctx, db),
+		func(rows *sql.Rows) ([]*LDAPProviderSync, error) {
+			syncs := make([]*LDAPProviderSync, 0)
+			for rows.Next() {
+				scan := new(ldapProviderSyncScan)
+				if err := rows.Scan(scan.destinations()...); err != nil {
+					return nil, err
+				}
+				sync, err := scan.result()
+				if err != nil {
+					return nil, err
+				}
+				syncs = append(syncs, sync)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Kp7zw", "Errors.Query.CloseRows")
+			}
+			return syncs, nil
+		}
+}
+
+type ldapProviderSyncScan struct {
+	sync         LDAPProviderSync
+	interval     int64
+	lastSyncDate sql.NullTime
+	report       []byte
+}
+
+func (s *ldapProviderSyncScan) destinations() []any {
+	return []any{
+		&s.sync.IDPID,
+		&s.sync.InstanceID,
+		&s.sync.ResourceOwner,
+		&s.sync.ChangeDate,
+		&s.sync.Sequence,
+		&s.sync.OwnerType,
+		&s.sync.Enabled,
+		&s.sync.DryRun,
+		&s.interval,
+		&s.sync.MaxDeactivationPercent,
+		&s.lastSyncDate,
+		&s.report,
+	}
+}
+
+func (s *ldapProviderSyncScan) result() (*LDAPProviderSync, error) {
+	s.sync.Interval = time.Duration(s.interval)
+	s.sync.LastSyncDate = s.lastSyncDate.Time
+	if len(s.report) > 0 {
+		s.sync.LastSyncReport = new(domain.LDAPSyncReport)
+		if err := json.Unmarshal(s.report, s.sync.LastSyncReport); err != nil {
+			return nil, err
+		}
+	}
+	return &s.sync, nil
+}
@@ -0,0 +1,469 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	expectedLDAPProviderSyncQuery = regexp.QuoteMeta("SELECT projections.idp_ldap_syncs.idp_id," +
+		" projections.idp_ldap_syncs.instance_id," +
+		" projections.idp_ldap_syncs.resource_owner," +
+		" projections.idp_ldap_syncs.change_date," +
+		" projections.idp_ldap_syncs.sequence," +
+		" projections.idp_ldap_syncs.owner_type," +
+		" projections.idp_ldap_syncs.enabled," +
+		" projections.idp_ldap_syncs.dry_run," +
+		" projections.idp_ldap_syncs.sync_interval," +
+		" projections.idp_ldap_syncs.max_deactivation_percent," +
+		" projections.idp_ldap_syncs.last_sync_date," +
+		" projections.idp_ldap_syncs.last_sync_report" +
+		" FROM projections.idp_ldap_syncs" +
+		" AS OF SYSTEM TIME '-1 ms'",
+	)
+
+	ldapProviderSyncCols = []string{
+		"idp_id",
+		"instance_id",
+		"resource_owner",
+		"change_date",
+		"sequence",
+		"owner_type",
+		"enabled",
+		"dry_run",
+		"sync_interval",
+		"max_deactivation_percent",
+		"last_sync_date",
+		"last_sync_report",
+	}
+)
+
+var (
+	expectedLDAPProviderLinkedUsersQuery = regexp.QuoteMeta("SELECT projections.idp_user_links3.user_id," +
+		" projections.idp_user_links3.resource_owner," +
+		" projections.idp_user_links3.external_user_id," +
+		" projections.users12.username," +
+		" projections.users12.state," +
+		" projections.users12_humans.first_name," +
+		" projections.users12_humans.last_name," +
+		" projections.users12_humans.nick_name," +
+		" projections.users12_humans.display_name," +
+		" projections.users12_humans.preferred_language," +
+		" projections.users12_humans.email," +
+		" projections.users12_humans.is_email_verified," +
+		" projections.users12_humans.phone," +
+		" projections.users12_humans.is_phone_verified" +
+		" FROM projections.idp_user_links3" +
+		" JOIN projections.users12 ON projections.idp_user_links3.user_id = projections.users12.id AND projections.idp_user_links3.instance_id = projections.users12.instance_id" +
+		" LEFT JOIN projections.users12_humans ON projections.users12.id = projections.users12_humans.user_id AND projections.users12.instance_id = projections.users12_humans.instance_id" +
+		" AS OF SYSTEM TIME '-1 ms'",
+	)
+
+	ldapProviderLinkedUsersCols = []string{
+		"user_id",
+		"resource_owner",
+		"external_user_id",
+		"username",
+		"state",
+		"first_name",
+		"last_name",
+		"nick_name",
+		"display_name",
+		"preferred_language",
+		"email",
+		"is_email_verified",
+		"phone",
+		"is_phone_verified",
+	}
+)
+
+func Test_LDAPProviderLinkedUsersPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+		object          interface{}
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+	}{
+		{
+			name:    "prepareLDAPProviderLinkedUsersQuery no result",
+			prepare: prepareLDAPProviderLinkedUsersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedLDAPProviderLinkedUsersQuery,
+					nil,
+					nil,
+				),
+				object: []*domain.LDAPSyncLinkedUser{},
+			},
+		},
+		{
+			name:    "prepareLDAPProviderLinkedUsersQuery",
+			prepare: prepareLDAPProviderLinkedUsersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedLDAPProviderLinkedUsersQuery,
+					ldapProviderLinkedUsersCols,
+					[][]driver.Value{
+						{
+							"user-id",
+							"ro",
+							"external-id",
+							"username",
+							domain.UserStateActive,
+							"first",
+							"last",
+							"nick",
+							"display",
+							"de",
+							"email@test.com",
+							true,
+							"+41791234567",
+							false,
+						},
+						{
+							"machine-id",
+							"ro",
+							"external-id2",
+							"machine",
+							domain.UserStateActive,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+				object: []*domain.LDAPSyncLinkedUser{
+					{
+						UserID:            "user-id",
+						ResourceOwner:     "ro",
+						ExternalUserID:    "external-id",
+						Username:          "username",
+						State:             domain.UserStateActive,
+						FirstName:         "first",
+						LastName:          "last",
+						NickName:          "nick",
+						DisplayName:       "display",
+						PreferredLanguage: language.German,
+						Email:             "email@test.com",
+						IsEmailVerified:   true,
+						Phone:             "+41791234567",
+					},
+					{
+						UserID:         "machine-id",
+						ResourceOwner:  "ro",
+						ExternalUserID: "external-id2",
+						Username:       "machine",
+						State:          domain.UserStateActive,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPProviderLinkedUsersQuery sql err",
+			prepare: prepareLDAPProviderLinkedUsersQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedLDAPProviderLinkedUsersQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+				object: ([]*domain.LDAPSyncLinkedUser)(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.want.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_LDAPProviderSyncPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+		object          interface{}
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+	}{
+		{
+			name:    "prepareLDAPProviderSyncQuery no result",
+			prepare: prepareLDAPProviderSyncQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					expectedLDAPProviderSyncQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrNoRows) {
+						return fmt.Errorf("err should be sql.ErrNoRows got: %w", err), false
+					}
+					return nil, true
+				},
+				object: (*LDAPProviderSync)(nil),
+			},
+		},
+		{
+			name:    "prepareLDAPProviderSyncQuery never synced",
+			prepare: prepareLDAPProviderSyncQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedLDAPProviderSyncQuery,
+					ldapProviderSyncCols,
+					[]driver.Value{
+						"idp-id",
+						"instance-id",
+						"ro",
+						testNow,
+						uint64(20211109),
+						domain.IdentityProviderTypeOrg,
+						true,
+						false,
+						int64(time.Hour),
+						int64(0),
+						nil,
+						nil,
+					},
+				),
+				object: &LDAPProviderSync{
+					IDPID:         "idp-id",
+					InstanceID:    "instance-id",
+					ResourceOwner: "ro",
+					ChangeDate:    testNow,
+					Sequence:      20211109,
+					OwnerType:     domain.IdentityProviderTypeOrg,
+					Enabled:       true,
+					Interval:      time.Hour,
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPProviderSyncQuery synced",
+			prepare: prepareLDAPProviderSyncQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedLDAPProviderSyncQuery,
+					ldapProviderSyncCols,
+					[]driver.Value{
+						"idp-id",
+						"instance-id",
+						"ro",
+						testNow,
+						uint64(20211109),
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						int64(time.Hour),
+						int64(20),
+						testNow,
+						[]byte(`{"dryRun":true,"found":2,"deactivated":1,"entries":[{"action":3,"userId":"user-id","externalUserId":"external-id"}]}`),
+					},
+				),
+				object: &LDAPProviderSync{
+					IDPID:                  "idp-id",
+					InstanceID:             "instance-id",
+					ResourceOwner:          "ro",
+					ChangeDate:             testNow,
+					Sequence:               20211109,
+					OwnerType:              domain.IdentityProviderTypeOrg,
+					Enabled:                true,
+					DryRun:                 true,
+					Interval:               time.Hour,
+					MaxDeactivationPercent: 20,
+					LastSyncDate:           testNow,
+					LastSyncReport: &domain.LDAPSyncReport{
+						DryRun:      true,
+						Found:       2,
+						Deactivated: 1,
+						Entries: []*domain.LDAPSyncReportEntry{
+							{
+								Action:         domain.LDAPSyncActionDeactivated,
+								UserID:         "user-id",
+								ExternalUserID: "external-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPProviderSyncQuery sql err",
+			prepare: prepareLDAPProviderSyncQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedLDAPProviderSyncQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+				object: (*LDAPProviderSync)(nil),
+			},
+		},
+		{
+			name:    "prepareLDAPProviderSyncsQuery no result",
+			prepare: prepareLDAPProviderSyncsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedLDAPProviderSyncQuery,
+					nil,
+					nil,
+				),
+				object: []*LDAPProviderSync{},
+			},
+		},
+		{
+			name:    "prepareLDAPProviderSyncsQuery",
+			prepare: prepareLDAPProviderSyncsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedLDAPProviderSyncQuery,
+					ldapProviderSyncCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							"instance-id",
+							"instance-id",
+							testNow,
+							uint64(20211109),
+							domain.IdentityProviderTypeSystem,
+							true,
+							false,
+							int64(time.Hour),
+							int64(0),
+							nil,
+							nil,
+						},
+						{
+							"idp-id2",
+							"instance-id",
+							"ro",
+							testNow,
+							uint64(20211109),
+							domain.IdentityProviderTypeOrg,
+							true,
+							false,
+							int64(time.Hour),
+							int64(0),
+							testNow,
+							[]byte(`{"found":1}`),
+						},
+					},
+				),
+				object: []*LDAPProviderSync{
+					{
+						IDPID:         "idp-id",
+						InstanceID:    "instance-id",
+						ResourceOwner: "instance-id",
+						ChangeDate:    testNow,
+						Sequence:      20211109,
+						OwnerType:     domain.IdentityProviderTypeSystem,
+						Enabled:       true,
+						Interval:      time.Hour,
+					},
+					{
+						IDPID:          "idp-id2",
+						InstanceID:     "instance-id",
+						ResourceOwner:  "ro",
+						ChangeDate:     testNow,
+						Sequence:       20211109,
+						OwnerType:      domain.IdentityProviderTypeOrg,
+						Enabled:        true,
+						Interval:       time.Hour,
+						LastSyncDate:   testNow,
+						LastSyncReport: &domain.LDAPSyncReport{Found: 1},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPProviderSyncsQuery sql err",
+			prepare: prepareLDAPProviderSyncsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedLDAPProviderSyncQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+				object: ([]*LDAPProviderSync)(nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.want.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestLDAPProviderSync_IsDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		sync *LDAPProviderSync
+		want bool
+	}{
+		{
+			name: "disabled",
+			sync: &LDAPProviderSync{Interval: time.Hour},
+			want: false,
+		},
+		{
+			name: "never synced",
+			sync: &LDAPProviderSync{Enabled: true, Interval: time.Hour},
+			want: true,
+		},
+		{
+			name: "interval not passed",
+			sync: &LDAPProviderSync{Enabled: true, Interval: time.Hour, LastSyncDate: now.Add(-30 * time.Minute)},
+			want: false,
+		},
+		{
+			name: "interval passed",
+			sync: &LDAPProviderSync{Enabled: true, Interval: time.Hour, LastSyncDate: now.Add(-time.Hour)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.sync.IsDue(now))
+		})
+	}
+}
@@ -33,6 +33,12 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	MFAAdded                 MessageText
+	MFARemoved               MessageText
+	EmailChanged             MessageText
+	NewUserAgentLogin        MessageText
+	UserLocked               MessageText
+	PasswordExpiryWarning    MessageText
 }
 
 type MessageText struct {
@@ -346,6 +352,18 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.MFAAddedMessageType:
+		return &m.MFAAdded
+	case domain.MFARemovedMessageType:
+		return &m.MFARemoved
+	case domain.EmailChangedMessageType:
+		return &m.EmailChanged
+	case domain.NewUserAgentLoginMessageType:
+		return &m.NewUserAgentLogin
+	case domain.UserLockedMessageType:
+		return &m.UserLocked
+	case domain.PasswordExpiryWarningMessageType:
+		return &m.PasswordExpiryWarning
 	}
 	return nil
 }
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	notificationDeliveryTable = table{
+		name:          projection.NotificationDeliveryTable,
+		instanceIDCol: projection.NotificationDeliveryInstanceIDCol,
+	}
+	NotificationDeliveryColumnID = Column{
+		name:  projection.NotificationDeliveryIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnCreationDate = Column{
+		name:  projection.NotificationDeliveryCreationDateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnChangeDate = Column{
+		name:  projection.NotificationDeliveryChangeDateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnResourceOwner = Column{
+		name:  projection.NotificationDeliveryResourceOwnerCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnInstanceID = Column{
+		name:  projection.NotificationDeliveryInstanceIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnSequence = Column{
+		name:  projection.NotificationDeliverySequenceCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnState = Column{
+		name:  projection.NotificationDeliveryStateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnUserID = Column{
+		name:  projection.NotificationDeliveryUserIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnChannel = Column{
+		name:  projection.NotificationDeliveryChannelCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnMessageType = Column{
+		name:  projection.NotificationDeliveryMessageTypeCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnRecipient = Column{
+		name:  projection.NotificationDeliveryRecipientCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnProvider = Column{
+		name:  projection.NotificationDeliveryProviderCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnResponse = Column{
+		name:  projection.NotificationDeliveryResponseCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnTriggeringEventType = Column{
+		name:  projection.NotificationDeliveryTriggeringEventTypeCol,
+		table: notificationDeliveryTable,
+	}
+)
+
+type NotificationDeliveries struct {
+	SearchResponse
+	Deliveries []*NotificationDelivery
+}
+
+func (d *NotificationDeliveries) SetState(s *State) {
+	d.State = s
+}
+
+// NotificationDelivery is an attempt of a provider to deliver a message
+type NotificationDelivery struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	State         domain.NotificationDeliveryState
+	UserID        string
+	Channel       domain.NotificationType
+	MessageType   string
+	// Recipient is the masked email address or phone number
+	Recipient string
+	Provider  string
+	// Response is the classified error of the provider, empty if the message was delivered
+	Response            string
+	TriggeringEventType string
+}
+
+type NotificationDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchNotificationDeliveries returns the attempts to deliver messages of the instance,
+// use [NewNotificationDeliveryResourceOwnerSearchQuery] to restrict them to an organization
+func (q *Queries) SearchNotificationDeliveries(ctx context.Context, queries *NotificationDeliverySearchQueries) (deliveries *NotificationDeliveries, err error) {
+	eq := sq.Eq{
+		NotificationDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareNotificationDeliveriesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*NotificationDeliveries](ctx, q.client, notificationDeliveryTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func NewNotificationDeliveryResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnResourceOwner, value, TextEquals)
+}
+
+func NewNotificationDeliveryUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnUserID, value, TextEquals)
+}
+
+func NewNotificationDeliveryStateSearchQuery(value domain.NotificationDeliveryState) (SearchQuery, error) {
+	return NewNumberQuery(NotificationDeliveryColumnState, value, NumberEquals)
+}
+
+func NewNotificationDeliveryChannelSearchQuery(value domain.NotificationType) (SearchQuery, error) {
+	return NewNumberQuery(NotificationDeliveryColumnChannel, value, NumberEquals)
+}
+
+func prepareNotificationDeliveriesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*NotificationDeliveries, error)) {
+	return sq.Select(
+			NotificationDeliveryColumnID.identifier(),
+			NotificationDeliveryColumnCreationDate.identifier(),
+			NotificationDeliveryColumnChangeDate.identifier(),
+			NotificationDeliveryColumnResourceOwner.identifier(),
+			NotificationDeliveryColumnSequence.identifier(),
+			NotificationDeliveryColumnState.identifier(),
+			NotificationDeliveryColumnUserID.identifier(),
+			NotificationDeliveryColumnChannel.identifier(),
+			NotificationDeliveryColumnMessageType.identifier(),
+			NotificationDeliveryColumnRecipient.identifier(),
+			NotificationDeliveryColumnProvider.identifier(),
+			NotificationDeliveryColumnResponse.identifier(),
+			NotificationDeliveryColumnTriggeringEventType.identifier(),
+			countColumn.identifier(),
+		).From(notificationDeliveryTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*NotificationDeliveries, error) {
+			deliveries := make([]*NotificationDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery := new(NotificationDelivery)
+				err := rows.Scan(
+					&delivery.ID,
+					&delivery.CreationDate,
+					&delivery.ChangeDate,
+					&delivery.ResourceOwner,
+					&delivery.Sequence,
+					&delivery.State,
+					&delivery.UserID,
+					&delivery.Channel,
+					&delivery.MessageType,
+					&delivery.Recipient,
+					&delivery.Provider,
+					&delivery.Response,
+					&delivery.TriggeringEventType,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				deliveries = append(deliveries, delivery)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-d5mr8wq2zc", "Errors.Query.CloseRows")
+			}
+
+			return &NotificationDeliveries{
+				Deliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,172 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	prepareNotificationDeliveriesStmt = `SELECT projections.notification_deliveries.id,` +
+		` projections.notification_deliveries.creation_date,` +
+		` projections.notification_deliveries.change_date,` +
+		` projections.notification_deliveries.resource_owner,` +
+		` projections.notification_deliveries.sequence,` +
+		` projections.notification_deliveries.state,` +
+		` projections.notification_deliveries.user_id,` +
+		` projections.notification_deliveries.channel,` +
+		` projections.notification_deliveries.message_type,` +
+		` projections.notification_deliveries.recipient,` +
+		` projections.notification_deliveries.provider,` +
+		` projections.notification_deliveries.response,` +
+		` projections.notification_deliveries.triggering_event_type,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.notification_deliveries`
+	prepareNotificationDeliveriesCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"user_id",
+		"channel",
+		"message_type",
+		"recipient",
+		"provider",
+		"response",
+		"triggering_event_type",
+		"count",
+	}
+)
+
+func Test_NotificationDeliveryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationDeliveriesQuery no result",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationDeliveriesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationDeliveries{Deliveries: []*NotificationDelivery{}},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery multiple result",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationDeliveriesStmt),
+					prepareNotificationDeliveriesCols,
+					[][]driver.Value{
+						{
+							"id-1",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							domain.NotificationDeliveryStateDelivered,
+							"user",
+							domain.NotificationTypeEmail,
+							domain.VerifyEmailMessageType,
+							"j***@example.com",
+							"smtp",
+							"",
+							"user.human.email.code.added",
+						},
+						{
+							"id-2",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211110),
+							domain.NotificationDeliveryStateFailed,
+							"user",
+							domain.NotificationTypeSms,
+							domain.VerifyPhoneMessageType,
+							"+41*******67",
+							"sms",
+							"unavailable",
+							"user.human.phone.code.added",
+						},
+					},
+				),
+			},
+			object: &NotificationDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Deliveries: []*NotificationDelivery{
+					{
+						ID:                  "id-1",
+						CreationDate:        testNow,
+						ChangeDate:          testNow,
+						ResourceOwner:       "ro",
+						Sequence:            20211109,
+						State:               domain.NotificationDeliveryStateDelivered,
+						UserID:              "user",
+						Channel:             domain.NotificationTypeEmail,
+						MessageType:         domain.VerifyEmailMessageType,
+						Recipient:           "j***@example.com",
+						Provider:            "smtp",
+						TriggeringEventType: "user.human.email.code.added",
+					},
+					{
+						ID:                  "id-2",
+						CreationDate:        testNow,
+						ChangeDate:          testNow,
+						ResourceOwner:       "ro",
+						Sequence:            20211110,
+						State:               domain.NotificationDeliveryStateFailed,
+						UserID:              "user",
+						Channel:             domain.NotificationTypeSms,
+						MessageType:         domain.VerifyPhoneMessageType,
+						Recipient:           "+41*******67",
+						Provider:            "sms",
+						Response:            "unavailable",
+						TriggeringEventType: "user.human.phone.code.added",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery sql err",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationDeliveriesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationDeliveries)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
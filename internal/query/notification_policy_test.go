@@ -13,15 +13,16 @@ import (
 )
 
 var (
-	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies.id,` +
-		` projections.notification_policies.sequence,` +
-		` projections.notification_policies.creation_date,` +
-		` projections.notification_policies.change_date,` +
-		` projections.notification_policies.resource_owner,` +
-		` projections.notification_policies.password_change,` +
-		` projections.notification_policies.is_default,` +
-		` projections.notification_policies.state` +
-		` FROM projections.notification_policies` +
+	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies2.id,` +
+		` projections.notification_policies2.sequence,` +
+		` projections.notification_policies2.creation_date,` +
+		` projections.notification_policies2.change_date,` +
+		` projections.notification_policies2.resource_owner,` +
+		` projections.notification_policies2.password_change,` +
+		` projections.notification_policies2.security_alerts,` +
+		` projections.notification_policies2.is_default,` +
+		` projections.notification_policies2.state` +
+		` FROM projections.notification_policies2` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	notificationPolicyCols = []string{
 		"id",
@@ -30,6 +31,7 @@ var (
 		"change_date",
 		"resource_owner",
 		"password_change",
+		"security_alerts",
 		"is_default",
 		"state",
 	}
@@ -79,6 +81,7 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						true,
+						true,
 						domain.PolicyStateActive,
 					},
 				),
@@ -91,6 +94,7 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 				ResourceOwner:  "ro",
 				State:          domain.PolicyStateActive,
 				PasswordChange: true,
+				SecurityAlerts: true,
 				IsDefault:      true,
 			},
 		},
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PasswordExpiryWarning is a user whose password expires soon
+// according to the password age policy of the organization or the instance.
+type PasswordExpiryWarning struct {
+	InstanceID    string
+	ResourceOwner string
+	UserID        string
+	Policy        *domain.PasswordAgePolicy
+}
+
+//go:embed password_expiry_warnings_due.sql
+var passwordExpiryWarningsDueQuery string
+
+// PasswordExpiryWarningsDue returns the active users of the instances, which are not yet warned about the expiry of their current password
+// and whose password expires within the warning period of the password age policy at the time now, but isn't expired yet.
+func (q *Queries) PasswordExpiryWarningsDue(ctx context.Context, instanceIDs []string, now time.Time, limit uint64) (warnings []*PasswordExpiryWarning, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	err = q.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			warnings, err = scanPasswordExpiryWarnings(rows)
+			return err
+		},
+		passwordExpiryWarningsDueQuery,
+		now,
+		domain.UserStateActive,
+		limit,
+		database.TextArray[string](instanceIDs),
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Gw3pa", "Errors.Internal")
+	}
+	return warnings, nil
+}
+
+func scanPasswordExpiryWarnings(rows *sql.Rows) ([]*PasswordExpiryWarning, error) {
+	warnings := make([]*PasswordExpiryWarning, 0)
+	for rows.Next() {
+		warning := &PasswordExpiryWarning{
+			Policy: new(domain.PasswordAgePolicy),
+		}
+		err := rows.Scan(
+			&warning.InstanceID,
+			&warning.ResourceOwner,
+			&warning.UserID,
+			&warning.Policy.MaxAgeDays,
+			&warning.Policy.ExpireWarnDays,
+		)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings, rows.Err()
+}
@@ -0,0 +1,86 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestQueries_PasswordExpiryWarningsDue(t *testing.T) {
+	expQuery := regexp.QuoteMeta(passwordExpiryWarningsDueQuery)
+	cols := []string{"instance_id", "resource_owner", "user_id", "max_age_days", "expire_warn_days"}
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    sqlExpectation
+		want    []*PasswordExpiryWarning
+		wantErr error
+	}{
+		{
+			name:    "internal error",
+			mock:    mockQueryErr(expQuery, sql.ErrConnDone, now, domain.UserStateActive, uint64(10), database.TextArray[string]{"instance1", "instance2"}),
+			wantErr: zerrors.ThrowInternal(sql.ErrConnDone, "QUERY-Gw3pa", "Errors.Internal"),
+		},
+		{
+			name: "no warnings",
+			mock: mockQueries(expQuery, cols, nil, now, domain.UserStateActive, uint64(10), database.TextArray[string]{"instance1", "instance2"}),
+			want: []*PasswordExpiryWarning{},
+		},
+		{
+			name: "warnings",
+			mock: mockQueries(expQuery, cols,
+				[][]driver.Value{
+					{"instance1", "org1", "user1", uint64(90), uint64(10)},
+					{"instance2", "org2", "user2", uint64(30), uint64(5)},
+				},
+				now, domain.UserStateActive, uint64(10), database.TextArray[string]{"instance1", "instance2"},
+			),
+			want: []*PasswordExpiryWarning{
+				{
+					InstanceID:    "instance1",
+					ResourceOwner: "org1",
+					UserID:        "user1",
+					Policy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     90,
+						ExpireWarnDays: 10,
+					},
+				},
+				{
+					InstanceID:    "instance2",
+					ResourceOwner: "org2",
+					UserID:        "user2",
+					Policy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     30,
+						ExpireWarnDays: 5,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execMock(t, tt.mock, func(db *sql.DB) {
+				q := &Queries{
+					client: &database.DB{
+						DB:       db,
+						Database: &prepareDB{},
+					},
+				}
+				got, err := q.PasswordExpiryWarningsDue(context.Background(), []string{"instance1", "instance2"}, now, 10)
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			})
+		})
+	}
+}
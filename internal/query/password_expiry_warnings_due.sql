@@ -0,0 +1,34 @@
+-- the policy of the organization overwrites the default policy of the instance
+WITH expiries AS (SELECT e.instance_id
+                       , e.resource_owner
+                       , e.user_id
+                       , e.password_change_date
+                       , COALESCE(o.max_age_days, i.max_age_days)         AS max_age_days
+                       , COALESCE(o.expire_warn_days, i.expire_warn_days) AS expire_warn_days
+                  FROM projections.password_expiry e
+                           JOIN projections.users12 u
+                                ON u.instance_id = e.instance_id
+                                    AND u.id = e.user_id
+                                    AND u.state = $2
+                           LEFT JOIN projections.password_age_policies2 o
+                                     ON o.instance_id = e.instance_id
+                                         AND o.id = e.resource_owner
+                           LEFT JOIN projections.password_age_policies2 i
+                                     ON i.instance_id = e.instance_id
+                                         AND i.id = e.instance_id
+                  WHERE e.instance_id = ANY($4)
+                    AND NOT e.warning_added
+                    AND NOT e.change_required)
+SELECT instance_id
+     , resource_owner
+     , user_id
+     , max_age_days
+     , expire_warn_days
+FROM expiries
+WHERE max_age_days > 0
+  AND expire_warn_days > 0
+  AND password_change_date + (max_age_days - expire_warn_days) * INTERVAL '1 day' <= $1
+  -- passwords which are already expired are not warned about, so they don't block the users due under the limit
+  AND password_change_date + max_age_days * INTERVAL '1 day' > $1
+ORDER BY password_change_date
+LIMIT $3
@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
@@ -12,12 +13,14 @@ import (
 )
 
 const (
-	ExecutionTable           = "projections.executions1"
-	ExecutionIDCol           = "id"
-	ExecutionCreationDateCol = "creation_date"
-	ExecutionChangeDateCol   = "change_date"
-	ExecutionInstanceIDCol   = "instance_id"
-	ExecutionSequenceCol     = "sequence"
+	ExecutionTable            = "projections.executions3"
+	ExecutionIDCol            = "id"
+	ExecutionCreationDateCol  = "creation_date"
+	ExecutionChangeDateCol    = "change_date"
+	ExecutionInstanceIDCol    = "instance_id"
+	ExecutionSequenceCol      = "sequence"
+	ExecutionConditionCol     = "condition"
+	ExecutionMutableFieldsCol = "mutable_fields"
 
 	ExecutionTargetSuffix         = "targets"
 	ExecutionTargetExecutionIDCol = "execution_id"
@@ -45,6 +48,8 @@ func (*executionProjection) Init() *old_handler.Check {
 			handler.NewColumn(ExecutionChangeDateCol, handler.ColumnTypeTimestamp),
 			handler.NewColumn(ExecutionSequenceCol, handler.ColumnTypeInt64),
 			handler.NewColumn(ExecutionInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(ExecutionConditionCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(ExecutionMutableFieldsCol, handler.ColumnTypeTextArray, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(ExecutionInstanceIDCol, ExecutionIDCol),
 		),
@@ -108,6 +113,8 @@ func (p *executionProjection) reduceExecutionSet(event eventstore.Event) (*handl
 				handler.NewCol(ExecutionCreationDateCol, handler.OnlySetValueOnInsert(ExecutionTable, e.CreationDate())),
 				handler.NewCol(ExecutionChangeDateCol, e.CreationDate()),
 				handler.NewCol(ExecutionSequenceCol, e.Sequence()),
+				handler.NewCol(ExecutionConditionCol, e.Condition),
+				handler.NewCol(ExecutionMutableFieldsCol, database.TextArray[string](e.MutableFields)),
 			},
 		),
 		// cleanup execution targets to re-insert them
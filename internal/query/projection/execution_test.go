@@ -3,6 +3,7 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	exec "github.com/zitadel/zitadel/internal/repository/execution"
@@ -27,7 +28,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 					testEvent(
 						exec.SetEventV2Type,
 						exec.AggregateType,
-						[]byte(`{"targets": [{"type":2,"target":"target"},{"type":1,"target":"include"}]}`),
+						[]byte(`{"targets": [{"type":2,"target":"target"},{"type":1,"target":"include"}], "condition": "org_id == \"org\"", "mutableFields": ["user.profile"]}`),
 					),
 					eventstore.GenericEventMapper[exec.SetEventV2],
 				),
@@ -39,24 +40,26 @@ func TestExecutionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.executions1 (instance_id, id, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, sequence) = (projections.executions1.creation_date, EXCLUDED.change_date, EXCLUDED.sequence)",
+							expectedStmt: "INSERT INTO projections.executions3 (instance_id, id, creation_date, change_date, sequence, condition, mutable_fields) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, sequence, condition, mutable_fields) = (projections.executions3.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.condition, EXCLUDED.mutable_fields)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 								anyArg{},
 								anyArg{},
 								uint64(15),
+								`org_id == "org"`,
+								database.TextArray[string]{"user.profile"},
 							},
 						},
 						{
-							expectedStmt: "DELETE FROM projections.executions1_targets WHERE (instance_id = $1) AND (execution_id = $2)",
+							expectedStmt: "DELETE FROM projections.executions3_targets WHERE (instance_id = $1) AND (execution_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.executions1_targets (instance_id, execution_id, position, include, target_id) VALUES ($1, $2, $3, $4, $5)",
+							expectedStmt: "INSERT INTO projections.executions3_targets (instance_id, execution_id, position, include, target_id) VALUES ($1, $2, $3, $4, $5)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -66,7 +69,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.executions1_targets (instance_id, execution_id, position, include, target_id) VALUES ($1, $2, $3, $4, $5)",
+							expectedStmt: "INSERT INTO projections.executions3_targets (instance_id, execution_id, position, include, target_id) VALUES ($1, $2, $3, $4, $5)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -98,7 +101,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.executions1 WHERE (instance_id = $1) AND (id = $2)",
+							expectedStmt: "DELETE FROM projections.executions3 WHERE (instance_id = $1) AND (id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -127,7 +130,7 @@ func TestExecutionProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.executions1 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.executions3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
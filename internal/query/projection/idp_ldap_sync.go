@@ -0,0 +1,212 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPLDAPSyncTable              = "projections.idp_ldap_syncs"
+	IDPLDAPSyncIDPIDCol           = "idp_id"
+	IDPLDAPSyncInstanceIDCol      = "instance_id"
+	IDPLDAPSyncResourceOwnerCol   = "resource_owner"
+	IDPLDAPSyncChangeDateCol      = "change_date"
+	IDPLDAPSyncSequenceCol        = "sequence"
+	IDPLDAPSyncOwnerTypeCol       = "owner_type"
+	IDPLDAPSyncEnabledCol         = "enabled"
+	IDPLDAPSyncDryRunCol          = "dry_run"
+	IDPLDAPSyncIntervalCol        = "sync_interval"
+	IDPLDAPSyncMaxDeactivationCol = "max_deactivation_percent"
+	IDPLDAPSyncLastSyncDateCol    = "last_sync_date"
+	IDPLDAPSyncLastSyncReportCol  = "last_sync_report"
+)
+
+// idpLDAPSyncProjection projects the synchronization settings and the report of the last synchronization of the LDAP providers
+type idpLDAPSyncProjection struct{}
+
+func newIDPLDAPSyncProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(idpLDAPSyncProjection))
+}
+
+func (*idpLDAPSyncProjection) Name() string {
+	return IDPLDAPSyncTable
+}
+
+func (*idpLDAPSyncProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(IDPLDAPSyncIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPLDAPSyncInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPLDAPSyncResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPLDAPSyncChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPLDAPSyncSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(IDPLDAPSyncOwnerTypeCol, handler.ColumnTypeEnum),
+			handler.NewColumn(IDPLDAPSyncEnabledCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(IDPLDAPSyncDryRunCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(IDPLDAPSyncIntervalCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(IDPLDAPSyncMaxDeactivationCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(IDPLDAPSyncLastSyncDateCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(IDPLDAPSyncLastSyncReportCol, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(IDPLDAPSyncInstanceIDCol, IDPLDAPSyncIDPIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{IDPLDAPSyncResourceOwnerCol})),
+		),
+	)
+}
+
+func (p *idpLDAPSyncProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.LDAPIDPSyncSetEventType,
+					Reduce: p.reduceSyncSet,
+				},
+				{
+					Event:  instance.LDAPIDPSyncedEventType,
+					Reduce: p.reduceSynced,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPLDAPSyncInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.LDAPIDPSyncSetEventType,
+					Reduce: p.reduceSyncSet,
+				},
+				{
+					Event:  org.LDAPIDPSyncedEventType,
+					Reduce: p.reduceSynced,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpLDAPSyncProjection) reduceSyncSet(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.LDAPIDPSyncSetEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.LDAPIDPSyncSetEvent:
+		idpEvent = e.LDAPIDPSyncSetEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.LDAPIDPSyncSetEvent:
+		idpEvent = e.LDAPIDPSyncSetEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Qw4ne", "reduce.wrong.event.type %v", []eventstore.EventType{org.LDAPIDPSyncSetEventType, instance.LDAPIDPSyncSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&idpEvent,
+		[]handler.Column{
+			handler.NewCol(IDPLDAPSyncInstanceIDCol, nil),
+			handler.NewCol(IDPLDAPSyncIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPLDAPSyncIDPIDCol, idpEvent.ID),
+			handler.NewCol(IDPLDAPSyncInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPLDAPSyncResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPLDAPSyncChangeDateCol, idpEvent.CreatedAt()),
+			handler.NewCol(IDPLDAPSyncSequenceCol, idpEvent.Sequence()),
+			handler.NewCol(IDPLDAPSyncOwnerTypeCol, idpOwnerType),
+			handler.NewCol(IDPLDAPSyncEnabledCol, idpEvent.Enabled),
+			handler.NewCol(IDPLDAPSyncDryRunCol, idpEvent.DryRun),
+			handler.NewCol(IDPLDAPSyncIntervalCol, idpEvent.Interval),
+			handler.NewCol(IDPLDAPSyncMaxDeactivationCol, idpEvent.MaxDeactivationPercent),
+		},
+	), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceSynced(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.LDAPIDPSyncedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.LDAPIDPSyncedEvent:
+		idpEvent = e.LDAPIDPSyncedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.LDAPIDPSyncedEvent:
+		idpEvent = e.LDAPIDPSyncedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Yb2kd", "reduce.wrong.event.type %v", []eventstore.EventType{org.LDAPIDPSyncedEventType, instance.LDAPIDPSyncedEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&idpEvent,
+		[]handler.Column{
+			handler.NewCol(IDPLDAPSyncInstanceIDCol, nil),
+			handler.NewCol(IDPLDAPSyncIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPLDAPSyncIDPIDCol, idpEvent.ID),
+			handler.NewCol(IDPLDAPSyncInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPLDAPSyncResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPLDAPSyncChangeDateCol, idpEvent.CreatedAt()),
+			handler.NewCol(IDPLDAPSyncSequenceCol, idpEvent.Sequence()),
+			handler.NewCol(IDPLDAPSyncOwnerTypeCol, idpOwnerType),
+			handler.NewCol(IDPLDAPSyncLastSyncDateCol, idpEvent.CreatedAt()),
+			handler.NewJSONCol(IDPLDAPSyncLastSyncReportCol, idpEvent.Report),
+		},
+	), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Cs8vt", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&idpEvent,
+		[]handler.Condition{
+			handler.NewCond(IDPLDAPSyncIDPIDCol, idpEvent.ID),
+			handler.NewCond(IDPLDAPSyncInstanceIDCol, idpEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpLDAPSyncProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPLDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(IDPLDAPSyncResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
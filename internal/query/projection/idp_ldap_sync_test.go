@@ -0,0 +1,234 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestIDPLDAPSyncProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceSyncSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.LDAPIDPSyncSetEventType,
+						instance.AggregateType,
+						[]byte(`{"id": "idp-id", "enabled": true, "dryRun": true, "interval": 3600000000000, "maxDeactivationPercent": 20}`),
+					),
+					instance.LDAPIDPSyncSetEventMapper,
+				),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceSyncSet,
+			want: wantReduce{
+				aggregateType: instance.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs (idp_id, instance_id, resource_owner, change_date, sequence, owner_type, enabled, dry_run, sync_interval, max_deactivation_percent) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, change_date, sequence, owner_type, enabled, dry_run, sync_interval, max_deactivation_percent) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.owner_type, EXCLUDED.enabled, EXCLUDED.dry_run, EXCLUDED.sync_interval, EXCLUDED.max_deactivation_percent)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								domain.IdentityProviderTypeSystem,
+								true,
+								true,
+								time.Hour,
+								uint32(20),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceSyncSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.LDAPIDPSyncSetEventType,
+						org.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					),
+					org.LDAPIDPSyncSetEventMapper,
+				),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceSyncSet,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs (idp_id, instance_id, resource_owner, change_date, sequence, owner_type, enabled, dry_run, sync_interval, max_deactivation_percent) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, change_date, sequence, owner_type, enabled, dry_run, sync_interval, max_deactivation_percent) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.owner_type, EXCLUDED.enabled, EXCLUDED.dry_run, EXCLUDED.sync_interval, EXCLUDED.max_deactivation_percent)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								domain.IdentityProviderTypeOrg,
+								false,
+								false,
+								time.Duration(0),
+								uint32(0),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceSynced",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.LDAPIDPSyncedEventType,
+						instance.AggregateType,
+						[]byte(`{"id": "idp-id", "report": {"startedAt": "2024-01-01T00:00:00Z", "finishedAt": "2024-01-01T00:01:00Z", "found": 2, "deactivated": 1, "entries": [{"action": 3, "userId": "user-id", "externalUserId": "external-id"}]}}`),
+					),
+					instance.LDAPIDPSyncedEventMapper,
+				),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceSynced,
+			want: wantReduce{
+				aggregateType: instance.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs (idp_id, instance_id, resource_owner, change_date, sequence, owner_type, last_sync_date, last_sync_report) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, change_date, sequence, owner_type, last_sync_date, last_sync_report) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.owner_type, EXCLUDED.last_sync_date, EXCLUDED.last_sync_report)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								domain.IdentityProviderTypeSystem,
+								anyArg{},
+								[]byte(`{"startedAt":"2024-01-01T00:00:00Z","finishedAt":"2024-01-01T00:01:00Z","found":2,"deactivated":1,"entries":[{"action":3,"userId":"user-id","externalUserId":"external-id"}]}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceIDPRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.IDPRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					),
+					org.IDPRemovedEventMapper,
+				),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					),
+					org.OrgRemovedEventMapper,
+				),
+			},
+			reduce: (&idpLDAPSyncProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(IDPLDAPSyncInstanceIDCol),
+			want: wantReduce{
+				aggregateType: instance.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPLDAPSyncTable, tt.want)
+		})
+	}
+}
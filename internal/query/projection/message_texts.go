@@ -272,7 +272,13 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyEmailOTPMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.MFAAddedMessageType ||
+		template == domain.MFARemovedMessageType ||
+		template == domain.EmailChangedMessageType ||
+		template == domain.NewUserAgentLoginMessageType ||
+		template == domain.UserLockedMessageType ||
+		template == domain.PasswordExpiryWarningMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,185 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	NotificationDeliveryTable                    = "projections.notification_deliveries"
+	NotificationDeliveryIDCol                    = "id"
+	NotificationDeliveryCreationDateCol          = "creation_date"
+	NotificationDeliveryChangeDateCol            = "change_date"
+	NotificationDeliveryResourceOwnerCol         = "resource_owner"
+	NotificationDeliveryInstanceIDCol            = "instance_id"
+	NotificationDeliverySequenceCol              = "sequence"
+	NotificationDeliveryStateCol                 = "state"
+	NotificationDeliveryUserIDCol                = "user_id"
+	NotificationDeliveryChannelCol               = "channel"
+	NotificationDeliveryMessageTypeCol           = "message_type"
+	NotificationDeliveryRecipientCol             = "recipient"
+	NotificationDeliveryProviderCol              = "provider"
+	NotificationDeliveryResponseCol              = "response"
+	NotificationDeliveryTriggeringEventTypeCol   = "triggering_event_type"
+	NotificationDeliveryTriggeringAggregateIDCol = "triggering_aggregate_id"
+)
+
+type notificationDeliveryProjection struct{}
+
+func newNotificationDeliveryProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(notificationDeliveryProjection))
+}
+
+func (*notificationDeliveryProjection) Name() string {
+	return NotificationDeliveryTable
+}
+
+func (*notificationDeliveryProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(NotificationDeliveryIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationDeliveryChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationDeliveryResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliverySequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(NotificationDeliveryStateCol, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationDeliveryUserIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryChannelCol, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationDeliveryMessageTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryRecipientCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryProviderCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryResponseCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryTriggeringEventTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryTriggeringAggregateIDCol, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(NotificationDeliveryInstanceIDCol, NotificationDeliveryIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{NotificationDeliveryResourceOwnerCol})),
+			handler.WithIndex(handler.NewIndex("user_id", []string{NotificationDeliveryUserIDCol})),
+		),
+	)
+}
+
+func (p *notificationDeliveryProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  notification.DeliveredEventType,
+					Reduce: p.reduceDelivered,
+				},
+				{
+					Event:  notification.DeliveryFailedEventType,
+					Reduce: p.reduceDeliveryFailed,
+				},
+				{
+					Event:  notification.ResendRequestedEventType,
+					Reduce: p.reduceResendRequested,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationDeliveryInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationDeliveryProjection) reduceDelivered(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.DeliveredEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.reduceDelivery(e, &e.Delivery, domain.NotificationDeliveryStateDelivered), nil
+}
+
+func (p *notificationDeliveryProjection) reduceDeliveryFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.DeliveryFailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.reduceDelivery(e, &e.Delivery, domain.NotificationDeliveryStateFailed), nil
+}
+
+func (p *notificationDeliveryProjection) reduceDelivery(event eventstore.Event, delivery *notification.Delivery, state domain.NotificationDeliveryState) *handler.Statement {
+	var triggeringEventType, triggeringAggregateID string
+	if delivery.TriggeringEvent != nil {
+		triggeringEventType = string(delivery.TriggeringEvent.EventType)
+		triggeringAggregateID = delivery.TriggeringEvent.AggregateID
+	}
+	return handler.NewCreateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(NotificationDeliveryIDCol, event.Aggregate().ID),
+			handler.NewCol(NotificationDeliveryCreationDateCol, event.CreatedAt()),
+			handler.NewCol(NotificationDeliveryChangeDateCol, event.CreatedAt()),
+			handler.NewCol(NotificationDeliveryResourceOwnerCol, event.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationDeliveryInstanceIDCol, event.Aggregate().InstanceID),
+			handler.NewCol(NotificationDeliverySequenceCol, event.Sequence()),
+			handler.NewCol(NotificationDeliveryStateCol, state),
+			handler.NewCol(NotificationDeliveryUserIDCol, delivery.UserID),
+			handler.NewCol(NotificationDeliveryChannelCol, delivery.Channel),
+			handler.NewCol(NotificationDeliveryMessageTypeCol, delivery.MessageType),
+			handler.NewCol(NotificationDeliveryRecipientCol, delivery.Recipient),
+			handler.NewCol(NotificationDeliveryProviderCol, delivery.Provider),
+			handler.NewCol(NotificationDeliveryResponseCol, delivery.Response),
+			handler.NewCol(NotificationDeliveryTriggeringEventTypeCol, triggeringEventType),
+			handler.NewCol(NotificationDeliveryTriggeringAggregateIDCol, triggeringAggregateID),
+		},
+	)
+}
+
+func (p *notificationDeliveryProjection) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.ResendRequestedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationDeliveryChangeDateCol, e.CreatedAt()),
+			handler.NewCol(NotificationDeliverySequenceCol, e.Sequence()),
+			handler.NewCol(NotificationDeliveryStateCol, domain.NotificationDeliveryStateResendRequested),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationDeliveryIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *notificationDeliveryProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationDeliveryResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,212 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestNotificationDeliveryProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceDelivered",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.DeliveredEventType,
+						notification.AggregateType,
+						[]byte(`{"userId": "user-id", "channel": 0, "messageType": "VerifyEmail", "recipient": "j***@example.com", "provider": "smtp-id", "triggeringEvent": {"aggregateType": "user", "aggregateId": "user-id", "resourceOwner": "ro-id", "eventType": "user.human.email.code.added", "sequence": 3}}`),
+					),
+					eventstore.GenericEventMapper[notification.DeliveredEvent],
+				),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceDelivered,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_deliveries (id, creation_date, change_date, resource_owner, instance_id, sequence, state, user_id, channel, message_type, recipient, provider, response, triggering_event_type, triggering_aggregate_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								domain.NotificationDeliveryStateDelivered,
+								"user-id",
+								domain.NotificationTypeEmail,
+								"VerifyEmail",
+								"j***@example.com",
+								"smtp-id",
+								"",
+								"user.human.email.code.added",
+								"user-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.DeliveryFailedEventType,
+						notification.AggregateType,
+						[]byte(`{"userId": "user-id", "channel": 1, "messageType": "VerifyPhone", "recipient": "+41*******67", "provider": "sms-id", "response": "unavailable", "triggeringEvent": {"aggregateType": "user", "aggregateId": "user-id", "resourceOwner": "ro-id", "eventType": "user.human.phone.code.added", "sequence": 3}}`),
+					),
+					eventstore.GenericEventMapper[notification.DeliveryFailedEvent],
+				),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceDeliveryFailed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_deliveries (id, creation_date, change_date, resource_owner, instance_id, sequence, state, user_id, channel, message_type, recipient, provider, response, triggering_event_type, triggering_aggregate_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								domain.NotificationDeliveryStateFailed,
+								"user-id",
+								domain.NotificationTypeSms,
+								"VerifyPhone",
+								"+41*******67",
+								"sms-id",
+								"unavailable",
+								"user.human.phone.code.added",
+								"user-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceResendRequested",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.ResendRequestedEventType,
+						notification.AggregateType,
+						[]byte(`{"triggeringEvent": {"aggregateType": "user", "aggregateId": "user-id", "resourceOwner": "ro-id", "eventType": "user.human.phone.code.added", "sequence": 3}}`),
+					),
+					eventstore.GenericEventMapper[notification.ResendRequestedEvent],
+				),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceResendRequested,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, state) = ($1, $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStateResendRequested,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					),
+					org.OrgRemovedEventMapper,
+				),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_deliveries WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationDeliveryInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_deliveries WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationDeliveryTable, tt.want)
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	NotificationPolicyProjectionTable = "projections.notification_policies"
+	NotificationPolicyProjectionTable = "projections.notification_policies2"
 
 	NotificationPolicyColumnID             = "id"
 	NotificationPolicyColumnCreationDate   = "creation_date"
@@ -25,6 +25,7 @@ const (
 	NotificationPolicyColumnStateCol       = "state"
 	NotificationPolicyColumnIsDefault      = "is_default"
 	NotificationPolicyColumnPasswordChange = "password_change"
+	NotificationPolicyColumnSecurityAlerts = "security_alerts"
 	NotificationPolicyColumnOwnerRemoved   = "owner_removed"
 )
 
@@ -50,6 +51,7 @@ func (*notificationPolicyProjection) Init() *old_handler.Check {
 			handler.NewColumn(NotificationPolicyColumnStateCol, handler.ColumnTypeEnum),
 			handler.NewColumn(NotificationPolicyColumnIsDefault, handler.ColumnTypeBool),
 			handler.NewColumn(NotificationPolicyColumnPasswordChange, handler.ColumnTypeBool),
+			handler.NewColumn(NotificationPolicyColumnSecurityAlerts, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(NotificationPolicyColumnOwnerRemoved, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(NotificationPolicyColumnInstanceID, NotificationPolicyColumnID),
@@ -122,6 +124,7 @@ func (p *notificationPolicyProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(NotificationPolicyColumnID, policyEvent.Aggregate().ID),
 			handler.NewCol(NotificationPolicyColumnStateCol, domain.PolicyStateActive),
 			handler.NewCol(NotificationPolicyColumnPasswordChange, policyEvent.PasswordChange),
+			handler.NewCol(NotificationPolicyColumnSecurityAlerts, policyEvent.SecurityAlerts),
 			handler.NewCol(NotificationPolicyColumnIsDefault, isDefault),
 			handler.NewCol(NotificationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(NotificationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
@@ -145,6 +148,9 @@ func (p *notificationPolicyProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.PasswordChange != nil {
 		cols = append(cols, handler.NewCol(NotificationPolicyColumnPasswordChange, *policyEvent.PasswordChange))
 	}
+	if policyEvent.SecurityAlerts != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnSecurityAlerts, *policyEvent.SecurityAlerts))
+	}
 	return handler.NewUpdateStatement(
 		&policyEvent,
 		cols,
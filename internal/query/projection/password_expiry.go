@@ -0,0 +1,214 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	PasswordExpiryTable                 = "projections.password_expiry"
+	PasswordExpiryUserIDCol             = "user_id"
+	PasswordExpiryInstanceIDCol         = "instance_id"
+	PasswordExpiryResourceOwnerCol      = "resource_owner"
+	PasswordExpiryChangeDateCol         = "change_date"
+	PasswordExpirySequenceCol           = "sequence"
+	PasswordExpiryPasswordChangeDateCol = "password_change_date"
+	PasswordExpiryChangeRequiredCol     = "change_required"
+	PasswordExpiryWarningAddedCol       = "warning_added"
+)
+
+// passwordExpiryProjection projects the change date of the current password of the human users,
+// so the users can be warned before their password expires according to the password age policy.
+type passwordExpiryProjection struct{}
+
+func newPasswordExpiryProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(passwordExpiryProjection))
+}
+
+func (*passwordExpiryProjection) Name() string {
+	return PasswordExpiryTable
+}
+
+func (*passwordExpiryProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(PasswordExpiryUserIDCol, handler.ColumnTypeText),
+			handler.NewColumn(PasswordExpiryInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(PasswordExpiryResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(PasswordExpiryChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(PasswordExpirySequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(PasswordExpiryPasswordChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(PasswordExpiryChangeRequiredCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(PasswordExpiryWarningAddedCol, handler.ColumnTypeBool, handler.Default(false)),
+		},
+			handler.NewPrimaryKey(PasswordExpiryInstanceIDCol, PasswordExpiryUserIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{PasswordExpiryResourceOwnerCol})),
+			handler.WithIndex(handler.NewIndex("password_change_date", []string{PasswordExpiryWarningAddedCol, PasswordExpiryPasswordChangeDateCol})),
+		),
+	)
+}
+
+func (p *passwordExpiryProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.UserV1AddedType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.HumanAddedType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.UserV1RegisteredType,
+					Reduce: p.reduceHumanRegistered,
+				},
+				{
+					Event:  user.HumanRegisteredType,
+					Reduce: p.reduceHumanRegistered,
+				},
+				{
+					Event:  user.UserV1PasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.HumanPasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.HumanPasswordExpiryWarningAddedType,
+					Reduce: p.reduceWarningAdded,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(PasswordExpiryInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *passwordExpiryProjection) reduceHumanAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash) == "" {
+		return handler.NewNoOpStatement(e), nil
+	}
+	return p.passwordSetStatement(e, e.ChangeRequired), nil
+}
+
+func (p *passwordExpiryProjection) reduceHumanRegistered(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanRegisteredEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash) == "" {
+		return handler.NewNoOpStatement(e), nil
+	}
+	return p.passwordSetStatement(e, e.ChangeRequired), nil
+}
+
+func (p *passwordExpiryProjection) reducePasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanPasswordChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.passwordSetStatement(e, e.ChangeRequired), nil
+}
+
+// passwordSetStatement starts a new expiry cycle of the password of the user
+func (p *passwordExpiryProjection) passwordSetStatement(event eventstore.Event, changeRequired bool) *handler.Statement {
+	return handler.NewUpsertStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(PasswordExpiryInstanceIDCol, nil),
+			handler.NewCol(PasswordExpiryUserIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(PasswordExpiryUserIDCol, event.Aggregate().ID),
+			handler.NewCol(PasswordExpiryInstanceIDCol, event.Aggregate().InstanceID),
+			handler.NewCol(PasswordExpiryResourceOwnerCol, event.Aggregate().ResourceOwner),
+			handler.NewCol(PasswordExpiryChangeDateCol, event.CreatedAt()),
+			handler.NewCol(PasswordExpirySequenceCol, event.Sequence()),
+			handler.NewCol(PasswordExpiryPasswordChangeDateCol, event.CreatedAt()),
+			handler.NewCol(PasswordExpiryChangeRequiredCol, changeRequired),
+			handler.NewCol(PasswordExpiryWarningAddedCol, false),
+		},
+	)
+}
+
+func (p *passwordExpiryProjection) reduceWarningAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanPasswordExpiryWarningAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(PasswordExpiryChangeDateCol, e.CreatedAt()),
+			handler.NewCol(PasswordExpirySequenceCol, e.Sequence()),
+			handler.NewCol(PasswordExpiryWarningAddedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(PasswordExpiryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(PasswordExpiryUserIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *passwordExpiryProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.UserRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(PasswordExpiryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(PasswordExpiryUserIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *passwordExpiryProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(PasswordExpiryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(PasswordExpiryResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
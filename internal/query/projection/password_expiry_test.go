@@ -0,0 +1,282 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestPasswordExpiryProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceHumanAdded with password",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanAddedType,
+						user.AggregateType,
+						[]byte(`{"userName": "username", "encodedHash": "$plain$x$password", "changeRequired": true}`),
+					),
+					user.HumanAddedEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reduceHumanAdded,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.password_expiry (user_id, instance_id, resource_owner, change_date, sequence, password_change_date, change_required, warning_added) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, user_id) DO UPDATE SET (resource_owner, change_date, sequence, password_change_date, change_required, warning_added) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.password_change_date, EXCLUDED.change_required, EXCLUDED.warning_added)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								true,
+								false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceHumanAdded without password",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanAddedType,
+						user.AggregateType,
+						[]byte(`{"userName": "username"}`),
+					),
+					user.HumanAddedEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reduceHumanAdded,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "reduceHumanRegistered",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanRegisteredType,
+						user.AggregateType,
+						[]byte(`{"userName": "username", "encodedHash": "$plain$x$password"}`),
+					),
+					user.HumanRegisteredEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reduceHumanRegistered,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.password_expiry (user_id, instance_id, resource_owner, change_date, sequence, password_change_date, change_required, warning_added) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, user_id) DO UPDATE SET (resource_owner, change_date, sequence, password_change_date, change_required, warning_added) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.password_change_date, EXCLUDED.change_required, EXCLUDED.warning_added)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								false,
+								false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reducePasswordChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanPasswordChangedType,
+						user.AggregateType,
+						[]byte(`{"encodedHash": "$plain$x$password", "changeRequired": false}`),
+					),
+					user.HumanPasswordChangedEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reducePasswordChanged,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.password_expiry (user_id, instance_id, resource_owner, change_date, sequence, password_change_date, change_required, warning_added) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, user_id) DO UPDATE SET (resource_owner, change_date, sequence, password_change_date, change_required, warning_added) = (EXCLUDED.resource_owner, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.password_change_date, EXCLUDED.change_required, EXCLUDED.warning_added)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								false,
+								false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWarningAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanPasswordExpiryWarningAddedType,
+						user.AggregateType,
+						[]byte(`{"expirationDate": "2024-05-01T00:00:00Z"}`),
+					),
+					user.HumanPasswordExpiryWarningAddedEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reduceWarningAdded,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.password_expiry SET (change_date, sequence, warning_added) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserRemovedType,
+						user.AggregateType,
+						nil,
+					),
+					user.UserRemovedEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.password_expiry WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					),
+					org.OrgRemovedEventMapper,
+				),
+			},
+			reduce: (&passwordExpiryProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.password_expiry WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(PasswordExpiryInstanceIDCol),
+			want: wantReduce{
+				aggregateType: instance.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.password_expiry WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, PasswordExpiryTable, tt.want)
+		})
+	}
+}
@@ -77,6 +77,9 @@ var (
 	TargetProjection                    *handler.Handler
 	ExecutionProjection                 *handler.Handler
 	UserSchemaProjection                *handler.Handler
+	NotificationDeliveryProjection      *handler.Handler
+	PasswordExpiryProjection            *handler.Handler
+	IDPLDAPSyncProjection               *handler.Handler
 )
 
 type projection interface {
@@ -158,6 +161,9 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	TargetProjection = newTargetProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["targets"]))
 	ExecutionProjection = newExecutionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["executions"]))
 	UserSchemaProjection = newUserSchemaProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_schemas"]))
+	NotificationDeliveryProjection = newNotificationDeliveryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_deliveries"]))
+	PasswordExpiryProjection = newPasswordExpiryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_expiry"]))
+	IDPLDAPSyncProjection = newIDPLDAPSyncProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_ldap_syncs"]))
 	newProjectionsList()
 	return nil
 }
@@ -272,5 +278,8 @@ func newProjectionsList() {
 		TargetProjection,
 		ExecutionProjection,
 		UserSchemaProjection,
+		NotificationDeliveryProjection,
+		PasswordExpiryProjection,
+		IDPLDAPSyncProjection,
 	}
 }
@@ -2,18 +2,22 @@ package projection
 
 import (
 	"context"
+	"time"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
 	SMSConfigProjectionTable = "projections.sms_configs2"
 	SMSTwilioTable           = SMSConfigProjectionTable + "_" + smsTwilioTableSuffix
+	SMSHTTPTable             = SMSConfigProjectionTable + "_" + smsHTTPTableSuffix
 
 	SMSColumnID            = "id"
 	SMSColumnAggregateID   = "aggregate_id"
@@ -30,6 +34,19 @@ const (
 	SMSTwilioConfigColumnSID          = "sid"
 	SMSTwilioConfigColumnSenderNumber = "sender_number"
 	SMSTwilioConfigColumnToken        = "token"
+
+	smsHTTPTableSuffix                    = "http"
+	SMSHTTPConfigColumnSMSID              = "sms_id"
+	SMSHTTPColumnInstanceID               = "instance_id"
+	SMSHTTPConfigColumnEndpoint           = "endpoint"
+	SMSHTTPConfigColumnMethod             = "method"
+	SMSHTTPConfigColumnHeaders            = "headers"
+	SMSHTTPConfigColumnBodyTemplate       = "body_template"
+	SMSHTTPConfigColumnContentType        = "content_type"
+	SMSHTTPConfigColumnSenderID           = "sender_id"
+	SMSHTTPConfigColumnSuccessStatusCodes = "success_status_codes"
+	SMSHTTPConfigColumnSuccessJSONPath    = "success_json_path"
+	SMSHTTPConfigColumnSuccessJSONValue   = "success_json_value"
 )
 
 type smsConfigProjection struct{}
@@ -67,6 +84,23 @@ func (*smsConfigProjection) Init() *old_handler.Check {
 			smsTwilioTableSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMSHTTPConfigColumnSMSID, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnMethod, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnHeaders, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMSHTTPConfigColumnBodyTemplate, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnContentType, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnSenderID, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnSuccessStatusCodes, handler.ColumnTypeEnumArray, handler.Nullable()),
+			handler.NewColumn(SMSHTTPConfigColumnSuccessJSONPath, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPConfigColumnSuccessJSONValue, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(SMSHTTPColumnInstanceID, SMSHTTPConfigColumnSMSID),
+			smsHTTPTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -87,6 +121,14 @@ func (p *smsConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMSConfigTwilioTokenChangedEventType,
 					Reduce: p.reduceSMSConfigTwilioTokenChanged,
 				},
+				{
+					Event:  instance.SMSConfigHTTPAddedEventType,
+					Reduce: p.reduceSMSConfigHTTPAdded,
+				},
+				{
+					Event:  instance.SMSConfigHTTPChangedEventType,
+					Reduce: p.reduceSMSConfigHTTPChanged,
+				},
 				{
 					Event:  instance.SMSConfigActivatedEventType,
 					Reduce: p.reduceSMSConfigActivated,
@@ -105,6 +147,43 @@ func (p *smsConfigProjection) Reducers() []handler.AggregateReducer {
 				},
 			},
 		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.SMSConfigTwilioAddedEventType,
+					Reduce: p.reduceOrgSMSConfigTwilioAdded,
+				},
+				{
+					Event:  org.SMSConfigTwilioChangedEventType,
+					Reduce: p.reduceOrgSMSConfigTwilioChanged,
+				},
+				{
+					Event:  org.SMSConfigHTTPAddedEventType,
+					Reduce: p.reduceOrgSMSConfigHTTPAdded,
+				},
+				{
+					Event:  org.SMSConfigHTTPChangedEventType,
+					Reduce: p.reduceOrgSMSConfigHTTPChanged,
+				},
+				{
+					Event:  org.SMSConfigActivatedEventType,
+					Reduce: p.reduceOrgSMSConfigActivated,
+				},
+				{
+					Event:  org.SMSConfigDeactivatedEventType,
+					Reduce: p.reduceOrgSMSConfigDeactivated,
+				},
+				{
+					Event:  org.SMSConfigRemovedEventType,
+					Reduce: p.reduceOrgSMSConfigRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
 	}
 }
 
@@ -210,6 +289,102 @@ func (p *smsConfigProjection) reduceSMSConfigTwilioTokenChanged(event eventstore
 	), nil
 }
 
+func (p *smsConfigProjection) reduceSMSConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Nh2re", "reduce.wrong.event.type %s", instance.SMSConfigHTTPAddedEventType)
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSHTTPConfigColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMSHTTPConfigColumnMethod, e.Method),
+				handler.NewCol(SMSHTTPConfigColumnHeaders, e.Headers),
+				handler.NewCol(SMSHTTPConfigColumnBodyTemplate, e.BodyTemplate),
+				handler.NewCol(SMSHTTPConfigColumnContentType, e.ContentType),
+				handler.NewCol(SMSHTTPConfigColumnSenderID, e.SenderID),
+				handler.NewCol(SMSHTTPConfigColumnSuccessStatusCodes, database.NumberArray[int](e.SuccessStatusCodes)),
+				handler.NewCol(SMSHTTPConfigColumnSuccessJSONPath, e.SuccessJSONPath),
+				handler.NewCol(SMSHTTPConfigColumnSuccessJSONValue, e.SuccessJSONValue),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Pj5gd", "reduce.wrong.event.type %s", instance.SMSConfigHTTPChangedEventType)
+	}
+	columns := make([]handler.Column, 0)
+	if e.Endpoint != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnEndpoint, *e.Endpoint))
+	}
+	if e.Method != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnMethod, *e.Method))
+	}
+	if e.Headers != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnHeaders, e.Headers))
+	}
+	if e.BodyTemplate != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.ContentType != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnContentType, *e.ContentType))
+	}
+	if e.SenderID != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSenderID, *e.SenderID))
+	}
+	if e.SuccessStatusCodes != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessStatusCodes, database.NumberArray[int](*e.SuccessStatusCodes)))
+	}
+	if e.SuccessJSONPath != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessJSONPath, *e.SuccessJSONPath))
+	}
+	if e.SuccessJSONValue != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessJSONValue, *e.SuccessJSONValue))
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
 func (p *smsConfigProjection) reduceSMSConfigActivated(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*instance.SMSConfigActivatedEvent)
 	if !ok {
@@ -261,3 +436,241 @@ func (p *smsConfigProjection) reduceSMSConfigRemoved(event eventstore.Event) (*h
 		},
 	), nil
 }
+
+func (p *smsConfigProjection) reduceOrgSMSConfigTwilioAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigTwilioAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSTwilioConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSTwilioColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSTwilioConfigColumnSID, e.SID),
+				handler.NewCol(SMSTwilioConfigColumnToken, e.Token),
+				handler.NewCol(SMSTwilioConfigColumnSenderNumber, e.SenderNumber),
+			},
+			handler.WithTableSuffix(smsTwilioTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceOrgSMSConfigTwilioChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigTwilioChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]handler.Column, 0, 3)
+	if e.SID != nil {
+		columns = append(columns, handler.NewCol(SMSTwilioConfigColumnSID, *e.SID))
+	}
+	if e.SenderNumber != nil {
+		columns = append(columns, handler.NewCol(SMSTwilioConfigColumnSenderNumber, *e.SenderNumber))
+	}
+	if e.Token != nil {
+		columns = append(columns, handler.NewCol(SMSTwilioConfigColumnToken, e.Token))
+	}
+
+	stmts := []func(eventstore.Event) handler.Exec{
+		p.orgSMSConfigChangedStatement(e.ID, e.Aggregate(), e.CreationDate(), e.Sequence()),
+	}
+	if len(columns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSTwilioConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSTwilioColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsTwilioTableSuffix),
+		))
+	}
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *smsConfigProjection) reduceOrgSMSConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigHTTPAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSHTTPConfigColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMSHTTPConfigColumnMethod, e.Method),
+				handler.NewCol(SMSHTTPConfigColumnHeaders, e.Headers),
+				handler.NewCol(SMSHTTPConfigColumnBodyTemplate, e.BodyTemplate),
+				handler.NewCol(SMSHTTPConfigColumnContentType, e.ContentType),
+				handler.NewCol(SMSHTTPConfigColumnSenderID, e.SenderID),
+				handler.NewCol(SMSHTTPConfigColumnSuccessStatusCodes, database.NumberArray[int](e.SuccessStatusCodes)),
+				handler.NewCol(SMSHTTPConfigColumnSuccessJSONPath, e.SuccessJSONPath),
+				handler.NewCol(SMSHTTPConfigColumnSuccessJSONValue, e.SuccessJSONValue),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceOrgSMSConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigHTTPChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]handler.Column, 0, 9)
+	if e.Endpoint != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnEndpoint, *e.Endpoint))
+	}
+	if e.Method != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnMethod, *e.Method))
+	}
+	if e.Headers != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnHeaders, e.Headers))
+	}
+	if e.BodyTemplate != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.ContentType != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnContentType, *e.ContentType))
+	}
+	if e.SenderID != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSenderID, *e.SenderID))
+	}
+	if e.SuccessStatusCodes != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessStatusCodes, database.NumberArray[int](*e.SuccessStatusCodes)))
+	}
+	if e.SuccessJSONPath != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessJSONPath, *e.SuccessJSONPath))
+	}
+	if e.SuccessJSONValue != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSuccessJSONValue, *e.SuccessJSONValue))
+	}
+
+	stmts := []func(eventstore.Event) handler.Exec{
+		p.orgSMSConfigChangedStatement(e.ID, e.Aggregate(), e.CreationDate(), e.Sequence()),
+	}
+	if len(columns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsHTTPTableSuffix),
+		))
+	}
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
+// orgSMSConfigChangedStatement updates the change date and sequence of the config of the organization
+func (p *smsConfigProjection) orgSMSConfigChangedStatement(id string, aggregate *eventstore.Aggregate, changeDate time.Time, sequence uint64) func(eventstore.Event) handler.Exec {
+	return handler.AddUpdateStatement(
+		[]handler.Column{
+			handler.NewCol(SMSColumnChangeDate, changeDate),
+			handler.NewCol(SMSColumnSequence, sequence),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMSColumnID, id),
+			handler.NewCond(SMSColumnResourceOwner, aggregate.ResourceOwner),
+			handler.NewCond(SMSColumnInstanceID, aggregate.InstanceID),
+		},
+	)
+}
+
+func (p *smsConfigProjection) reduceOrgSMSConfigActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigActivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMSColumnState, domain.SMSConfigStateActive),
+			handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMSColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMSColumnID, e.ID),
+			handler.NewCond(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smsConfigProjection) reduceOrgSMSConfigDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigDeactivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+			handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMSColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMSColumnID, e.ID),
+			handler.NewCond(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smsConfigProjection) reduceOrgSMSConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMSConfigRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SMSColumnID, e.ID),
+			handler.NewCond(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smsConfigProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SMSColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
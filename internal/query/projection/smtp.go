@@ -3,16 +3,20 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
-	SMTPConfigProjectionTable      = "projections.smtp_configs2"
+	SMTPConfigProjectionTable      = "projections.smtp_configs4"
+	SMTPConfigHTTPTable            = SMTPConfigProjectionTable + "_" + smtpConfigHTTPTableSuffix
 	SMTPConfigColumnInstanceID     = "instance_id"
 	SMTPConfigColumnResourceOwner  = "resource_owner"
 	SMTPConfigColumnID             = "id"
@@ -28,6 +32,20 @@ const (
 	SMTPConfigColumnSMTPPassword   = "password"
 	SMTPConfigColumnState          = "state"
 	SMTPConfigColumnDescription    = "description"
+	SMTPConfigColumnPriority       = "priority"
+	SMTPConfigColumnDKIMSelector   = "dkim_selector"
+	SMTPConfigColumnDKIMDomain     = "dkim_domain"
+	SMTPConfigColumnDKIMPrivateKey = "dkim_private_key"
+
+	smtpConfigHTTPTableSuffix              = "http"
+	SMTPConfigHTTPColumnSMTPID             = "smtp_id"
+	SMTPConfigHTTPColumnInstanceID         = "instance_id"
+	SMTPConfigHTTPColumnResourceOwner      = "resource_owner"
+	SMTPConfigHTTPColumnEndpoint           = "endpoint"
+	SMTPConfigHTTPColumnHeaders            = "headers"
+	SMTPConfigHTTPColumnBodyTemplate       = "body_template"
+	SMTPConfigHTTPColumnContentType        = "content_type"
+	SMTPConfigHTTPColumnSuccessStatusCodes = "success_status_codes"
 )
 
 type smtpConfigProjection struct{}
@@ -41,7 +59,7 @@ func (*smtpConfigProjection) Name() string {
 }
 
 func (*smtpConfigProjection) Init() *old_handler.Check {
-	return handler.NewTableCheck(
+	return handler.NewMultiTableCheck(
 		handler.NewTable([]*handler.InitColumn{
 			handler.NewColumn(SMTPConfigColumnID, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnCreationDate, handler.ColumnTypeTimestamp),
@@ -58,9 +76,27 @@ func (*smtpConfigProjection) Init() *old_handler.Check {
 			handler.NewColumn(SMTPConfigColumnSMTPPassword, handler.ColumnTypeJSONB, handler.Nullable()),
 			handler.NewColumn(SMTPConfigColumnState, handler.ColumnTypeEnum),
 			handler.NewColumn(SMTPConfigColumnDescription, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigColumnPriority, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(SMTPConfigColumnDKIMSelector, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(SMTPConfigColumnDKIMDomain, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(SMTPConfigColumnDKIMPrivateKey, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnResourceOwner, SMTPConfigColumnID),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMTPConfigHTTPColumnSMTPID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnHeaders, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMTPConfigHTTPColumnBodyTemplate, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnContentType, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnSuccessStatusCodes, handler.ColumnTypeEnumArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(SMTPConfigHTTPColumnInstanceID, SMTPConfigHTTPColumnResourceOwner, SMTPConfigHTTPColumnSMTPID),
+			smtpConfigHTTPTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -77,6 +113,14 @@ func (p *smtpConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigChangedEventType,
 					Reduce: p.reduceSMTPConfigChanged,
 				},
+				{
+					Event:  instance.SMTPConfigHTTPAddedEventType,
+					Reduce: p.reduceSMTPConfigHTTPAdded,
+				},
+				{
+					Event:  instance.SMTPConfigHTTPChangedEventType,
+					Reduce: p.reduceSMTPConfigHTTPChanged,
+				},
 				{
 					Event:  instance.SMTPConfigPasswordChangedEventType,
 					Reduce: p.reduceSMTPConfigPasswordChanged,
@@ -89,16 +133,65 @@ func (p *smtpConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigDeactivatedEventType,
 					Reduce: p.reduceSMTPConfigDeactivated,
 				},
+				{
+					Event:  instance.SMTPConfigPriorityChangedEventType,
+					Reduce: p.reduceSMTPConfigPriorityChanged,
+				},
 				{
 					Event:  instance.SMTPConfigRemovedEventType,
 					Reduce: p.reduceSMTPConfigRemoved,
 				},
+				{
+					Event:  instance.SMTPConfigDKIMSetEventType,
+					Reduce: p.reduceSMTPConfigDKIMSet,
+				},
+				{
+					Event:  instance.SMTPConfigDKIMRemovedEventType,
+					Reduce: p.reduceSMTPConfigDKIMRemoved,
+				},
 				{
 					Event:  instance.InstanceRemovedEventType,
 					Reduce: reduceInstanceRemovedHelper(SMTPConfigColumnInstanceID),
 				},
 			},
 		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.SMTPConfigAddedEventType,
+					Reduce: p.reduceOrgSMTPConfigAdded,
+				},
+				{
+					Event:  org.SMTPConfigChangedEventType,
+					Reduce: p.reduceOrgSMTPConfigChanged,
+				},
+				{
+					Event:  org.SMTPConfigActivatedEventType,
+					Reduce: p.reduceOrgSMTPConfigActivated,
+				},
+				{
+					Event:  org.SMTPConfigDeactivatedEventType,
+					Reduce: p.reduceOrgSMTPConfigDeactivated,
+				},
+				{
+					Event:  org.SMTPConfigRemovedEventType,
+					Reduce: p.reduceOrgSMTPConfigRemoved,
+				},
+				{
+					Event:  org.SMTPConfigDKIMSetEventType,
+					Reduce: p.reduceOrgSMTPConfigDKIMSet,
+				},
+				{
+					Event:  org.SMTPConfigDKIMRemovedEventType,
+					Reduce: p.reduceOrgSMTPConfigDKIMRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
 	}
 }
 
@@ -191,6 +284,111 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 	), nil
 }
 
+func (p *smtpConfigProjection) reduceSMTPConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigHTTPAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+				handler.NewCol(SMTPConfigColumnID, e.ID),
+				handler.NewCol(SMTPConfigColumnTLS, false),
+				handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
+				handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
+				handler.NewCol(SMTPConfigColumnReplyToAddress, e.ReplyToAddress),
+				handler.NewCol(SMTPConfigColumnSMTPHost, ""),
+				handler.NewCol(SMTPConfigColumnSMTPUser, ""),
+				handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateInactive),
+				handler.NewCol(SMTPConfigColumnDescription, e.Description),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigHTTPColumnSMTPID, e.ID),
+				handler.NewCol(SMTPConfigHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMTPConfigHTTPColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMTPConfigHTTPColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMTPConfigHTTPColumnHeaders, e.Headers),
+				handler.NewCol(SMTPConfigHTTPColumnBodyTemplate, e.BodyTemplate),
+				handler.NewCol(SMTPConfigHTTPColumnContentType, e.ContentType),
+				handler.NewCol(SMTPConfigHTTPColumnSuccessStatusCodes, database.NumberArray[int](e.SuccessStatusCodes)),
+			},
+			handler.WithTableSuffix(smtpConfigHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigHTTPChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []handler.Column{
+		handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+	}
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnDescription, *e.Description))
+	}
+	if e.SenderAddress != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderAddress, *e.SenderAddress))
+	}
+	if e.SenderName != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderName, *e.SenderName))
+	}
+	if e.ReplyToAddress != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnReplyToAddress, *e.ReplyToAddress))
+	}
+	stmts := []func(eventstore.Event) handler.Exec{
+		handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMTPConfigColumnID, e.ID),
+				handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	}
+
+	httpColumns := make([]handler.Column, 0, 5)
+	if e.Endpoint != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMTPConfigHTTPColumnEndpoint, *e.Endpoint))
+	}
+	if e.Headers != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMTPConfigHTTPColumnHeaders, e.Headers))
+	}
+	if e.BodyTemplate != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMTPConfigHTTPColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.ContentType != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMTPConfigHTTPColumnContentType, *e.ContentType))
+	}
+	if e.SuccessStatusCodes != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMTPConfigHTTPColumnSuccessStatusCodes, database.NumberArray[int](*e.SuccessStatusCodes)))
+	}
+	if len(httpColumns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			httpColumns,
+			[]handler.Condition{
+				handler.NewCond(SMTPConfigHTTPColumnSMTPID, e.ID),
+				handler.NewCond(SMTPConfigHTTPColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCond(SMTPConfigHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smtpConfigHTTPTableSuffix),
+		))
+	}
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
 func (p *smtpConfigProjection) reduceSMTPConfigPasswordChanged(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*instance.SMTPConfigPasswordChangedEvent)
 	if !ok {
@@ -272,6 +470,33 @@ func (p *smtpConfigProjection) reduceSMTPConfigDeactivated(event eventstore.Even
 	), nil
 }
 
+func (p *smtpConfigProjection) reduceSMTPConfigPriorityChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigPriorityChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deal with old and unique SMTP settings (empty ID)
+	id := e.ID
+	if e.ID == "" {
+		id = e.Aggregate().ResourceOwner
+	}
+
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnPriority, e.Priority),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, id),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *smtpConfigProjection) reduceSMTPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, err := assertEvent[*instance.SMTPConfigRemovedEvent](event)
 	if err != nil {
@@ -293,3 +518,200 @@ func (p *smtpConfigProjection) reduceSMTPConfigRemoved(event eventstore.Event) (
 		},
 	), nil
 }
+
+func (p *smtpConfigProjection) reduceSMTPConfigDKIMSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigDKIMSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return smtpConfigDKIMStatement(e, e.ID, e.Selector, e.Domain, e.PrivateKey), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigDKIMRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigDKIMRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return smtpConfigDKIMStatement(e, e.ID, "", "", nil), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnID, e.ID),
+			handler.NewCol(SMTPConfigColumnTLS, e.TLS),
+			handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
+			handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
+			handler.NewCol(SMTPConfigColumnReplyToAddress, e.ReplyToAddress),
+			handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
+			handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
+			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
+			handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateInactive),
+			handler.NewCol(SMTPConfigColumnDescription, e.Description),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []handler.Column{
+		handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+	}
+	if e.TLS != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnTLS, *e.TLS))
+	}
+	if e.FromAddress != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderAddress, *e.FromAddress))
+	}
+	if e.FromName != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSenderName, *e.FromName))
+	}
+	if e.ReplyToAddress != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnReplyToAddress, *e.ReplyToAddress))
+	}
+	if e.Host != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPHost, *e.Host))
+	}
+	if e.User != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPUser, *e.User))
+	}
+	if e.Password != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password))
+	}
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnDescription, *e.Description))
+	}
+	return handler.NewUpdateStatement(
+		e,
+		columns,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigActivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateActive),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigDeactivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateInactive),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, e.ID),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigDKIMSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigDKIMSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return smtpConfigDKIMStatement(e, e.ID, e.Selector, e.Domain, e.PrivateKey), nil
+}
+
+func (p *smtpConfigProjection) reduceOrgSMTPConfigDKIMRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.SMTPConfigDKIMRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return smtpConfigDKIMStatement(e, e.ID, "", "", nil), nil
+}
+
+// smtpConfigDKIMStatement sets the DKIM columns of the config, they are reset if the private key is nil
+func smtpConfigDKIMStatement(e eventstore.Event, id, selector, domain string, privateKey *crypto.CryptoValue) *handler.Statement {
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreatedAt()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnDKIMSelector, selector),
+			handler.NewCol(SMTPConfigColumnDKIMDomain, domain),
+			handler.NewCol(SMTPConfigColumnDKIMPrivateKey, privateKey),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, id),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	)
+}
+
+func (p *smtpConfigProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SMTPConfigColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
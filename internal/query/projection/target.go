@@ -11,7 +11,7 @@ import (
 )
 
 const (
-	TargetTable               = "projections.targets1"
+	TargetTable               = "projections.targets2"
 	TargetIDCol               = "id"
 	TargetCreationDateCol     = "creation_date"
 	TargetChangeDateCol       = "change_date"
@@ -23,6 +23,21 @@ const (
 	TargetEndpointCol         = "endpoint"
 	TargetTimeoutCol          = "timeout"
 	TargetInterruptOnErrorCol = "interrupt_on_error"
+	TargetMaxAttemptsCol      = "max_attempts"
+	TargetSigningKeyCol       = "signing_key"
+
+	TargetHeadersCol           = "headers"
+	TargetJWTAuthenticationCol = "jwt_authentication"
+	TargetClientCertificateCol = "client_certificate"
+	TargetClientKeyCol         = "client_key"
+	TargetRootCAsCol           = "root_cas"
+
+	TargetMaxConcurrencyCol   = "max_concurrency"
+	TargetFailureThresholdCol = "failure_threshold"
+	TargetOpenDurationCol     = "open_duration"
+
+	TargetPreviousSigningKeyCol           = "previous_signing_key"
+	TargetPreviousSigningKeyExpirationCol = "previous_signing_key_expiration"
 )
 
 type targetProjection struct{}
@@ -49,6 +64,18 @@ func (*targetProjection) Init() *old_handler.Check {
 			handler.NewColumn(TargetEndpointCol, handler.ColumnTypeText),
 			handler.NewColumn(TargetTimeoutCol, handler.ColumnTypeInt64),
 			handler.NewColumn(TargetInterruptOnErrorCol, handler.ColumnTypeBool),
+			handler.NewColumn(TargetMaxAttemptsCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(TargetSigningKeyCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(TargetPreviousSigningKeyCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(TargetPreviousSigningKeyExpirationCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(TargetHeadersCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(TargetJWTAuthenticationCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(TargetClientCertificateCol, handler.ColumnTypeBytes, handler.Nullable()),
+			handler.NewColumn(TargetClientKeyCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(TargetRootCAsCol, handler.ColumnTypeBytes, handler.Nullable()),
+			handler.NewColumn(TargetMaxConcurrencyCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(TargetFailureThresholdCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(TargetOpenDurationCol, handler.ColumnTypeInt64, handler.Default(0)),
 		},
 			handler.NewPrimaryKey(TargetInstanceIDCol, TargetIDCol),
 		),
@@ -68,6 +95,10 @@ func (p *targetProjection) Reducers() []handler.AggregateReducer {
 					Event:  target.ChangedEventType,
 					Reduce: p.reduceTargetChanged,
 				},
+				{
+					Event:  target.SigningKeyRotatedEventType,
+					Reduce: p.reduceTargetSigningKeyRotated,
+				},
 				{
 					Event:  target.RemovedEventType,
 					Reduce: p.reduceTargetRemoved,
@@ -91,22 +122,46 @@ func (p *targetProjection) reduceTargetAdded(event eventstore.Event) (*handler.S
 	if err != nil {
 		return nil, err
 	}
-	return handler.NewCreateStatement(
-		e,
-		[]handler.Column{
-			handler.NewCol(TargetInstanceIDCol, e.Aggregate().InstanceID),
-			handler.NewCol(TargetResourceOwnerCol, e.Aggregate().ResourceOwner),
-			handler.NewCol(TargetIDCol, e.Aggregate().ID),
-			handler.NewCol(TargetCreationDateCol, e.CreationDate()),
-			handler.NewCol(TargetChangeDateCol, e.CreationDate()),
-			handler.NewCol(TargetSequenceCol, e.Sequence()),
-			handler.NewCol(TargetNameCol, e.Name),
-			handler.NewCol(TargetEndpointCol, e.Endpoint),
-			handler.NewCol(TargetTargetType, e.TargetType),
-			handler.NewCol(TargetTimeoutCol, e.Timeout),
-			handler.NewCol(TargetInterruptOnErrorCol, e.InterruptOnError),
-		},
-	), nil
+	values := []handler.Column{
+		handler.NewCol(TargetInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(TargetResourceOwnerCol, e.Aggregate().ResourceOwner),
+		handler.NewCol(TargetIDCol, e.Aggregate().ID),
+		handler.NewCol(TargetCreationDateCol, e.CreationDate()),
+		handler.NewCol(TargetChangeDateCol, e.CreationDate()),
+		handler.NewCol(TargetSequenceCol, e.Sequence()),
+		handler.NewCol(TargetNameCol, e.Name),
+		handler.NewCol(TargetEndpointCol, e.Endpoint),
+		handler.NewCol(TargetTargetType, e.TargetType),
+		handler.NewCol(TargetTimeoutCol, e.Timeout),
+		handler.NewCol(TargetInterruptOnErrorCol, e.InterruptOnError),
+		handler.NewCol(TargetMaxAttemptsCol, e.MaxAttempts),
+		handler.NewCol(TargetMaxConcurrencyCol, e.MaxConcurrency),
+		handler.NewCol(TargetSigningKeyCol, e.SigningKey),
+	}
+	if e.Authentication != nil {
+		values = append(values, targetAuthenticationCols(e.Authentication)...)
+	}
+	if e.CircuitBreaker != nil {
+		values = append(values, targetCircuitBreakerCols(e.CircuitBreaker)...)
+	}
+	return handler.NewCreateStatement(e, values), nil
+}
+
+func targetCircuitBreakerCols(circuitBreaker *target.CircuitBreaker) []handler.Column {
+	return []handler.Column{
+		handler.NewCol(TargetFailureThresholdCol, circuitBreaker.FailureThreshold),
+		handler.NewCol(TargetOpenDurationCol, circuitBreaker.OpenDuration),
+	}
+}
+
+func targetAuthenticationCols(authentication *target.Authentication) []handler.Column {
+	return []handler.Column{
+		handler.NewCol(TargetHeadersCol, authentication.Headers),
+		handler.NewCol(TargetJWTAuthenticationCol, authentication.JWT),
+		handler.NewCol(TargetClientCertificateCol, authentication.ClientCertificate),
+		handler.NewCol(TargetClientKeyCol, authentication.ClientKey),
+		handler.NewCol(TargetRootCAsCol, authentication.RootCAs),
+	}
 }
 
 func (p *targetProjection) reduceTargetChanged(event eventstore.Event) (*handler.Statement, error) {
@@ -134,6 +189,18 @@ func (p *targetProjection) reduceTargetChanged(event eventstore.Event) (*handler
 	if e.InterruptOnError != nil {
 		values = append(values, handler.NewCol(TargetInterruptOnErrorCol, *e.InterruptOnError))
 	}
+	if e.MaxAttempts != nil {
+		values = append(values, handler.NewCol(TargetMaxAttemptsCol, *e.MaxAttempts))
+	}
+	if e.Authentication != nil {
+		values = append(values, targetAuthenticationCols(e.Authentication)...)
+	}
+	if e.MaxConcurrency != nil {
+		values = append(values, handler.NewCol(TargetMaxConcurrencyCol, *e.MaxConcurrency))
+	}
+	if e.CircuitBreaker != nil {
+		values = append(values, targetCircuitBreakerCols(e.CircuitBreaker)...)
+	}
 	return handler.NewUpdateStatement(
 		e,
 		values,
@@ -144,6 +211,27 @@ func (p *targetProjection) reduceTargetChanged(event eventstore.Event) (*handler
 	), nil
 }
 
+func (p *targetProjection) reduceTargetSigningKeyRotated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*target.SigningKeyRotatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TargetChangeDateCol, e.CreationDate()),
+			handler.NewCol(TargetSequenceCol, e.Sequence()),
+			handler.NewCol(TargetSigningKeyCol, e.SigningKey),
+			handler.NewCol(TargetPreviousSigningKeyCol, e.PreviousSigningKey),
+			handler.NewCol(TargetPreviousSigningKeyExpirationCol, e.PreviousSigningKeyExpiration()),
+		},
+		[]handler.Condition{
+			handler.NewCond(TargetInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(TargetIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
 func (p *targetProjection) reduceTargetRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, err := assertEvent[*target.RemovedEvent](event)
 	if err != nil {
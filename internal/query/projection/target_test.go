@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -29,7 +30,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 					testEvent(
 						target.AddedEventType,
 						target.AggregateType,
-						[]byte(`{"name": "name", "targetType":0, "endpoint":"https://example.com", "timeout": 3000000000, "async": true, "interruptOnError": true}`),
+						[]byte(`{"name": "name", "targetType":0, "endpoint":"https://example.com", "timeout": 3000000000, "async": true, "interruptOnError": true, "maxAttempts": 3, "maxConcurrency": 10, "circuitBreaker": {"failureThreshold": 5, "openDuration": 60000000000}, "signingKey": { "cryptoType": 0, "algorithm": "RSA-265", "keyId": "key-id" }}`),
 					),
 					eventstore.GenericEventMapper[target.AddedEvent],
 				),
@@ -41,7 +42,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.targets1 (instance_id, resource_owner, id, creation_date, change_date, sequence, name, endpoint, target_type, timeout, interrupt_on_error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedStmt: "INSERT INTO projections.targets2 (instance_id, resource_owner, id, creation_date, change_date, sequence, name, endpoint, target_type, timeout, interrupt_on_error, max_attempts, max_concurrency, signing_key, failure_threshold, open_duration) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"ro-id",
@@ -54,6 +55,45 @@ func TestTargetProjection_reduces(t *testing.T) {
 								domain.TargetTypeWebhook,
 								3 * time.Second,
 								true,
+								uint8(3),
+								uint16(10),
+								anyArg{},
+								uint16(5),
+								time.Minute,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTargetSigningKeyRotated",
+			args: args{
+				event: getEvent(
+					testEvent(
+						target.SigningKeyRotatedEventType,
+						target.AggregateType,
+						[]byte(`{"signingKey": { "cryptoType": 0, "algorithm": "RSA-265", "keyId": "key-id" }, "previousSigningKey": { "cryptoType": 0, "algorithm": "RSA-265", "keyId": "key-id" }, "gracePeriod": 3600000000000}`),
+					),
+					eventstore.GenericEventMapper[target.SigningKeyRotatedEvent],
+				),
+			},
+			reduce: (&targetProjection{}).reduceTargetSigningKeyRotated,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("target"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.targets2 SET (change_date, sequence, signing_key, previous_signing_key, previous_signing_key_expiration) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								anyArg{},
+								anyArg{},
+								"instance-id",
+								"agg-id",
 							},
 						},
 					},
@@ -67,7 +107,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 					testEvent(
 						target.ChangedEventType,
 						target.AggregateType,
-						[]byte(`{"name": "name2", "targetType":0, "endpoint":"https://example.com", "timeout": 3000000000, "async": true, "interruptOnError": true}`),
+						[]byte(`{"name": "name2", "targetType":0, "endpoint":"https://example.com", "timeout": 3000000000, "async": true, "interruptOnError": true, "maxAttempts": 3, "maxConcurrency": 10, "circuitBreaker": {"failureThreshold": 5, "openDuration": 60000000000}}`),
 					),
 					eventstore.GenericEventMapper[target.ChangedEvent],
 				),
@@ -79,7 +119,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.targets1 SET (change_date, sequence, resource_owner, name, target_type, endpoint, timeout, interrupt_on_error) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (instance_id = $9) AND (id = $10)",
+							expectedStmt: "UPDATE projections.targets2 SET (change_date, sequence, resource_owner, name, target_type, endpoint, timeout, interrupt_on_error, max_attempts, max_concurrency, failure_threshold, open_duration) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) WHERE (instance_id = $13) AND (id = $14)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -89,6 +129,47 @@ func TestTargetProjection_reduces(t *testing.T) {
 								"https://example.com",
 								3 * time.Second,
 								true,
+								uint8(3),
+								uint16(10),
+								uint16(5),
+								time.Minute,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTargetChanged authentication",
+			args: args{
+				event: getEvent(
+					testEvent(
+						target.ChangedEventType,
+						target.AggregateType,
+						[]byte(`{"authentication": {"headers": { "cryptoType": 0, "algorithm": "RSA-265", "keyId": "key-id" }, "jwt": true, "clientCertificate": "Y2VydA==", "rootCAs": "Y2E="}}`),
+					),
+					eventstore.GenericEventMapper[target.ChangedEvent],
+				),
+			},
+			reduce: (&targetProjection{}).reduceTargetChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("target"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.targets2 SET (change_date, sequence, resource_owner, headers, jwt_authentication, client_certificate, client_key, root_cas) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (instance_id = $9) AND (id = $10)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								anyArg{},
+								true,
+								[]byte("cert"),
+								(*crypto.CryptoValue)(nil),
+								[]byte("ca"),
 								"instance-id",
 								"agg-id",
 							},
@@ -116,7 +197,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.targets1 WHERE (instance_id = $1) AND (id = $2)",
+							expectedStmt: "DELETE FROM projections.targets2 WHERE (instance_id = $1) AND (id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -145,7 +226,7 @@ func TestTargetProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.targets1 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.targets2 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
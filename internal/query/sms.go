@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -32,6 +33,7 @@ type SMSConfig struct {
 	Sequence      uint64
 
 	TwilioConfig *Twilio
+	HTTPConfig   *HTTP
 }
 
 type Twilio struct {
@@ -40,6 +42,18 @@ type Twilio struct {
 	SenderNumber string
 }
 
+type HTTP struct {
+	Endpoint           string
+	Method             string
+	Headers            *crypto.CryptoValue
+	BodyTemplate       string
+	ContentType        string
+	SenderID           string
+	SuccessStatusCodes []int
+	SuccessJSONPath    string
+	SuccessJSONValue   string
+}
+
 type SMSConfigsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -115,15 +129,63 @@ var (
 	}
 )
 
-func (q *Queries) SMSProviderConfigByID(ctx context.Context, id string) (config *SMSConfig, err error) {
+var (
+	smsHTTPConfigsTable = table{
+		name:          projection.SMSHTTPTable,
+		instanceIDCol: projection.SMSHTTPColumnInstanceID,
+	}
+	SMSHTTPConfigColumnSMSID = Column{
+		name:  projection.SMSHTTPConfigColumnSMSID,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnEndpoint = Column{
+		name:  projection.SMSHTTPConfigColumnEndpoint,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnMethod = Column{
+		name:  projection.SMSHTTPConfigColumnMethod,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnHeaders = Column{
+		name:  projection.SMSHTTPConfigColumnHeaders,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnBodyTemplate = Column{
+		name:  projection.SMSHTTPConfigColumnBodyTemplate,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnContentType = Column{
+		name:  projection.SMSHTTPConfigColumnContentType,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSenderID = Column{
+		name:  projection.SMSHTTPConfigColumnSenderID,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSuccessStatusCodes = Column{
+		name:  projection.SMSHTTPConfigColumnSuccessStatusCodes,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSuccessJSONPath = Column{
+		name:  projection.SMSHTTPConfigColumnSuccessJSONPath,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSuccessJSONValue = Column{
+		name:  projection.SMSHTTPConfigColumnSuccessJSONValue,
+		table: smsHTTPConfigsTable,
+	}
+)
+
+func (q *Queries) SMSProviderConfigByID(ctx context.Context, resourceOwner, id string) (config *SMSConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	query, scan := prepareSMSConfigQuery(ctx, q.client)
 	stmt, args, err := query.Where(
 		sq.Eq{
-			SMSConfigColumnID.identifier():         id,
-			SMSConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			SMSConfigColumnID.identifier():            id,
+			SMSConfigColumnResourceOwner.identifier(): resourceOwner,
+			SMSConfigColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
 		},
 	).ToSql()
 	if err != nil {
@@ -189,6 +251,12 @@ func NewSMSProviderStateQuery(state domain.SMSConfigState) (SearchQuery, error)
 	return NewNumberQuery(SMSConfigColumnState, state, NumberEquals)
 }
 
+// NewSMSProviderResourceOwnerQuery returns the SMS providers of an organization,
+// or the ones of the instance if the instance id is passed
+func NewSMSProviderResourceOwnerQuery(resourceOwner string) (SearchQuery, error) {
+	return NewTextQuery(SMSConfigColumnResourceOwner, resourceOwner, TextEquals)
+}
+
 func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMSConfig, error)) {
 	return sq.Select(
 			SMSConfigColumnID.identifier(),
@@ -203,13 +271,26 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnEndpoint.identifier(),
+			SMSHTTPConfigColumnMethod.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnContentType.identifier(),
+			SMSHTTPConfigColumnSenderID.identifier(),
+			SMSHTTPConfigColumnSuccessStatusCodes.identifier(),
+			SMSHTTPConfigColumnSuccessJSONPath.identifier(),
+			SMSHTTPConfigColumnSuccessJSONValue.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*SMSConfig, error) {
 			config := new(SMSConfig)
 
 			var (
 				twilioConfig = sqlTwilioConfig{}
+				httpConfig   = sqlHTTPConfig{}
 			)
 
 			err := row.Scan(
@@ -225,6 +306,17 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				&twilioConfig.sid,
 				&twilioConfig.token,
 				&twilioConfig.senderNumber,
+
+				&httpConfig.smsID,
+				&httpConfig.endpoint,
+				&httpConfig.method,
+				&httpConfig.headers,
+				&httpConfig.bodyTemplate,
+				&httpConfig.contentType,
+				&httpConfig.senderID,
+				&httpConfig.successStatusCodes,
+				&httpConfig.successJSONPath,
+				&httpConfig.successJSONValue,
 			)
 
 			if err != nil {
@@ -235,6 +327,7 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			}
 
 			twilioConfig.set(config)
+			httpConfig.set(config)
 
 			return config, nil
 		}
@@ -254,9 +347,21 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnEndpoint.identifier(),
+			SMSHTTPConfigColumnMethod.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnContentType.identifier(),
+			SMSHTTPConfigColumnSenderID.identifier(),
+			SMSHTTPConfigColumnSuccessStatusCodes.identifier(),
+			SMSHTTPConfigColumnSuccessJSONPath.identifier(),
+			SMSHTTPConfigColumnSuccessJSONValue.identifier(),
 			countColumn.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*SMSConfigs, error) {
 			configs := &SMSConfigs{Configs: []*SMSConfig{}}
 
@@ -264,6 +369,7 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				config := new(SMSConfig)
 				var (
 					twilioConfig = sqlTwilioConfig{}
+					httpConfig   = sqlHTTPConfig{}
 				)
 
 				err := row.Scan(
@@ -279,6 +385,17 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					&twilioConfig.sid,
 					&twilioConfig.token,
 					&twilioConfig.senderNumber,
+
+					&httpConfig.smsID,
+					&httpConfig.endpoint,
+					&httpConfig.method,
+					&httpConfig.headers,
+					&httpConfig.bodyTemplate,
+					&httpConfig.contentType,
+					&httpConfig.senderID,
+					&httpConfig.successStatusCodes,
+					&httpConfig.successJSONPath,
+					&httpConfig.successJSONValue,
 					&configs.Count,
 				)
 
@@ -287,6 +404,8 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				}
 
 				twilioConfig.set(config)
+				httpConfig.set(config)
+				httpConfig.set(config)
 
 				configs.Configs = append(configs.Configs, config)
 			}
@@ -312,3 +431,33 @@ func (c sqlTwilioConfig) set(smsConfig *SMSConfig) {
 		SenderNumber: c.senderNumber.String,
 	}
 }
+
+type sqlHTTPConfig struct {
+	smsID              sql.NullString
+	endpoint           sql.NullString
+	method             sql.NullString
+	headers            *crypto.CryptoValue
+	bodyTemplate       sql.NullString
+	contentType        sql.NullString
+	senderID           sql.NullString
+	successStatusCodes database.NumberArray[int]
+	successJSONPath    sql.NullString
+	successJSONValue   sql.NullString
+}
+
+func (c sqlHTTPConfig) set(smsConfig *SMSConfig) {
+	if !c.smsID.Valid {
+		return
+	}
+	smsConfig.HTTPConfig = &HTTP{
+		Endpoint:           c.endpoint.String,
+		Method:             c.method.String,
+		Headers:            c.headers,
+		BodyTemplate:       c.bodyTemplate.String,
+		ContentType:        c.contentType.String,
+		SenderID:           c.senderID.String,
+		SuccessStatusCodes: c.successStatusCodes,
+		SuccessJSONPath:    c.successJSONPath.String,
+		SuccessJSONValue:   c.successJSONValue.String,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -26,9 +27,21 @@ var (
 		` projections.sms_configs2_twilio.sms_id,` +
 		` projections.sms_configs2_twilio.sid,` +
 		` projections.sms_configs2_twilio.token,` +
-		` projections.sms_configs2_twilio.sender_number` +
+		` projections.sms_configs2_twilio.sender_number,` +
+		// http config
+		` projections.sms_configs2_http.sms_id,` +
+		` projections.sms_configs2_http.endpoint,` +
+		` projections.sms_configs2_http.method,` +
+		` projections.sms_configs2_http.headers,` +
+		` projections.sms_configs2_http.body_template,` +
+		` projections.sms_configs2_http.content_type,` +
+		` projections.sms_configs2_http.sender_id,` +
+		` projections.sms_configs2_http.success_status_codes,` +
+		` projections.sms_configs2_http.success_json_path,` +
+		` projections.sms_configs2_http.success_json_value` +
 		` FROM projections.sms_configs2` +
 		` LEFT JOIN projections.sms_configs2_twilio ON projections.sms_configs2.id = projections.sms_configs2_twilio.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs2_http ON projections.sms_configs2.id = projections.sms_configs2_http.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs2.id,` +
 		` projections.sms_configs2.aggregate_id,` +
@@ -43,9 +56,21 @@ var (
 		` projections.sms_configs2_twilio.sid,` +
 		` projections.sms_configs2_twilio.token,` +
 		` projections.sms_configs2_twilio.sender_number,` +
+		// http config
+		` projections.sms_configs2_http.sms_id,` +
+		` projections.sms_configs2_http.endpoint,` +
+		` projections.sms_configs2_http.method,` +
+		` projections.sms_configs2_http.headers,` +
+		` projections.sms_configs2_http.body_template,` +
+		` projections.sms_configs2_http.content_type,` +
+		` projections.sms_configs2_http.sender_id,` +
+		` projections.sms_configs2_http.success_status_codes,` +
+		` projections.sms_configs2_http.success_json_path,` +
+		` projections.sms_configs2_http.success_json_value,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.sms_configs2` +
 		` LEFT JOIN projections.sms_configs2_twilio ON projections.sms_configs2.id = projections.sms_configs2_twilio.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs2_http ON projections.sms_configs2.id = projections.sms_configs2_http.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	smsConfigCols = []string{
@@ -61,6 +86,17 @@ var (
 		"sid",
 		"token",
 		"sender-number",
+		// http config
+		"sms_id",
+		"endpoint",
+		"method",
+		"headers",
+		"body_template",
+		"content_type",
+		"sender_id",
+		"success_status_codes",
+		"success_json_path",
+		"success_json_value",
 	}
 	smsConfigsCols = append(smsConfigCols, "count")
 )
@@ -109,6 +145,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid",
 							&crypto.CryptoValue{},
 							"sender-number",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -156,6 +203,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid",
 							&crypto.CryptoValue{},
 							"sender-number",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"sms-id2",
@@ -166,10 +224,21 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							domain.SMSConfigStateInactive,
 							uint64(20211109),
 							// twilio config
+							nil,
+							nil,
+							nil,
+							nil,
+							// http config
 							"sms-id2",
-							"sid2",
+							"https://example.com/sms",
+							"POST",
 							&crypto.CryptoValue{},
-							"sender-number2",
+							"{{.Text}}",
+							"text/plain",
+							"sender",
+							database.NumberArray[int]{200},
+							"status",
+							"queued",
 						},
 					},
 				),
@@ -201,10 +270,16 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 						ResourceOwner: "ro",
 						State:         domain.SMSConfigStateInactive,
 						Sequence:      20211109,
-						TwilioConfig: &Twilio{
-							SID:          "sid2",
-							Token:        &crypto.CryptoValue{},
-							SenderNumber: "sender-number2",
+						HTTPConfig: &HTTP{
+							Endpoint:           "https://example.com/sms",
+							Method:             "POST",
+							Headers:            &crypto.CryptoValue{},
+							BodyTemplate:       "{{.Text}}",
+							ContentType:        "text/plain",
+							SenderID:           "sender",
+							SuccessStatusCodes: []int{200},
+							SuccessJSONPath:    "status",
+							SuccessJSONValue:   "queued",
 						},
 					},
 				},
@@ -284,6 +359,17 @@ func Test_SMSConfigPrepare(t *testing.T) {
 						"sid",
 						&crypto.CryptoValue{},
 						"sender-number",
+						// http config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
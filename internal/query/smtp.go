@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -22,6 +23,14 @@ type SMTPConfigsSearchQueries struct {
 	Queries []SearchQuery
 }
 
+func (q *SMTPConfigsSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
 type SMTPConfigs struct {
 	SearchResponse
 	Configs []*SMTPConfig
@@ -92,6 +101,53 @@ var (
 		name:  projection.SMTPConfigColumnDescription,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnPriority = Column{
+		name:  projection.SMTPConfigColumnPriority,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnDKIMSelector = Column{
+		name:  projection.SMTPConfigColumnDKIMSelector,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnDKIMDomain = Column{
+		name:  projection.SMTPConfigColumnDKIMDomain,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnDKIMPrivateKey = Column{
+		name:  projection.SMTPConfigColumnDKIMPrivateKey,
+		table: smtpConfigsTable,
+	}
+)
+
+var (
+	smtpHTTPConfigsTable = table{
+		name:          projection.SMTPConfigHTTPTable,
+		instanceIDCol: projection.SMTPConfigHTTPColumnInstanceID,
+	}
+	SMTPHTTPConfigColumnSMTPID = Column{
+		name:  projection.SMTPConfigHTTPColumnSMTPID,
+		table: smtpHTTPConfigsTable,
+	}
+	SMTPHTTPConfigColumnEndpoint = Column{
+		name:  projection.SMTPConfigHTTPColumnEndpoint,
+		table: smtpHTTPConfigsTable,
+	}
+	SMTPHTTPConfigColumnHeaders = Column{
+		name:  projection.SMTPConfigHTTPColumnHeaders,
+		table: smtpHTTPConfigsTable,
+	}
+	SMTPHTTPConfigColumnBodyTemplate = Column{
+		name:  projection.SMTPConfigHTTPColumnBodyTemplate,
+		table: smtpHTTPConfigsTable,
+	}
+	SMTPHTTPConfigColumnContentType = Column{
+		name:  projection.SMTPConfigHTTPColumnContentType,
+		table: smtpHTTPConfigsTable,
+	}
+	SMTPHTTPConfigColumnSuccessStatusCodes = Column{
+		name:  projection.SMTPConfigHTTPColumnSuccessStatusCodes,
+		table: smtpHTTPConfigsTable,
+	}
 )
 
 type SMTPConfig struct {
@@ -109,8 +165,29 @@ type SMTPConfig struct {
 	ID             string
 	State          domain.SMTPConfigState
 	Description    string
+	Priority       uint32
+
+	// HTTPConfig is set if the emails are sent through an HTTP API instead of SMTP
+	HTTPConfig *SMTPHTTP
+	// DKIM is set if the emails are signed
+	DKIM *SMTPDKIM
+}
+
+type SMTPDKIM struct {
+	Selector   string
+	Domain     string
+	PrivateKey *crypto.CryptoValue
 }
 
+type SMTPHTTP struct {
+	Endpoint           string
+	Headers            *crypto.CryptoValue
+	BodyTemplate       string
+	ContentType        string
+	SuccessStatusCodes []int
+}
+
+// SMTPConfigActive returns the active config with the highest priority (lowest value)
 func (q *Queries) SMTPConfigActive(ctx context.Context, resourceOwner string) (config *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -118,9 +195,11 @@ func (q *Queries) SMTPConfigActive(ctx context.Context, resourceOwner string) (c
 	stmt, scan := prepareSMTPConfigQuery(ctx, q.client)
 	query, args, err := stmt.Where(sq.Eq{
 		SMTPConfigColumnResourceOwner.identifier(): resourceOwner,
-		SMTPConfigColumnInstanceID.identifier():    resourceOwner,
+		SMTPConfigColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
 		SMTPConfigColumnState.identifier():         domain.SMTPConfigStateActive,
-	}).ToSql()
+	}).OrderBy(SMTPConfigColumnPriority.identifier(), SMTPConfigColumnID.identifier()).
+		Limit(1).
+		ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-3m9sl", "Errors.Query.SQLStatement")
 	}
@@ -132,6 +211,40 @@ func (q *Queries) SMTPConfigActive(ctx context.Context, resourceOwner string) (c
 	return config, err
 }
 
+// SMTPConfigsActive returns all active configs ordered by their priority,
+// in which they are tried to send an email
+func (q *Queries) SMTPConfigsActive(ctx context.Context, resourceOwner string) (configs []*SMTPConfig, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareSMTPConfigsQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		SMTPConfigColumnResourceOwner.identifier(): resourceOwner,
+		SMTPConfigColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnState.identifier():         domain.SMTPConfigStateActive,
+	}).OrderBy(SMTPConfigColumnPriority.identifier(), SMTPConfigColumnID.identifier()).
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Wd4kq", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		result, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		configs = result.Configs
+		return nil
+	}, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Rz7bn", "Errors.SMTPConfig.NotFound")
+	}
+	return configs, nil
+}
+
 func (q *Queries) SMTPConfigByID(ctx context.Context, instanceID, resourceOwner, id string) (config *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -170,11 +283,25 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMTPConfigColumnSMTPPassword.identifier(),
 			SMTPConfigColumnID.identifier(),
 			SMTPConfigColumnState.identifier(),
-			SMTPConfigColumnDescription.identifier()).
-			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			SMTPConfigColumnDescription.identifier(),
+			SMTPConfigColumnPriority.identifier(),
+			SMTPConfigColumnDKIMSelector.identifier(),
+			SMTPConfigColumnDKIMDomain.identifier(),
+			SMTPConfigColumnDKIMPrivateKey.identifier(),
+
+			SMTPHTTPConfigColumnSMTPID.identifier(),
+			SMTPHTTPConfigColumnEndpoint.identifier(),
+			SMTPHTTPConfigColumnHeaders.identifier(),
+			SMTPHTTPConfigColumnBodyTemplate.identifier(),
+			SMTPHTTPConfigColumnContentType.identifier(),
+			SMTPHTTPConfigColumnSuccessStatusCodes.identifier(),
+		).From(smtpConfigsTable.identifier()).
+			LeftJoin(join(SMTPHTTPConfigColumnSMTPID, SMTPConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SMTPConfig, error) {
 			config := new(SMTPConfig)
+			httpConfig := sqlSMTPHTTPConfig{}
+			dkim := sqlSMTPDKIM{}
 			err := row.Scan(
 				&config.CreationDate,
 				&config.ChangeDate,
@@ -190,6 +317,17 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				&config.ID,
 				&config.State,
 				&config.Description,
+				&config.Priority,
+				&dkim.selector,
+				&dkim.domain,
+				&dkim.privateKey,
+
+				&httpConfig.smtpID,
+				&httpConfig.endpoint,
+				&httpConfig.headers,
+				&httpConfig.bodyTemplate,
+				&httpConfig.contentType,
+				&httpConfig.successStatusCodes,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -198,6 +336,8 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				return nil, zerrors.ThrowInternal(err, "QUERY-9k87F", "Errors.Internal")
 			}
 			config.Password = password
+			httpConfig.set(config)
+			dkim.set(config)
 			return config, nil
 		}
 }
@@ -218,13 +358,27 @@ func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.Select
 			SMTPConfigColumnID.identifier(),
 			SMTPConfigColumnState.identifier(),
 			SMTPConfigColumnDescription.identifier(),
-			countColumn.identifier()).
-			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			SMTPConfigColumnPriority.identifier(),
+			SMTPConfigColumnDKIMSelector.identifier(),
+			SMTPConfigColumnDKIMDomain.identifier(),
+			SMTPConfigColumnDKIMPrivateKey.identifier(),
+
+			SMTPHTTPConfigColumnSMTPID.identifier(),
+			SMTPHTTPConfigColumnEndpoint.identifier(),
+			SMTPHTTPConfigColumnHeaders.identifier(),
+			SMTPHTTPConfigColumnBodyTemplate.identifier(),
+			SMTPHTTPConfigColumnContentType.identifier(),
+			SMTPHTTPConfigColumnSuccessStatusCodes.identifier(),
+			countColumn.identifier(),
+		).From(smtpConfigsTable.identifier()).
+			LeftJoin(join(SMTPHTTPConfigColumnSMTPID, SMTPConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*SMTPConfigs, error) {
 			configs := &SMTPConfigs{Configs: []*SMTPConfig{}}
 			for rows.Next() {
 				config := new(SMTPConfig)
+				httpConfig := sqlSMTPHTTPConfig{}
+				dkim := sqlSMTPDKIM{}
 				err := rows.Scan(
 					&config.CreationDate,
 					&config.ChangeDate,
@@ -240,6 +394,17 @@ func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.Select
 					&config.ID,
 					&config.State,
 					&config.Description,
+					&config.Priority,
+					&dkim.selector,
+					&dkim.domain,
+					&dkim.privateKey,
+
+					&httpConfig.smtpID,
+					&httpConfig.endpoint,
+					&httpConfig.headers,
+					&httpConfig.bodyTemplate,
+					&httpConfig.contentType,
+					&httpConfig.successStatusCodes,
 					&configs.Count,
 				)
 				if err != nil {
@@ -248,12 +413,53 @@ func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.Select
 					}
 					return nil, zerrors.ThrowInternal(err, "QUERY-9k87F", "Errors.Internal")
 				}
+				httpConfig.set(config)
+				dkim.set(config)
 				configs.Configs = append(configs.Configs, config)
 			}
 			return configs, nil
 		}
 }
 
+type sqlSMTPHTTPConfig struct {
+	smtpID             sql.NullString
+	endpoint           sql.NullString
+	headers            *crypto.CryptoValue
+	bodyTemplate       sql.NullString
+	contentType        sql.NullString
+	successStatusCodes database.NumberArray[int]
+}
+
+func (c sqlSMTPHTTPConfig) set(smtpConfig *SMTPConfig) {
+	if !c.smtpID.Valid {
+		return
+	}
+	smtpConfig.HTTPConfig = &SMTPHTTP{
+		Endpoint:           c.endpoint.String,
+		Headers:            c.headers,
+		BodyTemplate:       c.bodyTemplate.String,
+		ContentType:        c.contentType.String,
+		SuccessStatusCodes: c.successStatusCodes,
+	}
+}
+
+type sqlSMTPDKIM struct {
+	selector   string
+	domain     string
+	privateKey *crypto.CryptoValue
+}
+
+func (d sqlSMTPDKIM) set(smtpConfig *SMTPConfig) {
+	if d.privateKey == nil {
+		return
+	}
+	smtpConfig.DKIM = &SMTPDKIM{
+		Selector:   d.selector,
+		Domain:     d.domain,
+		PrivateKey: d.privateKey,
+	}
+}
+
 func (q *Queries) SearchSMTPConfigs(ctx context.Context, queries *SMTPConfigsSearchQueries) (configs *SMTPConfigs, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -274,6 +480,12 @@ func (q *Queries) SearchSMTPConfigs(ctx context.Context, queries *SMTPConfigsSea
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-tOpKN", "Errors.Internal")
 	}
-	configs.State, err = q.latestState(ctx, smsConfigsTable)
+	configs.State, err = q.latestState(ctx, smtpConfigsTable)
 	return configs, err
 }
+
+// NewSMTPConfigResourceOwnerSearchQuery is used by the admin and management API
+// to separate the SMTP configs of the instance from those of its organizations
+func NewSMTPConfigResourceOwnerSearchQuery(resourceOwner string) (SearchQuery, error) {
+	return NewTextQuery(SMTPConfigColumnResourceOwner, resourceOwner, TextEquals)
+}
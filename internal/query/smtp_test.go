@@ -9,26 +9,38 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs2.creation_date,` +
-		` projections.smtp_configs2.change_date,` +
-		` projections.smtp_configs2.resource_owner,` +
-		` projections.smtp_configs2.sequence,` +
-		` projections.smtp_configs2.tls,` +
-		` projections.smtp_configs2.sender_address,` +
-		` projections.smtp_configs2.sender_name,` +
-		` projections.smtp_configs2.reply_to_address,` +
-		` projections.smtp_configs2.host,` +
-		` projections.smtp_configs2.username,` +
-		` projections.smtp_configs2.password,` +
-		` projections.smtp_configs2.id,` +
-		` projections.smtp_configs2.state,` +
-		` projections.smtp_configs2.description` +
-		` FROM projections.smtp_configs2` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs4.creation_date,` +
+		` projections.smtp_configs4.change_date,` +
+		` projections.smtp_configs4.resource_owner,` +
+		` projections.smtp_configs4.sequence,` +
+		` projections.smtp_configs4.tls,` +
+		` projections.smtp_configs4.sender_address,` +
+		` projections.smtp_configs4.sender_name,` +
+		` projections.smtp_configs4.reply_to_address,` +
+		` projections.smtp_configs4.host,` +
+		` projections.smtp_configs4.username,` +
+		` projections.smtp_configs4.password,` +
+		` projections.smtp_configs4.id,` +
+		` projections.smtp_configs4.state,` +
+		` projections.smtp_configs4.description,` +
+		` projections.smtp_configs4.priority,` +
+		` projections.smtp_configs4.dkim_selector,` +
+		` projections.smtp_configs4.dkim_domain,` +
+		` projections.smtp_configs4.dkim_private_key,` +
+		` projections.smtp_configs4_http.smtp_id,` +
+		` projections.smtp_configs4_http.endpoint,` +
+		` projections.smtp_configs4_http.headers,` +
+		` projections.smtp_configs4_http.body_template,` +
+		` projections.smtp_configs4_http.content_type,` +
+		` projections.smtp_configs4_http.success_status_codes` +
+		` FROM projections.smtp_configs4` +
+		` LEFT JOIN projections.smtp_configs4_http ON projections.smtp_configs4.id = projections.smtp_configs4_http.smtp_id AND projections.smtp_configs4.instance_id = projections.smtp_configs4_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
 		"creation_date",
@@ -45,6 +57,16 @@ var (
 		"id",
 		"state",
 		"description",
+		"priority",
+		"dkim_selector",
+		"dkim_domain",
+		"dkim_private_key",
+		"smtp_id",
+		"endpoint",
+		"headers",
+		"body_template",
+		"content_type",
+		"success_status_codes",
 	}
 )
 
@@ -99,6 +121,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						"2232323",
 						domain.SMTPConfigStateActive,
 						"test",
+						uint32(1),
+						"zitadel",
+						"example.com",
+						&crypto.CryptoValue{},
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -117,6 +149,12 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				ID:             "2232323",
 				State:          domain.SMTPConfigStateActive,
 				Description:    "test",
+				Priority:       1,
+				DKIM: &SMTPDKIM{
+					Selector:   "zitadel",
+					Domain:     "example.com",
+					PrivateKey: &crypto.CryptoValue{},
+				},
 			},
 		},
 		{
@@ -141,6 +179,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						"44442323",
 						domain.SMTPConfigStateInactive,
 						"test2",
+						uint32(0),
+						"",
+						"",
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -183,6 +231,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						"23234444",
 						domain.SMTPConfigStateInactive,
 						"test3",
+						uint32(0),
+						"",
+						"",
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -203,6 +261,62 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				Description:    "test3",
 			},
 		},
+		{
+			name:    "prepareSMTPConfigQuery http config found",
+			prepare: prepareSMTPConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSMTPConfigStmt),
+					prepareSMTPConfigCols,
+					[]driver.Value{
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						false,
+						"sender4",
+						"name4",
+						"reply-to4",
+						"",
+						"",
+						nil,
+						"34234444",
+						domain.SMTPConfigStateActive,
+						"test4",
+						uint32(2),
+						"",
+						"",
+						nil,
+						"34234444",
+						"https://api.example.com/mail",
+						&crypto.CryptoValue{},
+						"{{json .To}}",
+						"application/json",
+						database.NumberArray[int]{202},
+					},
+				),
+			},
+			object: &SMTPConfig{
+				CreationDate:   testNow,
+				ChangeDate:     testNow,
+				ResourceOwner:  "ro",
+				Sequence:       20211109,
+				SenderAddress:  "sender4",
+				SenderName:     "name4",
+				ReplyToAddress: "reply-to4",
+				ID:             "34234444",
+				State:          domain.SMTPConfigStateActive,
+				Description:    "test4",
+				Priority:       2,
+				HTTPConfig: &SMTPHTTP{
+					Endpoint:           "https://api.example.com/mail",
+					Headers:            &crypto.CryptoValue{},
+					BodyTemplate:       "{{json .To}}",
+					ContentType:        "application/json",
+					SuccessStatusCodes: []int{202},
+				},
+			},
+		},
 		{
 			name:    "prepareSMTPConfigQuery sql err",
 			prepare: prepareSMTPConfigQuery,
@@ -63,6 +63,34 @@ var (
 		name:  projection.TargetInterruptOnErrorCol,
 		table: targetTable,
 	}
+	TargetColumnMaxAttempts = Column{
+		name:  projection.TargetMaxAttemptsCol,
+		table: targetTable,
+	}
+	TargetColumnJWTAuthentication = Column{
+		name:  projection.TargetJWTAuthenticationCol,
+		table: targetTable,
+	}
+	TargetColumnClientCertificate = Column{
+		name:  projection.TargetClientCertificateCol,
+		table: targetTable,
+	}
+	TargetColumnRootCAs = Column{
+		name:  projection.TargetRootCAsCol,
+		table: targetTable,
+	}
+	TargetColumnMaxConcurrency = Column{
+		name:  projection.TargetMaxConcurrencyCol,
+		table: targetTable,
+	}
+	TargetColumnFailureThreshold = Column{
+		name:  projection.TargetFailureThresholdCol,
+		table: targetTable,
+	}
+	TargetColumnOpenDuration = Column{
+		name:  projection.TargetOpenDurationCol,
+		table: targetTable,
+	}
 )
 
 type Targets struct {
@@ -83,6 +111,17 @@ type Target struct {
 	Endpoint         string
 	Timeout          time.Duration
 	InterruptOnError bool
+	MaxAttempts      uint8
+
+	// JWTAuthentication, ClientCertificate and RootCAs are the public parts of the authentication,
+	// the custom headers and the client key are never returned.
+	JWTAuthentication bool
+	ClientCertificate []byte
+	RootCAs           []byte
+
+	MaxConcurrency   uint16
+	FailureThreshold uint16
+	OpenDuration     time.Duration
 }
 
 type TargetSearchQueries struct {
@@ -134,6 +173,13 @@ func prepareTargetsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			TargetColumnTimeout.identifier(),
 			TargetColumnURL.identifier(),
 			TargetColumnInterruptOnError.identifier(),
+			TargetColumnMaxAttempts.identifier(),
+			TargetColumnJWTAuthentication.identifier(),
+			TargetColumnClientCertificate.identifier(),
+			TargetColumnRootCAs.identifier(),
+			TargetColumnMaxConcurrency.identifier(),
+			TargetColumnFailureThreshold.identifier(),
+			TargetColumnOpenDuration.identifier(),
 			countColumn.identifier(),
 		).From(targetTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
@@ -152,6 +198,13 @@ func prepareTargetsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 					&target.Timeout,
 					&target.Endpoint,
 					&target.InterruptOnError,
+					&target.MaxAttempts,
+					&target.JWTAuthentication,
+					&target.ClientCertificate,
+					&target.RootCAs,
+					&target.MaxConcurrency,
+					&target.FailureThreshold,
+					&target.OpenDuration,
 					&count,
 				)
 				if err != nil {
@@ -184,6 +237,13 @@ func prepareTargetQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			TargetColumnTimeout.identifier(),
 			TargetColumnURL.identifier(),
 			TargetColumnInterruptOnError.identifier(),
+			TargetColumnMaxAttempts.identifier(),
+			TargetColumnJWTAuthentication.identifier(),
+			TargetColumnClientCertificate.identifier(),
+			TargetColumnRootCAs.identifier(),
+			TargetColumnMaxConcurrency.identifier(),
+			TargetColumnFailureThreshold.identifier(),
+			TargetColumnOpenDuration.identifier(),
 		).From(targetTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Target, error) {
@@ -198,6 +258,13 @@ func prepareTargetQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&target.Timeout,
 				&target.Endpoint,
 				&target.InterruptOnError,
+				&target.MaxAttempts,
+				&target.JWTAuthentication,
+				&target.ClientCertificate,
+				&target.RootCAs,
+				&target.MaxConcurrency,
+				&target.FailureThreshold,
+				&target.OpenDuration,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
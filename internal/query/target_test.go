@@ -14,17 +14,24 @@ import (
 )
 
 var (
-	prepareTargetsStmt = `SELECT projections.targets1.id,` +
-		` projections.targets1.change_date,` +
-		` projections.targets1.resource_owner,` +
-		` projections.targets1.sequence,` +
-		` projections.targets1.name,` +
-		` projections.targets1.target_type,` +
-		` projections.targets1.timeout,` +
-		` projections.targets1.endpoint,` +
-		` projections.targets1.interrupt_on_error,` +
+	prepareTargetsStmt = `SELECT projections.targets2.id,` +
+		` projections.targets2.change_date,` +
+		` projections.targets2.resource_owner,` +
+		` projections.targets2.sequence,` +
+		` projections.targets2.name,` +
+		` projections.targets2.target_type,` +
+		` projections.targets2.timeout,` +
+		` projections.targets2.endpoint,` +
+		` projections.targets2.interrupt_on_error,` +
+		` projections.targets2.max_attempts,` +
+		` projections.targets2.jwt_authentication,` +
+		` projections.targets2.client_certificate,` +
+		` projections.targets2.root_cas,` +
+		` projections.targets2.max_concurrency,` +
+		` projections.targets2.failure_threshold,` +
+		` projections.targets2.open_duration,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.targets1`
+		` FROM projections.targets2`
 	prepareTargetsCols = []string{
 		"id",
 		"change_date",
@@ -35,19 +42,33 @@ var (
 		"timeout",
 		"endpoint",
 		"interrupt_on_error",
+		"max_attempts",
+		"jwt_authentication",
+		"client_certificate",
+		"root_cas",
+		"max_concurrency",
+		"failure_threshold",
+		"open_duration",
 		"count",
 	}
 
-	prepareTargetStmt = `SELECT projections.targets1.id,` +
-		` projections.targets1.change_date,` +
-		` projections.targets1.resource_owner,` +
-		` projections.targets1.sequence,` +
-		` projections.targets1.name,` +
-		` projections.targets1.target_type,` +
-		` projections.targets1.timeout,` +
-		` projections.targets1.endpoint,` +
-		` projections.targets1.interrupt_on_error` +
-		` FROM projections.targets1`
+	prepareTargetStmt = `SELECT projections.targets2.id,` +
+		` projections.targets2.change_date,` +
+		` projections.targets2.resource_owner,` +
+		` projections.targets2.sequence,` +
+		` projections.targets2.name,` +
+		` projections.targets2.target_type,` +
+		` projections.targets2.timeout,` +
+		` projections.targets2.endpoint,` +
+		` projections.targets2.interrupt_on_error,` +
+		` projections.targets2.max_attempts,` +
+		` projections.targets2.jwt_authentication,` +
+		` projections.targets2.client_certificate,` +
+		` projections.targets2.root_cas,` +
+		` projections.targets2.max_concurrency,` +
+		` projections.targets2.failure_threshold,` +
+		` projections.targets2.open_duration` +
+		` FROM projections.targets2`
 	prepareTargetCols = []string{
 		"id",
 		"change_date",
@@ -58,6 +79,13 @@ var (
 		"timeout",
 		"endpoint",
 		"interrupt_on_error",
+		"max_attempts",
+		"jwt_authentication",
+		"client_certificate",
+		"root_cas",
+		"max_concurrency",
+		"failure_threshold",
+		"open_duration",
 	}
 )
 
@@ -102,6 +130,13 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							true,
+							uint8(3),
+							true,
+							[]byte("cert"),
+							[]byte("ca"),
+							uint16(10),
+							uint16(5),
+							time.Minute,
 						},
 					},
 				),
@@ -123,6 +158,15 @@ func Test_TargetPrepares(t *testing.T) {
 						Timeout:          1 * time.Second,
 						Endpoint:         "https://example.com",
 						InterruptOnError: true,
+						MaxAttempts:      3,
+
+						JWTAuthentication: true,
+						ClientCertificate: []byte("cert"),
+						RootCAs:           []byte("ca"),
+
+						MaxConcurrency:   10,
+						FailureThreshold: 5,
+						OpenDuration:     time.Minute,
 					},
 				},
 			},
@@ -145,6 +189,13 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							true,
+							uint8(3),
+							true,
+							[]byte("cert"),
+							[]byte("ca"),
+							uint16(10),
+							uint16(5),
+							time.Minute,
 						},
 						{
 							"id-2",
@@ -156,6 +207,13 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							false,
+							uint8(0),
+							false,
+							nil,
+							nil,
+							uint16(0),
+							uint16(0),
+							time.Duration(0),
 						},
 						{
 							"id-3",
@@ -167,6 +225,13 @@ func Test_TargetPrepares(t *testing.T) {
 							1 * time.Second,
 							"https://example.com",
 							false,
+							uint8(0),
+							false,
+							nil,
+							nil,
+							uint16(0),
+							uint16(0),
+							time.Duration(0),
 						},
 					},
 				),
@@ -188,6 +253,15 @@ func Test_TargetPrepares(t *testing.T) {
 						Timeout:          1 * time.Second,
 						Endpoint:         "https://example.com",
 						InterruptOnError: true,
+						MaxAttempts:      3,
+
+						JWTAuthentication: true,
+						ClientCertificate: []byte("cert"),
+						RootCAs:           []byte("ca"),
+
+						MaxConcurrency:   10,
+						FailureThreshold: 5,
+						OpenDuration:     time.Minute,
 					},
 					{
 						ID: "id-2",
@@ -270,6 +344,13 @@ func Test_TargetPrepares(t *testing.T) {
 						1 * time.Second,
 						"https://example.com",
 						true,
+						uint8(3),
+						true,
+						[]byte("cert"),
+						[]byte("ca"),
+						uint16(10),
+						uint16(5),
+						time.Minute,
 					},
 				),
 			},
@@ -285,6 +366,15 @@ func Test_TargetPrepares(t *testing.T) {
 				Timeout:          1 * time.Second,
 				Endpoint:         "https://example.com",
 				InterruptOnError: true,
+				MaxAttempts:      3,
+
+				JWTAuthentication: true,
+				ClientCertificate: []byte("cert"),
+				RootCAs:           []byte("ca"),
+
+				MaxConcurrency:   10,
+				FailureThreshold: 5,
+				OpenDuration:     time.Minute,
 			},
 		},
 		{
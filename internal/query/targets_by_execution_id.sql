@@ -1,24 +1,28 @@
 WITH RECURSIVE
     matched AS (SELECT *
-                 FROM projections.executions1
+                 FROM projections.executions3
                  WHERE instance_id = $1
                    AND id = ANY($2)
                  ORDER BY id DESC
                  LIMIT 1),
-    matched_targets_and_includes AS (SELECT pos.*
+    matched_targets_and_includes AS (SELECT pos.*, ARRAY_REMOVE(ARRAY [m.condition], '') AS conditions, m.mutable_fields
                                      FROM matched m
                                               JOIN
-                                          projections.executions1_targets pos
+                                          projections.executions3_targets pos
                                           ON m.id = pos.execution_id
                                               AND m.instance_id = pos.instance_id
                                      ORDER BY execution_id,
                                               position),
-    dissolved_execution_targets(execution_id, instance_id, position, "include", "target_id")
+    -- the conditions of the matched execution and all includes leading to a target are collected,
+    -- the mutable fields are defined by the matched execution
+    dissolved_execution_targets(execution_id, instance_id, position, "include", "target_id", conditions, mutable_fields)
         AS (SELECT execution_id
                  , instance_id
                  , ARRAY [position]
                  , "include"
                  , "target_id"
+                 , conditions
+                 , mutable_fields
             FROM matched_targets_and_includes
             UNION ALL
             SELECT e.execution_id
@@ -26,14 +30,19 @@ WITH RECURSIVE
                  , e.position || p.position
                  , p."include"
                  , p."target_id"
+                 , ARRAY_REMOVE(e.conditions || i.condition, '')
+                 , e.mutable_fields
             FROM dissolved_execution_targets e
-                     JOIN projections.executions1_targets p
-                          ON e.instance_id = p.instance_id
+                     JOIN projections.executions3 i
+                          ON e.instance_id = i.instance_id
                               AND e.include IS NOT NULL
-                              AND e.include = p.execution_id)
-select e.execution_id, e.instance_id, e.target_id, t.target_type, t.endpoint, t.timeout, t.interrupt_on_error
+                              AND e.include = i.id
+                     JOIN projections.executions3_targets p
+                          ON i.instance_id = p.instance_id
+                              AND i.id = p.execution_id)
+select e.execution_id, e.instance_id, e.target_id, t.target_type, t.endpoint, t.timeout, t.interrupt_on_error, t.max_attempts, t.signing_key, t.previous_signing_key, t.previous_signing_key_expiration, t.headers, t.jwt_authentication, t.client_certificate, t.client_key, t.root_cas, t.max_concurrency, t.failure_threshold, t.open_duration, e.conditions, e.mutable_fields
 FROM dissolved_execution_targets e
-         JOIN projections.targets1 t
+         JOIN projections.targets2 t
               ON e.instance_id = t.instance_id
                   AND e.target_id = t.id
 WHERE "include" = ''
@@ -58,6 +58,18 @@ type UserGrants struct {
 	UserGrants []*UserGrant
 }
 
+// Values returns copies of the user grants, nil if the user grants are nil
+func (g *UserGrants) Values() []UserGrant {
+	if g == nil {
+		return nil
+	}
+	grants := make([]UserGrant, len(g.UserGrants))
+	for i, grant := range g.UserGrants {
+		grants[i] = *grant
+	}
+	return grants
+}
+
 type UserGrantsQueries struct {
 	SearchRequest
 	Queries []SearchQuery
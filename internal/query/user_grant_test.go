@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -1012,3 +1014,29 @@ func Test_UserGrantPrepares(t *testing.T) {
 		})
 	}
 }
+
+func TestUserGrants_Values(t *testing.T) {
+	tests := []struct {
+		name   string
+		grants *UserGrants
+		want   []UserGrant
+	}{
+		{
+			name:   "nil",
+			grants: nil,
+			want:   nil,
+		},
+		{
+			name: "grants",
+			grants: &UserGrants{
+				UserGrants: []*UserGrant{{ID: "grant1"}, {ID: "grant2"}},
+			},
+			want: []UserGrant{{ID: "grant1"}, {ID: "grant2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.grants.Values())
+		})
+	}
+}
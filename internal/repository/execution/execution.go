@@ -37,6 +37,10 @@ type SetEventV2 struct {
 	*eventstore.BaseEvent `json:"-"`
 
 	Targets []*Target `json:"targets"`
+	// Condition is an optional CEL expression, the targets are only called if it evaluates to true
+	Condition string `json:"condition,omitempty"`
+	// MutableFields are the paths of the fields the targets are allowed to change, all fields if empty
+	MutableFields []string `json:"mutableFields,omitempty"`
 }
 
 func (e *SetEventV2) SetBaseEvent(b *eventstore.BaseEvent) {
@@ -60,12 +64,16 @@ func NewSetEventV2(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	targets []*Target,
+	condition string,
+	mutableFields []string,
 ) *SetEventV2 {
 	return &SetEventV2{
 		BaseEvent: eventstore.NewBaseEventForPush(
 			ctx, aggregate, SetEventV2Type,
 		),
-		Targets: targets,
+		Targets:       targets,
+		Condition:     condition,
+		MutableFields: mutableFields,
 	}
 }
 
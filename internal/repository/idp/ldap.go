@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -347,3 +348,93 @@ func LDAPIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error)
 
 	return e, nil
 }
+
+type LDAPIDPSyncSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID       string        `json:"id"`
+	Enabled  bool          `json:"enabled,omitempty"`
+	DryRun   bool          `json:"dryRun,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+	// MaxDeactivationPercent is the maximum share of the linked users deactivated in a single synchronization
+	MaxDeactivationPercent uint32 `json:"maxDeactivationPercent,omitempty"`
+}
+
+func NewLDAPIDPSyncSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	enabled bool,
+	dryRun bool,
+	interval time.Duration,
+	maxDeactivationPercent uint32,
+) *LDAPIDPSyncSetEvent {
+	return &LDAPIDPSyncSetEvent{
+		BaseEvent:              *base,
+		ID:                     id,
+		Enabled:                enabled,
+		DryRun:                 dryRun,
+		Interval:               interval,
+		MaxDeactivationPercent: maxDeactivationPercent,
+	}
+}
+
+func (e *LDAPIDPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPIDPSyncSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPIDPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPIDPSyncSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-k3Rvb", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type LDAPIDPSyncedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID     string                 `json:"id"`
+	Report *domain.LDAPSyncReport `json:"report,omitempty"`
+}
+
+func NewLDAPIDPSyncedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	report *domain.LDAPSyncReport,
+) *LDAPIDPSyncedEvent {
+	return &LDAPIDPSyncedEvent{
+		BaseEvent: *base,
+		ID:        id,
+		Report:    report,
+	}
+}
+
+func (e *LDAPIDPSyncedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPIDPSyncedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPIDPSyncedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPIDPSyncedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Wq7nz", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -18,9 +18,16 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigDeactivatedEventType, SMTPConfigDeactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigPasswordChangedEventType, SMTPConfigPasswordChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigRemovedEventType, SMTPConfigRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigPriorityChangedEventType, SMTPConfigPriorityChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPAddedEventType, SMTPConfigHTTPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPChangedEventType, SMTPConfigHTTPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigDKIMSetEventType, SMTPConfigDKIMSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigDKIMRemovedEventType, SMTPConfigDKIMRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioAddedEventType, SMSConfigTwilioAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioChangedEventType, SMSConfigTwilioChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioTokenChangedEventType, SMSConfigTwilioTokenChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigHTTPAddedEventType, SMSConfigHTTPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigHTTPChangedEventType, SMSConfigHTTPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigActivatedEventType, SMSConfigActivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigDeactivatedEventType, SMSConfigDeactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigRemovedEventType, SMSConfigRemovedEventMapper)
@@ -94,6 +101,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, GoogleIDPChangedEventType, GoogleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LDAPIDPAddedEventType, LDAPIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LDAPIDPChangedEventType, LDAPIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPIDPSyncSetEventType, LDAPIDPSyncSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPIDPSyncedEventType, LDAPIDPSyncedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPAddedEventType, AppleIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPChangedEventType, AppleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
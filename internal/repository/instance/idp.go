@@ -33,6 +33,8 @@ const (
 	GoogleIDPChangedEventType           eventstore.EventType = "instance.idp.google.changed"
 	LDAPIDPAddedEventType               eventstore.EventType = "instance.idp.ldap.v2.added"
 	LDAPIDPChangedEventType             eventstore.EventType = "instance.idp.ldap.v2.changed"
+	LDAPIDPSyncSetEventType             eventstore.EventType = "instance.idp.ldap.v2.sync.set"
+	LDAPIDPSyncedEventType              eventstore.EventType = "instance.idp.ldap.v2.synced"
 	AppleIDPAddedEventType              eventstore.EventType = "instance.idp.apple.added"
 	AppleIDPChangedEventType            eventstore.EventType = "instance.idp.apple.changed"
 	SAMLIDPAddedEventType               eventstore.EventType = "instance.idp.saml.added"
@@ -923,6 +925,76 @@ func LDAPIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error)
 	return &LDAPIDPChangedEvent{LDAPIDPChangedEvent: *e.(*idp.LDAPIDPChangedEvent)}, nil
 }
 
+type LDAPIDPSyncSetEvent struct {
+	idp.LDAPIDPSyncSetEvent
+}
+
+func NewLDAPIDPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	enabled bool,
+	dryRun bool,
+	interval time.Duration,
+	maxDeactivationPercent uint32,
+) *LDAPIDPSyncSetEvent {
+	return &LDAPIDPSyncSetEvent{
+		LDAPIDPSyncSetEvent: *idp.NewLDAPIDPSyncSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LDAPIDPSyncSetEventType,
+			),
+			id,
+			enabled,
+			dryRun,
+			interval,
+			maxDeactivationPercent,
+		),
+	}
+}
+
+func LDAPIDPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPIDPSyncSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPIDPSyncSetEvent{LDAPIDPSyncSetEvent: *e.(*idp.LDAPIDPSyncSetEvent)}, nil
+}
+
+type LDAPIDPSyncedEvent struct {
+	idp.LDAPIDPSyncedEvent
+}
+
+func NewLDAPIDPSyncedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	report *domain.LDAPSyncReport,
+) *LDAPIDPSyncedEvent {
+	return &LDAPIDPSyncedEvent{
+		LDAPIDPSyncedEvent: *idp.NewLDAPIDPSyncedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LDAPIDPSyncedEventType,
+			),
+			id,
+			report,
+		),
+	}
+}
+
+func LDAPIDPSyncedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPIDPSyncedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPIDPSyncedEvent{LDAPIDPSyncedEvent: *e.(*idp.LDAPIDPSyncedEvent)}, nil
+}
+
 type AppleIDPAddedEvent struct {
 	idp.AppleIDPAddedEvent
 }
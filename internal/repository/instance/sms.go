@@ -11,9 +11,12 @@ import (
 const (
 	smsConfigPrefix                      = "sms.config"
 	smsConfigTwilioPrefix                = "twilio."
+	smsConfigHTTPPrefix                  = "http."
 	SMSConfigTwilioAddedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "added"
 	SMSConfigTwilioChangedEventType      = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "changed"
 	SMSConfigTwilioTokenChangedEventType = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "token.changed"
+	SMSConfigHTTPAddedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "added"
+	SMSConfigHTTPChangedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "changed"
 	SMSConfigActivatedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "activated"
 	SMSConfigDeactivatedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "deactivated"
 	SMSConfigRemovedEventType            = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "removed"
@@ -178,6 +181,189 @@ func SMSConfigTwilioTokenChangedEventMapper(event eventstore.Event) (eventstore.
 	return smtpConfigTokenChagned, nil
 }
 
+type SMSConfigHTTPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID       string `json:"id,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Method   string `json:"method,omitempty"`
+	// Headers are the JSON encoded headers
+	Headers            *crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate       string              `json:"bodyTemplate,omitempty"`
+	ContentType        string              `json:"contentType,omitempty"`
+	SenderID           string              `json:"senderId,omitempty"`
+	SuccessStatusCodes []int               `json:"successStatusCodes,omitempty"`
+	SuccessJSONPath    string              `json:"successJsonPath,omitempty"`
+	SuccessJSONValue   string              `json:"successJsonValue,omitempty"`
+}
+
+func NewSMSConfigHTTPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	endpoint,
+	method string,
+	headers *crypto.CryptoValue,
+	bodyTemplate,
+	contentType,
+	senderID string,
+	successStatusCodes []int,
+	successJSONPath,
+	successJSONValue string,
+) *SMSConfigHTTPAddedEvent {
+	return &SMSConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPAddedEventType,
+		),
+		ID:                 id,
+		Endpoint:           endpoint,
+		Method:             method,
+		Headers:            headers,
+		BodyTemplate:       bodyTemplate,
+		ContentType:        contentType,
+		SenderID:           senderID,
+		SuccessStatusCodes: successStatusCodes,
+		SuccessJSONPath:    successJSONPath,
+		SuccessJSONValue:   successJSONValue,
+	}
+}
+
+func (e *SMSConfigHTTPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smsConfigAdded := &SMSConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smsConfigAdded)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Hs7kq", "unable to unmarshal sms config http added")
+	}
+
+	return smsConfigAdded, nil
+}
+
+type SMSConfigHTTPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                 string              `json:"id,omitempty"`
+	Endpoint           *string             `json:"endpoint,omitempty"`
+	Method             *string             `json:"method,omitempty"`
+	Headers            *crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate       *string             `json:"bodyTemplate,omitempty"`
+	ContentType        *string             `json:"contentType,omitempty"`
+	SenderID           *string             `json:"senderId,omitempty"`
+	SuccessStatusCodes *[]int              `json:"successStatusCodes,omitempty"`
+	SuccessJSONPath    *string             `json:"successJsonPath,omitempty"`
+	SuccessJSONValue   *string             `json:"successJsonValue,omitempty"`
+}
+
+func NewSMSConfigHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMSConfigHTTPChanges,
+) (*SMSConfigHTTPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IAM-Wd4mr", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMSConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMSConfigHTTPChanges func(event *SMSConfigHTTPChangedEvent)
+
+func ChangeSMSConfigHTTPEndpoint(endpoint string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeSMSConfigHTTPMethod(method string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Method = &method
+	}
+}
+
+func ChangeSMSConfigHTTPHeaders(headers *crypto.CryptoValue) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Headers = headers
+	}
+}
+
+func ChangeSMSConfigHTTPBodyTemplate(bodyTemplate string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.BodyTemplate = &bodyTemplate
+	}
+}
+
+func ChangeSMSConfigHTTPContentType(contentType string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.ContentType = &contentType
+	}
+}
+
+func ChangeSMSConfigHTTPSenderID(senderID string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SenderID = &senderID
+	}
+}
+
+func ChangeSMSConfigHTTPSuccessStatusCodes(successStatusCodes []int) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SuccessStatusCodes = &successStatusCodes
+	}
+}
+
+func ChangeSMSConfigHTTPSuccessJSONPath(successJSONPath string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SuccessJSONPath = &successJSONPath
+	}
+}
+
+func ChangeSMSConfigHTTPSuccessJSONValue(successJSONValue string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SuccessJSONValue = &successJSONValue
+	}
+}
+
+func (e *SMSConfigHTTPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smsConfigChanged := &SMSConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smsConfigChanged)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-t0Bxe", "unable to unmarshal sms config http changed")
+	}
+
+	return smsConfigChanged, nil
+}
+
 type SMSConfigActivatedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 	ID                   string `json:"id,omitempty"`
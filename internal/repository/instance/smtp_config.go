@@ -10,12 +10,19 @@ import (
 
 const (
 	smtpConfigPrefix                   = "smtp.config."
+	smtpConfigHTTPPrefix               = "http."
+	smtpConfigDKIMPrefix               = "dkim."
 	SMTPConfigAddedEventType           = instanceEventTypePrefix + smtpConfigPrefix + "added"
 	SMTPConfigChangedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "changed"
 	SMTPConfigPasswordChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "password.changed"
 	SMTPConfigRemovedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "removed"
 	SMTPConfigActivatedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "activated"
 	SMTPConfigDeactivatedEventType     = instanceEventTypePrefix + smtpConfigPrefix + "deactivated"
+	SMTPConfigPriorityChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "priority.changed"
+	SMTPConfigHTTPAddedEventType       = instanceEventTypePrefix + smtpConfigPrefix + smtpConfigHTTPPrefix + "added"
+	SMTPConfigHTTPChangedEventType     = instanceEventTypePrefix + smtpConfigPrefix + smtpConfigHTTPPrefix + "changed"
+	SMTPConfigDKIMSetEventType         = instanceEventTypePrefix + smtpConfigPrefix + smtpConfigDKIMPrefix + "set"
+	SMTPConfigDKIMRemovedEventType     = instanceEventTypePrefix + smtpConfigPrefix + smtpConfigDKIMPrefix + "removed"
 )
 
 type SMTPConfigAddedEvent struct {
@@ -237,6 +244,49 @@ func SMTPConfigPasswordChangedEventMapper(event eventstore.Event) (eventstore.Ev
 	return smtpConfigPasswordChanged, nil
 }
 
+type SMTPConfigPriorityChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+	Priority             uint32 `json:"priority,omitempty"`
+}
+
+func NewSMTPConfigPriorityChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	priority uint32,
+) *SMTPConfigPriorityChangedEvent {
+	return &SMTPConfigPriorityChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigPriorityChangedEventType,
+		),
+		ID:       id,
+		Priority: priority,
+	}
+}
+
+func (e *SMTPConfigPriorityChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigPriorityChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigPriorityChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigPriorityChanged := &SMTPConfigPriorityChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigPriorityChanged)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Zp4vf", "unable to unmarshal smtp config priority changed")
+	}
+
+	return smtpConfigPriorityChanged, nil
+}
+
 type SMTPConfigActivatedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 	ID                   string `json:"id,omitempty"`
@@ -356,3 +406,275 @@ func SMTPConfigRemovedEventMapper(event eventstore.Event) (eventstore.Event, err
 
 	return smtpConfigRemoved, nil
 }
+
+type SMTPConfigHTTPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID             string `json:"id,omitempty"`
+	Description    string `json:"description,omitempty"`
+	SenderAddress  string `json:"senderAddress,omitempty"`
+	SenderName     string `json:"senderName,omitempty"`
+	ReplyToAddress string `json:"replyToAddress,omitempty"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	// Headers are the JSON encoded headers
+	Headers            *crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate       string              `json:"bodyTemplate,omitempty"`
+	ContentType        string              `json:"contentType,omitempty"`
+	SuccessStatusCodes []int               `json:"successStatusCodes,omitempty"`
+}
+
+func NewSMTPConfigHTTPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id, description,
+	senderAddress,
+	senderName,
+	replyToAddress,
+	endpoint string,
+	headers *crypto.CryptoValue,
+	bodyTemplate,
+	contentType string,
+	successStatusCodes []int,
+) *SMTPConfigHTTPAddedEvent {
+	return &SMTPConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigHTTPAddedEventType,
+		),
+		ID:                 id,
+		Description:        description,
+		SenderAddress:      senderAddress,
+		SenderName:         senderName,
+		ReplyToAddress:     replyToAddress,
+		Endpoint:           endpoint,
+		Headers:            headers,
+		BodyTemplate:       bodyTemplate,
+		ContentType:        contentType,
+		SuccessStatusCodes: successStatusCodes,
+	}
+}
+
+func (e *SMTPConfigHTTPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigHTTPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigHTTPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigAdded := &SMTPConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigAdded)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Gk5nw", "unable to unmarshal smtp config http added")
+	}
+
+	return smtpConfigAdded, nil
+}
+
+type SMTPConfigHTTPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                 string              `json:"id,omitempty"`
+	Description        *string             `json:"description,omitempty"`
+	SenderAddress      *string             `json:"senderAddress,omitempty"`
+	SenderName         *string             `json:"senderName,omitempty"`
+	ReplyToAddress     *string             `json:"replyToAddress,omitempty"`
+	Endpoint           *string             `json:"endpoint,omitempty"`
+	Headers            *crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate       *string             `json:"bodyTemplate,omitempty"`
+	ContentType        *string             `json:"contentType,omitempty"`
+	SuccessStatusCodes *[]int              `json:"successStatusCodes,omitempty"`
+}
+
+func NewSMTPConfigHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMTPConfigHTTPChanges,
+) (*SMTPConfigHTTPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IAM-Xb2rs", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMTPConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigHTTPChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMTPConfigHTTPChanges func(event *SMTPConfigHTTPChangedEvent)
+
+func ChangeSMTPConfigHTTPDescription(description string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeSMTPConfigHTTPSenderAddress(senderAddress string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.SenderAddress = &senderAddress
+	}
+}
+
+func ChangeSMTPConfigHTTPSenderName(senderName string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.SenderName = &senderName
+	}
+}
+
+func ChangeSMTPConfigHTTPReplyToAddress(replyToAddress string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.ReplyToAddress = &replyToAddress
+	}
+}
+
+func ChangeSMTPConfigHTTPEndpoint(endpoint string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeSMTPConfigHTTPHeaders(headers *crypto.CryptoValue) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.Headers = headers
+	}
+}
+
+func ChangeSMTPConfigHTTPBodyTemplate(bodyTemplate string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.BodyTemplate = &bodyTemplate
+	}
+}
+
+func ChangeSMTPConfigHTTPContentType(contentType string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.ContentType = &contentType
+	}
+}
+
+func ChangeSMTPConfigHTTPSuccessStatusCodes(successStatusCodes []int) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.SuccessStatusCodes = &successStatusCodes
+	}
+}
+
+func (e *SMTPConfigHTTPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigHTTPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigHTTPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigChanged := &SMTPConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigChanged)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Oj7vm", "unable to unmarshal smtp config http changed")
+	}
+
+	return smtpConfigChanged, nil
+}
+
+type SMTPConfigDKIMSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID         string              `json:"id,omitempty"`
+	Selector   string              `json:"selector,omitempty"`
+	Domain     string              `json:"domain,omitempty"`
+	PrivateKey *crypto.CryptoValue `json:"privateKey,omitempty"`
+}
+
+func NewSMTPConfigDKIMSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	selector,
+	domain string,
+	privateKey *crypto.CryptoValue,
+) *SMTPConfigDKIMSetEvent {
+	return &SMTPConfigDKIMSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigDKIMSetEventType,
+		),
+		ID:         id,
+		Selector:   selector,
+		Domain:     domain,
+		PrivateKey: privateKey,
+	}
+}
+
+func (e *SMTPConfigDKIMSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigDKIMSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigDKIMSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigDKIMSet := &SMTPConfigDKIMSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigDKIMSet)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Wq7bd", "unable to unmarshal smtp config dkim set")
+	}
+
+	return smtpConfigDKIMSet, nil
+}
+
+type SMTPConfigDKIMRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ID                   string `json:"id,omitempty"`
+}
+
+func NewSMTPConfigDKIMRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *SMTPConfigDKIMRemovedEvent {
+	return &SMTPConfigDKIMRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigDKIMRemovedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *SMTPConfigDKIMRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigDKIMRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigDKIMRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigDKIMRemoved := &SMTPConfigDKIMRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigDKIMRemoved)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Gs2xn", "unable to unmarshal smtp config dkim removed")
+	}
+
+	return smtpConfigDKIMRemoved, nil
+}
@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix          eventstore.EventType = "notification."
+	DeliveredEventType                            = eventTypePrefix + "delivered"
+	DeliveryFailedEventType                       = eventTypePrefix + "delivery.failed"
+	ResendRequestedEventType                      = eventTypePrefix + "resend.requested"
+)
+
+// Delivery describes a single attempt of a provider to deliver a message
+type Delivery struct {
+	UserID      string                  `json:"userId,omitempty"`
+	Channel     domain.NotificationType `json:"channel"`
+	MessageType string                  `json:"messageType,omitempty"`
+	// Recipient is the masked email address or phone number
+	Recipient string `json:"recipient,omitempty"`
+	// Provider is the id of the provider which tried to deliver the message
+	Provider string `json:"provider,omitempty"`
+	// Response is the classified error of the provider, empty if the message was delivered
+	Response        string           `json:"response,omitempty"`
+	TriggeringEvent *TriggeringEvent `json:"triggeringEvent,omitempty"`
+}
+
+// TriggeringEvent references the event which caused the message to be sent
+type TriggeringEvent struct {
+	AggregateType eventstore.AggregateType `json:"aggregateType"`
+	AggregateID   string                   `json:"aggregateId"`
+	ResourceOwner string                   `json:"resourceOwner"`
+	EventType     eventstore.EventType     `json:"eventType"`
+	Sequence      uint64                   `json:"sequence"`
+}
+
+func NewTriggeringEvent(event eventstore.Event) *TriggeringEvent {
+	return &TriggeringEvent{
+		AggregateType: event.Aggregate().Type,
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		EventType:     event.Type(),
+		Sequence:      event.Sequence(),
+	}
+}
+
+type DeliveredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Delivery
+}
+
+func (e *DeliveredEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *DeliveredEvent) Payload() any {
+	return e
+}
+
+func (e *DeliveredEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewDeliveredEvent(ctx context.Context, aggregate *eventstore.Aggregate, delivery Delivery) *DeliveredEvent {
+	return &DeliveredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, DeliveredEventType,
+		),
+		Delivery: delivery,
+	}
+}
+
+type DeliveryFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Delivery
+}
+
+func (e *DeliveryFailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *DeliveryFailedEvent) Payload() any {
+	return e
+}
+
+func (e *DeliveryFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewDeliveryFailedEvent(ctx context.Context, aggregate *eventstore.Aggregate, delivery Delivery) *DeliveryFailedEvent {
+	return &DeliveryFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, DeliveryFailedEventType,
+		),
+		Delivery: delivery,
+	}
+}
+
+// ResendRequestedEvent requests the message of a failed delivery to be sent again,
+// the new attempts are recorded as separate deliveries
+type ResendRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TriggeringEvent *TriggeringEvent `json:"triggeringEvent"`
+}
+
+func (e *ResendRequestedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *ResendRequestedEvent) Payload() any {
+	return e
+}
+
+func (e *ResendRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewResendRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate, triggeringEvent *TriggeringEvent) *ResendRequestedEvent {
+	return &ResendRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, ResendRequestedEventType,
+		),
+		TriggeringEvent: triggeringEvent,
+	}
+}
@@ -0,0 +1,9 @@
+package notification
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func init() {
+	eventstore.RegisterFilterEventMapper(AggregateType, DeliveredEventType, eventstore.GenericEventMapper[DeliveredEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, DeliveryFailedEventType, eventstore.GenericEventMapper[DeliveryFailedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, ResendRequestedEventType, eventstore.GenericEventMapper[ResendRequestedEvent])
+}
@@ -33,6 +33,8 @@ const (
 	GoogleIDPChangedEventType           eventstore.EventType = "org.idp.google.changed"
 	LDAPIDPAddedEventType               eventstore.EventType = "org.idp.ldap.added"
 	LDAPIDPChangedEventType             eventstore.EventType = "org.idp.ldap.changed"
+	LDAPIDPSyncSetEventType             eventstore.EventType = "org.idp.ldap.sync.set"
+	LDAPIDPSyncedEventType              eventstore.EventType = "org.idp.ldap.synced"
 	AppleIDPAddedEventType              eventstore.EventType = "org.idp.apple.added"
 	AppleIDPChangedEventType            eventstore.EventType = "org.idp.apple.changed"
 	SAMLIDPAddedEventType               eventstore.EventType = "org.idp.saml.added"
@@ -924,6 +926,76 @@ func LDAPIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error)
 	return &LDAPIDPChangedEvent{LDAPIDPChangedEvent: *e.(*idp.LDAPIDPChangedEvent)}, nil
 }
 
+type LDAPIDPSyncSetEvent struct {
+	idp.LDAPIDPSyncSetEvent
+}
+
+func NewLDAPIDPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	enabled bool,
+	dryRun bool,
+	interval time.Duration,
+	maxDeactivationPercent uint32,
+) *LDAPIDPSyncSetEvent {
+	return &LDAPIDPSyncSetEvent{
+		LDAPIDPSyncSetEvent: *idp.NewLDAPIDPSyncSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LDAPIDPSyncSetEventType,
+			),
+			id,
+			enabled,
+			dryRun,
+			interval,
+			maxDeactivationPercent,
+		),
+	}
+}
+
+func LDAPIDPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPIDPSyncSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPIDPSyncSetEvent{LDAPIDPSyncSetEvent: *e.(*idp.LDAPIDPSyncSetEvent)}, nil
+}
+
+type LDAPIDPSyncedEvent struct {
+	idp.LDAPIDPSyncedEvent
+}
+
+func NewLDAPIDPSyncedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	report *domain.LDAPSyncReport,
+) *LDAPIDPSyncedEvent {
+	return &LDAPIDPSyncedEvent{
+		LDAPIDPSyncedEvent: *idp.NewLDAPIDPSyncedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				LDAPIDPSyncedEventType,
+			),
+			id,
+			report,
+		),
+	}
+}
+
+func LDAPIDPSyncedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPIDPSyncedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPIDPSyncedEvent{LDAPIDPSyncedEvent: *e.(*idp.LDAPIDPSyncedEvent)}, nil
+}
+
 type AppleIDPAddedEvent struct {
 	idp.AppleIDPAddedEvent
 }
@@ -20,7 +20,8 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	securityAlerts bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -29,6 +30,7 @@ func NewNotificationPolicyAddedEvent(
 				aggregate,
 				NotificationPolicyAddedEventType),
 			passwordChange,
+			securityAlerts,
 		),
 	}
 }
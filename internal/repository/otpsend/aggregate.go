@@ -0,0 +1,42 @@
+package otpsend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	AggregateType    = "otp_send"
+	AggregateVersion = "v1"
+)
+
+// NewAggregate returns the aggregate counting the codes sent in the scope.
+// The key is the id of the user, the recipient or the id of the instance.
+func NewAggregate(scope domain.OTPSendLimitScope, key, instanceID string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            aggregateID(scope, key),
+		Type:          AggregateType,
+		ResourceOwner: instanceID,
+		InstanceID:    instanceID,
+		Version:       AggregateVersion,
+	}
+}
+
+// aggregateID prefixes the key with the scope, as user ids can be chosen freely.
+// Recipients are hashed, so the phone numbers and email addresses aren't part of the id.
+func aggregateID(scope domain.OTPSendLimitScope, key string) string {
+	switch scope {
+	case domain.OTPSendLimitScopeUser:
+		return "user:" + key
+	case domain.OTPSendLimitScopeRecipient:
+		hash := sha256.Sum256([]byte(strings.ToLower(key)))
+		return "recipient:" + hex.EncodeToString(hash[:])
+	case domain.OTPSendLimitScopeUnspecified, domain.OTPSendLimitScopeInstance:
+		return "instance:" + key
+	}
+	return key
+}
@@ -0,0 +1,8 @@
+package otpsend
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func init() {
+	eventstore.RegisterFilterEventMapper(AggregateType, RecordedEventType, eventstore.GenericEventMapper[RecordedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, LimitReachedEventType, eventstore.GenericEventMapper[LimitReachedEvent])
+}
@@ -0,0 +1,93 @@
+package otpsend
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix       eventstore.EventType = "otp_send."
+	RecordedEventType                          = eventTypePrefix + "recorded"
+	LimitReachedEventType                      = eventTypePrefix + "limit.reached"
+)
+
+var (
+	_ eventstore.SequenceAsserter = (*RecordedEvent)(nil)
+	_ eventstore.SequenceAsserter = (*LimitReachedEvent)(nil)
+)
+
+// RecordedEvent is pushed together with every code which will be sent by SMS or email.
+// It is used to enforce the send limits.
+type RecordedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	NotificationType domain.NotificationType `json:"notificationType"`
+
+	expectedSequence uint64
+}
+
+func (e *RecordedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RecordedEvent) Payload() any {
+	return e
+}
+
+func (e *RecordedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RecordedEvent) ExpectedSequence() uint64 {
+	return e.expectedSequence
+}
+
+// NewRecordedEvent records a sent code, if no other code was recorded since the sequence of the aggregate was read.
+func NewRecordedEvent(ctx context.Context, aggregate *eventstore.Aggregate, sequence uint64, notificationType domain.NotificationType) *RecordedEvent {
+	return &RecordedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, RecordedEventType,
+		),
+		NotificationType: notificationType,
+		expectedSequence: sequence,
+	}
+}
+
+// LimitReachedEvent is pushed when a send limit trips.
+// No further codes are sent for the scope until the cool-down has passed.
+type LimitReachedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	RetryAfter time.Duration `json:"retryAfter,omitempty"`
+
+	expectedSequence uint64
+}
+
+func (e *LimitReachedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *LimitReachedEvent) Payload() any {
+	return e
+}
+
+func (e *LimitReachedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *LimitReachedEvent) ExpectedSequence() uint64 {
+	return e.expectedSequence
+}
+
+func NewLimitReachedEvent(ctx context.Context, aggregate *eventstore.Aggregate, sequence uint64, retryAfter time.Duration) *LimitReachedEvent {
+	return &LimitReachedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, LimitReachedEventType,
+		),
+		RetryAfter:       retryAfter,
+		expectedSequence: sequence,
+	}
+}
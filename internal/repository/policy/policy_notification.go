@@ -15,6 +15,7 @@ type NotificationPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
 	PasswordChange bool `json:"passwordChange,omitempty"`
+	SecurityAlerts bool `json:"securityAlerts,omitempty"`
 }
 
 func (e *NotificationPolicyAddedEvent) Payload() interface{} {
@@ -27,11 +28,13 @@ func (e *NotificationPolicyAddedEvent) UniqueConstraints() []*eventstore.UniqueC
 
 func NewNotificationPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	passwordChange bool,
+	passwordChange,
+	securityAlerts bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		BaseEvent:      *base,
 		PasswordChange: passwordChange,
+		SecurityAlerts: securityAlerts,
 	}
 }
 
@@ -52,6 +55,7 @@ type NotificationPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
 	PasswordChange *bool `json:"passwordChange,omitempty"`
+	SecurityAlerts *bool `json:"securityAlerts,omitempty"`
 }
 
 func (e *NotificationPolicyChangedEvent) Payload() interface{} {
@@ -86,6 +90,12 @@ func ChangePasswordChange(passwordChange bool) func(*NotificationPolicyChangedEv
 	}
 }
 
+func ChangeSecurityAlerts(securityAlerts bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.SecurityAlerts = &securityAlerts
+	}
+}
+
 func NotificationPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &NotificationPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -5,5 +5,6 @@ import "github.com/zitadel/zitadel/internal/eventstore"
 func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, AddedEventType, eventstore.GenericEventMapper[AddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, ChangedEventType, eventstore.GenericEventMapper[ChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SigningKeyRotatedEventType, eventstore.GenericEventMapper[SigningKeyRotatedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, RemovedEventType, eventstore.GenericEventMapper[RemovedEvent])
 }
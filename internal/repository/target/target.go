@@ -4,25 +4,54 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
 const (
-	eventTypePrefix  eventstore.EventType = "target."
-	AddedEventType                        = eventTypePrefix + "added"
-	ChangedEventType                      = eventTypePrefix + "changed"
-	RemovedEventType                      = eventTypePrefix + "removed"
+	eventTypePrefix            eventstore.EventType = "target."
+	AddedEventType                                  = eventTypePrefix + "added"
+	ChangedEventType                                = eventTypePrefix + "changed"
+	SigningKeyRotatedEventType                      = eventTypePrefix + "signing.key.rotated"
+	RemovedEventType                                = eventTypePrefix + "removed"
 )
 
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Name             string            `json:"name"`
-	TargetType       domain.TargetType `json:"targetType"`
-	Endpoint         string            `json:"endpoint"`
-	Timeout          time.Duration     `json:"timeout"`
-	InterruptOnError bool              `json:"interruptOnError"`
+	Name             string              `json:"name"`
+	TargetType       domain.TargetType   `json:"targetType"`
+	Endpoint         string              `json:"endpoint"`
+	Timeout          time.Duration       `json:"timeout"`
+	InterruptOnError bool                `json:"interruptOnError"`
+	MaxAttempts      uint8               `json:"maxAttempts,omitempty"`
+	Authentication   *Authentication     `json:"authentication,omitempty"`
+	MaxConcurrency   uint16              `json:"maxConcurrency,omitempty"`
+	CircuitBreaker   *CircuitBreaker     `json:"circuitBreaker,omitempty"`
+	SigningKey       *crypto.CryptoValue `json:"signingKey"`
+}
+
+// Authentication defines how ZITADEL authenticates itself to the endpoint of the target.
+type Authentication struct {
+	// Headers is the encrypted JSON object of the custom headers sent with every call
+	Headers *crypto.CryptoValue `json:"headers,omitempty"`
+	// JWT defines if a short-lived JWT signed by the key of the instance is sent as bearer token
+	JWT bool `json:"jwt,omitempty"`
+	// ClientCertificate is the PEM encoded certificate presented to the endpoint for mTLS
+	ClientCertificate []byte `json:"clientCertificate,omitempty"`
+	// ClientKey is the encrypted PEM encoded private key of the client certificate
+	ClientKey *crypto.CryptoValue `json:"clientKey,omitempty"`
+	// RootCAs is the PEM encoded CA bundle used to verify the certificate of the endpoint
+	RootCAs []byte `json:"rootCAs,omitempty"`
+}
+
+// CircuitBreaker defines when calls to the target are stopped after consecutive failures.
+type CircuitBreaker struct {
+	// FailureThreshold is the amount of consecutive failures after which the circuit is opened
+	FailureThreshold uint16 `json:"failureThreshold,omitempty"`
+	// OpenDuration is the duration the circuit stays open until a call is let through to probe the target
+	OpenDuration time.Duration `json:"openDuration,omitempty"`
 }
 
 func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
@@ -45,12 +74,17 @@ func NewAddedEvent(
 	endpoint string,
 	timeout time.Duration,
 	interruptOnError bool,
+	maxAttempts uint8,
+	authentication *Authentication,
+	maxConcurrency uint16,
+	circuitBreaker *CircuitBreaker,
+	signingKey *crypto.CryptoValue,
 ) *AddedEvent {
 	return &AddedEvent{
 		*eventstore.NewBaseEventForPush(
 			ctx, aggregate, AddedEventType,
 		),
-		name, targetType, endpoint, timeout, interruptOnError}
+		name, targetType, endpoint, timeout, interruptOnError, maxAttempts, authentication, maxConcurrency, circuitBreaker, signingKey}
 }
 
 type ChangedEvent struct {
@@ -61,6 +95,11 @@ type ChangedEvent struct {
 	Endpoint         *string            `json:"endpoint,omitempty"`
 	Timeout          *time.Duration     `json:"timeout,omitempty"`
 	InterruptOnError *bool              `json:"interruptOnError,omitempty"`
+	MaxAttempts      *uint8             `json:"maxAttempts,omitempty"`
+	// Authentication replaces the whole authentication of the target if set
+	Authentication *Authentication `json:"authentication,omitempty"`
+	MaxConcurrency *uint16         `json:"maxConcurrency,omitempty"`
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
 
 	oldName string
 }
@@ -134,6 +173,70 @@ func ChangeInterruptOnError(interruptOnError bool) func(event *ChangedEvent) {
 	}
 }
 
+func ChangeMaxAttempts(maxAttempts uint8) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.MaxAttempts = &maxAttempts
+	}
+}
+
+func ChangeAuthentication(authentication *Authentication) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Authentication = authentication
+	}
+}
+
+func ChangeMaxConcurrency(maxConcurrency uint16) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.MaxConcurrency = &maxConcurrency
+	}
+}
+
+func ChangeCircuitBreaker(circuitBreaker *CircuitBreaker) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.CircuitBreaker = circuitBreaker
+	}
+}
+
+type SigningKeyRotatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SigningKey         *crypto.CryptoValue `json:"signingKey"`
+	PreviousSigningKey *crypto.CryptoValue `json:"previousSigningKey,omitempty"`
+	GracePeriod        time.Duration       `json:"gracePeriod,omitempty"`
+}
+
+func (e *SigningKeyRotatedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *SigningKeyRotatedEvent) Payload() any {
+	return e
+}
+
+func (e *SigningKeyRotatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// PreviousSigningKeyExpiration returns the point in time until the previous signing key is still used.
+func (e *SigningKeyRotatedEvent) PreviousSigningKeyExpiration() time.Time {
+	return e.CreationDate().Add(e.GracePeriod)
+}
+
+// NewSigningKeyRotatedEvent replaces the signing key of the target,
+// the previous signing key is still used to sign the calls during the grace period.
+func NewSigningKeyRotatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	signingKey *crypto.CryptoValue,
+	previousSigningKey *crypto.CryptoValue,
+	gracePeriod time.Duration,
+) *SigningKeyRotatedEvent {
+	return &SigningKeyRotatedEvent{
+		*eventstore.NewBaseEventForPush(ctx, aggregate, SigningKeyRotatedEventType),
+		signingKey, previousSigningKey, gracePeriod,
+	}
+}
+
 type RemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -0,0 +1,85 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	HumanPasswordExpiryWarningAddedType = passwordEventPrefix + "expiry.warning.added"
+	HumanPasswordExpiryWarningSentType  = passwordEventPrefix + "expiry.warning.sent"
+)
+
+// HumanPasswordExpiryWarningAddedEvent is pushed when the password of the user expires soon
+// according to the password age policy, so the user is notified about the expiry.
+// The warning is added once per password, a password change starts a new cycle.
+type HumanPasswordExpiryWarningAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ExpirationDate time.Time `json:"expirationDate,omitempty"`
+}
+
+func (e *HumanPasswordExpiryWarningAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPasswordExpiryWarningAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordExpiryWarningAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	expirationDate time.Time,
+) *HumanPasswordExpiryWarningAddedEvent {
+	return &HumanPasswordExpiryWarningAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordExpiryWarningAddedType,
+		),
+		ExpirationDate: expirationDate,
+	}
+}
+
+func HumanPasswordExpiryWarningAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	warningAdded := &HumanPasswordExpiryWarningAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(warningAdded)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USER-Tq3ze", "unable to unmarshal password expiry warning added")
+	}
+	return warningAdded, nil
+}
+
+type HumanPasswordExpiryWarningSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanPasswordExpiryWarningSentEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *HumanPasswordExpiryWarningSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordExpiryWarningSentEvent(ctx context.Context, aggregate *eventstore.Aggregate) *HumanPasswordExpiryWarningSentEvent {
+	return &HumanPasswordExpiryWarningSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordExpiryWarningSentType,
+		),
+	}
+}
+
+func HumanPasswordExpiryWarningSentEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &HumanPasswordExpiryWarningSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	HumanSecurityAlertSentType = humanEventPrefix + "security.alert.sent"
+)
+
+// HumanSecurityAlertSentEvent is pushed after the user has been notified
+// about a security relevant change of the account, e.g. an added multi-factor.
+type HumanSecurityAlertSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+	// TriggeringSequence is the sequence of the event on the user which caused the alert
+	TriggeringSequence uint64 `json:"triggeringSequence"`
+}
+
+func (e *HumanSecurityAlertSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanSecurityAlertSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanSecurityAlertSentEvent(ctx context.Context, aggregate *eventstore.Aggregate, messageType string, triggeringSequence uint64) *HumanSecurityAlertSentEvent {
+	return &HumanSecurityAlertSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSecurityAlertSentType,
+		),
+		MessageType:        messageType,
+		TriggeringSequence: triggeringSequence,
+	}
+}
+
+func HumanSecurityAlertSentEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	alertSent := &HumanSecurityAlertSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(alertSent)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USER-Qc5wn", "unable to unmarshal security alert sent")
+	}
+	return alertSent, nil
+}
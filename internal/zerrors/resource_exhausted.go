@@ -1,7 +1,9 @@
 package zerrors
 
 import (
+	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -16,10 +18,16 @@ type ResourceExhausted interface {
 
 type ResourceExhaustedError struct {
 	*ZitadelError
+	// RetryAfter is the time after which the request can be retried, 0 if unknown
+	RetryAfter time.Duration
 }
 
 func ThrowResourceExhausted(parent error, id, message string) error {
-	return &ResourceExhaustedError{CreateZitadelError(parent, id, message)}
+	return &ResourceExhaustedError{ZitadelError: CreateZitadelError(parent, id, message)}
+}
+
+func ThrowResourceExhaustedRetryAfter(parent error, id, message string, retryAfter time.Duration) error {
+	return &ResourceExhaustedError{ZitadelError: CreateZitadelError(parent, id, message), RetryAfter: retryAfter}
 }
 
 func ThrowResourceExhaustedf(parent error, id, format string, a ...interface{}) error {
@@ -34,6 +42,16 @@ func IsResourceExhausted(err error) bool {
 	return ok
 }
 
+// RetryAfter returns the time after which the request can be retried
+// if err is a resource exhausted error which provides it
+func RetryAfter(err error) (time.Duration, bool) {
+	exhausted := new(ResourceExhaustedError)
+	if !errors.As(err, &exhausted) || exhausted.RetryAfter <= 0 {
+		return 0, false
+	}
+	return exhausted.RetryAfter, true
+}
+
 func (err *ResourceExhaustedError) Is(target error) bool {
 	//nolint:errorlint
 	t, ok := target.(*ResourceExhaustedError)
@@ -2,7 +2,9 @@ package zerrors_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -31,3 +33,19 @@ func TestIsResourceExhausted(t *testing.T) {
 	ok = zerrors.IsResourceExhausted(err)
 	assert.False(t, ok)
 }
+
+func TestRetryAfter(t *testing.T) {
+	retryAfter, ok := zerrors.RetryAfter(zerrors.ThrowResourceExhaustedRetryAfter(nil, "id", "msg", time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, retryAfter)
+
+	retryAfter, ok = zerrors.RetryAfter(fmt.Errorf("wrapped: %w", zerrors.ThrowResourceExhaustedRetryAfter(nil, "id", "msg", time.Second)))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	_, ok = zerrors.RetryAfter(zerrors.ThrowResourceExhausted(nil, "id", "msg"))
+	assert.False(t, ok)
+
+	_, ok = zerrors.RetryAfter(zerrors.ThrowInternal(nil, "id", "msg"))
+	assert.False(t, ok)
+}
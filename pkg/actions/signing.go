@@ -0,0 +1,106 @@
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SigningHeader is the HTTP header ZITADEL sets on every call to a target.
+	SigningHeader = "ZITADEL-Signature"
+	// DefaultTolerance is the maximum age of a signature accepted by ValidatePayload.
+	DefaultTolerance = 300 * time.Second
+
+	signingTimestamp = "t"
+	signingVersion   = "v1"
+	partSeparator    = ","
+	valueSeparator   = "="
+)
+
+var (
+	ErrNotSigned        = errors.New("payload has no ZITADEL-Signature header")
+	ErrInvalidHeader    = errors.New("payload has an invalid ZITADEL-Signature header")
+	ErrTooOld           = errors.New("timestamp of the signature is not within the tolerance")
+	ErrNoValidSignature = errors.New("payload has no valid signature")
+)
+
+// ComputeSignatureHeader returns the value of the ZITADEL-Signature header for the payload.
+// A separate v1 signature is added for every signing key,
+// so receivers can verify the payload with either key while a signing key is rotated.
+func ComputeSignatureHeader(t time.Time, payload []byte, signingKeys ...string) string {
+	parts := make([]string, 0, len(signingKeys)+1)
+	parts = append(parts, signingTimestamp+valueSeparator+strconv.FormatInt(t.Unix(), 10))
+	for _, signingKey := range signingKeys {
+		parts = append(parts, signingVersion+valueSeparator+hex.EncodeToString(computeSignature(t, payload, []byte(signingKey))))
+	}
+	return strings.Join(parts, partSeparator)
+}
+
+// ValidatePayload checks the ZITADEL-Signature header of a received payload with the signing key of the target,
+// signatures older than the DefaultTolerance are rejected.
+func ValidatePayload(payload []byte, header string, signingKey string) error {
+	return ValidatePayloadWithTolerance(payload, header, signingKey, DefaultTolerance)
+}
+
+// ValidatePayloadWithTolerance checks the ZITADEL-Signature header of a received payload with the signing key of the target,
+// signatures older than the provided tolerance are rejected.
+func ValidatePayloadWithTolerance(payload []byte, header string, signingKey string, tolerance time.Duration) error {
+	if header == "" {
+		return ErrNotSigned
+	}
+	t, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		return err
+	}
+	if time.Since(t) > tolerance {
+		return ErrTooOld
+	}
+	expected := computeSignature(t, payload, []byte(signingKey))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, signature) {
+			return nil
+		}
+	}
+	return ErrNoValidSignature
+}
+
+func computeSignature(t time.Time, payload []byte, signingKey []byte) []byte {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(strconv.FormatInt(t.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func parseSignatureHeader(header string) (t time.Time, signatures [][]byte, err error) {
+	for _, part := range strings.Split(header, partSeparator) {
+		key, value, found := strings.Cut(part, valueSeparator)
+		if !found {
+			return t, nil, ErrInvalidHeader
+		}
+		switch key {
+		case signingTimestamp:
+			unix, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return t, nil, ErrInvalidHeader
+			}
+			t = time.Unix(unix, 0)
+		case signingVersion:
+			signature, err := hex.DecodeString(value)
+			if err != nil {
+				// ignore signatures which are not parsable, another one might still be valid
+				continue
+			}
+			signatures = append(signatures, signature)
+		}
+	}
+	if t.IsZero() || len(signatures) == 0 {
+		return t, nil, ErrInvalidHeader
+	}
+	return t, signatures, nil
+}
@@ -0,0 +1,122 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePayloadWithTolerance(t *testing.T) {
+	payload := []byte(`{"request":"content"}`)
+	now := time.Now()
+
+	type args struct {
+		payload    []byte
+		header     string
+		signingKey string
+		tolerance  time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			"no header",
+			args{
+				payload:    payload,
+				header:     "",
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			ErrNotSigned,
+		},
+		{
+			"invalid header",
+			args{
+				payload:    payload,
+				header:     "invalid",
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			ErrInvalidHeader,
+		},
+		{
+			"no signature",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload),
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			ErrInvalidHeader,
+		},
+		{
+			"too old",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now.Add(-time.Hour), payload, "key"),
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			ErrTooOld,
+		},
+		{
+			"wrong key",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "other"),
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			ErrNoValidSignature,
+		},
+		{
+			"changed payload",
+			args{
+				payload:    []byte(`{"request":"changed"}`),
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			ErrNoValidSignature,
+		},
+		{
+			"ok",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "key",
+				tolerance:  DefaultTolerance,
+			},
+			nil,
+		},
+		{
+			"ok, current key during rotation",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "new", "old"),
+				signingKey: "new",
+				tolerance:  DefaultTolerance,
+			},
+			nil,
+		},
+		{
+			"ok, previous key during rotation",
+			args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "new", "old"),
+				signingKey: "old",
+				tolerance:  DefaultTolerance,
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePayloadWithTolerance(tt.args.payload, tt.args.header, tt.args.signingKey, tt.args.tolerance)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}